@@ -26,6 +26,9 @@ import (
 
 const MsgKey = "msg"
 
+// ErrKey is the key used by Err.
+const ErrKey = "error"
+
 // ValueType represents the underlying type stored in a Field.
 // The Type determines how Num and Any should be interpreted.
 type ValueType int
@@ -40,6 +43,7 @@ const (
 	ValueTypeArray
 	ValueTypeObject
 	ValueTypeFromMap
+	ValueTypeError
 )
 
 // Field represents a structured log field with a key and a typed value.
@@ -265,6 +269,8 @@ func FieldsFromMap(m map[string]any) Field {
 
 // Any creates a Field from a value of any type by inspecting its dynamic type.
 // It dispatches to the appropriate typed constructor based on the actual value.
+// An error goes through NamedErr rather than being marshalled by Reflect, so
+// its message and cause tree are logged; call Reflect to keep the JSON form.
 // If the type is not explicitly handled, it falls back to using Reflect.
 func Any(key string, value any) Field {
 	switch val := value.(type) {
@@ -369,6 +375,9 @@ func Any(key string, value any) Field {
 	case []string:
 		return Strings(key, val)
 
+	case error:
+		return NamedErr(key, val)
+
 	default:
 		return Reflect(key, val)
 	}
@@ -410,6 +419,8 @@ func (f Field) Encode(enc Encoder) {
 		for _, k := range ordered.MapKeys(m) {
 			Any(k, m[k]).Encode(enc)
 		}
+	case ValueTypeError:
+		encodeError(enc, f.Key, f.Any.(error))
 	default: // for linter
 	}
 }
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+)
+
+// maxErrorDepth bounds how deep a cause tree is encoded, protecting the
+// encoder from pathological or cyclic Unwrap implementations.
+const maxErrorDepth = 32
+
+// StackTracer is implemented by errors that captured the call stack where
+// they were created. The program counters are in the form returned by
+// runtime.Callers and are rendered as "function file:line" frames.
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// Err creates an error Field with the fixed key "error".
+func Err(err error) Field {
+	return NamedErr(ErrKey, err)
+}
+
+// NamedErr creates a Field that encodes err as a structured object: its
+// message, its Go type, its stack trace if it implements StackTracer, and
+// its cause tree as reported by errors.Unwrap (single cause) or
+// errors.Join (multiple causes). A nil err is encoded as null.
+func NamedErr(key string, err error) Field {
+	if err == nil {
+		return Nil(key)
+	}
+	return Field{Key: key, Type: ValueTypeError, Any: err}
+}
+
+// encodeError writes err under key. At the top level of a TextEncoder the
+// error is flattened into "key=message key.type=... key.cause=..." pairs so
+// the message stays greppable; everywhere else it is a nested object.
+func encodeError(enc Encoder, key string, err error) {
+	if t, ok := enc.(*TextEncoder); ok && t.jsonDepth == 0 {
+		enc.AppendKey(key)
+		enc.AppendString(err.Error())
+		enc.AppendKey(key + ".type")
+		enc.AppendString(errorType(err))
+		if pcs := errorStack(err); len(pcs) > 0 {
+			enc.AppendKey(key + ".stack")
+			encodeStack(enc, pcs)
+		}
+		encodeCauses(enc, key+".", err, 1)
+		return
+	}
+	enc.AppendKey(key)
+	encodeErrorObject(enc, err, 1)
+}
+
+// encodeErrorObject writes err as {"msg":...,"type":...,"stack":[...],
+// "cause":{...}} or, for joined errors, with a "causes" array.
+func encodeErrorObject(enc Encoder, err error, depth int) {
+	enc.AppendObjectBegin()
+	enc.AppendKey(MsgKey)
+	enc.AppendString(err.Error())
+	enc.AppendKey("type")
+	enc.AppendString(errorType(err))
+	if pcs := errorStack(err); len(pcs) > 0 {
+		enc.AppendKey("stack")
+		encodeStack(enc, pcs)
+	}
+	encodeCauses(enc, "", err, depth)
+	enc.AppendObjectEnd()
+}
+
+// encodeCauses writes the direct causes of err, if any, under
+// prefix+"cause" or prefix+"causes".
+func encodeCauses(enc Encoder, prefix string, err error, depth int) {
+	if depth >= maxErrorDepth {
+		return
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := x.Unwrap(); cause != nil {
+			enc.AppendKey(prefix + "cause")
+			encodeErrorObject(enc, cause, depth+1)
+		}
+	case interface{ Unwrap() []error }:
+		var causes []error
+		for _, cause := range x.Unwrap() {
+			if cause != nil {
+				causes = append(causes, cause)
+			}
+		}
+		if len(causes) == 0 {
+			return
+		}
+		enc.AppendKey(prefix + "causes")
+		enc.AppendArrayBegin()
+		for _, cause := range causes {
+			encodeErrorObject(enc, cause, depth+1)
+		}
+		enc.AppendArrayEnd()
+	default: // for linter
+	}
+}
+
+// encodeStack writes the program counters as an array of "function
+// file:line" strings.
+func encodeStack(enc Encoder, pcs []uintptr) {
+	enc.AppendArrayBegin()
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" || frame.File != "" {
+			enc.AppendString(frame.Function + " " + frame.File + ":" + strconv.Itoa(frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	enc.AppendArrayEnd()
+}
+
+// errorStack returns the stack captured by err itself. Stacks of wrapped
+// errors are reported on their own nodes of the cause tree.
+func errorStack(err error) []uintptr {
+	if x, ok := err.(StackTracer); ok {
+		return x.StackTrace()
+	}
+	return nil
+}
+
+// errorType returns the Go type name of err, e.g. "*fs.PathError".
+func errorType(err error) string {
+	return reflect.TypeOf(err).String()
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"runtime"
+	"testing"
+
+	"go-spring.org/stdlib/errutil"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type stackError struct {
+	msg string
+	pcs []uintptr
+}
+
+func newStackError(msg string) *stackError {
+	pcs := make([]uintptr, 1)
+	n := runtime.Callers(2, pcs)
+	return &stackError{msg: msg, pcs: pcs[:n]}
+}
+
+func (e *stackError) Error() string         { return e.msg }
+func (e *stackError) StackTrace() []uintptr { return e.pcs }
+
+func TestErrField(t *testing.T) {
+
+	t.Run("nil", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		enc := NewJSONEncoder(buf)
+		enc.AppendEncoderBegin()
+		Err(nil).Encode(enc)
+		enc.AppendEncoderEnd()
+		assert.String(t, buf.String()).Equal(`{"error":null}`)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		root := errors.New("connection refused")
+		other := errors.New("timeout")
+		err := errutil.Explain(errors.Join(root, other), "failed to connect")
+
+		buf := bytes.NewBuffer(nil)
+		enc := NewJSONEncoder(buf)
+		enc.AppendEncoderBegin()
+		EncodeFields(enc, []Field{Msg("oops"), Err(err), Any("any", root)})
+		enc.AppendEncoderEnd()
+		assert.String(t, buf.String()).JSONEqual(`{
+			"msg": "oops",
+			"error": {
+				"msg": "failed to connect: connection refused\ntimeout",
+				"type": "*fmt.wrapError",
+				"cause": {
+					"msg": "connection refused\ntimeout",
+					"type": "*errors.joinError",
+					"causes": [
+						{"msg": "connection refused", "type": "*errors.errorString"},
+						{"msg": "timeout", "type": "*errors.errorString"}
+					]
+				}
+			},
+			"any": {"msg": "connection refused", "type": "*errors.errorString"}
+		}`)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		err := errutil.Explain(errors.New("file not found"), "load config")
+
+		buf := bytes.NewBuffer(nil)
+		enc := NewTextEncoder(buf, "||")
+		enc.AppendEncoderBegin()
+		EncodeFields(enc, []Field{NamedErr("err", err), Object("obj", Err(err))})
+		enc.AppendEncoderEnd()
+		assert.String(t, buf.String()).Equal(`err=load config: file not found||` +
+			`err.type=*fmt.wrapError||` +
+			`err.cause={"msg":"file not found","type":"*errors.errorString"}||` +
+			`obj={"error":{"msg":"load config: file not found","type":"*fmt.wrapError",` +
+			`"cause":{"msg":"file not found","type":"*errors.errorString"}}}`)
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		err := newStackError("boom")
+
+		buf := bytes.NewBuffer(nil)
+		enc := NewJSONEncoder(buf)
+		enc.AppendEncoderBegin()
+		Err(err).Encode(enc)
+		enc.AppendEncoderEnd()
+		assert.String(t, buf.String()).Contains(`"stack":["go-spring.org/log.TestErrField.func4 `)
+		assert.String(t, buf.String()).Contains(`field_error_test.go:`)
+	})
+}
+
+func TestAnyError(t *testing.T) {
+	err := &fs.PathError{Op: "open", Path: "a.txt", Err: errors.New("denied")}
+
+	buf := bytes.NewBuffer(nil)
+	enc := NewJSONEncoder(buf)
+	enc.AppendEncoderBegin()
+	EncodeFields(enc, []Field{Any("any", err), Reflect("reflect", err)})
+	enc.AppendEncoderEnd()
+	assert.String(t, buf.String()).JSONEqual(`{
+		"any": {
+			"msg": "open a.txt: denied",
+			"type": "*fs.PathError",
+			"cause": {"msg": "denied", "type": "*errors.errorString"}
+		},
+		"reflect": {"Op": "open", "Path": "a.txt", "Err": {}}
+	}`)
+}
@@ -41,11 +41,11 @@ var (
 			},
 		},
 		Layout: defaultLayout,
-		appender: &ConsoleAppender{
+		appender: ownedRef(&ConsoleAppender{
 			AppenderBase: AppenderBase{
 				Layout: defaultLayout,
 			},
-		},
+		}),
 	}
 
 	// TagAppDef is the default tag for application-related logs.
@@ -137,14 +137,14 @@ func getLogger(tag *Tag) Logger {
 // Trace logs a message at TraceLevel using a lazy field generator.
 // The generator function is only invoked if the level is enabled.
 func Trace(ctx context.Context, tag *Tag, fn func() []Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(TraceLevel) {
+	if l := getLogger(tag); enable(ctx, l, TraceLevel) {
 		record(ctx, TraceLevel, tag.tag, l, 2, fn()...)
 	}
 }
 
 // Tracef logs a formatted message at TraceLevel.
 func Tracef(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(TraceLevel) {
+	if l := getLogger(tag); enable(ctx, l, TraceLevel) {
 		record(ctx, TraceLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
@@ -152,91 +152,93 @@ func Tracef(ctx context.Context, tag *Tag, format string, args ...any) {
 // Debug logs a message at DebugLevel using a lazy field generator.
 // The generator function is only invoked if the level is enabled.
 func Debug(ctx context.Context, tag *Tag, fn func() []Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(DebugLevel) {
+	if l := getLogger(tag); enable(ctx, l, DebugLevel) {
 		record(ctx, DebugLevel, tag.tag, l, 2, fn()...)
 	}
 }
 
 // Debugf logs a formatted message at DebugLevel.
 func Debugf(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(DebugLevel) {
+	if l := getLogger(tag); enable(ctx, l, DebugLevel) {
 		record(ctx, DebugLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Info logs structured fields at InfoLevel.
 func Info(ctx context.Context, tag *Tag, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(InfoLevel) {
+	if l := getLogger(tag); enable(ctx, l, InfoLevel) {
 		record(ctx, InfoLevel, tag.tag, l, 2, fields...)
 	}
 }
 
 // Infof logs a formatted message at InfoLevel.
 func Infof(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(InfoLevel) {
+	if l := getLogger(tag); enable(ctx, l, InfoLevel) {
 		record(ctx, InfoLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Warn logs structured fields at WarnLevel.
 func Warn(ctx context.Context, tag *Tag, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(WarnLevel) {
+	if l := getLogger(tag); enable(ctx, l, WarnLevel) {
 		record(ctx, WarnLevel, tag.tag, l, 2, fields...)
 	}
 }
 
 // Warnf logs a formatted message at WarnLevel.
 func Warnf(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(WarnLevel) {
+	if l := getLogger(tag); enable(ctx, l, WarnLevel) {
 		record(ctx, WarnLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Error logs structured fields at ErrorLevel.
 func Error(ctx context.Context, tag *Tag, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(ErrorLevel) {
+	if l := getLogger(tag); enable(ctx, l, ErrorLevel) {
 		record(ctx, ErrorLevel, tag.tag, l, 2, fields...)
 	}
 }
 
 // Errorf logs a formatted message at ErrorLevel.
 func Errorf(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(ErrorLevel) {
+	if l := getLogger(tag); enable(ctx, l, ErrorLevel) {
 		record(ctx, ErrorLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Panic logs structured fields at PanicLevel.
 func Panic(ctx context.Context, tag *Tag, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(PanicLevel) {
+	if l := getLogger(tag); enable(ctx, l, PanicLevel) {
 		record(ctx, PanicLevel, tag.tag, l, 2, fields...)
 	}
 }
 
 // Panicf logs a formatted message at PanicLevel.
 func Panicf(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(PanicLevel) {
+	if l := getLogger(tag); enable(ctx, l, PanicLevel) {
 		record(ctx, PanicLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Fatal logs structured fields at FatalLevel.
 func Fatal(ctx context.Context, tag *Tag, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(FatalLevel) {
+	if l := getLogger(tag); enable(ctx, l, FatalLevel) {
 		record(ctx, FatalLevel, tag.tag, l, 2, fields...)
 	}
 }
 
 // Fatalf logs a formatted message at FatalLevel.
 func Fatalf(ctx context.Context, tag *Tag, format string, args ...any) {
-	if l := getLogger(tag); l.GetLevel().Enable(FatalLevel) {
+	if l := getLogger(tag); enable(ctx, l, FatalLevel) {
 		record(ctx, FatalLevel, tag.tag, l, 2, Msgf(format, args...))
 	}
 }
 
 // Record logs a message at the given level for the given tag.
+// Like the level-specific helpers, it honors context-scoped overrides
+// installed by WithLevel and SetLevelRules.
 func Record(ctx context.Context, level Level, tag *Tag, skip int, fields ...Field) {
-	if l := getLogger(tag); l.GetLevel().Enable(level) {
+	if l := getLogger(tag); enable(ctx, l, level) {
 		record(ctx, level, tag.tag, l, skip, fields...)
 	}
 }
@@ -280,5 +282,6 @@ func record(ctx context.Context, level Level, tag string, logger Logger, skip in
 	e.Fields = fields
 	e.CtxString = ctxString
 	e.CtxFields = ctxFields
+	e.Elevated = !logger.GetLevel().Enable(level)
 	logger.Append(e)
 }
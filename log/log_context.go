@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+)
+
+// ctxLevelActive is a one-way latch set the first time a context-scoped level
+// override is used (WithLevel, WithLevelKey or a non-empty rule table). Until
+// then the disabled-level path costs a single atomic load and never walks the
+// context chain.
+var ctxLevelActive atomic.Bool
+
+// levelRules holds the runtime rule table installed by SetLevelRules.
+var levelRules atomic.Pointer[[]LevelRule]
+
+type ctxLevelKey struct{}
+
+type ctxLevelAttrKey struct{}
+
+// levelAttr is a key/value pair attached to a context by WithLevelKey.
+// Attributes form a linked list so nested calls accumulate instead of
+// shadowing each other.
+type levelAttr struct {
+	key   string
+	value string
+	next  *levelAttr
+}
+
+// LevelRule elevates the level of events whose context carries the
+// attribute Key=Value (see WithLevelKey). Rules never raise the threshold:
+// they only let more verbose events through for the matching contexts.
+type LevelRule struct {
+	Key   string // Attribute key, e.g. "user_id"
+	Value string // Attribute value that triggers the rule, e.g. "42"
+	Level Level  // Minimum level enabled for matching contexts
+}
+
+// WithLevel returns a copy of ctx that enables events at or above level,
+// even if the logger bound to the tag is configured with a higher minimum.
+// The logger's MaxLevel still applies. It is typically installed by a
+// request middleware to debug a single request in production:
+//
+//	ctx = log.WithLevel(ctx, log.DebugLevel)
+//	log.Debugf(ctx, TagRequestIn, "payload: %s", body) // recorded
+func WithLevel(ctx context.Context, level Level) context.Context {
+	ctxLevelActive.Store(true)
+	return context.WithValue(ctx, ctxLevelKey{}, level)
+}
+
+// WithLevelKey returns a copy of ctx carrying the attribute key=value, which
+// is matched against the rule table installed by SetLevelRules. Attaching an
+// attribute is cheap and does not elevate anything on its own, so middleware
+// can tag every request (e.g. with its user ID) and operators can target a
+// single customer at runtime.
+func WithLevelKey(ctx context.Context, key, value string) context.Context {
+	ctxLevelActive.Store(true)
+	next, _ := ctx.Value(ctxLevelAttrKey{}).(*levelAttr)
+	return context.WithValue(ctx, ctxLevelAttrKey{}, &levelAttr{
+		key:   key,
+		value: value,
+		next:  next,
+	})
+}
+
+// SetLevelRules atomically replaces the runtime rule table. Passing an empty
+// slice removes all rules. It is safe to call concurrently with logging.
+func SetLevelRules(rules []LevelRule) {
+	rules = slices.Clone(rules)
+	if len(rules) > 0 {
+		ctxLevelActive.Store(true)
+	}
+	levelRules.Store(&rules)
+}
+
+// LevelRules returns a copy of the current runtime rule table.
+func LevelRules() []LevelRule {
+	if p := levelRules.Load(); p != nil {
+		return slices.Clone(*p)
+	}
+	return nil
+}
+
+// ContextLevel returns the most verbose level enabled for ctx by WithLevel
+// or by a matching rule, and false if ctx carries no override.
+func ContextLevel(ctx context.Context) (Level, bool) {
+	if ctx == nil || !ctxLevelActive.Load() {
+		return Level{}, false
+	}
+
+	var (
+		found bool
+		level Level
+	)
+
+	if l, ok := ctx.Value(ctxLevelKey{}).(Level); ok {
+		level, found = l, true
+	}
+
+	p := levelRules.Load()
+	if p == nil || len(*p) == 0 {
+		return level, found
+	}
+	attr, _ := ctx.Value(ctxLevelAttrKey{}).(*levelAttr)
+	for ; attr != nil; attr = attr.next {
+		for _, r := range *p {
+			if r.Key != attr.key || r.Value != attr.value {
+				continue
+			}
+			if !found || r.Level.code < level.code {
+				level, found = r.Level, true
+			}
+		}
+	}
+	return level, found
+}
+
+// enable reports whether an event at the given level should be recorded by l,
+// either because l's level range enables it or because ctx elevates it. The
+// common case (no override ever installed) stays a pair of loads.
+func enable(ctx context.Context, l Logger, level Level) bool {
+	r := l.GetLevel()
+	if r.Enable(level) {
+		return true
+	}
+	if !ctxLevelActive.Load() || level.code >= r.MaxLevel.code {
+		return false
+	}
+	v, ok := ContextLevel(ctx)
+	return ok && level.code >= v.code
+}
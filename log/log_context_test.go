@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type levelCaptureAppender struct {
+	DiscardAppender
+	levels []Level
+}
+
+func (c *levelCaptureAppender) Append(e *Event) {
+	c.levels = append(c.levels, e.Level)
+}
+
+func newCaptureLogger(minLevel Level) (*SyncLogger, *levelCaptureAppender) {
+	a := &levelCaptureAppender{}
+	l := &SyncLogger{
+		LoggerBase: LoggerBase{
+			Level: LevelRange{MinLevel: minLevel, MaxLevel: MaxLevel},
+		},
+		AppenderRefs: []*AppenderRef{
+			{
+				Appender: a,
+				Level:    LevelRange{MinLevel: NoneLevel, MaxLevel: MaxLevel},
+			},
+		},
+	}
+	return l, a
+}
+
+func TestContextLevel(t *testing.T) {
+	defer SetLevelRules(nil)
+
+	t.Run("no override", func(t *testing.T) {
+		_, ok := ContextLevel(t.Context())
+		assert.That(t, ok).False()
+	})
+
+	t.Run("with level", func(t *testing.T) {
+		ctx := WithLevel(t.Context(), DebugLevel)
+		l, ok := ContextLevel(ctx)
+		assert.That(t, ok).True()
+		assert.That(t, l).Equal(DebugLevel)
+	})
+
+	t.Run("rule table", func(t *testing.T) {
+		ctx := WithLevelKey(t.Context(), "user_id", "42")
+		ctx = WithLevelKey(ctx, "tenant", "acme")
+
+		_, ok := ContextLevel(ctx)
+		assert.That(t, ok).False()
+
+		SetLevelRules([]LevelRule{
+			{Key: "user_id", Value: "42", Level: DebugLevel},
+			{Key: "tenant", Value: "acme", Level: TraceLevel},
+		})
+		l, ok := ContextLevel(ctx)
+		assert.That(t, ok).True()
+		assert.That(t, l).Equal(TraceLevel)
+		assert.That(t, len(LevelRules())).Equal(2)
+
+		_, ok = ContextLevel(WithLevelKey(t.Context(), "user_id", "7"))
+		assert.That(t, ok).False()
+
+		SetLevelRules(nil)
+		_, ok = ContextLevel(ctx)
+		assert.That(t, ok).False()
+	})
+}
+
+func TestRecordElevated(t *testing.T) {
+	l, a := newCaptureLogger(InfoLevel)
+	tag := &Tag{tag: "_test_elevate"}
+	tag.logger.Store(&loggerValue{l})
+
+	ctx := t.Context()
+	Debugf(ctx, tag, "dropped")
+	Infof(ctx, tag, "kept")
+	assert.That(t, a.levels).Equal([]Level{InfoLevel})
+
+	ctx = WithLevel(ctx, DebugLevel)
+	Tracef(ctx, tag, "still dropped")
+	Debugf(ctx, tag, "elevated")
+	assert.That(t, a.levels).Equal([]Level{InfoLevel, DebugLevel})
+
+	t.Run("max level still applies", func(t *testing.T) {
+		l.SetLevel(LevelRange{MinLevel: InfoLevel, MaxLevel: WarnLevel})
+		defer l.SetLevel(l.Level)
+		Errorf(WithLevel(context.Background(), TraceLevel), tag, "dropped")
+		assert.That(t, a.levels).Equal([]Level{InfoLevel, DebugLevel})
+	})
+}
+
+func BenchmarkDisabledLevel(b *testing.B) {
+	l, _ := newCaptureLogger(InfoLevel)
+	tag := &Tag{tag: "_bench_elevate"}
+	tag.logger.Store(&loggerValue{l})
+	ctx := WithLevelKey(context.Background(), "user_id", "1")
+	b.ReportAllocs()
+	for b.Loop() {
+		Debug(ctx, tag, func() []Field { return []Field{Msg("x")} })
+	}
+}
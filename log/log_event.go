@@ -40,6 +40,7 @@ type Event struct {
 	CtxString string    // String representation extracted from the context (e.g., trace ID)
 	CtxFields []Field   // Additional structured fields extracted from the context (e.g., request ID, user ID)
 	RawBytes  []byte    // Raw data, only used for Write operations, mutually exclusive with other fields
+	Elevated  bool      // Whether the event was admitted by a context-scoped level override (see WithLevel)
 }
 
 // getEvent retrieves an *Event from the pool.
@@ -59,5 +60,6 @@ func (e *Event) Reset() {
 	e.CtxString = ""
 	e.CtxFields = nil
 	e.RawBytes = nil
+	e.Elevated = false
 	eventPool.Put(e)
 }
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go-spring.org/stdlib/ordered"
+)
+
+// errorCounter counts every error reported by the logging system itself.
+var errorCounter atomic.Int64
+
+// reportError counts err and forwards it to the ReportError hook.
+func reportError(err error) {
+	errorCounter.Add(1)
+	ReportError(err)
+}
+
+// LoggerMetrics is a point-in-time snapshot of a logger's queue and
+// backpressure counters. Counters are cumulative since the logger started.
+type LoggerMetrics struct {
+	Name              string        // Logger name
+	Enqueued          int64         // Events accepted into the buffer
+	Discarded         int64         // Events dropped by the buffer-full policy
+	QueueDepth        int           // Events currently buffered
+	QueueCapacity     int           // Buffer capacity
+	Blocked           int64         // Append calls that found the buffer full and had to wait or evict
+	BlockedTime       time.Duration // Total time spent in those calls
+	MaxEnqueueLatency time.Duration // Longest single Append spent waiting for space
+}
+
+// AppenderMetrics is a point-in-time snapshot of an appender's write
+// counters. Counters are cumulative since the appender was created, except
+// WriteTime and MaxWriteTime: writes are only timed once appender metrics
+// have been read for the first time, so an application that never reads
+// them pays no clock reads on the write path.
+type AppenderMetrics struct {
+	Name         string        // Appender name
+	Writes       int64         // Events written
+	Errors       int64         // Write errors reported by the appender
+	WriteTime    time.Duration // Total time spent in Append
+	MaxWriteTime time.Duration // Longest single Append
+}
+
+// MetricsSnapshot groups the metrics of every configured logger and
+// appender that reports them.
+type MetricsSnapshot struct {
+	Loggers   []LoggerMetrics
+	Appenders []AppenderMetrics
+	Errors    int64 // Total errors passed to ReportError by the logging system
+}
+
+// LoggerMetricsReporter is implemented by loggers that expose runtime
+// metrics, such as AsyncLogger.
+type LoggerMetricsReporter interface {
+	Metrics() LoggerMetrics
+}
+
+// AppenderMetricsReporter is implemented by appenders that expose runtime
+// metrics. Every appender embedding AppenderBase implements it.
+type AppenderMetricsReporter interface {
+	Metrics() AppenderMetrics
+}
+
+// Metrics returns a snapshot of the metrics of the loggers and appenders
+// defined by the last successful Refresh, sorted by name. Appenders created
+// internally by a logger are reported as "<logger>/<file>" for the files of
+// a FileLogger or RollingFileLogger and "<logger>/console" for a
+// ConsoleLogger.
+func Metrics() MetricsSnapshot {
+	global.mutex.Lock()
+	defer global.mutex.Unlock()
+
+	var ret MetricsSnapshot
+	for _, name := range ordered.MapKeys(global.named) {
+		if r, ok := global.named[name].(LoggerMetricsReporter); ok {
+			m := r.Metrics()
+			m.Name = name
+			ret.Loggers = append(ret.Loggers, m)
+		}
+	}
+	appenders := make(map[string]AppenderMetricsReporter)
+	add := func(a Appender) {
+		if r, ok := a.(AppenderMetricsReporter); ok {
+			appenders[a.GetName()] = r
+		}
+	}
+	for _, a := range global.appenders {
+		add(a)
+	}
+	for _, l := range global.loggers {
+		if o, ok := l.(appenderOwner); ok {
+			for _, a := range o.ownedAppenders() {
+				add(a)
+			}
+		}
+	}
+	for _, name := range ordered.MapKeys(appenders) {
+		m := appenders[name].Metrics()
+		m.Name = name
+		ret.Appenders = append(ret.Appenders, m)
+	}
+	ret.Errors = errorCounter.Load()
+	return ret
+}
+
+// latencyCounter accumulates a count, a total and a maximum duration.
+type latencyCounter struct {
+	count atomic.Int64
+	total atomic.Int64
+	max   atomic.Int64
+}
+
+func (c *latencyCounter) record(d time.Duration) {
+	c.count.Add(1)
+	c.total.Add(int64(d))
+	for {
+		m := c.max.Load()
+		if int64(d) <= m || c.max.CompareAndSwap(m, int64(d)) {
+			return
+		}
+	}
+}
+
+// appenderCounters holds the counters behind AppenderBase.Metrics.
+type appenderCounters struct {
+	writes latencyCounter
+	errors atomic.Int64
+}
+
+// appenderOwner is implemented by loggers that create their own appenders
+// instead of referencing configured ones, such as RollingFileLogger.
+type appenderOwner interface {
+	ownedAppenders() []Appender
+}
+
+// timeWrites switches on write timing in AppenderRef. It is set by the first
+// read of appender metrics and never cleared.
+var timeWrites atomic.Bool
+
+// writeRecorder is implemented by appenders embedding AppenderBase so that
+// AppenderRef can attribute writes, and their latency once timed, to them.
+type writeRecorder interface {
+	countWrite()
+	recordWrite(d time.Duration)
+}
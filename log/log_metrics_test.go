@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// slowAppender blocks each write until released so the async buffer fills.
+type slowAppender struct {
+	DiscardAppender
+	release chan struct{}
+}
+
+func (a *slowAppender) Append(e *Event) { <-a.release }
+
+func TestAsyncLoggerMetrics(t *testing.T) {
+	a := &slowAppender{release: make(chan struct{})}
+	a.Name = "slow"
+	a.Metrics() // reading metrics switches on write timing
+	l := &AsyncLogger{
+		LoggerBase: LoggerBase{
+			Name:  "async",
+			Level: LevelRange{MinLevel: InfoLevel, MaxLevel: MaxLevel},
+		},
+		AppenderRefs: []*AppenderRef{
+			{Appender: a, Level: LevelRange{MinLevel: NoneLevel, MaxLevel: MaxLevel}},
+		},
+		BufferSize:   100,
+		OnBufferFull: BufferFullPolicyBlock,
+	}
+	assert.Error(t, l.Start()).Nil()
+
+	// Fill the buffer; the next Append blocks until the appender is released.
+	for range 101 {
+		l.Append(&Event{Level: InfoLevel})
+	}
+	m := l.Metrics()
+	assert.That(t, m.QueueCapacity).Equal(100)
+	assert.That(t, m.QueueDepth > 0).True()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(a.release)
+	}()
+	l.Append(&Event{Level: InfoLevel})
+	l.Stop()
+
+	m = l.Metrics()
+	assert.That(t, m.Name).Equal("async")
+	assert.That(t, m.Enqueued).Equal(int64(102))
+	assert.That(t, m.Discarded).Equal(int64(0))
+	assert.That(t, m.Blocked >= 1).True()
+	assert.That(t, m.MaxEnqueueLatency >= 10*time.Millisecond).True()
+	assert.That(t, m.BlockedTime >= m.MaxEnqueueLatency).True()
+
+	am := a.Metrics()
+	assert.That(t, am.Name).Equal("slow")
+	assert.That(t, am.Writes).Equal(int64(102))
+	assert.That(t, am.MaxWriteTime > 0).True()
+}
+
+func TestAppenderErrorMetrics(t *testing.T) {
+	saved := Stdout
+	Stdout = failingWriter{}
+	defer func() { Stdout = saved }()
+
+	// Until metrics are read, writes are counted but not timed.
+	timing := timeWrites.Swap(false)
+	defer timeWrites.Store(timing)
+
+	before := errorCounter.Load()
+	a := &ConsoleAppender{AppenderBase: AppenderBase{Layout: &TextLayout{}}}
+	r := &AppenderRef{Appender: a, Level: LevelRange{MinLevel: NoneLevel, MaxLevel: MaxLevel}}
+	r.Append(&Event{Level: InfoLevel})
+	r.Append(&Event{Level: InfoLevel})
+
+	m := a.Metrics()
+	assert.That(t, m.Writes).Equal(int64(2))
+	assert.That(t, m.WriteTime).Equal(time.Duration(0))
+	assert.That(t, timeWrites.Load()).True()
+	assert.That(t, m.Errors).Equal(int64(2))
+	assert.That(t, errorCounter.Load()-before).Equal(int64(2))
+}
+
+func TestMetricsIncludesRollingFileAppenders(t *testing.T) {
+	l := &RollingFileLogger{
+		LoggerBase: LoggerBase{
+			Name:  "app",
+			Level: LevelRange{MinLevel: InfoLevel, MaxLevel: MaxLevel},
+		},
+		Layout:   &TextLayout{},
+		FileDir:  t.TempDir(),
+		FileName: "app.log",
+		Separate: true,
+		Interval: time.Hour,
+	}
+	assert.Error(t, l.Start()).Nil()
+	defer l.Stop()
+
+	global.mutex.Lock()
+	saved, savedNamed := global.loggers, global.named
+	global.loggers, global.named = []Logger{l}, map[string]Logger{"app": l}
+	global.mutex.Unlock()
+	defer func() {
+		global.mutex.Lock()
+		global.loggers, global.named = saved, savedNamed
+		global.mutex.Unlock()
+	}()
+
+	l.Append(&Event{Level: InfoLevel})
+	l.Append(&Event{Level: ErrorLevel})
+
+	m := Metrics()
+	writes := map[string]int64{}
+	for _, a := range m.Appenders {
+		writes[a.Name] = a.Writes
+	}
+	assert.That(t, writes["app/app.log"]).Equal(int64(1))
+	assert.That(t, writes["app/app.log.wf"]).Equal(int64(1))
+}
+
+func TestMetricsIncludesConsoleAndFileAppenders(t *testing.T) {
+	saved := Stdout
+	Stdout = failingWriter{}
+	defer func() { Stdout = saved }()
+
+	level := LevelRange{MinLevel: InfoLevel, MaxLevel: MaxLevel}
+	console := &ConsoleLogger{LoggerBase: LoggerBase{Name: "stdout", Level: level}, Layout: &TextLayout{}}
+	file := &FileLogger{LoggerBase: LoggerBase{Name: "app", Level: level}, Layout: &TextLayout{}, FileDir: t.TempDir(), FileName: "app.log"}
+	assert.Error(t, console.Start()).Nil()
+	defer console.Stop()
+	assert.Error(t, file.Start()).Nil()
+	defer file.Stop()
+
+	global.mutex.Lock()
+	saved2, savedNamed := global.loggers, global.named
+	global.loggers = []Logger{console, file}
+	global.named = map[string]Logger{"stdout": console, "app": file}
+	global.mutex.Unlock()
+	defer func() {
+		global.mutex.Lock()
+		global.loggers, global.named = saved2, savedNamed
+		global.mutex.Unlock()
+	}()
+
+	console.Append(&Event{Level: InfoLevel})
+	file.Append(&Event{Level: InfoLevel})
+	file.Append(&Event{Level: DebugLevel}) // filtered by the logger
+
+	writes := map[string]int64{}
+	for _, a := range Metrics().Appenders {
+		writes[a.Name] = a.Writes
+	}
+	assert.That(t, writes["stdout/console"]).Equal(int64(1))
+	assert.That(t, writes["app/app.log"]).Equal(int64(1))
+}
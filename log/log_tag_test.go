@@ -160,3 +160,14 @@ func TestRegisterTags(t *testing.T) {
 	assert.That(t, tag2).NotNil()
 	assert.String(t, tag2.tag).Equal("_rpc_http")
 }
+
+func TestBuildTagLimits(t *testing.T) {
+	// A three-segment subtype fits under the "infra" main type ...
+	assert.That(t, isValidTag(BuildTag("infra", "starter_saga_gorm", ""))).True()
+	// ... a fourth segment does not, and neither does a tag past 36 bytes.
+	assert.That(t, isValidTag(BuildTag("infra", "starter_transaction_saga_gorm", ""))).False()
+	assert.That(t, isValidTag(BuildTag("infra", "starter_"+strings.Repeat("x", 24), ""))).False()
+	assert.Panic(t, func() {
+		RegisterInfraTag("starter_transaction_at_gorm", "")
+	}, "invalid log tag")
+}
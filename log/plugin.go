@@ -175,7 +175,7 @@ func injectAttribute(fv reflect.Value, ft reflect.StructField, prefix string, ta
 		return injectArrayAttribute(fv, ft, elemKey, attrTag, s)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String, reflect.Struct:
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String, reflect.Struct, reflect.Pointer:
 		return injectSingleAttribute(fv, ft, elemKey, attrTag, s)
 	default:
 		return errutil.Explain(nil, "unsupported inject type %s for field at %s", ft.Type.String(), prefix)
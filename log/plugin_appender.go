@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 	"unicode"
+	"unsafe"
 
 	"go-spring.org/stdlib/errutil"
 )
@@ -111,19 +112,51 @@ func putBuffer(buf *bytes.Buffer) {
 // Otherwise, the event is encoded using the layout into a temporary buffer.
 // Any write errors are reported via ReportError.
 func WriteEvent(w io.Writer, e *Event, layout Layout) {
+	if err := writeEvent(w, e, layout); err != nil {
+		reportError(err)
+	}
+}
+
+// writeEvent is WriteEvent returning the write error to the caller, so
+// built-in appenders can attribute it to themselves.
+func writeEvent(w io.Writer, e *Event, layout Layout) error {
 	if e.RawBytes != nil {
-		if _, err := w.Write(e.RawBytes); err != nil {
-			ReportError(err)
+		b, ok := rawBytes(e, layout)
+		if !ok {
+			return nil
 		}
-		return
+		_, err := w.Write(b)
+		return err
 	}
 
 	buf := getBuffer()
 	defer putBuffer(buf)
 	layout.EncodeTo(e, buf)
-	if _, err := w.Write(buf.Bytes()); err != nil {
-		ReportError(err)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// textRedactor is implemented by layouts that embed BaseLayout.
+type textRedactor interface {
+	RedactText(tag, s string) (string, bool)
+}
+
+// rawBytes returns e.RawBytes after the layout's redaction rules, and false
+// when a drop rule discarded them.
+func rawBytes(e *Event, layout Layout) ([]byte, bool) {
+	r, ok := layout.(textRedactor)
+	if !ok {
+		return e.RawBytes, true
+	}
+	s := unsafe.String(unsafe.SliceData(e.RawBytes), len(e.RawBytes))
+	out, keep := r.RedactText(e.Tag, s)
+	if !keep {
+		return nil, false
+	}
+	if unsafe.StringData(out) == unsafe.StringData(s) {
+		return e.RawBytes, true
 	}
+	return []byte(out), true
 }
 
 // Appender defines components responsible for writing log events.
@@ -141,11 +174,37 @@ type Appender interface {
 type AppenderBase struct {
 	Name   string `PluginAttribute:"name"`
 	Layout Layout `PluginElement:"layout,default=TextLayout"`
+
+	counters appenderCounters
 }
 
 // GetName returns the appender's name.
 func (c *AppenderBase) GetName() string { return c.Name }
 
+// ReportError records a write failure against this appender and forwards
+// it to the package-level ReportError hook. Custom appenders should use it
+// instead of calling ReportError directly so the failure shows up in Metrics.
+func (c *AppenderBase) ReportError(err error) {
+	c.counters.errors.Add(1)
+	reportError(err)
+}
+
+// Metrics returns a snapshot of the appender's write counters. The first
+// call switches on write timing for every appender.
+func (c *AppenderBase) Metrics() AppenderMetrics {
+	timeWrites.Store(true)
+	return AppenderMetrics{
+		Name:         c.Name,
+		Writes:       c.counters.writes.count.Load(),
+		Errors:       c.counters.errors.Load(),
+		WriteTime:    time.Duration(c.counters.writes.total.Load()),
+		MaxWriteTime: time.Duration(c.counters.writes.max.Load()),
+	}
+}
+
+func (c *AppenderBase) countWrite()                 { c.counters.writes.count.Add(1) }
+func (c *AppenderBase) recordWrite(d time.Duration) { c.counters.writes.record(d) }
+
 var (
 	_ Appender = (*DiscardAppender)(nil)
 	_ Appender = (*ConsoleAppender)(nil)
@@ -173,7 +232,9 @@ func (c *ConsoleAppender) Stop()        {}
 
 // Append formats the event and writes it to standard output.
 func (c *ConsoleAppender) Append(e *Event) {
-	WriteEvent(Stdout, e, c.Layout)
+	if err := writeEvent(Stdout, e, c.Layout); err != nil {
+		c.ReportError(err)
+	}
 }
 
 func (c *ConsoleAppender) ConcurrentSafe() bool { return true }
@@ -208,7 +269,9 @@ func (c *FileAppender) Stop() {
 
 // Append formats the log event and writes it to the file.
 func (c *FileAppender) Append(e *Event) {
-	WriteEvent(c.file, e, c.Layout)
+	if err := writeEvent(c.file, e, c.Layout); err != nil {
+		c.ReportError(err)
+	}
 }
 
 func (c *FileAppender) ConcurrentSafe() bool { return true }
@@ -260,10 +323,12 @@ func (c *RollingFileAppender) Append(e *Event) {
 		file, err = c.writer.Rotate()
 	}
 	if err != nil {
-		ReportError(err)
+		c.ReportError(err)
 	}
 	if file != nil {
-		WriteEvent(file, e, c.Layout)
+		if err = writeEvent(file, e, c.Layout); err != nil {
+			c.ReportError(err)
+		}
 	}
 }
 
@@ -32,9 +32,48 @@ type Layout interface {
 	EncodeTo(e *Event, w Writer)
 }
 
-// BaseLayout provides common utilities for layouts, e.g., file:line formatting.
+// BaseLayout provides common utilities for layouts, e.g., file:line formatting
+// and redaction of sensitive fields.
 type BaseLayout struct {
-	FileLineMaxLength int `PluginAttribute:"fileLineMaxLength,default=48"`
+	FileLineMaxLength int           `PluginAttribute:"fileLineMaxLength,default=48"`
+	Redact            []*RedactRule `PluginElement:"redact?"`
+}
+
+// EncodeEventFields encodes the context fields and the fields of a log
+// event, applying the Redact rules first. Without rules it is equivalent to
+// calling EncodeFields on both slices.
+func (c *BaseLayout) EncodeEventFields(enc Encoder, e *Event) {
+	if len(c.Redact) == 0 {
+		EncodeFields(enc, e.CtxFields)
+		EncodeFields(enc, e.Fields)
+		return
+	}
+	ctxFields, _ := redactFields(c.Redact, e.Tag, e.CtxFields)
+	fields, _ := redactFields(c.Redact, e.Tag, e.Fields)
+	EncodeFields(enc, ctxFields)
+	EncodeFields(enc, fields)
+}
+
+// RedactText applies the Redact rules to text logged without a field key:
+// the context string of an event and the raw bytes written through
+// LoggerWrapper.Write. Only rules with a value pattern and no key apply.
+// It returns keep=false when a drop rule matched, and s itself when
+// nothing was rewritten.
+func (c *BaseLayout) RedactText(tag, s string) (_ string, keep bool) {
+	if len(c.Redact) == 0 {
+		return s, true
+	}
+	s, keep, _ = redactText(c.Redact, tag, s)
+	return s, keep
+}
+
+// ctxString returns the context string of e after redaction.
+func (c *BaseLayout) ctxString(e *Event) string {
+	if e.CtxString == "" {
+		return ""
+	}
+	s, _ := c.RedactText(e.Tag, e.CtxString)
+	return s
 }
 
 // GetFileLine returns the "file:line" string for a log event.
@@ -70,16 +109,15 @@ func (c *TextLayout) EncodeTo(e *Event, w Writer) {
 	_, _ = w.WriteString("] ")
 	_, _ = w.WriteString(e.Tag)
 	_, _ = w.WriteString(separator)
-	if e.CtxString != "" {
-		_, _ = w.WriteString(e.CtxString)
+	if s := c.ctxString(e); s != "" {
+		_, _ = w.WriteString(s)
 		_, _ = w.WriteString(separator)
 	}
 
 	// Encode structured fields
 	enc := NewTextEncoder(w, separator)
 	enc.AppendEncoderBegin()
-	EncodeFields(enc, e.CtxFields)
-	EncodeFields(enc, e.Fields)
+	c.EncodeEventFields(enc, e)
 	enc.AppendEncoderEnd()
 
 	_ = w.WriteByte('\n')
@@ -100,13 +138,12 @@ func (c *JSONLayout) EncodeTo(e *Event, w Writer) {
 	String("time", e.Time.Format("2006-01-02T15:04:05.000")).Encode(enc)
 	String("fileLine", c.GetFileLine(e)).Encode(enc)
 	String("tag", e.Tag).Encode(enc)
-	if e.CtxString != "" {
-		String("ctxString", e.CtxString).Encode(enc)
+	if s := c.ctxString(e); s != "" {
+		String("ctxString", s).Encode(enc)
 	}
 
 	// Encode structured fields
-	EncodeFields(enc, e.CtxFields)
-	EncodeFields(enc, e.Fields)
+	c.EncodeEventFields(enc, e)
 	enc.AppendEncoderEnd()
 
 	_ = w.WriteByte('\n')
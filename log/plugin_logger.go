@@ -57,11 +57,26 @@ type AppenderRef struct {
 	Level LevelRange `PluginAttribute:"level,default="`
 }
 
-// Append forwards the event to the referenced appender if the level matches.
+// Append forwards the event to the referenced appender if the level matches,
+// counting the write for appenders that report metrics and timing it once
+// metrics have been read. Appenders that do not record metrics are not timed.
 func (c *AppenderRef) Append(e *Event) {
-	if c.Level.Enable(e.Level) {
+	if !c.Level.Enable(e.Level) {
+		return
+	}
+	r, ok := c.Appender.(writeRecorder)
+	if !ok {
 		c.Appender.Append(e)
+		return
 	}
+	if !timeWrites.Load() {
+		c.Appender.Append(e)
+		r.countWrite()
+		return
+	}
+	start := time.Now()
+	c.Appender.Append(e)
+	r.recordWrite(time.Since(start))
 }
 
 // AppenderRefs is implemented by loggers that support appender references.
@@ -107,6 +122,12 @@ func (c *LoggerBase) GetLevel() LevelRange {
 // installs an override (use the configured value to revert semantics).
 func (c *LoggerBase) SetLevel(r LevelRange) { c.override.Store(&r) }
 
+// accept reports whether the event passes the logger's level range, or was
+// already admitted upstream by a context-scoped override (see WithLevel).
+func (c *LoggerBase) accept(e *Event) bool {
+	return e.Elevated || c.GetLevel().Enable(e.Level)
+}
+
 var (
 	_ Logger = (*DiscardLogger)(nil)
 	_ Logger = (*ConsoleLogger)(nil)
@@ -133,7 +154,7 @@ func (c *SyncLogger) Stop()        {}
 
 // Append sends the event directly to appenders.
 func (c *SyncLogger) Append(e *Event) {
-	if c.GetLevel().Enable(e.Level) {
+	if c.accept(e) {
 		for _, r := range c.AppenderRefs {
 			r.Append(e)
 		}
@@ -176,7 +197,9 @@ type AsyncLogger struct {
 	wait chan struct{} // Waiting for the worker goroutine to finish
 	stop *Event        // Sentinel value used to signal shutdown
 
-	discardCounter atomic.Int64 // Count of discarded events
+	discardCounter atomic.Int64   // Count of discarded events
+	enqueueCounter atomic.Int64   // Count of events accepted into the buffer
+	blocked        latencyCounter // Appends that found the buffer full
 }
 
 // GetDiscardCounter returns the total number of discarded events.
@@ -184,6 +207,20 @@ func (c *AsyncLogger) GetDiscardCounter() int64 {
 	return c.discardCounter.Load()
 }
 
+// Metrics returns a snapshot of the logger's queue and backpressure counters.
+func (c *AsyncLogger) Metrics() LoggerMetrics {
+	return LoggerMetrics{
+		Name:              c.Name,
+		Enqueued:          c.enqueueCounter.Load(),
+		Discarded:         c.discardCounter.Load(),
+		QueueDepth:        len(c.buf),
+		QueueCapacity:     cap(c.buf),
+		Blocked:           c.blocked.count.Load(),
+		BlockedTime:       time.Duration(c.blocked.total.Load()),
+		MaxEnqueueLatency: time.Duration(c.blocked.max.Load()),
+	}
+}
+
 // GetAppenderRefs returns false for async mode and the appender references.
 func (c *AsyncLogger) GetAppenderRefs() (syncMode bool, _ []*AppenderRef) {
 	return false, c.AppenderRefs
@@ -230,19 +267,21 @@ func (c *AsyncLogger) Stop() {
 // Append enqueues a log event into the async buffer.
 // Behavior on full buffer depends on BufferFullPolicy.
 func (c *AsyncLogger) Append(e *Event) {
-	if !c.GetLevel().Enable(e.Level) {
+	if !c.accept(e) {
 		e.Reset()
 		return
 	}
 
 	select {
 	case c.buf <- e:
+		c.enqueueCounter.Add(1)
 		return
 	default:
 	}
 
 	switch c.OnBufferFull {
 	case BufferFullPolicyDropOldest:
+		start := time.Now()
 		for {
 			select {
 			case x := <-c.buf: // Remove one element to make space
@@ -252,12 +291,17 @@ func (c *AsyncLogger) Append(e *Event) {
 			}
 			select {
 			case c.buf <- e:
+				c.enqueueCounter.Add(1)
+				c.blocked.record(time.Since(start))
 				return
 			default: // for linter
 			}
 		}
 	case BufferFullPolicyBlock:
+		start := time.Now()
 		c.buf <- e // Block until space is available
+		c.enqueueCounter.Add(1)
+		c.blocked.record(time.Since(start))
 	case BufferFullPolicyDiscard:
 		c.discardCounter.Add(1)
 		e.Reset()
@@ -277,17 +321,19 @@ func (d *DiscardLogger) Append(e *Event) { e.Reset() }
 // ConsoleLogger writes log events to standard output.
 type ConsoleLogger struct {
 	LoggerBase
-	appender *ConsoleAppender
+	appender *AppenderRef
 	Layout   Layout `PluginElement:"layout,default=TextLayout"`
 }
 
-// Start initializes the console appender and starts it.
+// Start initializes the console appender, named "<logger>/console", and
+// starts it.
 func (c *ConsoleLogger) Start() error {
-	c.appender = &ConsoleAppender{
+	c.appender = ownedRef(&ConsoleAppender{
 		AppenderBase: AppenderBase{
+			Name:   c.Name + "/console",
 			Layout: c.Layout,
 		},
-	}
+	})
 	// Append operation is not managed by the framework,
 	// so we start the appender manually.
 	return c.appender.Start()
@@ -302,12 +348,17 @@ func (c *ConsoleLogger) Stop() {
 
 // Append writes the event to the console if its level is enabled.
 func (c *ConsoleLogger) Append(e *Event) {
-	if c.GetLevel().Enable(e.Level) {
+	if c.accept(e) {
 		c.appender.Append(e)
 	}
 	e.Reset()
 }
 
+// ownedAppenders returns the console appender so Metrics reports it.
+func (c *ConsoleLogger) ownedAppenders() []Appender {
+	return []Appender{c.appender.Appender}
+}
+
 // FileLogger writes log events to a file.
 type FileLogger struct {
 	LoggerBase
@@ -315,18 +366,20 @@ type FileLogger struct {
 	FileDir  string `PluginAttribute:"dir,default=./logs"`
 	FileName string `PluginAttribute:"file"`
 
-	appender *FileAppender
+	appender *AppenderRef
 }
 
-// Start initializes the file appender and starts it.
+// Start initializes the file appender, named "<logger>/<file>", and starts
+// it.
 func (c *FileLogger) Start() error {
-	c.appender = &FileAppender{
+	c.appender = ownedRef(&FileAppender{
 		AppenderBase: AppenderBase{
+			Name:   c.Name + "/" + c.FileName,
 			Layout: c.Layout,
 		},
 		FileDir:  c.FileDir,
 		FileName: c.FileName,
-	}
+	})
 	// Append operation is not managed by the framework,
 	// so we start the appender manually.
 	return c.appender.Start()
@@ -341,12 +394,24 @@ func (c *FileLogger) Stop() {
 
 // Append writes the log event to the file if its level is enabled.
 func (c *FileLogger) Append(e *Event) {
-	if c.GetLevel().Enable(e.Level) {
+	if c.accept(e) {
 		c.appender.Append(e)
 	}
 	e.Reset()
 }
 
+// ownedAppenders returns the file appender so Metrics reports it.
+func (c *FileLogger) ownedAppenders() []Appender {
+	return []Appender{c.appender.Appender}
+}
+
+// ownedRef wraps an appender created by a logger in a reference that passes
+// every level, the logger having filtered already, so its writes are counted
+// and timed like those of a configured appender.
+func ownedRef(a Appender) *AppenderRef {
+	return &AppenderRef{Appender: a, Level: LevelRange{MinLevel: NoneLevel, MaxLevel: MaxLevel}}
+}
+
 // RollingFileLogger writes log events to files with time-based rotation
 // and optional level-based separation. It supports both synchronous and
 // asynchronous modes.
@@ -414,6 +479,7 @@ func (f *RollingFileLogger) Start() error {
 		{
 			Appender: &RollingFileAppender{
 				AppenderBase: AppenderBase{
+					Name:   f.Name + "/" + f.FileName,
 					Layout: f.Layout,
 				},
 				FileDir:  f.FileDir,
@@ -422,8 +488,10 @@ func (f *RollingFileLogger) Start() error {
 				MaxAge:   f.MaxAge,
 				SyncLock: !f.AsyncWrite,
 			},
+			// The lower bound is enforced by the logger itself, which also
+			// lets context-elevated events and SetLevel overrides through.
 			Level: LevelRange{
-				MinLevel: f.Level.MinLevel,
+				MinLevel: NoneLevel,
 				MaxLevel: normalMaxLevel,
 			},
 		},
@@ -434,6 +502,7 @@ func (f *RollingFileLogger) Start() error {
 		f.appenders = append(f.appenders, &AppenderRef{
 			Appender: &RollingFileAppender{
 				AppenderBase: AppenderBase{
+					Name:   f.Name + "/" + f.FileName + ".wf",
 					Layout: f.Layout,
 				},
 				FileDir:  f.FileDir,
@@ -501,9 +570,8 @@ func (f *RollingFileLogger) Stop() {
 
 // SetLevel overrides the level range at runtime and propagates the override to
 // the internal sync/async logger so the change takes effect on the hot path.
-// Note: in separate mode the per-file appender-level splits (normal vs .wf) are
-// fixed at Start; lowering the level below the originally configured minimum is
-// bounded by those appender refs.
+// Note: in separate mode the per-file appender-level split (normal vs .wf) is
+// fixed at Start.
 func (f *RollingFileLogger) SetLevel(r LevelRange) {
 	f.LoggerBase.SetLevel(r)
 	if f.logger != nil {
@@ -511,9 +579,30 @@ func (f *RollingFileLogger) SetLevel(r LevelRange) {
 	}
 }
 
+// Metrics reports the internal async logger's counters; in sync mode there
+// is no queue and only the name is set.
+func (f *RollingFileLogger) Metrics() LoggerMetrics {
+	if r, ok := f.logger.(LoggerMetricsReporter); ok {
+		m := r.Metrics()
+		m.Name = f.Name
+		return m
+	}
+	return LoggerMetrics{Name: f.Name}
+}
+
+// ownedAppenders returns the appenders created by Start, named
+// "<logger>/<file>", so Metrics reports them alongside configured ones.
+func (f *RollingFileLogger) ownedAppenders() []Appender {
+	ret := make([]Appender, len(f.appenders))
+	for i, a := range f.appenders {
+		ret[i] = a.Appender
+	}
+	return ret
+}
+
 // Append forwards the log event to the internal logger when enabled.
 func (f *RollingFileLogger) Append(e *Event) {
-	if !f.GetLevel().Enable(e.Level) {
+	if !f.accept(e) {
 		e.Reset()
 		return
 	}
@@ -0,0 +1,307 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+	"unsafe"
+
+	"go-spring.org/stdlib/errutil"
+	"go-spring.org/stdlib/ordered"
+)
+
+func init() {
+	RegisterConverter(ParseRedactAction)
+	RegisterConverter(compileRegexp)
+}
+
+// compileRegexp compiles a regular expression attribute. An empty string
+// yields a nil pattern, meaning "not configured".
+func compileRegexp(s string) (*regexp.Regexp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, errutil.Explain(err, "invalid regexp %q", s)
+	}
+	return re, nil
+}
+
+// RedactAction is what a RedactRule does to the data it matches.
+type RedactAction string
+
+const (
+	RedactMask = RedactAction("mask") // Replace with the rule's mask string
+	RedactHash = RedactAction("hash") // Replace with a short SHA-256 digest, keeping values correlatable
+	RedactDrop = RedactAction("drop") // Remove the whole field
+)
+
+// ParseRedactAction converts a string (case-insensitive) to a RedactAction.
+func ParseRedactAction(s string) (RedactAction, error) {
+	switch a := RedactAction(strings.ToLower(strings.TrimSpace(s))); a {
+	case RedactMask, RedactHash, RedactDrop:
+		return a, nil
+	default:
+		return "", errutil.Explain(nil, "invalid redact action %q", s)
+	}
+}
+
+// RedactRule describes sensitive data to be rewritten by a layout before
+// the encoded bytes reach an appender. Rules are configured as the
+// "redact" element of a layout, for example:
+//
+//	logging.appender.file.layout.redact[0].key=password,*token*
+//	logging.appender.file.layout.redact[1].value=[0-9]{13,19}
+//	logging.appender.file.layout.redact[1].action=hash
+//	logging.appender.file.layout.redact[1].tag=_biz_pay_*
+//
+// A rule with Key only rewrites the whole value of every matching field,
+// whatever its type. A rule with Value rewrites the substrings matching the
+// regexp in string fields (including msg and strings nested in Object),
+// error messages, and the JSON encoding of array and reflected values;
+// combined with Key it only looks at matching fields. The drop action
+// removes the field in both cases. A rule with Value but no Key also
+// rewrites the event's context string and the raw bytes written through
+// LoggerWrapper.Write, where drop discards the whole text.
+type RedactRule struct {
+	Key    []string       `PluginAttribute:"key,default="`        // Field key globs (path.Match syntax)
+	Value  *regexp.Regexp `PluginAttribute:"value,default="`      // Pattern for sensitive substrings
+	Tag    []string       `PluginAttribute:"tag,default="`        // Exact tags or "_*" suffix patterns; empty means every tag
+	Action RedactAction   `PluginAttribute:"action,default=mask"` // mask, hash or drop
+	Mask   string         `PluginAttribute:"mask,default=******"` // Replacement used by the mask action
+	Salt   string         `PluginAttribute:"salt,default="`       // Prepended to values before hashing
+}
+
+// matchTag reports whether the rule applies to events with the given tag.
+func (r *RedactRule) matchTag(tag string) bool {
+	matched := true
+	for _, s := range r.Tag {
+		if s == "" {
+			continue
+		}
+		if s == tag {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(s, "*"); ok && strings.HasPrefix(tag, prefix) {
+			return true
+		}
+		matched = false
+	}
+	return matched
+}
+
+// matchKey reports whether the rule applies to a field with the given key,
+// and whether the rule restricts keys at all.
+func (r *RedactRule) matchKey(key string) (matched, restricted bool) {
+	for _, s := range r.Key {
+		if s == "" {
+			continue
+		}
+		restricted = true
+		if ok, _ := path.Match(s, key); ok {
+			return true, true
+		}
+	}
+	return !restricted, restricted
+}
+
+// replace returns the replacement for a sensitive string s.
+func (r *RedactRule) replace(s string) string {
+	if r.Action == RedactHash {
+		sum := sha256.Sum256([]byte(r.Salt + s))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return r.Mask
+}
+
+// apply rewrites f according to the rule. It returns keep=false when the
+// field must be dropped and changed=true when f was rewritten.
+func (r *RedactRule) apply(f Field) (_ Field, keep, changed bool) {
+	matched, restricted := r.matchKey(f.Key)
+	if !matched {
+		return f, true, false
+	}
+
+	// Key-only rule: the whole value is sensitive.
+	if r.Value == nil {
+		if !restricted {
+			return f, true, false
+		}
+		if r.Action == RedactDrop {
+			return f, false, true
+		}
+		return String(f.Key, r.replace(fieldText(f))), true, true
+	}
+
+	var s string
+	switch f.Type {
+	case ValueTypeString:
+		s = unsafe.String(f.Any.(*byte), f.Num)
+	case ValueTypeError:
+		s = f.Any.(error).Error()
+	case ValueTypeArray, ValueTypeReflect:
+		if f.Any == nil {
+			return f, true, false
+		}
+		s = valueJSON(f)
+	default:
+		return f, true, false
+	}
+	if !r.Value.MatchString(s) {
+		return f, true, false
+	}
+	if r.Action == RedactDrop {
+		return f, false, true
+	}
+	out := r.Value.ReplaceAllStringFunc(s, r.replace)
+	if f.Type == ValueTypeArray || f.Type == ValueTypeReflect {
+		// A match that spans JSON syntax leaves invalid JSON behind; the
+		// whole value is rewritten then, as a key-only rule would do.
+		if !json.Valid([]byte(out)) {
+			return String(f.Key, r.replace(s)), true, true
+		}
+		return Reflect(f.Key, json.RawMessage(out)), true, true
+	}
+	return String(f.Key, out), true, true
+}
+
+// redactText rewrites s with the rules that have Value but no Key, for text
+// logged without a field key. It returns keep=false when a drop rule matched.
+func (r *RedactRule) redactText(s string) (_ string, keep, changed bool) {
+	if r.Value == nil || !r.Value.MatchString(s) {
+		return s, true, false
+	}
+	if _, restricted := r.matchKey(""); restricted {
+		return s, true, false
+	}
+	if r.Action == RedactDrop {
+		return "", false, true
+	}
+	return r.Value.ReplaceAllStringFunc(s, r.replace), true, true
+}
+
+// fieldText returns the text hashed for a whole-value rewrite: the string
+// itself for string fields, the JSON encoding of the value otherwise.
+func fieldText(f Field) string {
+	if f.Type == ValueTypeString {
+		return unsafe.String(f.Any.(*byte), f.Num)
+	}
+	var buf bytes.Buffer
+	enc := NewJSONEncoder(&buf)
+	enc.AppendEncoderBegin()
+	f.Encode(enc)
+	enc.AppendEncoderEnd()
+	return buf.String()
+}
+
+// valueJSON returns the JSON encoding of an array or reflected field value,
+// which is what a value rule matches for them.
+func valueJSON(f Field) string {
+	var buf bytes.Buffer
+	enc := NewJSONEncoder(&buf)
+	if f.Type == ValueTypeArray {
+		enc.AppendArrayBegin()
+		f.Any.(ArrayValue).EncodeArray(enc)
+		enc.AppendArrayEnd()
+	} else {
+		enc.AppendReflect(f.Any)
+	}
+	return buf.String()
+}
+
+// redactText applies the rules that match tag to text logged without a
+// field key. It returns keep=false when a drop rule matched.
+func redactText(rules []*RedactRule, tag, s string) (_ string, keep, changed bool) {
+	for _, r := range rules {
+		if !r.matchTag(tag) {
+			continue
+		}
+		var ok bool
+		if s, keep, ok = r.redactText(s); !keep {
+			return "", false, true
+		}
+		changed = changed || ok
+	}
+	return s, true, changed
+}
+
+// redactFields applies the rules that match tag to fields. It returns
+// fields itself and false when nothing was rewritten, so the common case
+// does not allocate.
+func redactFields(rules []*RedactRule, tag string, fields []Field) ([]Field, bool) {
+	var out []Field
+	for i, f := range fields {
+		var redacted []Field
+		keep, changed := true, false
+
+		if f.Type == ValueTypeFromMap {
+			// Expand the map so that rules see its individual keys.
+			m := f.Any.(map[string]any)
+			expanded := make([]Field, 0, len(m))
+			for _, k := range ordered.MapKeys(m) {
+				expanded = append(expanded, Any(k, m[k]))
+			}
+			redacted, _ = redactFields(rules, tag, expanded)
+			keep, changed = false, true
+		} else {
+			f, keep, changed = redactField(rules, tag, f)
+		}
+
+		if changed && out == nil {
+			out = make([]Field, i, len(fields)+len(redacted))
+			copy(out, fields[:i])
+		}
+		if out != nil {
+			if keep {
+				out = append(out, f)
+			}
+			out = append(out, redacted...)
+		}
+	}
+	if out == nil {
+		return fields, false
+	}
+	return out, true
+}
+
+// redactField applies every matching rule to f in order, then descends into
+// nested objects that survived.
+func redactField(rules []*RedactRule, tag string, f Field) (_ Field, keep, changed bool) {
+	for _, r := range rules {
+		if !r.matchTag(tag) {
+			continue
+		}
+		var ok bool
+		if f, keep, ok = r.apply(f); !keep {
+			return f, false, true
+		}
+		changed = changed || ok
+	}
+	if f.Type == ValueTypeObject {
+		if sub, ok := redactFields(rules, tag, f.Any.([]Field)); ok {
+			return Object(f.Key, sub...), true, true
+		}
+	}
+	return f, true, changed
+}
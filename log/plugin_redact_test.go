@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-spring.org/stdlib/flatten"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func newRedactLayout(t *testing.T, m map[string]string) *JSONLayout {
+	s := flatten.NewPropertiesStorage(flatten.NewProperties(m))
+	v, err := newPlugin(reflect.TypeFor[JSONLayout](), "layout", s)
+	assert.Error(t, err).Nil()
+	return v.Interface().(*JSONLayout)
+}
+
+func encodeRedacted(l Layout, e *Event) string {
+	buf := bytes.NewBuffer(nil)
+	l.EncodeTo(e, buf)
+	return buf.String()
+}
+
+func TestRedactRule(t *testing.T) {
+
+	t.Run("invalid config", func(t *testing.T) {
+		s := flatten.NewPropertiesStorage(flatten.NewProperties(map[string]string{
+			"layout.redact[0].action": "encrypt",
+		}))
+		_, err := newPlugin(reflect.TypeFor[JSONLayout](), "layout", s)
+		assert.Error(t, err).Matches("invalid redact action")
+
+		s = flatten.NewPropertiesStorage(flatten.NewProperties(map[string]string{
+			"layout.redact[0].value": "(",
+		}))
+		_, err = newPlugin(reflect.TypeFor[JSONLayout](), "layout", s)
+		assert.Error(t, err).Matches("invalid regexp")
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		l := newRedactLayout(t, nil)
+		assert.That(t, len(l.Redact)).Equal(0)
+		fields := []Field{String("password", "secret")}
+		out, changed := redactFields(l.Redact, "_app_def", fields)
+		assert.That(t, changed).False()
+		assert.That(t, &out[0]).Equal(&fields[0])
+	})
+
+	t.Run("key, value and tag", func(t *testing.T) {
+		l := newRedactLayout(t, map[string]string{
+			"layout.redact[0].key":    "password, *token*",
+			"layout.redact[1].key":    "card",
+			"layout.redact[1].action": "hash",
+			"layout.redact[2].key":    "ssn",
+			"layout.redact[2].action": "drop",
+			"layout.redact[3].value":  `[a-z0-9.]+@[a-z0-9.]+`,
+			"layout.redact[3].mask":   "<email>",
+			"layout.redact[4].key":    "amount",
+			"layout.redact[4].tag":    "_biz_pay_*",
+		})
+		assert.That(t, len(l.Redact)).Equal(5)
+
+		e := &Event{
+			Level:     InfoLevel,
+			Tag:       "_biz_pay_order",
+			CtxFields: []Field{String("access_token", "abc")},
+			Fields: []Field{
+				Msg("mail sent to bob@example.com"),
+				String("password", "secret"),
+				String("card", "4111111111111111"),
+				Int("ssn", 123456789),
+				Int("amount", 100),
+				Object("user", String("password", "p"), String("name", "bob")),
+				FieldsFromMap(map[string]any{"refresh_token": "x", "n": 1}),
+				Err(errors.New("no user alice@example.com")),
+			},
+		}
+		assert.String(t, encodeRedacted(l, e)).JSONEqual(`{
+			"level": "info",
+			"time": "0001-01-01T00:00:00.000",
+			"fileLine": ":0",
+			"tag": "_biz_pay_order",
+			"access_token": "******",
+			"msg": "mail sent to <email>",
+			"password": "******",
+			"card": "sha256:9bbef19476623ca5",
+			"amount": "******",
+			"user": {"password": "******", "name": "bob"},
+			"n": 1,
+			"refresh_token": "******",
+			"error": "no user <email>"
+		}`)
+
+		e.Tag = "_app_def"
+		e.Fields = []Field{Int("amount", 100)}
+		assert.String(t, encodeRedacted(l, e)).Contains(`"amount":100`)
+	})
+
+	t.Run("arrays, reflect and context string", func(t *testing.T) {
+		l := newRedactLayout(t, map[string]string{
+			"layout.redact[0].value": `[a-z0-9.]+@[a-z0-9.]+`,
+			"layout.redact[0].mask":  "<email>",
+			"layout.redact[1].value": `"[a-z]+@`,
+		})
+		e := &Event{
+			Level:     InfoLevel,
+			Tag:       "_app_def",
+			CtxString: "user=bob@example.com",
+			Fields: []Field{
+				Strings("to", []string{"bob@example.com", "ops"}),
+				Reflect("user", map[string]any{"mail": "alice@example.com", "id": 7}),
+				Ints("ids", []int{1, 2}),
+			},
+		}
+		assert.String(t, encodeRedacted(l, e)).JSONEqual(`{
+			"level": "info",
+			"time": "0001-01-01T00:00:00.000",
+			"fileLine": ":0",
+			"tag": "_app_def",
+			"ctxString": "user=<email>",
+			"to": ["<email>", "ops"],
+			"user": {"id": 7, "mail": "<email>"},
+			"ids": [1, 2]
+		}`)
+
+		// A match spanning JSON syntax masks the whole value instead.
+		e.CtxString = ""
+		e.Fields = []Field{Strings("to", []string{"x@y"})}
+		l.Redact = l.Redact[1:]
+		assert.String(t, encodeRedacted(l, e)).Contains(`"to":"******"`)
+	})
+
+	t.Run("raw bytes", func(t *testing.T) {
+		l := newRedactLayout(t, map[string]string{
+			"layout.redact[0].value":  `\d{13,19}`,
+			"layout.redact[1].value":  `DROP`,
+			"layout.redact[1].action": "drop",
+			"layout.redact[2].key":    "password",
+			"layout.redact[2].value":  `.+`,
+		})
+		buf := bytes.NewBuffer(nil)
+		WriteEvent(buf, &Event{RawBytes: []byte("card 4111111111111111 password\n")}, l)
+		WriteEvent(buf, &Event{RawBytes: []byte("DROP me\n")}, l)
+		assert.String(t, buf.String()).Equal("card ****** password\n")
+
+		raw := []byte("nothing here\n")
+		b, ok := rawBytes(&Event{RawBytes: raw}, l)
+		assert.That(t, ok).True()
+		assert.That(t, &b[0]).Equal(&raw[0])
+	})
+}
@@ -0,0 +1,349 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"cmp"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-spring.org/stdlib/errutil"
+	"go-spring.org/stdlib/ordered"
+)
+
+func init() {
+	RegisterPlugin[RingBufferAppender]("RingBufferAppender")
+}
+
+// ringRegistry tracks started RingBufferAppenders by name so operational
+// tooling (e.g. an actuator "/logfile" endpoint) can reach them without
+// holding a reference to the logging configuration.
+var ringRegistry struct {
+	mutex sync.Mutex
+	rings map[string]*RingBufferAppender
+}
+
+// RingBuffers returns the running RingBufferAppenders sorted by name.
+func RingBuffers() []*RingBufferAppender {
+	ringRegistry.mutex.Lock()
+	defer ringRegistry.mutex.Unlock()
+	ret := make([]*RingBufferAppender, 0, len(ringRegistry.rings))
+	for _, name := range ordered.MapKeys(ringRegistry.rings) {
+		ret = append(ret, ringRegistry.rings[name])
+	}
+	return ret
+}
+
+// DumpRingBuffers writes every retained entry of every running
+// RingBufferAppender to w. It is intended for crash handlers, such as
+// DumpOnPanic.
+func DumpRingBuffers(w io.Writer) {
+	for _, r := range RingBuffers() {
+		r.dump(w, r.Entries(RingFilter{}))
+	}
+}
+
+// DumpOnPanic writes the ring buffers to Stdout if the calling goroutine is
+// panicking, then re-panics with the same value, so the crash report is
+// preceded by the recent log context. It must be deferred directly:
+//
+//	func main() {
+//		defer log.DumpOnPanic()
+//		...
+//	}
+func DumpOnPanic() {
+	if r := recover(); r != nil {
+		DumpRingBuffers(Stdout)
+		panic(r)
+	}
+}
+
+// RingEntry is a formatted snapshot of a log event retained by a
+// RingBufferAppender. Unlike Event it is immutable and safe to keep.
+type RingEntry struct {
+	Seq   uint64    // Monotonic sequence number within the appender
+	Time  time.Time // The timestamp of the event
+	Level Level     // The severity level of the event
+	Tag   string    // The tag of the event
+	Text  string    // The event rendered by the appender's layout, without the trailing newline
+}
+
+// RingFilter selects entries returned by RingBufferAppender.Entries.
+// The zero value matches everything.
+type RingFilter struct {
+	Tag      string    // Exact tag, or a "_*" suffix pattern like the logger config
+	MinLevel Level     // Only entries at or above this level
+	Since    time.Time // Only entries strictly after this time
+	Limit    int       // At most this many of the newest matching entries; 0 means all
+}
+
+// Match reports whether the entry satisfies the filter (ignoring Limit).
+func (f RingFilter) Match(e RingEntry) bool {
+	if e.Level.code < f.MinLevel.code {
+		return false
+	}
+	if !f.Since.IsZero() && !e.Time.After(f.Since) {
+		return false
+	}
+	if f.Tag == "" || f.Tag == e.Tag {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(f.Tag, "*"); ok {
+		return strings.HasPrefix(e.Tag, prefix)
+	}
+	return false
+}
+
+// ringQueue is a fixed-capacity circular queue of entries.
+type ringQueue struct {
+	items []RingEntry
+	next  int
+	full  bool
+}
+
+func (q *ringQueue) push(e RingEntry) {
+	q.items[q.next] = e
+	q.next++
+	if q.next == len(q.items) {
+		q.next, q.full = 0, true
+	}
+}
+
+// appendTo appends the entries in insertion order.
+func (q *ringQueue) appendTo(out []RingEntry) []RingEntry {
+	if q.full {
+		out = append(out, q.items[q.next:]...)
+	}
+	return append(out, q.items[:q.next]...)
+}
+
+// RingBufferAppender keeps the most recent log events in memory so they can
+// be inspected from a running process, streamed to subscribers, or dumped
+// when an error occurs.
+//
+// Entries are rendered with the appender's layout at append time, so the
+// buffer holds plain strings and never references pooled events. With
+// PartitionBy set to "level" or "tag", Capacity applies to each partition,
+// which keeps a burst of DEBUG noise from evicting the last few errors.
+type RingBufferAppender struct {
+	AppenderBase
+
+	// Capacity is the number of entries kept (per partition).
+	Capacity int `PluginAttribute:"capacity,default=1000"`
+
+	// PartitionBy is "", "level" or "tag".
+	PartitionBy string `PluginAttribute:"partitionBy,default="`
+
+	// If true, an event at ERROR or above (including PANIC and FATAL) writes
+	// the entries recorded since the previous dump (including the event
+	// itself) to Stdout. A Go panic that is never logged is covered by
+	// deferring DumpOnPanic instead.
+	DumpOnError bool `PluginAttribute:"dumpOnError,default=false"`
+
+	mutex  sync.Mutex
+	seq    uint64
+	dumped uint64
+	queues map[string]*ringQueue
+	subs   map[chan RingEntry]struct{}
+}
+
+// Start validates the configuration and registers the appender so that
+// RingBuffers can find it.
+func (c *RingBufferAppender) Start() error {
+	if c.Capacity <= 0 {
+		return errutil.Explain(nil, "ring buffer capacity must be positive")
+	}
+	switch c.PartitionBy {
+	case "", "level", "tag":
+	default:
+		return errutil.Explain(nil, "invalid ring buffer partitionBy %q", c.PartitionBy)
+	}
+	c.queues = make(map[string]*ringQueue)
+	c.subs = make(map[chan RingEntry]struct{})
+
+	ringRegistry.mutex.Lock()
+	defer ringRegistry.mutex.Unlock()
+	if ringRegistry.rings == nil {
+		ringRegistry.rings = make(map[string]*RingBufferAppender)
+	}
+	ringRegistry.rings[c.Name] = c
+	return nil
+}
+
+// Stop unregisters the appender and closes all subscriber channels.
+func (c *RingBufferAppender) Stop() {
+	ringRegistry.mutex.Lock()
+	if ringRegistry.rings[c.Name] == c {
+		delete(ringRegistry.rings, c.Name)
+	}
+	ringRegistry.mutex.Unlock()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for ch := range c.subs {
+		close(ch)
+	}
+	c.subs = nil
+}
+
+func (c *RingBufferAppender) ConcurrentSafe() bool { return true }
+
+// Append renders the event and stores it, evicting the oldest entry of its
+// partition when full. Subscribers that cannot keep up miss entries rather
+// than blocking the logger.
+func (c *RingBufferAppender) Append(e *Event) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if e.RawBytes != nil {
+		b, ok := rawBytes(e, c.Layout)
+		if !ok {
+			return
+		}
+		buf.Write(b)
+	} else {
+		c.Layout.EncodeTo(e, buf)
+	}
+	entry := RingEntry{
+		Time:  e.Time,
+		Level: e.Level,
+		Tag:   e.Tag,
+		Text:  strings.TrimSuffix(buf.String(), "\n"),
+	}
+
+	// The dump is collected under the mutex and written after releasing it,
+	// so a slow Stdout never stalls the other goroutines logging here.
+	if dump := c.store(entry); dump != nil {
+		c.dump(Stdout, dump)
+	}
+}
+
+// store adds entry to its partition and fans it out to subscribers. When the
+// entry triggers DumpOnError it returns the entries to dump.
+func (c *RingBufferAppender) store(entry RingEntry) []RingEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Appending before Start (or after a failed Start) must not panic.
+	if c.queues == nil {
+		c.queues = make(map[string]*ringQueue)
+	}
+	if c.Capacity <= 0 {
+		return nil
+	}
+
+	c.seq++
+	entry.Seq = c.seq
+
+	var key string
+	switch c.PartitionBy {
+	case "level":
+		key = entry.Level.upperName
+	case "tag":
+		key = entry.Tag
+	default: // for linter
+	}
+	q, ok := c.queues[key]
+	if !ok {
+		q = &ringQueue{items: make([]RingEntry, c.Capacity)}
+		c.queues[key] = q
+	}
+	q.push(entry)
+
+	for ch := range c.subs {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+
+	if !c.DumpOnError || entry.Level.code < ErrorLevel.code {
+		return nil
+	}
+	dump := c.entries(RingFilter{}, c.dumped)
+	c.dumped = c.seq
+	return dump
+}
+
+// Entries returns the retained entries matching f, oldest first.
+func (c *RingBufferAppender) Entries(f RingFilter) []RingEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.entries(f, 0)
+}
+
+// entries collects entries matching f with Seq > after. It must be called
+// with the mutex held.
+func (c *RingBufferAppender) entries(f RingFilter, after uint64) []RingEntry {
+	var all []RingEntry
+	for _, q := range c.queues {
+		all = q.appendTo(all)
+	}
+	if len(c.queues) > 1 {
+		slices.SortFunc(all, func(a, b RingEntry) int {
+			return cmp.Compare(a.Seq, b.Seq)
+		})
+	}
+	ret := all[:0]
+	for _, e := range all {
+		if e.Seq > after && f.Match(e) {
+			ret = append(ret, e)
+		}
+	}
+	if f.Limit > 0 && len(ret) > f.Limit {
+		ret = ret[len(ret)-f.Limit:]
+	}
+	return ret
+}
+
+// Subscribe returns a channel receiving every entry appended from now on,
+// and a function that cancels the subscription. The channel is closed when
+// the subscription is cancelled or the appender stops.
+func (c *RingBufferAppender) Subscribe(size int) (<-chan RingEntry, func()) {
+	ch := make(chan RingEntry, size)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.subs == nil { // already stopped
+		close(ch)
+		return ch, func() {}
+	}
+	c.subs[ch] = struct{}{}
+	return ch, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if _, ok := c.subs[ch]; ok {
+			delete(c.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// dump writes entries to w, one per line. It must be called without the
+// mutex held.
+func (c *RingBufferAppender) dump(w io.Writer, entries []RingEntry) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	for _, e := range entries {
+		buf.WriteString(e.Text)
+		buf.WriteByte('\n')
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		c.ReportError(err)
+	}
+}
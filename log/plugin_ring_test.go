@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func newRingBuffer(capacity int, partitionBy string) *RingBufferAppender {
+	return &RingBufferAppender{
+		AppenderBase: AppenderBase{
+			Name:   "ring",
+			Layout: &TextLayout{},
+		},
+		Capacity:    capacity,
+		PartitionBy: partitionBy,
+	}
+}
+
+func ringEvent(level Level, tag, msg string, t time.Time) *Event {
+	return &Event{Level: level, Tag: tag, Time: t, Fields: []Field{Msg(msg)}}
+}
+
+func TestRingBufferAppender(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid config", func(t *testing.T) {
+		err := newRingBuffer(0, "").Start()
+		assert.Error(t, err).Matches("capacity must be positive")
+		err = newRingBuffer(10, "thread").Start()
+		assert.Error(t, err).Matches("invalid ring buffer partitionBy")
+	})
+
+	t.Run("evicts oldest", func(t *testing.T) {
+		a := newRingBuffer(3, "")
+		assert.Error(t, a.Start()).Nil()
+		defer a.Stop()
+
+		assert.That(t, RingBuffers()).Equal([]*RingBufferAppender{a})
+
+		for i := range 5 {
+			a.Append(ringEvent(InfoLevel, "_app_x", string(rune('a'+i)), now))
+		}
+		entries := a.Entries(RingFilter{})
+		assert.That(t, len(entries)).Equal(3)
+		assert.That(t, entries[0].Seq).Equal(uint64(3))
+		assert.String(t, entries[2].Text).HasSuffix("msg=e")
+	})
+
+	t.Run("partition and filter", func(t *testing.T) {
+		a := newRingBuffer(2, "level")
+		assert.Error(t, a.Start()).Nil()
+		defer a.Stop()
+
+		a.Append(ringEvent(ErrorLevel, "_biz_order", "boom", now))
+		for i := range 10 {
+			a.Append(ringEvent(DebugLevel, "_app_x", "noise", now.Add(time.Duration(i)*time.Second)))
+		}
+
+		entries := a.Entries(RingFilter{})
+		assert.That(t, len(entries)).Equal(3)
+		assert.That(t, entries[0].Level).Equal(ErrorLevel)
+
+		entries = a.Entries(RingFilter{MinLevel: WarnLevel})
+		assert.That(t, len(entries)).Equal(1)
+
+		entries = a.Entries(RingFilter{Tag: "_app_*"})
+		assert.That(t, len(entries)).Equal(2)
+
+		entries = a.Entries(RingFilter{Since: now.Add(8 * time.Second)})
+		assert.That(t, len(entries)).Equal(1)
+
+		entries = a.Entries(RingFilter{Limit: 1})
+		assert.That(t, entries[0].Seq).Equal(uint64(11))
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		a := newRingBuffer(10, "")
+		assert.Error(t, a.Start()).Nil()
+
+		ch, cancel := a.Subscribe(4)
+		a.Append(ringEvent(InfoLevel, "_app_x", "hello", now))
+		e := <-ch
+		assert.String(t, e.Text).HasSuffix("msg=hello")
+
+		cancel()
+		_, ok := <-ch
+		assert.That(t, ok).False()
+
+		ch, _ = a.Subscribe(4)
+		a.Stop()
+		_, ok = <-ch
+		assert.That(t, ok).False()
+	})
+
+	t.Run("dump on error", func(t *testing.T) {
+		var buf bytes.Buffer
+		saved := Stdout
+		Stdout = &buf
+		defer func() { Stdout = saved }()
+
+		a := newRingBuffer(10, "")
+		a.DumpOnError = true
+		assert.Error(t, a.Start()).Nil()
+		defer a.Stop()
+
+		a.Append(ringEvent(DebugLevel, "_app_x", "step1", now))
+		a.Append(ringEvent(ErrorLevel, "_app_x", "failed", now))
+		assert.That(t, strings.Count(buf.String(), "\n")).Equal(2)
+
+		a.Append(ringEvent(DebugLevel, "_app_x", "step2", now))
+		a.Append(ringEvent(ErrorLevel, "_app_x", "failed again", now))
+		assert.That(t, strings.Count(buf.String(), "\n")).Equal(4)
+
+		buf.Reset()
+		DumpRingBuffers(&buf)
+		assert.That(t, strings.Count(buf.String(), "\n")).Equal(4)
+
+		buf.Reset()
+		assert.Panic(t, func() {
+			defer DumpOnPanic()
+			panic("crash")
+		}, "crash")
+		assert.That(t, strings.Count(buf.String(), "\n")).Equal(4)
+	})
+
+	t.Run("dump does not hold the ring", func(t *testing.T) {
+		w := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+		saved := Stdout
+		Stdout = w
+		defer func() { Stdout = saved }()
+
+		a := newRingBuffer(10, "")
+		a.DumpOnError = true
+		assert.Error(t, a.Start()).Nil()
+		defer a.Stop()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			a.Append(ringEvent(ErrorLevel, "_app_x", "failed", now))
+		}()
+		<-w.entered
+		// The dump is stuck writing, yet the ring keeps taking events.
+		a.Append(ringEvent(InfoLevel, "_app_x", "next", now))
+		assert.That(t, len(a.Entries(RingFilter{}))).Equal(2)
+		close(w.release)
+		<-done
+	})
+
+	t.Run("append before start", func(t *testing.T) {
+		a := newRingBuffer(2, "")
+		a.Append(ringEvent(InfoLevel, "_app_x", "early", now))
+		assert.That(t, len(a.Entries(RingFilter{}))).Equal(1)
+	})
+}
+
+// blockingWriter blocks every Write until released.
+type blockingWriter struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	select {
+	case <-w.entered:
+	default:
+		close(w.entered)
+	}
+	<-w.release
+	return len(p), nil
+}
@@ -0,0 +1,390 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CacheConfig configures a [CachingDiscovery].
+type CacheConfig struct {
+	// Dir is the directory the last known snapshot of every service is
+	// persisted to, one JSON file per lookup. It is created on first write.
+	// Empty keeps the cache in memory only, which still rides out a registry
+	// outage but not a restart during one.
+	Dir string
+
+	// ProtectThreshold is the self-preservation threshold in (0, 1): a snapshot
+	// that drops more than this fraction of the known instances at once is
+	// held back and the previous snapshot keeps being served, as Nacos and
+	// Eureka do when a registry partition makes instances vanish en masse. 0
+	// disables protection; 1 or more could never trip and is rejected.
+	ProtectThreshold float64
+
+	// ProtectFor bounds how long a shrunken snapshot is held back. Once the
+	// drop has persisted this long it is accepted as a real scale-in. 0 holds
+	// it until the registry reports enough instances again.
+	ProtectFor time.Duration
+
+	// RetryInterval is the initial delay before a failed or ended watch is
+	// re-opened; it doubles up to one minute. 0 means one second.
+	RetryInterval time.Duration
+
+	// OnProtect, when set, is called each time a snapshot is held back, with
+	// the number of instances served and the number the registry reported.
+	OnProtect func(name string, served, reported int)
+
+	// OnError, when set, is called with failures the cache absorbs: a
+	// snapshot that could not be persisted, a watch that could not be
+	// re-opened.
+	OnError func(name string, err error)
+}
+
+// CachingDiscovery decorates a [Discovery] with a last-known-good cache so a
+// registry outage does not empty the client's endpoint set:
+//
+//   - every accepted snapshot is kept in memory and, when [CacheConfig.Dir] is
+//     set, persisted to disk;
+//   - Resolve falls back to the cached snapshot — from disk on a cold start —
+//     when the backend fails;
+//   - Watch hides backend failures: it seeds from the cache when the watch
+//     cannot be opened and re-opens an ended watch with backoff, so the
+//     registry coming back refreshes the set without the caller noticing;
+//   - the protection threshold holds back a snapshot that loses too many
+//     instances at once.
+//
+// Only an error with nothing cached reaches the caller. A CachingDiscovery is
+// itself a Discovery and is safe for concurrent use.
+type CachingDiscovery struct {
+	d   Discovery
+	cfg CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry // cache key -> last accepted snapshot
+	gen     uint64                 // bumped on every accepted change
+
+	persistMu sync.Mutex // serializes snapshot writes, taken without mu
+}
+
+// cacheEntry is the cached state of one lookup.
+type cacheEntry struct {
+	eps      []Endpoint
+	gen      uint64    // the change that produced eps; 0 when read from disk
+	heldFrom time.Time // when a shrunken snapshot was first held back; zero when none
+}
+
+// cacheFile is the on-disk form of a cacheEntry.
+type cacheFile struct {
+	Service   string          `json:"service"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Endpoints []cacheEndpoint `json:"endpoints"`
+}
+
+type cacheEndpoint struct {
+	Addr     string            `json:"addr"`
+	Scheme   string            `json:"scheme,omitempty"`
+	Weight   int               `json:"weight,omitempty"`
+	Disabled bool              `json:"disabled,omitempty"`
+	Healthy  bool              `json:"healthy,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// NewCachingDiscovery returns d decorated with the cache described by cfg. It
+// panics if cfg.ProtectThreshold is outside [0, 1).
+func NewCachingDiscovery(d Discovery, cfg CacheConfig) *CachingDiscovery {
+	if cfg.ProtectThreshold < 0 || cfg.ProtectThreshold >= 1 {
+		panic("discovery: ProtectThreshold must be in [0, 1)")
+	}
+	return &CachingDiscovery{d: d, cfg: cfg, entries: map[string]*cacheEntry{}}
+}
+
+// Resolve implements [Discovery]. A successful backend snapshot passes the
+// protection threshold and is cached; a failure is answered from the cache.
+func (c *CachingDiscovery) Resolve(ctx context.Context, name string, opts ...Option) ([]Endpoint, error) {
+	key := cacheKey(NewQuery(name, opts...))
+	eps, err := c.d.Resolve(ctx, name, opts...)
+	if err == nil {
+		eps, _ = c.accept(name, key, eps)
+		return slices.Clone(eps), nil
+	}
+	if cached, ok := c.load(key); ok {
+		return slices.Clone(cached), nil
+	}
+	return nil, err
+}
+
+// Watch implements [Discovery]. The returned channel stays open until ctx is
+// cancelled: a backend watch that fails to open or ends is re-opened with
+// backoff while the last snapshot keeps being served. A slow reader only
+// misses intermediate snapshots.
+func (c *CachingDiscovery) Watch(ctx context.Context, name string, opts ...Option) (<-chan WatchResult, error) {
+	key := cacheKey(NewQuery(name, opts...))
+	out := make(chan WatchResult, 1)
+	in, err := c.d.Watch(ctx, name, opts...)
+	if err != nil {
+		cached, ok := c.load(key)
+		if !ok {
+			return nil, err
+		}
+		c.onError(name, err)
+		out <- WatchResult{Endpoints: slices.Clone(cached)}
+	}
+	go c.watch(ctx, name, key, opts, in, out, err != nil)
+	return out, nil
+}
+
+// Services implements [Catalog] by delegating to the decorated backend, or
+// returns [ErrUnsupported] when it cannot enumerate.
+func (c *CachingDiscovery) Services(ctx context.Context) ([]string, error) {
+	if cat, ok := c.d.(Catalog); ok {
+		return cat.Services(ctx)
+	}
+	return nil, ErrUnsupported
+}
+
+// watch forwards accepted snapshots from in to out, re-opening the backend
+// watch whenever it ends, until ctx is cancelled.
+func (c *CachingDiscovery) watch(ctx context.Context, name, key string, opts []Option, in <-chan WatchResult, out chan WatchResult, seeded bool) {
+	defer close(out)
+	base := c.cfg.RetryInterval
+	if base <= 0 {
+		base = time.Second
+	}
+	backoff := base
+	for {
+		if c.forward(ctx, name, key, in, out, &seeded) {
+			backoff = base
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, time.Minute)
+		var err error
+		if in, err = c.d.Watch(ctx, name, opts...); err != nil {
+			c.onError(name, err)
+			in = nil
+		}
+	}
+}
+
+// forward drains one backend watch until it ends or ctx is cancelled and
+// reports whether it delivered any snapshot. A nil in ends immediately.
+func (c *CachingDiscovery) forward(ctx context.Context, name, key string, in <-chan WatchResult, out chan WatchResult, seeded *bool) bool {
+	if in == nil {
+		return false
+	}
+	got := false
+	for {
+		var res WatchResult
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return got
+		case res, ok = <-in:
+		}
+		if !ok {
+			return got
+		}
+		if res.Err != nil {
+			c.onError(name, res.Err)
+			return got
+		}
+		got = true
+		eps, held := c.accept(name, key, res.Endpoints)
+		if held && *seeded {
+			continue
+		}
+		*seeded = true
+		sendLatest(out, WatchResult{Endpoints: slices.Clone(eps)})
+	}
+}
+
+// accept applies the protection threshold to a snapshot reported by the
+// backend and caches it unless it is held back. It returns the snapshot to
+// serve and whether the reported one was held back. Only a changed snapshot
+// is persisted, and the write happens after mu is released.
+func (c *CachingDiscovery) accept(name, key string, eps []Endpoint) ([]Endpoint, bool) {
+	c.mu.Lock()
+	e := c.entryLocked(key)
+	if e != nil && c.protect(len(e.eps), len(eps)) {
+		now := time.Now()
+		if e.heldFrom.IsZero() {
+			e.heldFrom = now
+		}
+		if c.cfg.ProtectFor <= 0 || now.Sub(e.heldFrom) < c.cfg.ProtectFor {
+			served := e.eps
+			c.mu.Unlock()
+			if c.cfg.OnProtect != nil {
+				c.cfg.OnProtect(name, len(served), len(eps))
+			}
+			return served, true
+		}
+	}
+	if e != nil && slices.EqualFunc(e.eps, eps, sameEndpoint) {
+		e.heldFrom = time.Time{}
+		served := e.eps
+		c.mu.Unlock()
+		return served, false
+	}
+	eps = slices.Clone(eps)
+	c.gen++
+	gen := c.gen
+	c.entries[key] = &cacheEntry{eps: eps, gen: gen}
+	c.mu.Unlock()
+	if err := c.persist(name, key, eps, gen); err != nil {
+		c.onError(name, err)
+	}
+	return eps, false
+}
+
+// sameEndpoint reports whether a and b describe the same instance state.
+func sameEndpoint(a, b Endpoint) bool {
+	return a.Addr == b.Addr && a.Scheme == b.Scheme && a.Weight == b.Weight &&
+		a.Disabled == b.Disabled && a.Healthy == b.Healthy && maps.Equal(a.Metadata, b.Metadata)
+}
+
+// protect reports whether going from prev to next instances loses more than
+// the threshold.
+func (c *CachingDiscovery) protect(prev, next int) bool {
+	if c.cfg.ProtectThreshold <= 0 || prev == 0 || next >= prev {
+		return false
+	}
+	return float64(prev-next)/float64(prev) > c.cfg.ProtectThreshold
+}
+
+// load returns the cached snapshot for key, reading it from disk on a cold
+// start.
+func (c *CachingDiscovery) load(key string) ([]Endpoint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.entryLocked(key); e != nil {
+		return e.eps, true
+	}
+	return nil, false
+}
+
+// entryLocked returns the in-memory entry for key, populating it from disk
+// the first time; nil when nothing is cached.
+func (c *CachingDiscovery) entryLocked(key string) *cacheEntry {
+	if e, ok := c.entries[key]; ok {
+		return e
+	}
+	if c.cfg.Dir == "" {
+		return nil
+	}
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+	var f cacheFile
+	if json.Unmarshal(b, &f) != nil {
+		return nil
+	}
+	eps := make([]Endpoint, len(f.Endpoints))
+	for i, ep := range f.Endpoints {
+		eps[i] = Endpoint(ep)
+	}
+	e := &cacheEntry{eps: eps}
+	c.entries[key] = e
+	return e
+}
+
+// persist writes eps for key to disk, atomically through a rename of a synced
+// temporary file so a crash never leaves a truncated snapshot behind. Writes
+// are serialized, and one whose change gen was superseded while it waited is
+// skipped, so the file always ends at the newest accepted snapshot even when
+// two watchers of the same lookup race.
+func (c *CachingDiscovery) persist(name, key string, eps []Endpoint, gen uint64) error {
+	if c.cfg.Dir == "" {
+		return nil
+	}
+	c.persistMu.Lock()
+	defer c.persistMu.Unlock()
+	c.mu.Lock()
+	stale := c.entries[key].gen != gen
+	c.mu.Unlock()
+	if stale {
+		return nil
+	}
+	f := cacheFile{Service: name, UpdatedAt: time.Now(), Endpoints: make([]cacheEndpoint, len(eps))}
+	for i, ep := range eps {
+		f.Endpoints[i] = cacheEndpoint(ep)
+	}
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(c.cfg.Dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.cfg.Dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (c *CachingDiscovery) path(key string) string {
+	return filepath.Join(c.cfg.Dir, url.PathEscape(key)+".json")
+}
+
+func (c *CachingDiscovery) onError(name string, err error) {
+	if c.cfg.OnError != nil {
+		c.cfg.OnError(name, err)
+	}
+}
+
+// cacheKey identifies a lookup: the same name narrowed differently caches
+// separately.
+func cacheKey(q Query) string {
+	v := url.Values{}
+	if q.Scheme != "" {
+		v.Set("scheme", q.Scheme)
+	}
+	if q.Tag != "" {
+		v.Set("tag", q.Tag)
+	}
+	if q.Group != "" {
+		v.Set("group", q.Group)
+	}
+	if len(v) == 0 {
+		return q.Name
+	}
+	return q.Name + "?" + v.Encode()
+}
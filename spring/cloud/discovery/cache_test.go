@@ -0,0 +1,281 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyBackend is a Discovery whose registry can be taken down: while down,
+// Resolve and Watch fail and open watches end with a terminal error.
+type flakyBackend struct {
+	mu      sync.Mutex
+	down    bool
+	eps     []Endpoint
+	watches []chan WatchResult
+}
+
+func (b *flakyBackend) set(down bool, eps ...Endpoint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down, b.eps = down, eps
+	for _, ch := range b.watches {
+		if down {
+			ch <- WatchResult{Err: errors.New("registry down")}
+			close(ch)
+		} else {
+			ch <- WatchResult{Endpoints: append([]Endpoint(nil), eps...)}
+		}
+	}
+	if down {
+		b.watches = nil
+	}
+}
+
+func (b *flakyBackend) Resolve(context.Context, string, ...Option) ([]Endpoint, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return nil, errors.New("registry down")
+	}
+	return append([]Endpoint(nil), b.eps...), nil
+}
+
+func (b *flakyBackend) Watch(context.Context, string, ...Option) (<-chan WatchResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.down {
+		return nil, errors.New("registry down")
+	}
+	ch := make(chan WatchResult, 16)
+	ch <- WatchResult{Endpoints: append([]Endpoint(nil), b.eps...)}
+	b.watches = append(b.watches, ch)
+	return ch, nil
+}
+
+func eps(addrs ...string) []Endpoint {
+	out := make([]Endpoint, len(addrs))
+	for i, a := range addrs {
+		out[i] = Endpoint{Addr: a, Healthy: true, Metadata: map[string]string{"zone": "z1"}}
+	}
+	return out
+}
+
+func TestCachingDiscoveryColdStartFromDisk(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b := &flakyBackend{eps: eps("a:1", "b:1")}
+	if _, err := NewCachingDiscovery(b, CacheConfig{Dir: dir}).Resolve(ctx, "orders", WithScheme("tcp")); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 1 {
+		t.Fatalf("snapshot files = %v, want one", files)
+	}
+
+	// A restarted process with the registry down serves the disk snapshot.
+	b.set(true)
+	got, err := NewCachingDiscovery(b, CacheConfig{Dir: dir}).Resolve(ctx, "orders", WithScheme("tcp"))
+	if err != nil || len(got) != 2 || got[0].Addr != "a:1" || !got[0].Healthy || got[0].Metadata["zone"] != "z1" {
+		t.Fatalf("cold start = %+v, %v; want the persisted snapshot", got, err)
+	}
+	// A lookup that was never cached still fails.
+	if _, err = NewCachingDiscovery(b, CacheConfig{Dir: dir}).Resolve(ctx, "orders"); err == nil {
+		t.Fatal("uncached lookup should surface the backend error")
+	}
+}
+
+func TestCachingDiscoveryPersistsOnlyChanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b := &flakyBackend{eps: eps("a:1", "b:1")}
+	c := NewCachingDiscovery(b, CacheConfig{Dir: dir})
+	_, _ = c.Resolve(ctx, "orders")
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("snapshot files = %v, want one", files)
+	}
+
+	// An unchanged snapshot does not touch the disk.
+	if err := os.Remove(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.Resolve(ctx, "orders")
+	if _, err := os.Stat(files[0]); !os.IsNotExist(err) {
+		t.Fatalf("unchanged snapshot was rewritten: %v", err)
+	}
+	// A changed one does.
+	b.set(false, eps("a:1", "b:1", "c:1")...)
+	_, _ = c.Resolve(ctx, "orders")
+	if _, err := os.Stat(files[0]); err != nil {
+		t.Fatalf("changed snapshot was not persisted: %v", err)
+	}
+}
+
+func TestCachingDiscoveryRejectsUntrippableThreshold(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("ProtectThreshold 1 should be rejected")
+		}
+	}()
+	NewCachingDiscovery(&flakyBackend{}, CacheConfig{ProtectThreshold: 1})
+}
+
+func TestCachingDiscoveryProtectThreshold(t *testing.T) {
+	ctx := context.Background()
+	b := &flakyBackend{eps: eps("a:1", "b:1", "c:1", "d:1")}
+	var held []int
+	c := NewCachingDiscovery(b, CacheConfig{
+		ProtectThreshold: 0.5,
+		OnProtect:        func(_ string, served, reported int) { held = append(held, served, reported) },
+	})
+	_, _ = c.Resolve(ctx, "orders")
+
+	// Losing half is within the threshold.
+	b.set(false, eps("a:1", "b:1")...)
+	if got, _ := c.Resolve(ctx, "orders"); len(got) != 2 {
+		t.Fatalf("drop within threshold: %d endpoints, want 2", len(got))
+	}
+	// Losing everything at once is held back.
+	b.set(false)
+	if got, _ := c.Resolve(ctx, "orders"); len(got) != 2 {
+		t.Fatalf("mass drop: %d endpoints, want the previous 2", len(got))
+	}
+	if len(held) != 2 || held[0] != 2 || held[1] != 0 {
+		t.Fatalf("OnProtect = %v, want [2 0]", held)
+	}
+	// Growth is always accepted.
+	b.set(false, eps("a:1", "b:1", "c:1")...)
+	if got, _ := c.Resolve(ctx, "orders"); len(got) != 3 {
+		t.Fatalf("growth: %d endpoints, want 3", len(got))
+	}
+}
+
+func TestCachingDiscoveryProtectFor(t *testing.T) {
+	ctx := context.Background()
+	b := &flakyBackend{eps: eps("a:1", "b:1")}
+	c := NewCachingDiscovery(b, CacheConfig{ProtectThreshold: 0.1, ProtectFor: 20 * time.Millisecond})
+	_, _ = c.Resolve(ctx, "orders")
+
+	b.set(false, eps("a:1")...)
+	if got, _ := c.Resolve(ctx, "orders"); len(got) != 2 {
+		t.Fatalf("fresh drop: %d endpoints, want 2", len(got))
+	}
+	time.Sleep(30 * time.Millisecond)
+	// A drop that persists past ProtectFor is a real scale-in.
+	if got, _ := c.Resolve(ctx, "orders"); len(got) != 1 {
+		t.Fatalf("persistent drop: %d endpoints, want 1", len(got))
+	}
+}
+
+func TestCachingDiscoveryWatchSurvivesOutage(t *testing.T) {
+	b := &flakyBackend{eps: eps("a:1")}
+	var errs sync.Map
+	c := NewCachingDiscovery(b, CacheConfig{
+		Dir:           t.TempDir(),
+		RetryInterval: time.Millisecond,
+		OnError:       func(name string, err error) { errs.Store(name, err) },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := c.Watch(ctx, "orders")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	recv := func() WatchResult {
+		t.Helper()
+		select {
+		case res, ok := <-ch:
+			if !ok {
+				t.Fatal("watch channel closed early")
+			}
+			return res
+		case <-time.After(time.Second):
+			t.Fatal("no snapshot delivered")
+		}
+		return WatchResult{}
+	}
+	if res := recv(); len(res.Endpoints) != 1 {
+		t.Fatalf("seed = %+v", res)
+	}
+
+	// The registry goes down: the caller sees nothing, not a terminal error.
+	b.set(true)
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case res := <-ch:
+		t.Fatalf("outage leaked to the caller: %+v", res)
+	default:
+	}
+	if _, ok := errs.Load("orders"); !ok {
+		t.Fatal("OnError was not told about the outage")
+	}
+
+	// It comes back with a new instance: the re-opened watch refreshes.
+	b.set(false, eps("a:1", "b:1")...)
+	if res := recv(); len(res.Endpoints) != 2 || res.Err != nil {
+		t.Fatalf("after recovery = %+v, want two endpoints", res)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			for range ch {
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after ctx cancel")
+	}
+}
+
+func TestCachingDiscoveryWatchSeedsFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	b := &flakyBackend{eps: eps("a:1")}
+	_, _ = NewCachingDiscovery(b, CacheConfig{Dir: dir}).Resolve(context.Background(), "orders")
+	b.set(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := NewCachingDiscovery(b, CacheConfig{Dir: dir, RetryInterval: time.Hour}).Watch(ctx, "orders")
+	if err != nil {
+		t.Fatalf("Watch with a cached snapshot should not fail: %v", err)
+	}
+	if res := <-ch; len(res.Endpoints) != 1 || res.Endpoints[0].Addr != "a:1" {
+		t.Fatalf("seed = %+v, want the disk snapshot", res)
+	}
+	if _, err = NewCachingDiscovery(b, CacheConfig{}).Watch(ctx, "orders"); err == nil {
+		t.Fatal("Watch with nothing cached should surface the backend error")
+	}
+}
+
+func TestCachingDiscoveryIgnoresCorruptSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b := &flakyBackend{down: true}
+	if _, err := NewCachingDiscovery(b, CacheConfig{Dir: dir}).Resolve(context.Background(), "orders"); err == nil {
+		t.Fatal("a corrupt snapshot must not be served")
+	}
+}
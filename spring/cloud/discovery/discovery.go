@@ -26,9 +26,22 @@
 // A company adapts its own naming service by implementing the single
 // [Discovery] interface and registering one or more fully-built backends via
 // [RegisterDiscovery]; every client starter then resolves names through a named
-// Discovery without any per-component adaptation. Publishing this process to a
-// registry (the provider-side write) is handled by a registry starter such as
-// starter-registry-etcd, not by this package.
+// Discovery without any per-component adaptation.
+//
+// The provider-side write — publishing this process as a plain [Instance] —
+// is the [Registrar] interface. A registry starter such as
+// starter-registry-etcd implements it and hands it to the shared registration
+// lifecycle (package go-spring.org/spring/experimental/cloud/registration)
+// rather than running its own. [MemoryRegistry] implements both sides in
+// process for tests.
+//
+// [NewCachingDiscovery] wraps any backend with a last-known-good cache, on disk
+// if configured, so a registry outage — even across a restart — does not leave
+// clients without endpoints.
+//
+// Two registry-less backends need nothing beyond the standard library and live
+// here too: [NewDNSDiscovery] resolves A/AAAA/SRV records and re-resolves on
+// TTL expiry, and [NewFileDiscovery] serves services listed in watched files.
 package discovery
 
 import (
@@ -234,6 +247,17 @@ type WatchResult struct {
 	Err error
 }
 
+// sendLatest delivers res on a one-slot watch channel, first dropping any
+// snapshot the watcher has not read yet: a newer snapshot supersedes it. The
+// caller must be the channel's only sender, so the send never blocks.
+func sendLatest(ch chan WatchResult, res WatchResult) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- res
+}
+
 // Catalog is an OPTIONAL capability a Discovery backend may implement when it
 // can enumerate every service name it knows — the "discover" half of service
 // discovery, used by gateways building routes dynamically, governance
@@ -263,7 +287,7 @@ var ErrUnsupported = errors.New("discovery: service enumeration not supported")
 
 // discoveriesMu guards discoveries. It is independent of registrarsMu (in
 // registrar.go): the two registries are written only during init and read only
-// at client construction, so neither needs to serialize against the other.
+// at construction, so neither needs to serialize against the other.
 var (
 	discoveriesMu sync.RWMutex
 	discoveries   = map[string]Discovery{}
@@ -282,8 +306,8 @@ var (
 //
 // It is deliberately distinct from "Register" in the service-registration
 // sense: publishing a service instance to a registry (the provider-side write,
-// handled by a registry starter such as starter-registry-etcd) is a different
-// operation from plugging a Discovery adapter into this package.
+// [Registrar.Register]) is a different operation from plugging a Discovery
+// adapter into this package.
 //
 // It panics on empty name, nil Discovery, or a duplicate name — mirroring the
 // driver-registry idiom used elsewhere (e.g. starter-go-redis RegisterDriver) —
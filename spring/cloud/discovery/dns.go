@@ -0,0 +1,383 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSConfig configures a [DNSDiscovery].
+type DNSConfig struct {
+	// Servers are the "host:port" DNS servers queried, in order, until one
+	// answers. Empty uses the nameservers of /etc/resolv.conf.
+	Servers []string
+
+	// Port is paired with A/AAAA addresses when the looked-up name carries no
+	// port of its own. SRV lookups take the port from the record.
+	Port int
+
+	// Scheme is set on every endpoint resolved, so [WithScheme] can narrow a
+	// DNS backend like any other. Empty means plain TCP.
+	Scheme string
+
+	// MinTTL and MaxTTL clamp the re-resolution interval a Watch derives from
+	// the record TTLs: a zero TTL does not turn into a query storm and a day
+	// long TTL does not hide a change for a day. They default to 5s and 5m.
+	// A failed re-resolution is retried after MinTTL.
+	MinTTL, MaxTTL time.Duration
+
+	// Timeout bounds one query to one server. 0 means two seconds.
+	Timeout time.Duration
+
+	// OnError, when set, is called with re-resolution failures a Watch
+	// absorbs while it keeps serving the last snapshot.
+	OnError func(name string, err error)
+}
+
+// DNSDiscovery is a [Discovery] backed by plain DNS. The looked-up name picks
+// the record type:
+//
+//   - "_service._proto.domain" is an SRV lookup; every record becomes one
+//     endpoint carrying the record's port and weight. The target is resolved
+//     through the additional section when the server includes it and kept as
+//     a host name otherwise. Only the lowest priority is marked Healthy, so the
+//     higher ones serve as the fallback SRV priorities describe;
+//   - "host:port" or "host" is an A and AAAA lookup, each address paired with
+//     the port in the name or [DNSConfig.Port].
+//
+// Names are absolute: no search domain is appended. A name that does not exist
+// resolves to an empty set rather than an error.
+//
+// Watch re-resolves when the shortest TTL of the records it read expires, and
+// pushes a snapshot only when the set changed. DNS cannot enumerate, so
+// DNSDiscovery does not implement [Catalog].
+type DNSDiscovery struct {
+	cfg     DNSConfig
+	servers []string
+}
+
+// NewDNSDiscovery returns a DNS backend configured by cfg. It fails when no
+// server is configured and none can be read from /etc/resolv.conf.
+func NewDNSDiscovery(cfg DNSConfig) (*DNSDiscovery, error) {
+	if cfg.MinTTL <= 0 {
+		cfg.MinTTL = 5 * time.Second
+	}
+	if cfg.MaxTTL <= 0 {
+		cfg.MaxTTL = 5 * time.Minute
+	}
+	cfg.MaxTTL = max(cfg.MaxTTL, cfg.MinTTL)
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+	servers := cfg.Servers
+	if len(servers) == 0 {
+		var err error
+		if servers, err = resolvConfServers("/etc/resolv.conf"); err != nil {
+			return nil, err
+		}
+	}
+	return &DNSDiscovery{cfg: cfg, servers: servers}, nil
+}
+
+// Resolve implements [Discovery].
+func (d *DNSDiscovery) Resolve(ctx context.Context, name string, opts ...Option) ([]Endpoint, error) {
+	eps, _, err := d.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return FilterByScheme(eps, NewQuery(name, opts...).Scheme), nil
+}
+
+// Watch implements [Discovery]. The first lookup must succeed; later failures
+// are reported to [DNSConfig.OnError] and retried while the last snapshot
+// stands. A slow reader only misses intermediate snapshots.
+func (d *DNSDiscovery) Watch(ctx context.Context, name string, opts ...Option) (<-chan WatchResult, error) {
+	scheme := NewQuery(name, opts...).Scheme
+	eps, ttl, err := d.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan WatchResult, 1)
+	ch <- WatchResult{Endpoints: FilterByScheme(slices.Clone(eps), scheme)}
+	go func() {
+		defer close(ch)
+		last := eps
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(ttl):
+			}
+			next, nextTTL, err := d.lookup(ctx, name)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if d.cfg.OnError != nil {
+					d.cfg.OnError(name, err)
+				}
+				ttl = d.cfg.MinTTL
+				continue
+			}
+			ttl = nextTTL
+			if reflect.DeepEqual(next, last) {
+				continue
+			}
+			last = next
+			sendLatest(ch, WatchResult{Endpoints: FilterByScheme(slices.Clone(next), scheme)})
+		}
+	}()
+	return ch, nil
+}
+
+// lookup resolves name into endpoints sorted by address and returns the
+// clamped interval after which the answer should be refreshed.
+func (d *DNSDiscovery) lookup(ctx context.Context, name string) ([]Endpoint, time.Duration, error) {
+	var (
+		eps []Endpoint
+		ttl uint32
+		err error
+	)
+	if strings.HasPrefix(name, "_") {
+		eps, ttl, err = d.lookupSRV(ctx, name)
+	} else {
+		eps, ttl, err = d.lookupHost(ctx, name)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.Slice(eps, func(i, j int) bool { return eps[i].Addr < eps[j].Addr })
+	return eps, min(max(time.Duration(ttl)*time.Second, d.cfg.MinTTL), d.cfg.MaxTTL), nil
+}
+
+// lookupSRV resolves an SRV name. The returned TTL is the shortest of the
+// records used, 0 when there were none.
+func (d *DNSDiscovery) lookupSRV(ctx context.Context, name string) ([]Endpoint, uint32, error) {
+	resp, err := d.exchange(ctx, name, dnsTypeSRV)
+	if err != nil {
+		return nil, 0, err
+	}
+	var ttl ttlMin
+	var srvs []dnsRR
+	for _, rr := range resp.Answers {
+		// A root target means the service is decidedly not available here.
+		if rr.Type == dnsTypeSRV && rr.Target != "" {
+			srvs = append(srvs, rr)
+			ttl.add(rr.TTL)
+		}
+	}
+	if len(srvs) == 0 {
+		return []Endpoint{}, ttl.get(), nil
+	}
+	best := srvs[0].Priority
+	for _, rr := range srvs {
+		best = min(best, rr.Priority)
+	}
+	eps := make([]Endpoint, 0, len(srvs))
+	for _, srv := range srvs {
+		port := strconv.Itoa(int(srv.Port))
+		meta := map[string]string{
+			"dns.target":   srv.Target,
+			"dns.priority": strconv.Itoa(int(srv.Priority)),
+		}
+		hosts := []string{srv.Target}
+		var ips []string
+		for _, rr := range resp.Additional {
+			if (rr.Type == dnsTypeA || rr.Type == dnsTypeAAAA) && rr.Name == srv.Target {
+				ips = append(ips, rr.IP.String())
+				ttl.add(rr.TTL)
+			}
+		}
+		if len(ips) > 0 {
+			hosts = ips
+		}
+		for _, h := range hosts {
+			eps = append(eps, Endpoint{
+				Addr:     net.JoinHostPort(h, port),
+				Scheme:   d.cfg.Scheme,
+				Weight:   int(srv.Weight),
+				Healthy:  srv.Priority == best,
+				Metadata: maps.Clone(meta),
+			})
+		}
+	}
+	return eps, ttl.get(), nil
+}
+
+// lookupHost resolves a "host[:port]" name through its A and AAAA records.
+func (d *DNSDiscovery) lookupHost(ctx context.Context, name string) ([]Endpoint, uint32, error) {
+	host, port, err := net.SplitHostPort(name)
+	if err != nil {
+		if d.cfg.Port <= 0 {
+			return nil, 0, fmt.Errorf("discovery: dns name %q has no port and DNSConfig.Port is unset", name)
+		}
+		host, port = name, strconv.Itoa(d.cfg.Port)
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return []Endpoint{{Addr: net.JoinHostPort(host, port), Scheme: d.cfg.Scheme, Healthy: true}}, 0, nil
+	}
+	var ttl ttlMin
+	eps := []Endpoint{}
+	for _, qtype := range []uint16{dnsTypeA, dnsTypeAAAA} {
+		resp, err := d.exchange(ctx, host, qtype)
+		if err != nil {
+			return nil, 0, err
+		}
+		// A CNAME chain ends in the addresses: every record of the queried
+		// type in the answer belongs to the name.
+		for _, rr := range resp.Answers {
+			if rr.Type == qtype {
+				eps = append(eps, Endpoint{Addr: net.JoinHostPort(rr.IP.String(), port), Scheme: d.cfg.Scheme, Healthy: true})
+				ttl.add(rr.TTL)
+			}
+		}
+	}
+	return eps, ttl.get(), nil
+}
+
+// exchange sends one query to the configured servers in order and returns the
+// first usable reply. A non-existent name is a usable, empty reply.
+func (d *DNSDiscovery) exchange(ctx context.Context, name string, qtype uint16) (*dnsResponse, error) {
+	var errs []error
+	for _, server := range d.servers {
+		resp, err := d.exchangeWith(ctx, server, name, qtype)
+		if err == nil {
+			switch resp.Rcode {
+			case dnsRcodeSuccess:
+				return resp, nil
+			case dnsRcodeNXDomain:
+				return &dnsResponse{Rcode: resp.Rcode}, nil
+			}
+			err = fmt.Errorf("rcode %d", resp.Rcode)
+		}
+		errs = append(errs, fmt.Errorf("discovery: dns query %q via %s: %w", name, server, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// exchangeWith queries one server over UDP, retrying over TCP when the reply
+// is truncated.
+func (d *DNSDiscovery) exchangeWith(ctx context.Context, server, name string, qtype uint16) (*dnsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+	id := uint16(rand.Uint32())
+	msg, err := dnsQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dnsRoundTrip(ctx, "udp", server, msg, id)
+	if errors.Is(err, errDNSTruncated) {
+		resp, err = dnsRoundTrip(ctx, "tcp", server, msg, id)
+	}
+	return resp, err
+}
+
+// dnsRoundTrip sends msg over network and reads the reply. TCP messages are
+// framed by a two-byte length.
+func dnsRoundTrip(ctx context.Context, network, server string, msg []byte, id uint16) (*dnsResponse, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if network == "tcp" {
+		msg = append(binary.BigEndian.AppendUint16(nil, uint16(len(msg))), msg...)
+	}
+	if _, err = conn.Write(msg); err != nil {
+		return nil, err
+	}
+	if network == "tcp" {
+		var size [2]byte
+		if _, err = io.ReadFull(conn, size[:]); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, binary.BigEndian.Uint16(size[:]))
+		if _, err = io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+		return parseDNSResponse(buf, id)
+	}
+	buf := make([]byte, dnsUDPSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		// A stray datagram (a late reply to an earlier query) is skipped.
+		if n >= 2 && binary.BigEndian.Uint16(buf) != id {
+			continue
+		}
+		return parseDNSResponse(buf[:n], id)
+	}
+}
+
+// resolvConfServers reads the nameserver lines of a resolv.conf file.
+func resolvConfServers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: no DNS servers configured: %w", err)
+	}
+	defer f.Close()
+	var servers []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("discovery: no nameserver in %s", path)
+	}
+	return servers, s.Err()
+}
+
+// ttlMin tracks the shortest TTL seen; get returns 0 when none was.
+type ttlMin struct {
+	ttl uint32
+	set bool
+}
+
+func (m *ttlMin) add(ttl uint32) {
+	if !m.set || ttl < m.ttl {
+		m.ttl, m.set = ttl, true
+	}
+}
+
+func (m *ttlMin) get() uint32 { return m.ttl }
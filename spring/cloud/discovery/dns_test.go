@@ -0,0 +1,313 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// dnsStub is a local DNS server answering from an in-memory zone over UDP
+// and TCP on the same port. With truncate set every UDP reply is truncated,
+// forcing the client onto TCP.
+type dnsStub struct {
+	addr     string
+	truncate atomic.Bool
+	queries  atomic.Int32
+
+	mu         sync.Mutex
+	answers    map[string][]dnsRR // "name/type" -> answer records
+	additional map[string][]dnsRR // "name/type" -> additional records
+}
+
+func newDNSStub(t *testing.T) *dnsStub {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc, err := net.ListenPacket("udp", ln.Addr().String())
+	if err != nil {
+		_ = ln.Close()
+		t.Skipf("cannot bind udp next to tcp: %v", err)
+	}
+	s := &dnsStub{addr: ln.Addr().String(), answers: map[string][]dnsRR{}, additional: map[string][]dnsRR{}}
+	t.Cleanup(func() { _ = ln.Close(); _ = pc.Close() })
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, from, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = pc.WriteTo(s.reply(buf[:n], s.truncate.Load()), from)
+		}
+	}()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var size [2]byte
+				if _, err := io.ReadFull(conn, size[:]); err != nil {
+					return
+				}
+				q := make([]byte, binary.BigEndian.Uint16(size[:]))
+				if _, err := io.ReadFull(conn, q); err != nil {
+					return
+				}
+				r := s.reply(q, false)
+				_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(r))), r...))
+			}()
+		}
+	}()
+	return s
+}
+
+func (s *dnsStub) set(name string, qtype uint16, answers []dnsRR, additional ...dnsRR) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := fmt.Sprintf("%s/%d", name, qtype)
+	s.answers[key], s.additional[key] = answers, additional
+}
+
+// reply builds the response to query q.
+func (s *dnsStub) reply(q []byte, truncate bool) []byte {
+	s.queries.Add(1)
+	name, off, err := readDNSName(q, 12)
+	if err != nil {
+		return nil
+	}
+	qtype := binary.BigEndian.Uint16(q[off:])
+	s.mu.Lock()
+	key := fmt.Sprintf("%s/%d", name, qtype)
+	an, ok := s.answers[key]
+	ar := s.additional[key]
+	s.mu.Unlock()
+
+	flags := uint16(0x8180) // QR, RD, RA
+	switch {
+	case truncate:
+		flags |= 0x0200
+		an, ar = nil, nil
+	case !ok:
+		flags |= dnsRcodeNXDomain
+	}
+	b := make([]byte, 12)
+	copy(b, q[:2])
+	binary.BigEndian.PutUint16(b[2:], flags)
+	binary.BigEndian.PutUint16(b[4:], 1)
+	binary.BigEndian.PutUint16(b[6:], uint16(len(an)))
+	binary.BigEndian.PutUint16(b[10:], uint16(len(ar)))
+	b = append(b, q[12:off+4]...)
+	for _, rr := range append(an, ar...) {
+		b = appendStubRR(b, rr)
+	}
+	return b
+}
+
+func appendStubRR(b []byte, rr dnsRR) []byte {
+	b, _ = appendDNSName(b, rr.Name)
+	b = binary.BigEndian.AppendUint16(b, rr.Type)
+	b = binary.BigEndian.AppendUint16(b, dnsClassINET)
+	b = binary.BigEndian.AppendUint32(b, rr.TTL)
+	var data []byte
+	switch rr.Type {
+	case dnsTypeA:
+		data = rr.IP.To4()
+	case dnsTypeAAAA:
+		data = rr.IP.To16()
+	case dnsTypeSRV:
+		data = binary.BigEndian.AppendUint16(data, rr.Priority)
+		data = binary.BigEndian.AppendUint16(data, rr.Weight)
+		data = binary.BigEndian.AppendUint16(data, rr.Port)
+		data, _ = appendDNSName(data, rr.Target)
+	}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
+
+func aRR(name, ip string, ttl uint32) dnsRR {
+	typ := dnsTypeA
+	if strings.Contains(ip, ":") {
+		typ = dnsTypeAAAA
+	}
+	return dnsRR{Name: name, Type: typ, TTL: ttl, IP: net.ParseIP(ip)}
+}
+
+func srvRR(name, target string, prio, weight, port uint16) dnsRR {
+	return dnsRR{Name: name, Type: dnsTypeSRV, TTL: 30, Priority: prio, Weight: weight, Port: port, Target: target}
+}
+
+// joinAddrs renders the endpoint addresses as one comparable string.
+func joinAddrs(eps []Endpoint) string {
+	return strings.Join(addrsOf(eps), ",")
+}
+
+func TestDNSDiscoverySRV(t *testing.T) {
+	s := newDNSStub(t)
+	const name = "_grpc._tcp.orders.example.com"
+	s.set(name, dnsTypeSRV, []dnsRR{
+		srvRR(name, "a.example.com", 1, 10, 9000),
+		srvRR(name, "b.example.com", 1, 20, 9001),
+		srvRR(name, "c.example.com", 2, 0, 9002),
+	}, aRR("a.example.com", "10.0.0.1", 30))
+
+	d, err := NewDNSDiscovery(DNSConfig{Servers: []string{s.addr}, Scheme: "grpc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eps, err := d.Resolve(context.Background(), name, WithScheme("grpc"))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	// The additional section resolves a; b and c stay host names.
+	if got := joinAddrs(eps); got != "10.0.0.1:9000,b.example.com:9001,c.example.com:9002" {
+		t.Fatalf("addrs = %s", got)
+	}
+	if !eps[0].Healthy || !eps[1].Healthy || eps[2].Healthy {
+		t.Fatalf("only priority 1 should be healthy: %+v", eps)
+	}
+	if eps[1].Weight != 20 || eps[0].Metadata["dns.target"] != "a.example.com" || eps[2].Metadata["dns.priority"] != "2" {
+		t.Fatalf("record attributes lost: %+v", eps)
+	}
+	if eps, _ = d.Resolve(context.Background(), name, WithScheme("tls")); len(eps) != 0 {
+		t.Fatalf("scheme narrowing ignored: %+v", eps)
+	}
+}
+
+func TestDNSDiscoveryHost(t *testing.T) {
+	s := newDNSStub(t)
+	s.set("orders.example.com", dnsTypeA, []dnsRR{aRR("orders.example.com", "10.0.0.2", 30), aRR("orders.example.com", "10.0.0.1", 30)})
+	s.set("orders.example.com", dnsTypeAAAA, []dnsRR{aRR("orders.example.com", "fd00::1", 30)})
+	ctx := context.Background()
+
+	d, err := NewDNSDiscovery(DNSConfig{Servers: []string{s.addr}, Port: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eps, err := d.Resolve(ctx, "orders.example.com")
+	if err != nil || joinAddrs(eps) != "10.0.0.1:80,10.0.0.2:80,[fd00::1]:80" || !eps[0].Healthy {
+		t.Fatalf("Resolve = %+v, %v", eps, err)
+	}
+	// A port in the name wins over the configured one.
+	if eps, _ = d.Resolve(ctx, "orders.example.com:8080"); joinAddrs(eps) != "10.0.0.1:8080,10.0.0.2:8080,[fd00::1]:8080" {
+		t.Fatalf("explicit port = %s", joinAddrs(eps))
+	}
+	// A name that does not exist is an empty set, not an error.
+	if eps, err = d.Resolve(ctx, "gone.example.com"); err != nil || eps == nil || len(eps) != 0 {
+		t.Fatalf("NXDOMAIN = %+v, %v", eps, err)
+	}
+
+	noPort, _ := NewDNSDiscovery(DNSConfig{Servers: []string{s.addr}})
+	if _, err = noPort.Resolve(ctx, "orders.example.com"); err == nil {
+		t.Fatal("a name without a port needs DNSConfig.Port")
+	}
+}
+
+func TestDNSDiscoveryTruncatedFallsBackToTCP(t *testing.T) {
+	s := newDNSStub(t)
+	s.truncate.Store(true)
+	s.set("orders.example.com", dnsTypeA, []dnsRR{aRR("orders.example.com", "10.0.0.1", 30)})
+	s.set("orders.example.com", dnsTypeAAAA, nil)
+	d, _ := NewDNSDiscovery(DNSConfig{Servers: []string{s.addr}, Port: 80})
+	if eps, err := d.Resolve(context.Background(), "orders.example.com"); err != nil || joinAddrs(eps) != "10.0.0.1:80" {
+		t.Fatalf("Resolve over tcp = %+v, %v", eps, err)
+	}
+}
+
+func TestDNSDiscoveryWatchFollowsTTL(t *testing.T) {
+	s := newDNSStub(t)
+	s.set("orders.example.com", dnsTypeA, []dnsRR{aRR("orders.example.com", "10.0.0.1", 0)})
+	s.set("orders.example.com", dnsTypeAAAA, nil)
+	var failures atomic.Int32
+	d, _ := NewDNSDiscovery(DNSConfig{
+		Servers: []string{s.addr},
+		Port:    80,
+		MinTTL:  5 * time.Millisecond,
+		Timeout: 100 * time.Millisecond,
+		OnError: func(string, error) { failures.Add(1) },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := d.Watch(ctx, "orders.example.com")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if res := <-ch; joinAddrs(res.Endpoints) != "10.0.0.1:80" {
+		t.Fatalf("seed = %+v", res)
+	}
+
+	// The zero TTL is clamped to MinTTL: re-resolution happens, but an
+	// unchanged answer is not pushed.
+	seen := s.queries.Load()
+	time.Sleep(30 * time.Millisecond)
+	if s.queries.Load() <= seen {
+		t.Fatal("the watch did not re-resolve after the TTL")
+	}
+	select {
+	case res := <-ch:
+		t.Fatalf("unchanged answer pushed: %+v", res)
+	default:
+	}
+
+	s.set("orders.example.com", dnsTypeA, []dnsRR{aRR("orders.example.com", "10.0.0.1", 0), aRR("orders.example.com", "10.0.0.2", 0)})
+	select {
+	case res := <-ch:
+		if joinAddrs(res.Endpoints) != "10.0.0.1:80,10.0.0.2:80" {
+			t.Fatalf("update = %+v", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("change was not pushed")
+	}
+
+	cancel()
+	for range ch {
+	}
+	if failures.Load() != 0 {
+		t.Fatalf("unexpected failures: %d", failures.Load())
+	}
+}
+
+func TestDNSDiscoveryLongTTLIsCapped(t *testing.T) {
+	s := newDNSStub(t)
+	s.set("orders.example.com", dnsTypeA, []dnsRR{aRR("orders.example.com", "10.0.0.1", 86400)})
+	s.set("orders.example.com", dnsTypeAAAA, nil)
+	d, _ := NewDNSDiscovery(DNSConfig{Servers: []string{s.addr}, Port: 80, MinTTL: time.Millisecond, MaxTTL: time.Minute})
+	if _, ttl, err := d.lookup(context.Background(), "orders.example.com"); err != nil || ttl != time.Minute {
+		t.Fatalf("ttl = %v, %v; want capped at MaxTTL", ttl, err)
+	}
+}
+
+func TestReadDNSNameRejectsPointerLoop(t *testing.T) {
+	msg := make([]byte, 14)
+	binary.BigEndian.PutUint16(msg[12:], 0xc000|12) // points at itself
+	if _, _, err := readDNSName(msg, 12); err == nil {
+		t.Fatal("a compression loop must be rejected")
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// This file is the minimal DNS wire codec behind the DNS backend. The standard
+// library resolver does not expose record TTLs, which TTL-driven re-resolution
+// needs, and the package takes no third-party dependency, so it encodes the
+// one question it asks and decodes only the record types it reads.
+
+// DNS record types and response codes used by the DNS backend.
+const (
+	dnsTypeA     uint16 = 1
+	dnsTypeCNAME uint16 = 5
+	dnsTypeAAAA  uint16 = 28
+	dnsTypeSRV   uint16 = 33
+	dnsTypeOPT   uint16 = 41
+
+	dnsClassINET uint16 = 1
+
+	dnsRcodeSuccess  = 0
+	dnsRcodeNXDomain = 3
+
+	// dnsUDPSize is the UDP payload size advertised through EDNS0, so most
+	// answers fit without falling back to TCP.
+	dnsUDPSize = 4096
+)
+
+var errDNSTruncated = errors.New("discovery: dns response truncated")
+
+// dnsRR is one decoded resource record. Only the fields of the types the
+// backend reads are populated.
+type dnsRR struct {
+	Name string
+	Type uint16
+	TTL  uint32
+
+	IP net.IP // A, AAAA
+
+	Priority, Weight, Port uint16 // SRV
+	Target                 string // SRV, CNAME
+}
+
+// dnsResponse is a decoded reply: its rcode, the answer section and the
+// additional section (where servers put the addresses of SRV targets).
+type dnsResponse struct {
+	Rcode      int
+	Answers    []dnsRR
+	Additional []dnsRR
+}
+
+// dnsQuery encodes a recursive query for name and qtype with an EDNS0 record.
+func dnsQuery(id uint16, name string, qtype uint16) ([]byte, error) {
+	b := make([]byte, 12, 64)
+	binary.BigEndian.PutUint16(b[0:], id)
+	binary.BigEndian.PutUint16(b[2:], 0x0100) // RD
+	binary.BigEndian.PutUint16(b[4:], 1)      // QDCOUNT
+	binary.BigEndian.PutUint16(b[10:], 1)     // ARCOUNT: the OPT record
+	b, err := appendDNSName(b, name)
+	if err != nil {
+		return nil, err
+	}
+	b = binary.BigEndian.AppendUint16(b, qtype)
+	b = binary.BigEndian.AppendUint16(b, dnsClassINET)
+	// OPT: root name, type, UDP size as class, zero TTL and no options.
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint16(b, dnsTypeOPT)
+	b = binary.BigEndian.AppendUint16(b, dnsUDPSize)
+	b = binary.BigEndian.AppendUint32(b, 0)
+	b = binary.BigEndian.AppendUint16(b, 0)
+	return b, nil
+}
+
+// appendDNSName appends name in wire format, without compression.
+func appendDNSName(b []byte, name string) ([]byte, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		for label := range strings.SplitSeq(name, ".") {
+			if label == "" || len(label) > 63 {
+				return nil, fmt.Errorf("discovery: invalid dns name %q", name)
+			}
+			b = append(b, byte(len(label)))
+			b = append(b, label...)
+		}
+	}
+	return append(b, 0), nil
+}
+
+// parseDNSResponse decodes msg as the reply to the query with id.
+func parseDNSResponse(msg []byte, id uint16) (*dnsResponse, error) {
+	if len(msg) < 12 {
+		return nil, errors.New("discovery: short dns response")
+	}
+	if binary.BigEndian.Uint16(msg[0:]) != id {
+		return nil, errors.New("discovery: dns response id mismatch")
+	}
+	flags := binary.BigEndian.Uint16(msg[2:])
+	if flags&0x8000 == 0 {
+		return nil, errors.New("discovery: dns message is not a response")
+	}
+	if flags&0x0200 != 0 {
+		return nil, errDNSTruncated
+	}
+	qd := int(binary.BigEndian.Uint16(msg[4:]))
+	an := int(binary.BigEndian.Uint16(msg[6:]))
+	ns := int(binary.BigEndian.Uint16(msg[8:]))
+	ar := int(binary.BigEndian.Uint16(msg[10:]))
+
+	off := 12
+	for range qd {
+		_, n, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, err
+		}
+		off = n + 4
+	}
+	resp := &dnsResponse{Rcode: int(flags & 0x000f)}
+	var err error
+	if resp.Answers, off, err = readDNSRRs(msg, off, an); err != nil {
+		return nil, err
+	}
+	if _, off, err = readDNSRRs(msg, off, ns); err != nil {
+		return nil, err
+	}
+	if resp.Additional, _, err = readDNSRRs(msg, off, ar); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// readDNSRRs decodes count records starting at off.
+func readDNSRRs(msg []byte, off, count int) ([]dnsRR, int, error) {
+	var rrs []dnsRR
+	for range count {
+		name, n, err := readDNSName(msg, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		if n+10 > len(msg) {
+			return nil, 0, errors.New("discovery: short dns record")
+		}
+		rr := dnsRR{
+			Name: name,
+			Type: binary.BigEndian.Uint16(msg[n:]),
+			TTL:  binary.BigEndian.Uint32(msg[n+4:]),
+		}
+		size := int(binary.BigEndian.Uint16(msg[n+8:]))
+		start := n + 10
+		end := start + size
+		if end > len(msg) {
+			return nil, 0, errors.New("discovery: short dns record data")
+		}
+		data := msg[start:end]
+		switch rr.Type {
+		case dnsTypeA, dnsTypeAAAA:
+			if len(data) != net.IPv4len && len(data) != net.IPv6len {
+				return nil, 0, errors.New("discovery: bad dns address record")
+			}
+			rr.IP = net.IP(append([]byte(nil), data...))
+		case dnsTypeSRV:
+			if size < 7 {
+				return nil, 0, errors.New("discovery: bad dns srv record")
+			}
+			rr.Priority = binary.BigEndian.Uint16(data[0:])
+			rr.Weight = binary.BigEndian.Uint16(data[2:])
+			rr.Port = binary.BigEndian.Uint16(data[4:])
+			if rr.Target, _, err = readDNSName(msg, start+6); err != nil {
+				return nil, 0, err
+			}
+		case dnsTypeCNAME:
+			if rr.Target, _, err = readDNSName(msg, start); err != nil {
+				return nil, 0, err
+			}
+		}
+		rrs = append(rrs, rr)
+		off = end
+	}
+	return rrs, off, nil
+}
+
+// readDNSName decodes the possibly compressed name at off. It returns the
+// name in lower case without the trailing dot and the offset just past it.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	var sb strings.Builder
+	next := -1
+	for hops := 0; ; hops++ {
+		if off >= len(msg) || hops > 127 {
+			return "", 0, errors.New("discovery: bad dns name")
+		}
+		n := int(msg[off])
+		switch {
+		case n == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(sb.String()), next, nil
+		case n&0xc0 == 0xc0:
+			if off+1 >= len(msg) {
+				return "", 0, errors.New("discovery: bad dns name pointer")
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		default:
+			if off+1+n > len(msg) {
+				return "", 0, errors.New("discovery: bad dns label")
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.Write(msg[off+1 : off+1+n])
+			off += 1 + n
+		}
+	}
+}
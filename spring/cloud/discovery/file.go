@@ -0,0 +1,273 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FileConfig configures a [FileDiscovery].
+type FileConfig struct {
+	// Files are the paths or glob patterns of the files to read. Every match
+	// contributes its groups; a service listed in several files gets the
+	// union of their targets.
+	Files []string
+
+	// RefreshInterval is how often a Watch checks the files for changes. 0
+	// means five seconds.
+	RefreshInterval time.Duration
+
+	// Unmarshal decodes files that are not ".json". Unset, ".yaml" and ".yml"
+	// files are decoded with gopkg.in/yaml.v2 and any other file as JSON.
+	Unmarshal func(data []byte, v any) error
+
+	// OnError, when set, is called when the files can no longer be read or
+	// decoded. The last good content keeps being served meanwhile.
+	OnError func(err error)
+}
+
+// fileGroup is one entry of a discovery file, in the spirit of Prometheus
+// file_sd: a service, its "host:port" targets and attributes shared by them.
+type fileGroup struct {
+	Service  string            `json:"service" yaml:"service"`
+	Targets  []string          `json:"targets" yaml:"targets"`
+	Scheme   string            `json:"scheme" yaml:"scheme"`
+	Weight   int               `json:"weight" yaml:"weight"`
+	Disabled bool              `json:"disabled" yaml:"disabled"`
+	Labels   map[string]string `json:"labels" yaml:"labels"`
+}
+
+// FileDiscovery is a [Discovery] backed by files listing services and their
+// endpoints, for environments without a registry or as a hand-edited override
+// of one. Each file holds a list of groups:
+//
+//	[
+//	  {"service": "orders", "targets": ["10.0.0.1:80", "10.0.0.2:80"],
+//	   "labels": {"zone": "z1"}},
+//	  {"service": "orders", "targets": ["10.0.1.1:80"], "weight": 50,
+//	   "labels": {"zone": "z2"}}
+//	]
+//
+// A group may also set "scheme" and "disabled". Labels become the endpoint
+// metadata and every listed target is Healthy: the file is the operator's
+// statement of what is up.
+//
+// The files are polled by size and modification time — the package has no
+// file-notification dependency — and re-read when either changes, so an
+// atomic rename by a config agent is picked up on the next check. A file that
+// fails to read or decode keeps the previous content in service. FileDiscovery
+// implements [Catalog] and is safe for concurrent use.
+type FileDiscovery struct {
+	cfg FileConfig
+
+	mu       sync.Mutex
+	sig      string                // size and mtime of the files last read
+	services map[string][]Endpoint // service -> endpoints sorted by address
+}
+
+// NewFileDiscovery returns a file backend configured by cfg. It reads the
+// files once and fails when no file matches or one is invalid.
+func NewFileDiscovery(cfg FileConfig) (*FileDiscovery, error) {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 5 * time.Second
+	}
+	d := &FileDiscovery{cfg: cfg}
+	paths, err := d.paths()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("discovery: no file matches %v", cfg.Files)
+	}
+	if err = d.refresh(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Resolve implements [Discovery]. An unknown service resolves to an empty
+// set.
+func (d *FileDiscovery) Resolve(_ context.Context, name string, opts ...Option) ([]Endpoint, error) {
+	return FilterByScheme(d.current(name), NewQuery(name, opts...).Scheme), nil
+}
+
+// Watch implements [Discovery]. The files are checked every
+// [FileConfig.RefreshInterval] and a snapshot is pushed when the service's
+// endpoints changed. A slow reader only misses intermediate snapshots.
+func (d *FileDiscovery) Watch(ctx context.Context, name string, opts ...Option) (<-chan WatchResult, error) {
+	scheme := NewQuery(name, opts...).Scheme
+	last := d.current(name)
+	ch := make(chan WatchResult, 1)
+	ch <- WatchResult{Endpoints: FilterByScheme(slices.Clone(last), scheme)}
+	go func() {
+		defer close(ch)
+		t := time.NewTicker(d.cfg.RefreshInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			next := d.current(name)
+			if reflect.DeepEqual(next, last) {
+				continue
+			}
+			last = next
+			sendLatest(ch, WatchResult{Endpoints: FilterByScheme(slices.Clone(next), scheme)})
+		}
+	}()
+	return ch, nil
+}
+
+// Services implements [Catalog].
+func (d *FileDiscovery) Services(context.Context) ([]string, error) {
+	d.check()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.services) == 0 {
+		return nil, nil
+	}
+	names := slices.Collect(maps.Keys(d.services))
+	sort.Strings(names)
+	return names, nil
+}
+
+// current returns a copy of name's endpoints after picking up file changes.
+func (d *FileDiscovery) current(name string) []Endpoint {
+	d.check()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	eps := make([]Endpoint, 0, len(d.services[name]))
+	for _, ep := range d.services[name] {
+		ep.Metadata = maps.Clone(ep.Metadata)
+		eps = append(eps, ep)
+	}
+	return eps
+}
+
+// check refreshes the content and reports a failure to OnError.
+func (d *FileDiscovery) check() {
+	if err := d.refresh(); err != nil && d.cfg.OnError != nil {
+		d.cfg.OnError(err)
+	}
+}
+
+// refresh re-reads the files when their size or modification time changed.
+func (d *FileDiscovery) refresh() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	paths, err := d.paths()
+	if err != nil {
+		return err
+	}
+	var sig strings.Builder
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&sig, "%s|%d|%d\n", p, fi.Size(), fi.ModTime().UnixNano())
+	}
+	if d.services != nil && sig.String() == d.sig {
+		return nil
+	}
+	services := map[string][]Endpoint{}
+	for _, p := range paths {
+		if err = d.load(p, services); err != nil {
+			return err
+		}
+	}
+	for _, eps := range services {
+		sort.SliceStable(eps, func(i, j int) bool { return eps[i].Addr < eps[j].Addr })
+	}
+	d.sig, d.services = sig.String(), services
+	return nil
+}
+
+// paths expands the configured patterns into sorted, de-duplicated paths.
+func (d *FileDiscovery) paths() ([]string, error) {
+	var paths []string
+	for _, pattern := range d.cfg.Files {
+		m, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("discovery: bad file pattern %q: %w", pattern, err)
+		}
+		paths = append(paths, m...)
+	}
+	sort.Strings(paths)
+	return slices.Compact(paths), nil
+}
+
+// load decodes the file at path and adds its groups to services.
+func (d *FileDiscovery) load(path string, services map[string][]Endpoint) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	unmarshal := json.Unmarshal
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".json" {
+		if d.cfg.Unmarshal != nil {
+			unmarshal = d.cfg.Unmarshal
+		} else if ext == ".yaml" || ext == ".yml" {
+			unmarshal = yaml.Unmarshal
+		}
+	}
+	var groups []fileGroup
+	if err = unmarshal(b, &groups); err != nil {
+		return fmt.Errorf("discovery: %s: %w", path, err)
+	}
+	for i, g := range groups {
+		if g.Service == "" {
+			return fmt.Errorf("discovery: %s: group %d has no service", path, i)
+		}
+		for _, t := range g.Targets {
+			if _, _, err = net.SplitHostPort(t); err != nil {
+				return fmt.Errorf("discovery: %s: service %s: %w", path, g.Service, err)
+			}
+			var meta map[string]string
+			if len(g.Labels) > 0 {
+				meta = maps.Clone(g.Labels)
+			}
+			services[g.Service] = append(services[g.Service], Endpoint{
+				Addr:     t,
+				Scheme:   g.Scheme,
+				Weight:   g.Weight,
+				Disabled: g.Disabled,
+				Healthy:  true,
+				Metadata: meta,
+			})
+		}
+	}
+	return nil
+}
+
+var _ Catalog = (*FileDiscovery)(nil)
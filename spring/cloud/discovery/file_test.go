@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	// Write then rename, as a config agent would.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileDiscoveryResolve(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.json"), `[
+		{"service": "orders", "targets": ["10.0.0.2:80", "10.0.0.1:80"], "labels": {"zone": "z1"}},
+		{"service": "users", "targets": ["10.0.1.1:443"], "scheme": "tls"}
+	]`)
+	writeFile(t, filepath.Join(dir, "b.json"), `[
+		{"service": "orders", "targets": ["10.0.0.3:80"], "weight": 50, "disabled": true}
+	]`)
+	d, err := NewFileDiscovery(FileConfig{Files: []string{filepath.Join(dir, "*.json")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	eps, _ := d.Resolve(ctx, "orders")
+	if joinAddrs(eps) != "10.0.0.1:80,10.0.0.2:80,10.0.0.3:80" {
+		t.Fatalf("orders = %s; want the union of both files", joinAddrs(eps))
+	}
+	if !eps[0].Healthy || eps[0].Metadata["zone"] != "z1" || eps[2].Weight != 50 || !eps[2].Disabled {
+		t.Fatalf("group attributes lost: %+v", eps)
+	}
+	if eps, _ = d.Resolve(ctx, "users", WithScheme("tls")); len(eps) != 1 {
+		t.Fatalf("users over tls = %+v", eps)
+	}
+	if eps, err = d.Resolve(ctx, "unknown"); err != nil || len(eps) != 0 {
+		t.Fatalf("unknown = %+v, %v", eps, err)
+	}
+	if names, _ := d.Services(ctx); strings.Join(names, ",") != "orders,users" {
+		t.Fatalf("Services = %v", names)
+	}
+}
+
+func TestFileDiscoveryRejectsBadConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewFileDiscovery(FileConfig{Files: []string{filepath.Join(dir, "*.json")}}); err == nil {
+		t.Fatal("no matching file should fail")
+	}
+	writeFile(t, filepath.Join(dir, "sd.json"), `[{"service": "orders", "targets": ["10.0.0.1"]}]`)
+	if _, err := NewFileDiscovery(FileConfig{Files: []string{filepath.Join(dir, "sd.json")}}); err == nil {
+		t.Fatal("a target without a port should fail")
+	}
+	writeFile(t, filepath.Join(dir, "sd.yaml"), "- targets: [10.0.0.1:80]\n")
+	if _, err := NewFileDiscovery(FileConfig{Files: []string{filepath.Join(dir, "sd.yaml")}}); err == nil {
+		t.Fatal("a YAML group without a service should fail")
+	}
+}
+
+func TestFileDiscoveryYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sd.yml")
+	writeFile(t, path, `
+- service: orders
+  targets: ["10.0.0.1:80", "10.0.0.2:80"]
+  weight: 50
+  labels:
+    zone: z1
+`)
+	d, err := NewFileDiscovery(FileConfig{Files: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eps, _ := d.Resolve(context.Background(), "orders")
+	if joinAddrs(eps) != "10.0.0.1:80,10.0.0.2:80" || eps[0].Weight != 50 || eps[0].Metadata["zone"] != "z1" {
+		t.Fatalf("orders = %+v", eps)
+	}
+}
+
+func TestFileDiscoveryPluggableUnmarshal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sd.yaml")
+	writeFile(t, path, "ignored")
+	// Stands in for a YAML decoder.
+	unmarshal := func(_ []byte, v any) error {
+		return json.Unmarshal([]byte(`[{"service": "orders", "targets": ["10.0.0.1:80"]}]`), v)
+	}
+	d, err := NewFileDiscovery(FileConfig{Files: []string{path}, Unmarshal: unmarshal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eps, _ := d.Resolve(context.Background(), "orders"); joinAddrs(eps) != "10.0.0.1:80" {
+		t.Fatalf("orders = %+v", eps)
+	}
+}
+
+func TestFileDiscoveryWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sd.json")
+	writeFile(t, path, `[{"service": "orders", "targets": ["10.0.0.1:80"]}]`)
+	var errs atomic.Int32
+	d, err := NewFileDiscovery(FileConfig{
+		Files:           []string{path},
+		RefreshInterval: 5 * time.Millisecond,
+		OnError:         func(error) { errs.Add(1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, _ := d.Watch(ctx, "orders")
+	if res := <-ch; joinAddrs(res.Endpoints) != "10.0.0.1:80" {
+		t.Fatalf("seed = %+v", res)
+	}
+
+	// A change to another service is not pushed to this watch.
+	writeFile(t, path, `[{"service": "orders", "targets": ["10.0.0.1:80"]}, {"service": "users", "targets": ["10.0.1.1:80"]}]`)
+	time.Sleep(30 * time.Millisecond)
+	select {
+	case res := <-ch:
+		t.Fatalf("unrelated change pushed: %+v", res)
+	default:
+	}
+
+	// A broken file keeps the last good content.
+	writeFile(t, path, `[{"service": `)
+	time.Sleep(30 * time.Millisecond)
+	if errs.Load() == 0 {
+		t.Fatal("OnError was not told about the broken file")
+	}
+	if eps, _ := d.Resolve(ctx, "orders"); joinAddrs(eps) != "10.0.0.1:80" {
+		t.Fatalf("broken file = %+v; want the last good content", eps)
+	}
+
+	writeFile(t, path, `[{"service": "orders", "targets": ["10.0.0.1:80", "10.0.0.2:80"]}]`)
+	select {
+	case res := <-ch:
+		if joinAddrs(res.Endpoints) != "10.0.0.1:80,10.0.0.2:80" {
+			t.Fatalf("update = %+v", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("change was not pushed")
+	}
+	cancel()
+	for range ch {
+	}
+}
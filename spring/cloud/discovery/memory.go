@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// MemoryRegistry is an in-process registry that is both a [Registrar] and a
+// [Discovery] (and a [Catalog]): instances registered through it are resolved
+// and watched through it. It is meant for tests and single-process demos that
+// exercise the register/discover round trip without a naming service.
+type MemoryRegistry struct {
+	mu       sync.Mutex
+	services map[string]map[string]Instance // service name -> instance id -> instance
+	watchers map[string]map[*memoryWatch]struct{}
+}
+
+var (
+	_ Registrar = (*MemoryRegistry)(nil)
+	_ Discovery = (*MemoryRegistry)(nil)
+	_ Catalog   = (*MemoryRegistry)(nil)
+)
+
+type memoryWatch struct {
+	ch     chan WatchResult
+	scheme string
+}
+
+// NewMemoryRegistry returns an empty [MemoryRegistry].
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		services: map[string]map[string]Instance{},
+		watchers: map[string]map[*memoryWatch]struct{}{},
+	}
+}
+
+// Register implements [Registrar].
+func (r *MemoryRegistry) Register(_ context.Context, inst Instance) error {
+	if inst.ServiceName == "" || inst.Addr == "" {
+		return fmt.Errorf("discovery: instance service name and addr are required")
+	}
+	inst.Metadata = maps.Clone(inst.Metadata)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.services[inst.ServiceName]
+	if m == nil {
+		m = map[string]Instance{}
+		r.services[inst.ServiceName] = m
+	}
+	m[InstanceID(inst)] = inst
+	r.notifyLocked(inst.ServiceName)
+	return nil
+}
+
+// Deregister implements [Registrar].
+func (r *MemoryRegistry) Deregister(_ context.Context, inst Instance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.services[inst.ServiceName]
+	id := InstanceID(inst)
+	if _, ok := m[id]; !ok {
+		return nil
+	}
+	delete(m, id)
+	if len(m) == 0 {
+		delete(r.services, inst.ServiceName)
+	}
+	r.notifyLocked(inst.ServiceName)
+	return nil
+}
+
+// Heartbeat implements [Registrar]. It updates the Status and Metadata of a
+// registered instance and fails for one that is not registered.
+func (r *MemoryRegistry) Heartbeat(_ context.Context, inst Instance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := InstanceID(inst)
+	old, ok := r.services[inst.ServiceName][id]
+	if !ok {
+		return fmt.Errorf("discovery: instance %s of %s is not registered", id, inst.ServiceName)
+	}
+	if old.Status == inst.Status && reflect.DeepEqual(old.Metadata, inst.Metadata) {
+		return nil
+	}
+	old.Status = inst.Status
+	old.Metadata = maps.Clone(inst.Metadata)
+	r.services[inst.ServiceName][id] = old
+	r.notifyLocked(inst.ServiceName)
+	return nil
+}
+
+// Resolve implements [Discovery]. An unknown service resolves to an empty set.
+func (r *MemoryRegistry) Resolve(_ context.Context, name string, opts ...Option) ([]Endpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return FilterByScheme(r.endpointsLocked(name), NewQuery(name, opts...).Scheme), nil
+}
+
+// Watch implements [Discovery]. A slow reader only ever misses intermediate
+// snapshots: the latest one replaces an undelivered earlier one.
+func (r *MemoryRegistry) Watch(ctx context.Context, name string, opts ...Option) (<-chan WatchResult, error) {
+	w := &memoryWatch{ch: make(chan WatchResult, 1), scheme: NewQuery(name, opts...).Scheme}
+	r.mu.Lock()
+	if r.watchers[name] == nil {
+		r.watchers[name] = map[*memoryWatch]struct{}{}
+	}
+	r.watchers[name][w] = struct{}{}
+	w.ch <- WatchResult{Endpoints: FilterByScheme(r.endpointsLocked(name), w.scheme)}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.watchers[name], w)
+		if len(r.watchers[name]) == 0 {
+			delete(r.watchers, name)
+		}
+		close(w.ch)
+	}()
+	return w.ch, nil
+}
+
+// Services implements [Catalog]: the names with at least one instance.
+func (r *MemoryRegistry) Services(context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.services))
+	for name := range r.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Instances returns the instances registered for name, ordered by id.
+func (r *MemoryRegistry) Instances(name string) []Instance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.instancesLocked(name)
+}
+
+func (r *MemoryRegistry) instancesLocked(name string) []Instance {
+	m := r.services[name]
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]Instance, len(ids))
+	for i, id := range ids {
+		out[i] = m[id]
+	}
+	return out
+}
+
+func (r *MemoryRegistry) endpointsLocked(name string) []Endpoint {
+	insts := r.instancesLocked(name)
+	eps := make([]Endpoint, len(insts))
+	for i, inst := range insts {
+		eps[i] = inst.Endpoint()
+	}
+	return eps
+}
+
+// notifyLocked pushes the current snapshot of name to its watchers, replacing
+// any snapshot a watcher has not read yet. Sends happen under r.mu, which the
+// closing goroutine also holds, so a closed channel is never written.
+func (r *MemoryRegistry) notifyLocked(name string) {
+	if len(r.watchers[name]) == 0 {
+		return
+	}
+	eps := r.endpointsLocked(name)
+	for w := range r.watchers[name] {
+		sendLatest(w.ch, WatchResult{Endpoints: FilterByScheme(append([]Endpoint(nil), eps...), w.scheme)})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+)
+
+// Status is the health an [Instance] advertises to its registry.
+type Status string
+
+const (
+	// StatusUp means the instance is healthy and may receive traffic. It is
+	// the default: an empty Status is treated as StatusUp.
+	StatusUp Status = "UP"
+
+	// StatusDown means the instance is registered but failing its health
+	// checks. Consumers see it as an unhealthy [Endpoint], used only when no
+	// healthy instance exists.
+	StatusDown Status = "DOWN"
+
+	// StatusOutOfService means the instance is registered but taken out of
+	// rotation (draining, maintenance). Consumers see it as a disabled
+	// [Endpoint] that never receives traffic.
+	StatusOutOfService Status = "OUT_OF_SERVICE"
+)
+
+// Instance is the provider-side description of one process published to a
+// registry through a [Registrar]. A [Discovery] backend reading the same
+// registry turns it back into an [Endpoint] (see [Instance.Endpoint]).
+type Instance struct {
+	// ServiceName is the logical name consumers resolve. Required.
+	ServiceName string
+
+	// ID identifies the instance within the service. Empty derives a stable
+	// one from ServiceName and Addr (see [InstanceID]), so a restart replaces
+	// the same entry.
+	ID string
+
+	// Addr is the connectable "host:port". Required.
+	Addr string
+
+	// Scheme is the transport scheme advertised as [Endpoint.Scheme].
+	Scheme string
+
+	// Weight is the load-balancing weight; 0 means the backend default.
+	Weight int
+
+	// Metadata carries attributes consumers route on (zone, unit, version,
+	// lane, ...).
+	Metadata map[string]string
+
+	// Status is the advertised health; empty means [StatusUp].
+	Status Status
+}
+
+// InstanceID returns inst.ID, or "<service>-<addr>" when it is empty.
+func InstanceID(inst Instance) string {
+	if inst.ID != "" {
+		return inst.ID
+	}
+	return inst.ServiceName + "-" + inst.Addr
+}
+
+// Endpoint converts inst to the consumer-side [Endpoint], mapping Status onto
+// the Healthy and Disabled flags.
+func (inst Instance) Endpoint() Endpoint {
+	return Endpoint{
+		Addr:     inst.Addr,
+		Scheme:   inst.Scheme,
+		Weight:   inst.Weight,
+		Disabled: inst.Status == StatusOutOfService,
+		Healthy:  inst.Status == "" || inst.Status == StatusUp,
+		Metadata: maps.Clone(inst.Metadata),
+	}
+}
+
+// Registrar is the provider-side counterpart of [Discovery]: it publishes this
+// process to a naming service. A registry starter (etcd, Consul, Nacos,
+// ZooKeeper, ...) implements it once and plugs it into the shared
+// registration lifecycle instead of running its own.
+//
+// Registration must never depend on Deregister for correctness: a backend
+// binds the entry to a lease, session or TTL check that expires when the
+// process dies. Implementations must be safe for concurrent use.
+type Registrar interface {
+	// Register publishes inst. Registering an instance that is already
+	// registered replaces it.
+	Register(ctx context.Context, inst Instance) error
+
+	// Deregister removes inst. It is idempotent: removing an instance that is
+	// not registered returns nil.
+	Deregister(ctx context.Context, inst Instance) error
+
+	// Heartbeat reports that inst is still alive and publishes its current
+	// Status and Metadata. A backend whose registration renews itself (an etcd
+	// lease, a Nacos ephemeral instance) only needs to publish a change.
+	Heartbeat(ctx context.Context, inst Instance) error
+}
+
+// registrarsMu guards registrars; see discoveriesMu.
+var (
+	registrarsMu sync.RWMutex
+	registrars   = map[string]Registrar{}
+)
+
+// RegisterRegistrar publishes a fully-built [Registrar] under the label name,
+// the provider-side mirror of [RegisterDiscovery]. It panics on empty name,
+// nil Registrar, or a duplicate name.
+func RegisterRegistrar(name string, r Registrar) {
+	if name == "" {
+		panic("discovery: register Registrar with empty name")
+	}
+	if r == nil {
+		panic("discovery: register nil Registrar for " + name)
+	}
+	registrarsMu.Lock()
+	defer registrarsMu.Unlock()
+	if _, ok := registrars[name]; ok {
+		panic("discovery: Registrar already registered: " + name)
+	}
+	registrars[name] = r
+}
+
+// GetRegistrar returns the [Registrar] registered under name, or an error
+// listing every registered one.
+func GetRegistrar(name string) (Registrar, error) {
+	registrarsMu.RLock()
+	defer registrarsMu.RUnlock()
+	if r, ok := registrars[name]; ok {
+		return r, nil
+	}
+	names := make([]string, 0, len(registrars))
+	for k := range registrars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("discovery: no Registrar registered as %q (registered: %v)", name, names)
+}
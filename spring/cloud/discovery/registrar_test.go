@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInstanceID(t *testing.T) {
+	if got := InstanceID(Instance{ID: "fixed", ServiceName: "orders", Addr: "1.2.3.4:80"}); got != "fixed" {
+		t.Fatalf("explicit ID = %q, want fixed", got)
+	}
+	if got := InstanceID(Instance{ServiceName: "orders", Addr: "1.2.3.4:80"}); got != "orders-1.2.3.4:80" {
+		t.Fatalf("derived ID = %q, want orders-1.2.3.4:80", got)
+	}
+}
+
+func TestInstanceEndpointMapsStatus(t *testing.T) {
+	for _, c := range []struct {
+		status            Status
+		healthy, disabled bool
+	}{
+		{"", true, false},
+		{StatusUp, true, false},
+		{StatusDown, false, false},
+		{StatusOutOfService, false, true},
+	} {
+		ep := Instance{Addr: "a:1", Status: c.status}.Endpoint()
+		if ep.Healthy != c.healthy || ep.Disabled != c.disabled {
+			t.Errorf("status %q: healthy=%v disabled=%v, want %v %v", c.status, ep.Healthy, ep.Disabled, c.healthy, c.disabled)
+		}
+	}
+}
+
+func TestRegisterRegistrarAndGet(t *testing.T) {
+	r := NewMemoryRegistry()
+	RegisterRegistrar("test-registrar", r)
+	got, err := GetRegistrar("test-registrar")
+	if err != nil || got != Registrar(r) {
+		t.Fatalf("GetRegistrar = %v, %v; want the registered Registrar", got, err)
+	}
+	_, err = GetRegistrar("does-not-exist")
+	if err == nil || !strings.Contains(err.Error(), "test-registrar") {
+		t.Fatalf("missing Registrar error should list registered ones: %v", err)
+	}
+	if !panics("already registered", func() { RegisterRegistrar("test-registrar", r) }) {
+		t.Error("registering a duplicate name should panic")
+	}
+	if !panics("nil Registrar", func() { RegisterRegistrar("test-nil-registrar", nil) }) {
+		t.Error("registering a nil Registrar should panic")
+	}
+}
+
+func TestMemoryRegistryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemoryRegistry()
+	inst := Instance{ServiceName: "orders", Addr: "10.0.0.1:80", Metadata: map[string]string{"zone": "z1"}}
+	if err := r.Register(ctx, inst); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := r.Register(ctx, Instance{ServiceName: "orders", Addr: "10.0.0.2:80", Scheme: "tls"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := r.Register(ctx, Instance{ServiceName: "orders"}); err == nil {
+		t.Fatal("Register without addr should fail")
+	}
+
+	eps, _ := r.Resolve(ctx, "orders")
+	if len(eps) != 2 || !eps[0].Healthy || eps[0].Metadata["zone"] != "z1" {
+		t.Fatalf("Resolve = %+v, want two healthy endpoints with metadata", eps)
+	}
+	eps, _ = r.Resolve(ctx, "orders", WithScheme("tls"))
+	if len(eps) != 1 || eps[0].Addr != "10.0.0.2:80" {
+		t.Fatalf("Resolve tls = %+v, want only the tls endpoint", eps)
+	}
+	names, _ := r.Services(ctx)
+	if len(names) != 1 || names[0] != "orders" {
+		t.Fatalf("Services = %v, want [orders]", names)
+	}
+
+	// Heartbeat publishes a status change; an unknown instance is an error.
+	inst.Status = StatusOutOfService
+	if err := r.Heartbeat(ctx, inst); err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if eps, _ = r.Resolve(ctx, "orders"); !eps[0].Disabled {
+		t.Fatalf("heartbeat status not applied: %+v", eps[0])
+	}
+	if err := r.Heartbeat(ctx, Instance{ServiceName: "orders", Addr: "10.0.0.9:80"}); err == nil {
+		t.Fatal("Heartbeat of an unregistered instance should fail")
+	}
+
+	// Deregister is idempotent and drops the service once empty.
+	for range 2 {
+		if err := r.Deregister(ctx, inst); err != nil {
+			t.Fatalf("Deregister: %v", err)
+		}
+	}
+	_ = r.Deregister(ctx, Instance{ServiceName: "orders", Addr: "10.0.0.2:80"})
+	if names, _ = r.Services(ctx); len(names) != 0 {
+		t.Fatalf("Services after deregister = %v, want none", names)
+	}
+}
+
+func TestMemoryRegistryWatch(t *testing.T) {
+	r := NewMemoryRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := r.Watch(ctx, "orders")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if res := <-ch; res.Endpoints == nil || len(res.Endpoints) != 0 {
+		t.Fatalf("seed = %+v, want an empty non-nil snapshot", res)
+	}
+
+	// Two changes without a read: the reader sees only the latest snapshot.
+	_ = r.Register(context.Background(), Instance{ServiceName: "orders", Addr: "a:1"})
+	_ = r.Register(context.Background(), Instance{ServiceName: "orders", Addr: "b:1"})
+	select {
+	case res := <-ch:
+		if len(res.Endpoints) != 2 {
+			t.Fatalf("update = %+v, want two endpoints", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("registration was not pushed to the watcher")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("channel should be closed after ctx cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed after ctx cancel")
+	}
+}
@@ -429,3 +429,90 @@ func Example_cache() {
 	// loaded
 	// db calls: 1
 }
+
+func TestTransactionalSynchronizations(t *testing.T) {
+	var log []string
+	run := func(tm *fakeTx, fail, veto bool) error {
+		c := aspect.NewChain(aspect.Transactional(tm))
+		_, err := c.Run(context.Background(), "m", func(ctx context.Context) (any, error) {
+			assert.That(t, aspect.OnBeforeCommit(ctx, func(context.Context) error {
+				log = append(log, "before")
+				if veto {
+					return errors.New("veto")
+				}
+				return nil
+			})).True()
+			aspect.OnAfterCompletion(ctx, func(_ context.Context, committed bool) {
+				log = append(log, fmt.Sprintf("after:%v", committed))
+			})
+			if fail {
+				return nil, errors.New("boom")
+			}
+			return nil, nil
+		})
+		return err
+	}
+
+	tm := &fakeTx{}
+	assert.Error(t, run(tm, false, false)).Nil()
+	assert.That(t, log).Equal([]string{"before", "after:true"})
+	assert.That(t, tm.committed).True()
+
+	log, tm = nil, &fakeTx{}
+	assert.Error(t, run(tm, true, false)).Matches("boom")
+	assert.That(t, log).Equal([]string{"after:false"}) // no before-commit on failure
+
+	log, tm = nil, &fakeTx{}
+	assert.Error(t, run(tm, false, true)).Matches("veto")
+	assert.That(t, log).Equal([]string{"before", "after:false"})
+	assert.That(t, tm.committed).False()
+	assert.That(t, tm.rolledBack).True()
+
+	// Outside a transaction nothing is registered.
+	assert.That(t, aspect.OnBeforeCommit(context.Background(), func(context.Context) error { return nil })).False()
+}
+
+// joiningTx joins the transaction ctx already carries, as REQUIRED
+// propagation does, and logs only the physical commit.
+type joiningTx struct{ log *[]string }
+
+type joinedTx struct{}
+
+func (j joiningTx) Begin(ctx context.Context) (context.Context, any, error) {
+	if ctx.Value(txKey{}) != nil {
+		return ctx, joinedTx{}, nil
+	}
+	return context.WithValue(ctx, txKey{}, j), j, nil
+}
+
+func (j joiningTx) Commit(tx any) error {
+	if _, ok := tx.(joinedTx); !ok {
+		*j.log = append(*j.log, "commit")
+	}
+	return nil
+}
+
+func (joiningTx) Rollback(any) error { return nil }
+
+func (joiningTx) Joined(tx any) bool { _, ok := tx.(joinedTx); return ok }
+
+func TestTransactionalJoinedDefersSynchronizations(t *testing.T) {
+	var log []string
+	c := aspect.NewChain(aspect.Transactional(joiningTx{log: &log}))
+	_, err := c.Run(context.Background(), "outer", func(ctx context.Context) (any, error) {
+		_, err := c.Run(ctx, "inner", func(ctx context.Context) (any, error) {
+			aspect.OnBeforeCommit(ctx, func(context.Context) error {
+				log = append(log, "before")
+				return nil
+			})
+			aspect.OnAfterCompletion(ctx, func(_ context.Context, committed bool) {
+				log = append(log, fmt.Sprintf("after:%v", committed))
+			})
+			return nil, nil
+		})
+		log = append(log, "inner returned")
+		return nil, err
+	})
+	assert.Error(t, err).Nil()
+	assert.That(t, log).Equal([]string{"inner returned", "before", "commit", "after:true"})
+}
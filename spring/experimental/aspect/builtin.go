@@ -164,12 +164,29 @@ type TxManager interface {
 	Rollback(tx any) error
 }
 
+// TxJoiner is optionally implemented by a [TxManager] whose Begin may join a
+// transaction the context already carries instead of opening a new one.
+// Joined reports whether tx, a handle returned by Begin, belongs to such an
+// outer transaction. [Transactional] then leaves the synchronizations to the
+// interceptor that started it, so they run when the outer transaction
+// completes rather than when the joined scope returns.
+type TxJoiner interface {
+	Joined(tx any) bool
+}
+
 // Transactional returns an interceptor that provides the declarative-transaction
 // equivalent (@Transactional): it begins a transaction, proceeds with the
 // tx-carrying context so the business code runs inside it, then commits on
 // success or rolls back on error. A panic further down the chain triggers a
 // rollback and is re-raised so an outer [Recover] can translate it. A nil
 // TxManager makes the interceptor a transparent pass-through.
+//
+// The tx-carrying context also accepts synchronizations registered with
+// [OnBeforeCommit] and [OnAfterCompletion]: before-commit callbacks run after
+// the target succeeds and may veto the commit; after-completion callbacks run
+// once the transaction has committed or rolled back. When a [TxJoiner] reports
+// that Begin joined an outer transaction, callbacks registered inside go to
+// that outer transaction and run when it completes.
 func Transactional(tm TxManager) Interceptor {
 	return InterceptorFunc(func(jp *Joinpoint) (result any, err error) {
 		if tm == nil {
@@ -179,9 +196,18 @@ func Transactional(tm TxManager) Interceptor {
 		if err != nil {
 			return nil, fmt.Errorf("aspect: begin transaction for %q: %w", jp.Method, err)
 		}
+		// A joined transaction keeps the outer synchronizations: ctx derives
+		// from jp.Context, so registrations reach the outer txSync, which
+		// fires them where the transaction actually completes.
+		var ts *txSync
+		if j, ok := tm.(TxJoiner); !ok || !j.Joined(tx) {
+			ts = &txSync{}
+			ctx = context.WithValue(ctx, txSyncKey{}, ts)
+		}
 		committed := false
 		defer func() {
 			if committed {
+				ts.afterCompletion(jp.Context, true)
 				return
 			}
 			// Rollback on error or on a propagating panic. Preserve the original
@@ -189,11 +215,15 @@ func Transactional(tm TxManager) Interceptor {
 			if rbErr := tm.Rollback(tx); rbErr != nil && err == nil {
 				err = fmt.Errorf("aspect: rollback transaction for %q: %w", jp.Method, rbErr)
 			}
+			ts.afterCompletion(jp.Context, false)
 		}()
 		result, err = jp.Proceed(ctx)
 		if err != nil {
 			return nil, err
 		}
+		if err = ts.beforeCommit(ctx); err != nil {
+			return nil, err
+		}
 		if cErr := tm.Commit(tx); cErr != nil {
 			return nil, fmt.Errorf("aspect: commit transaction for %q: %w", jp.Method, cErr)
 		}
@@ -202,6 +232,84 @@ func Transactional(tm TxManager) Interceptor {
 	})
 }
 
+// txSyncKey is the context key of the synchronizations of the innermost
+// [Transactional] transaction.
+type txSyncKey struct{}
+
+// txSync collects the callbacks registered against one transaction.
+type txSync struct {
+	mu     sync.Mutex
+	before []func(ctx context.Context) error
+	after  []func(ctx context.Context, committed bool)
+}
+
+// OnBeforeCommit registers fn to run when the transaction carried by ctx is
+// about to commit, inside the transaction. An error from fn rolls the
+// transaction back and is returned by the intercepted call. It reports false,
+// without registering, when ctx carries no [Transactional] transaction.
+func OnBeforeCommit(ctx context.Context, fn func(ctx context.Context) error) bool {
+	s, ok := ctx.Value(txSyncKey{}).(*txSync)
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	s.before = append(s.before, fn)
+	s.mu.Unlock()
+	return true
+}
+
+// OnAfterCompletion registers fn to run after the transaction carried by ctx
+// has committed (committed is true) or rolled back. fn receives the context
+// the transaction was started from, since the transaction itself is over. It
+// reports false, without registering, when ctx carries no [Transactional]
+// transaction.
+func OnAfterCompletion(ctx context.Context, fn func(ctx context.Context, committed bool)) bool {
+	s, ok := ctx.Value(txSyncKey{}).(*txSync)
+	if !ok {
+		return false
+	}
+	s.mu.Lock()
+	s.after = append(s.after, fn)
+	s.mu.Unlock()
+	return true
+}
+
+// beforeCommit runs the before-commit callbacks in registration order,
+// including any registered by an earlier callback, and stops at the first
+// error. A nil s, the sync of a joined transaction, runs nothing.
+func (s *txSync) beforeCommit(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		if i >= len(s.before) {
+			s.mu.Unlock()
+			return nil
+		}
+		fn := s.before[i]
+		s.mu.Unlock()
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// afterCompletion runs the after-completion callbacks in registration order.
+// A nil s runs nothing.
+func (s *txSync) afterCompletion(ctx context.Context, committed bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	after := s.after
+	s.after = nil
+	s.mu.Unlock()
+	for _, fn := range after {
+		fn(ctx, committed)
+	}
+}
+
 // Only returns an interceptor that applies inner only when the joinpoint's method
 // is one of methods; for any other method it proceeds straight through. It is the
 // pointcut equivalent: a way to scope a concern to a subset of the operations a
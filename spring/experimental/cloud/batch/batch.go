@@ -51,8 +51,12 @@
 // therefore requires an idempotent [Writer] (e.g. keyed upserts). This mirrors
 // Spring Batch's chunk-commit semantics.
 //
-// The scope of the first version is single-process chunk processing. Remote
-// partitioning (sharding a step across processes) is intentionally out of scope.
+// Beyond a straight sequence of steps, a [Job] can route on each step's exit
+// status ([Transition]) and run flows side by side ([Split]); a [PartitionStep]
+// splits one step's input into partitions that run concurrently, in process or
+// on worker processes over messaging, each with its own checkpoint; a
+// [SkipPolicy] lets a [ChunkStep] drop a bounded number of bad items; and
+// [Listener] hooks observe jobs, steps and chunks.
 package batch
 
 import (
@@ -72,6 +76,21 @@ var (
 	// to do work, so it fails fast rather than looping to no effect.
 	ErrNoReader = errors.New("batch: chunk step has no reader")
 	ErrNoWriter = errors.New("batch: chunk step has no writer")
+
+	// ErrSkipLimitExceeded is returned by a [ChunkStep] when a skippable error
+	// would take its skip count past [SkipPolicy.Limit]. The error that could
+	// not be skipped is wrapped alongside it.
+	ErrSkipLimitExceeded = errors.New("batch: skip limit exceeded")
+
+	// ErrUnknownStep is returned by [Job.Run] when a [Transition] names a step
+	// that is not in the job, and by a partition worker asked to run a step it
+	// does not know.
+	ErrUnknownStep = errors.New("batch: unknown step")
+
+	// ErrStepRevisited is returned by [Job.Run] when its transitions lead back to
+	// a step that already ran in the same run. Flows are acyclic, so a restart
+	// can always tell which steps are done.
+	ErrStepRevisited = errors.New("batch: flow revisits a step")
 )
 
 // BatchStatus is the lifecycle state of a [JobExecution] or [StepExecution].
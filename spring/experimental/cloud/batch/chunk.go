@@ -18,6 +18,7 @@ package batch
 
 import (
 	"context"
+	"errors"
 
 	"go-spring.org/spring/experimental/cloud/resilience"
 )
@@ -63,6 +64,10 @@ type ChunkStep[I, O any] struct {
 	// from Retry — plug in a production driver (e.g. sentinel) here. When both
 	// Executor and Retry are unset, a chunk runs once with no retry.
 	Executor resilience.Executor
+
+	// Skip, when its Limit is positive, skips bad items instead of failing the
+	// step. See [SkipPolicy].
+	Skip SkipPolicy
 }
 
 // StepName implements [Step].
@@ -125,75 +130,148 @@ func (s *ChunkStep[I, O]) Run(ctx context.Context, rc *StepContext) error {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
+		rc.notify(func(l *Listener) {
+			if l.BeforeChunk != nil {
+				l.BeforeChunk(ctx, se)
+			}
+		})
+		if err := s.runChunk(ctx, rc, exec, chunkSize); err != nil {
+			if errors.Is(err, errExhausted) {
+				return nil
+			}
+			rc.notify(func(l *Listener) {
+				if l.ChunkError != nil {
+					l.ChunkError(ctx, se, err)
+				}
+			})
+			return err
+		}
+	}
+}
 
-		// Read one chunk of raw items. Reads advance the reader exactly once per
-		// chunk and are deliberately outside the retry guard: the read items are
-		// buffered so a retry replays process+write against the buffer instead of
-		// re-reading (a reader cannot re-yield items it already advanced past).
-		items := make([]I, 0, chunkSize)
-		for len(items) < chunkSize {
-			item, ok, err := s.Reader.Read(ctx)
-			if err != nil {
+// errExhausted tells Run the reader had nothing left for another chunk.
+var errExhausted = errors.New("batch: reader exhausted")
+
+// runChunk reads, processes, writes and commits one chunk.
+func (s *ChunkStep[I, O]) runChunk(ctx context.Context, rc *StepContext, exec resilience.Executor, chunkSize int) error {
+	se := rc.StepExecution
+	skip := &skips{policy: s.Skip, used: se.SkipCount}
+
+	// Read one chunk of raw items. Reads advance the reader exactly once per
+	// chunk and are deliberately outside the retry guard: the read items are
+	// buffered so a retry replays process+write against the buffer instead of
+	// re-reading (a reader cannot re-yield items it already advanced past).
+	items := make([]I, 0, chunkSize)
+	for len(items) < chunkSize {
+		item, ok, err := s.Reader.Read(ctx)
+		if err != nil {
+			if err = skip.add(ctx, nil, err); err != nil {
 				return err
 			}
-			if !ok {
-				break
-			}
-			items = append(items, item)
+			continue
 		}
-		if len(items) == 0 {
-			// Source exhausted: nothing more to commit.
-			return nil
+		if !ok {
+			break
 		}
-		read := int64(len(items))
-
-		// Process and write the buffered chunk, guarded so a transient failure
-		// retries process+write rather than failing the step. Processing is a
-		// pure transform, so replaying it on retry is safe.
-		var written int64
-		writeChunk := func(ctx context.Context) error {
-			out := make([]O, 0, len(items))
-			for _, item := range items {
-				o, keep, err := s.process(ctx, item)
-				if err != nil {
-					return err
-				}
-				if keep {
-					out = append(out, o)
-				}
-			}
-			if len(out) == 0 {
-				written = 0
-				return nil
-			}
-			if err := s.Writer.Write(ctx, out); err != nil {
+		items = append(items, item)
+	}
+	if len(items) == 0 && len(skip.items) == 0 {
+		// Source exhausted: nothing more to commit.
+		return errExhausted
+	}
+	read := int64(len(items))
+
+	// Process and write the buffered chunk, guarded so a transient failure
+	// retries process+write rather than failing the step. Processing is a
+	// pure transform, so replaying it on retry is safe.
+	var written int64
+	writeChunk := func(ctx context.Context) error {
+		out := make([]O, 0, len(items))
+		for _, item := range items {
+			o, keep, err := s.process(ctx, item)
+			if err != nil {
 				return err
 			}
-			written = int64(len(out))
+			if keep {
+				out = append(out, o)
+			}
+		}
+		if len(out) == 0 {
+			written = 0
 			return nil
 		}
+		if err := s.Writer.Write(ctx, out); err != nil {
+			return err
+		}
+		written = int64(len(out))
+		return nil
+	}
 
-		if exec != nil {
-			err = exec.Execute(ctx, s.Name, writeChunk)
-		} else {
-			err = writeChunk(ctx)
+	var err error
+	if exec != nil {
+		err = exec.Execute(ctx, s.Name, writeChunk)
+	} else {
+		err = writeChunk(ctx)
+	}
+	if err != nil && s.Skip.skippable(ctx, err) {
+		written, err = s.scan(ctx, items, skip)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Commit: persist progress and the reader's position. This is the durable
+	// boundary — a restart resumes after the last successful commit.
+	se.ReadCount += read
+	se.WriteCount += written
+	se.SkipCount += int64(len(skip.items))
+	if cp, ok := s.Reader.(Checkpointer); ok {
+		se.Checkpoint = cp.Checkpoint()
+	}
+	se.Status = StatusStarted
+	if err := rc.Repo.SaveStepExecution(ctx, se); err != nil {
+		return err
+	}
+	rc.notify(func(l *Listener) {
+		for _, sk := range skip.items {
+			if l.Skip != nil {
+				l.Skip(ctx, se, sk.item, sk.err)
+			}
 		}
-		if err != nil {
-			return err
+		if l.AfterChunk != nil {
+			l.AfterChunk(ctx, se)
 		}
+	})
+	return nil
+}
 
-		// Commit: persist progress and the reader's position. This is the durable
-		// boundary — a restart resumes after the last successful commit.
-		se.ReadCount += read
-		se.WriteCount += written
-		if cp, ok := s.Reader.(Checkpointer); ok {
-			se.Checkpoint = cp.Checkpoint()
+// scan isolates the bad items of a chunk that failed with a skippable error:
+// it processes the items again and writes the survivors one at a time,
+// skipping each item whose processing or write fails with a skippable error.
+// Items written before the failure may be written again, which the writer's
+// idempotency already has to absorb.
+func (s *ChunkStep[I, O]) scan(ctx context.Context, items []I, skip *skips) (int64, error) {
+	var written int64
+	for _, item := range items {
+		o, keep, err := s.process(ctx, item)
+		if err != nil {
+			if err = skip.add(ctx, item, err); err != nil {
+				return 0, err
+			}
+			continue
 		}
-		se.Status = StatusStarted
-		if err := rc.Repo.SaveStepExecution(ctx, se); err != nil {
-			return err
+		if !keep {
+			continue
+		}
+		if err = s.Writer.Write(ctx, []O{o}); err != nil {
+			if err = skip.add(ctx, o, err); err != nil {
+				return 0, err
+			}
+			continue
 		}
+		written++
 	}
+	return written, nil
 }
 
 // process applies the processor, or passes the item through when no processor is
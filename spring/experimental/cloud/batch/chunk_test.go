@@ -19,6 +19,8 @@ package batch_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
@@ -159,3 +161,63 @@ func TestChunkStep_RetryRecoversChunk(t *testing.T) {
 	assert.That(t, je.Status).Equal(batch.StatusCompleted)
 	assert.That(t, attempts >= 3).True("chunk retried until it succeeded")
 }
+
+var errBadItem = errors.New("bad item")
+
+// TestChunkStep_SkipPolicy verifies bad items are skipped — a processing
+// failure directly, a write failure by scanning the chunk item by item — and
+// that the limit still fails the step.
+func TestChunkStep_SkipPolicy(t *testing.T) {
+	ctx := context.Background()
+	build := func(limit int64, written *[]int) *batch.ChunkStep[int, int] {
+		return &batch.ChunkStep[int, int]{
+			Name:   "load",
+			Reader: newSeqReader(20),
+			Processor: batch.ProcessorFunc[int, int](func(_ context.Context, v int) (int, bool, error) {
+				if v == 7 {
+					return 0, false, errBadItem
+				}
+				return v, true, nil
+			}),
+			Writer: batch.WriterFunc[int](func(_ context.Context, items []int) error {
+				if slices.Contains(items, 13) {
+					return fmt.Errorf("insert: %w", errBadItem)
+				}
+				*written = append(*written, items...)
+				return nil
+			}),
+			ChunkSize: 5,
+			Skip:      batch.SkipPolicy{Limit: limit, Errors: []error{errBadItem}},
+		}
+	}
+
+	var (
+		written []int
+		skipped []any
+	)
+	job := &batch.Job{Name: "skip", Steps: []batch.Step{build(2, &written)}, Listeners: []batch.Listener{{
+		Skip: func(_ context.Context, _ *batch.StepExecution, item any, err error) {
+			assert.Error(t, err).Is(errBadItem)
+			skipped = append(skipped, item)
+		},
+	}}}
+	repo := batch.NewMemoryRepository()
+	je, err := job.Run(ctx, repo, nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, skipped).Equal([]any{7, 13})
+	assert.That(t, len(written)).Equal(18)
+	assert.That(t, slices.Contains(written, 13)).False()
+	rec, _, _ := repo.FindStepExecution(ctx, je.ID, "load")
+	assert.Number(t, rec.SkipCount).Equal(int64(2))
+	assert.Number(t, rec.WriteCount).Equal(int64(18))
+
+	written = nil
+	repo = batch.NewMemoryRepository()
+	job = &batch.Job{Name: "skip", Steps: []batch.Step{build(1, &written)}}
+	je, err = job.Run(ctx, repo, nil)
+	assert.Error(t, err).Is(batch.ErrSkipLimitExceeded)
+	assert.Error(t, err).Is(errBadItem)
+	rec, _, _ = repo.FindStepExecution(ctx, je.ID, "load")
+	assert.Number(t, rec.SkipCount).Equal(int64(1)) // the skip of 7 committed
+	assert.Number(t, rec.WriteCount).Equal(int64(9))
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"go-spring.org/spring/experimental/cloud/batch"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// exitStep finishes with a custom exit status, or fails with err.
+type exitStep struct {
+	name string
+	exit string
+	err  error
+	ran  *[]string
+}
+
+func (s *exitStep) StepName() string { return s.name }
+
+func (s *exitStep) Run(_ context.Context, rc *batch.StepContext) error {
+	*s.ran = append(*s.ran, s.name)
+	rc.StepExecution.ExitStatus = s.exit
+	return s.err
+}
+
+func TestJob_Transitions(t *testing.T) {
+	ctx := context.Background()
+	var ran []string
+	step := func(name, exit string, err error) batch.Step {
+		return &exitStep{name: name, exit: exit, err: err, ran: &ran}
+	}
+
+	// "check" finds nothing to load, so the job jumps straight to "report".
+	job := &batch.Job{
+		Name: "etl",
+		Steps: []batch.Step{
+			step("check", "no-data", nil),
+			step("load", "", nil),
+			step("report", "", nil),
+		},
+		Transitions: map[string][]batch.Transition{
+			"check": {{On: "no-data", To: "report"}, {On: "*", To: "load"}},
+		},
+	}
+	je, err := job.Run(ctx, batch.NewMemoryRepository(), nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, je.Status).Equal(batch.StatusCompleted)
+	assert.That(t, ran).Equal([]string{"check", "report"})
+
+	// A failed step with a matching transition does not fail the job.
+	ran = nil
+	repo := batch.NewMemoryRepository()
+	job = &batch.Job{
+		Name: "etl",
+		Steps: []batch.Step{
+			step("load", "", errors.New("boom")),
+			step("report", "", nil),
+			step("cleanup", "", nil),
+		},
+		Transitions: map[string][]batch.Transition{
+			"load":    {{On: "failed", To: "cleanup"}},
+			"cleanup": {{On: "*"}}, // end
+		},
+	}
+	je, err = job.Run(ctx, repo, nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, je.Status).Equal(batch.StatusCompleted)
+	assert.That(t, ran).Equal([]string{"load", "cleanup"})
+	se, _, _ := repo.FindStepExecution(ctx, je.ID, "load")
+	assert.That(t, se.ExitStatus).Equal("failed")
+
+	// Transitions are validated and may not loop.
+	job.Transitions = map[string][]batch.Transition{"load": {{On: "*", To: "missing"}}}
+	_, err = job.Run(ctx, repo, nil)
+	assert.Error(t, err).Is(batch.ErrUnknownStep)
+	job.Transitions = map[string][]batch.Transition{"report": {{On: "*", To: "report"}}}
+	job.Steps[0] = step("load", "", nil)
+	_, err = job.Run(ctx, batch.NewMemoryRepository(), nil)
+	assert.Error(t, err).Is(batch.ErrStepRevisited)
+}
+
+func TestJob_SplitAndListeners(t *testing.T) {
+	ctx := context.Background()
+	repo := batch.NewMemoryRepository()
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+	failC := true
+	fn := func(name string) batch.Step {
+		return batch.Func(name, func(context.Context) error {
+			if name == "c" && failC {
+				return errors.New("boom")
+			}
+			return nil
+		})
+	}
+	build := func() *batch.Job {
+		return &batch.Job{
+			Name: "fanout",
+			Steps: []batch.Step{
+				batch.Split("extract", []batch.Step{fn("a"), fn("b")}, []batch.Step{fn("c")}),
+				fn("merge"),
+			},
+			Listeners: []batch.Listener{{
+				BeforeJob:  func(_ context.Context, je *batch.JobExecution) { record("job+") },
+				AfterJob:   func(_ context.Context, je *batch.JobExecution) { record("job-:" + je.Status.String()) },
+				BeforeStep: func(_ context.Context, se *batch.StepExecution) { record("+" + se.StepName) },
+				AfterStep:  func(_ context.Context, se *batch.StepExecution) { record("-" + se.StepName) },
+			}},
+		}
+	}
+
+	_, err := build().Run(ctx, repo, nil)
+	assert.Error(t, err).NotNil()
+	assert.That(t, events[0]).Equal("job+")
+	assert.That(t, events[len(events)-1]).Equal("job-:failed")
+	assert.That(t, slices.Contains(events, "-b")).True() // the other flow ran to its end
+	assert.That(t, slices.Contains(events, "+merge")).False()
+
+	// On restart only the failed flow step and what follows run.
+	failC = false
+	events = nil
+	je, err := build().Run(ctx, repo, nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, je.Status).Equal(batch.StatusCompleted)
+	assert.That(t, slices.Contains(events, "+a")).False()
+	assert.That(t, slices.Contains(events, "+c")).True()
+	assert.That(t, slices.Contains(events, "+merge")).True()
+}
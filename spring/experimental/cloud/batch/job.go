@@ -18,7 +18,11 @@ package batch
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -33,6 +37,10 @@ type StepContext struct {
 	// StepExecution is this step's record, pre-loaded with any progress from a
 	// previous run (ReadCount/WriteCount/Checkpoint) on restart.
 	StepExecution *StepExecution
+
+	// run lets composite steps ([Split], [PartitionStep]) run child steps the
+	// way the job runs its own, and carries the listeners.
+	run *jobRun
 }
 
 // Step is one unit of a [Job]. A [ChunkStep] implements it for chunk processing;
@@ -47,14 +55,41 @@ type Step interface {
 	Run(ctx context.Context, rc *StepContext) error
 }
 
-// Job is an ordered sequence of [Step]s run as one unit. Steps run in order;
-// the first failing step fails the job, and a restart of the same instance skips
-// steps that already completed and resumes the one that did not.
+// Transition routes a [Job] from a finished step to the next one, by matching
+// the step's [StepExecution.ExitStatus].
+type Transition struct {
+	// On is a [path.Match] pattern for the exit status, e.g. "failed",
+	// "no-data" or "*".
+	On string
+	// To names the step to run next. Empty ends the job as completed.
+	To string
+}
+
+// Job is a sequence of [Step]s run as one unit. By default steps run in order
+// and the first failing step fails the job; Transitions override that per
+// step. A restart of the same instance skips steps that already completed and
+// resumes the one that did not.
 type Job struct {
 	// Name identifies the job; together with [Params] it identifies an instance.
 	Name string
-	// Steps run in order.
+	// Steps run in order, unless Transitions say otherwise.
 	Steps []Step
+	// Transitions, keyed by step name, choose what follows a step: the first
+	// entry whose On matches the step's exit status wins. A step without a
+	// matching entry is followed by the next one in Steps when it completed,
+	// and fails the job when it did not — a matching entry is how a job
+	// carries on past a failed step. A transition may only lead forward to a
+	// step that has not run yet in this run.
+	Transitions map[string][]Transition
+	// Listeners observe the job, its steps and their chunks.
+	Listeners []Listener
+}
+
+// jobRun is what one run of a job shares with the steps it runs.
+type jobRun struct {
+	repo           JobRepository
+	jobExecutionID string
+	listeners      []Listener
 }
 
 // Run executes the job against repo for the (Name, params) instance. If a prior
@@ -65,6 +100,17 @@ func (j *Job) Run(ctx context.Context, repo JobRepository, params Params) (*JobE
 	if repo == nil {
 		return nil, ErrNoRepository
 	}
+	index := make(map[string]int, len(j.Steps))
+	for i, step := range j.Steps {
+		index[step.StepName()] = i
+	}
+	for from, ts := range j.Transitions {
+		for _, t := range ts {
+			if _, ok := index[t.To]; t.To != "" && !ok {
+				return nil, fmt.Errorf("%w: transition from %q to %q", ErrUnknownStep, from, t.To)
+			}
+		}
+	}
 
 	je, _, err := repo.ObtainExecution(ctx, j.Name, params)
 	if err != nil {
@@ -81,59 +127,45 @@ func (j *Job) Run(ctx context.Context, repo JobRepository, params Params) (*JobE
 		return je, err
 	}
 
-	for _, step := range j.Steps {
-		se, ok, err := repo.FindStepExecution(ctx, je.ID, step.StepName())
-		if err != nil {
-			return je, err
-		}
-		if ok && se.Status == StatusCompleted {
-			continue // already done on a previous run; skip it on restart
+	run := &jobRun{repo: repo, jobExecutionID: je.ID, listeners: j.Listeners}
+	run.notify(func(l *Listener) {
+		if l.BeforeJob != nil {
+			l.BeforeJob(ctx, je)
 		}
-		if !ok {
-			se = &StepExecution{
-				JobExecutionID: je.ID,
-				StepName:       step.StepName(),
-				Status:         StatusStarted,
-				StartTime:      time.Now(),
-			}
-		} else {
-			se.Status = StatusStarted
-			se.FailureMsg = ""
-			se.EndTime = time.Time{}
-			if se.StartTime.IsZero() {
-				se.StartTime = time.Now()
-			}
+	})
+	defer run.notify(func(l *Listener) {
+		if l.AfterJob != nil {
+			l.AfterJob(ctx, je)
 		}
-		if err := repo.SaveStepExecution(ctx, se); err != nil {
+	})
+
+	visited := make(map[string]bool, len(j.Steps))
+	for i := 0; i < len(j.Steps); {
+		step := j.Steps[i]
+		if visited[step.StepName()] {
+			err := fmt.Errorf("%w: %q", ErrStepRevisited, step.StepName())
+			j.fail(ctx, repo, je, StatusFailed, step.StepName(), err)
 			return je, err
 		}
+		visited[step.StepName()] = true
 
-		rc := &StepContext{JobExecutionID: je.ID, Repo: repo, StepExecution: se}
-		runErr := step.Run(ctx, rc)
-
-		se.EndTime = time.Now()
-		if runErr != nil {
-			// Distinguish a clean stop (context cancelled) from a failure so a
-			// deliberate shutdown is restartable without looking like an error.
-			if ctx.Err() != nil {
-				se.Status = StatusStopped
-			} else {
-				se.Status = StatusFailed
-				se.FailureMsg = runErr.Error()
-			}
-			_ = repo.SaveStepExecution(ctx, se)
-
-			je.EndTime = time.Now()
-			je.Status = se.Status
-			je.FailureMsg = fmt.Sprintf("step %q: %v", step.StepName(), runErr)
-			_ = repo.SaveJobExecution(ctx, je)
+		se, runErr := run.runStep(ctx, step.StepName(), step)
+		if se == nil {
+			return je, runErr // the repository failed
+		}
+		next, routed := j.route(step.StepName(), se.ExitStatus)
+		if runErr != nil && (!routed || ctx.Err() != nil) {
+			j.fail(ctx, repo, je, se.Status, step.StepName(), runErr)
 			return je, runErr
 		}
-
-		se.Status = StatusCompleted
-		if err := repo.SaveStepExecution(ctx, se); err != nil {
-			return je, err
+		if !routed {
+			i++
+			continue
+		}
+		if next == "" {
+			break
 		}
+		i = index[next]
 	}
 
 	je.Status = StatusCompleted
@@ -144,6 +176,143 @@ func (j *Job) Run(ctx context.Context, repo JobRepository, params Params) (*JobE
 	return je, nil
 }
 
+// fail records the job as ended by the named step's error.
+func (j *Job) fail(ctx context.Context, repo JobRepository, je *JobExecution, status BatchStatus, step string, err error) {
+	je.EndTime = time.Now()
+	je.Status = status
+	je.FailureMsg = fmt.Sprintf("step %q: %v", step, err)
+	_ = repo.SaveJobExecution(ctx, je)
+}
+
+// route returns the target of the first transition of step matching exit.
+func (j *Job) route(step, exit string) (to string, ok bool) {
+	for _, t := range j.Transitions[step] {
+		if m, _ := path.Match(t.On, exit); m {
+			return t.To, true
+		}
+	}
+	return "", false
+}
+
+// runStep runs step under name, recording it as a [StepExecution] of the run.
+// A step that already completed is not run again; its record is returned as
+// is. The returned error is the step's; when the record is nil the error came
+// from the repository and nothing could be recorded.
+func (r *jobRun) runStep(ctx context.Context, name string, step Step) (*StepExecution, error) {
+	se, ok, err := r.repo.FindStepExecution(ctx, r.jobExecutionID, name)
+	if err != nil {
+		return nil, err
+	}
+	if ok && se.Status == StatusCompleted {
+		// Already done on a previous run; skip it on restart.
+		if se.ExitStatus == "" {
+			se.ExitStatus = se.Status.String()
+		}
+		return se, nil
+	}
+	if !ok {
+		se = &StepExecution{
+			JobExecutionID: r.jobExecutionID,
+			StepName:       name,
+			Status:         StatusStarted,
+			StartTime:      time.Now(),
+		}
+	} else {
+		se.Status = StatusStarted
+		se.FailureMsg = ""
+		se.ExitStatus = ""
+		se.EndTime = time.Time{}
+		if se.StartTime.IsZero() {
+			se.StartTime = time.Now()
+		}
+	}
+	if err := r.repo.SaveStepExecution(ctx, se); err != nil {
+		return nil, err
+	}
+
+	r.notify(func(l *Listener) {
+		if l.BeforeStep != nil {
+			l.BeforeStep(ctx, se)
+		}
+	})
+	rc := &StepContext{JobExecutionID: r.jobExecutionID, Repo: r.repo, StepExecution: se, run: r}
+	runErr := step.Run(ctx, rc)
+
+	se.EndTime = time.Now()
+	switch {
+	case runErr == nil:
+		se.Status = StatusCompleted
+	case ctx.Err() != nil:
+		// Distinguish a clean stop (context cancelled) from a failure so a
+		// deliberate shutdown is restartable without looking like an error.
+		se.Status = StatusStopped
+	default:
+		se.Status = StatusFailed
+		se.FailureMsg = runErr.Error()
+	}
+	if se.ExitStatus == "" || runErr != nil {
+		se.ExitStatus = se.Status.String()
+	}
+	r.notify(func(l *Listener) {
+		if l.AfterStep != nil {
+			l.AfterStep(ctx, se)
+		}
+	})
+
+	if err := r.repo.SaveStepExecution(ctx, se); err != nil && runErr == nil {
+		return nil, err
+	}
+	return se, runErr
+}
+
+// splitStep runs several flows concurrently. See [Split].
+type splitStep struct {
+	name  string
+	flows [][]Step
+}
+
+func (s *splitStep) StepName() string { return s.name }
+
+func (s *splitStep) Run(ctx context.Context, rc *StepContext) error {
+	if rc.run == nil {
+		return fmt.Errorf("batch: split step %q must run inside a job", s.name)
+	}
+	errs := make([]error, len(s.flows))
+	var wg sync.WaitGroup
+	for i, flow := range s.flows {
+		wg.Go(func() {
+			for _, step := range flow {
+				if _, err := rc.run.runStep(ctx, step.StepName(), step); err != nil {
+					errs[i] = fmt.Errorf("step %q: %w", step.StepName(), err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Split returns a [Step] that runs each flow — an ordered list of steps — on
+// its own goroutine and completes when all of them have. A failing flow stops
+// at its failed step while the others run to their end; the split then fails
+// with every flow's error, and a restart re-runs only the steps that did not
+// complete. The steps of every flow are recorded like the job's own, so their
+// names must be unique within the job. Transitions do not apply inside a flow.
+//
+// It panics on an empty name or a nil step.
+func Split(name string, flows ...[]Step) Step {
+	if name == "" {
+		panic("batch: split step name must not be empty")
+	}
+	for _, flow := range flows {
+		if slices.Contains(flow, nil) {
+			panic("batch: split step " + name + " has a nil step")
+		}
+	}
+	return &splitStep{name: name, flows: flows}
+}
+
 // funcStep is a [Step] that runs a plain function once, with no chunking. It is
 // the Cloud Task building block: the step completes when fn returns nil and
 // fails when it returns an error, and it does not resume mid-way (a restart
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import "context"
+
+// Listener observes a job as it runs. Every hook is optional. Hooks run on the
+// goroutine doing the work, so a slow hook slows the job; steps of a [Split]
+// and partitions of a [PartitionStep] run concurrently, so hooks shared by
+// them must be safe for concurrent use.
+//
+// Hooks receive the live execution records. AfterStep may set se.ExitStatus
+// to steer a [Transition]; changes to other fields are not supported.
+type Listener struct {
+	// BeforeJob runs once the execution is marked started; AfterJob runs when it
+	// ends, whatever the outcome.
+	BeforeJob func(ctx context.Context, je *JobExecution)
+	AfterJob  func(ctx context.Context, je *JobExecution)
+
+	// BeforeStep runs before a step (or partition) starts; AfterStep runs after
+	// it ends and before its final state is saved. A step skipped on restart
+	// because it already completed fires neither.
+	BeforeStep func(ctx context.Context, se *StepExecution)
+	AfterStep  func(ctx context.Context, se *StepExecution)
+
+	// BeforeChunk runs before a [ChunkStep] reads a chunk — including the last
+	// read, which finds the reader exhausted — AfterChunk after the chunk
+	// committed, and ChunkError when a chunk fails the step.
+	BeforeChunk func(ctx context.Context, se *StepExecution)
+	AfterChunk  func(ctx context.Context, se *StepExecution)
+	ChunkError  func(ctx context.Context, se *StepExecution, err error)
+
+	// Skip runs once per item a [SkipPolicy] skipped, after the chunk holding it
+	// committed. item is nil for a skipped read.
+	Skip func(ctx context.Context, se *StepExecution, item any, err error)
+}
+
+// notify calls fn for every listener of the run.
+func (r *jobRun) notify(fn func(l *Listener)) {
+	for i := range r.listeners {
+		fn(&r.listeners[i])
+	}
+}
+
+// notify calls fn for every listener of the run. A StepContext built outside
+// a [Job] has none.
+func (rc *StepContext) notify(fn func(l *Listener)) {
+	if rc.run != nil {
+		rc.run.notify(fn)
+	}
+}
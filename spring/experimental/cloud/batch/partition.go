@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Partition is one slice of a [PartitionStep]'s work.
+type Partition struct {
+	// Name identifies the partition within its step, e.g. "p0".
+	Name string `json:"name"`
+	// Params tell the worker which slice to process: a key range, a file, ...
+	Params Params `json:"params,omitempty"`
+}
+
+// Partitioner splits a step's work into partitions. gridSize is the number
+// of partitions the step asks for; a partitioner may return fewer or more.
+type Partitioner interface {
+	Partition(ctx context.Context, gridSize int) ([]Partition, error)
+}
+
+// PartitionerFunc adapts a function to a [Partitioner].
+type PartitionerFunc func(ctx context.Context, gridSize int) ([]Partition, error)
+
+// Partition implements [Partitioner].
+func (f PartitionerFunc) Partition(ctx context.Context, gridSize int) ([]Partition, error) {
+	return f(ctx, gridSize)
+}
+
+// PartitionRequest asks a [PartitionHandler] to run one partition.
+type PartitionRequest struct {
+	JobExecutionID string    `json:"jobExecutionId"`
+	Step           string    `json:"step"` // the PartitionStep's name
+	Partition      Partition `json:"partition"`
+}
+
+// PartitionHandler runs partitions somewhere other than the current process.
+// It returns once the partition has ended; the outcome is read back from the
+// [JobRepository], so the side that runs the partition must record it in the
+// repository the job uses. [RemotePartitionHandler] sends partitions to
+// workers over messaging.
+type PartitionHandler interface {
+	Handle(ctx context.Context, req PartitionRequest) error
+}
+
+// DefaultGridSize is used when [PartitionStep.GridSize] is not set.
+const DefaultGridSize = 4
+
+// PartitionStep is a [Step] that splits its work with a Partitioner and runs
+// one worker step per partition, concurrently. Each partition is recorded as
+// its own [StepExecution] named "<step>:<partition>", with its own counts and
+// checkpoint, so a restart re-runs only the partitions that did not complete
+// and resumes each from where it stopped. The partition list of the first run
+// is persisted in the step's checkpoint and reused on restart, so the split
+// stays stable even if the data has changed since.
+//
+// The step's own counts are the sums of its partitions'. It fails when any
+// partition fails, after the others have ended.
+type PartitionStep struct {
+	// Name identifies the step within its job. It must be non-empty and unique.
+	Name string
+
+	// Partitioner splits the work. Required.
+	Partitioner Partitioner
+
+	// GridSize is passed to the Partitioner. Defaults to [DefaultGridSize].
+	GridSize int
+
+	// Worker builds the step that processes one partition, typically a
+	// [ChunkStep] whose reader is bounded by p.Params. Required when Handler is
+	// nil; with a Handler the workers build their own.
+	Worker func(p Partition) Step
+
+	// Concurrency bounds the partitions running at once. Defaults to all.
+	Concurrency int
+
+	// Handler, when set, runs the partitions instead of this process.
+	Handler PartitionHandler
+}
+
+// StepName implements [Step].
+func (s *PartitionStep) StepName() string { return s.Name }
+
+// Run implements [Step].
+func (s *PartitionStep) Run(ctx context.Context, rc *StepContext) error {
+	if s.Partitioner == nil {
+		return fmt.Errorf("batch: partition step %q has no partitioner", s.Name)
+	}
+	if s.Handler == nil && (s.Worker == nil || rc.run == nil) {
+		return fmt.Errorf("batch: partition step %q needs a worker and must run inside a job", s.Name)
+	}
+	parts, err := s.plan(ctx, rc)
+	if err != nil {
+		return err
+	}
+
+	limit := s.Concurrency
+	if limit <= 0 || limit > len(parts) {
+		limit = len(parts)
+	}
+	sem := make(chan struct{}, max(limit, 1))
+	errs := make([]error, len(parts))
+	var wg sync.WaitGroup
+	for i, p := range parts {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			if err := s.runPartition(ctx, rc, p); err != nil {
+				errs[i] = fmt.Errorf("partition %q: %w", p.Name, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Roll the partitions' counts up into the step.
+	se := rc.StepExecution
+	se.ReadCount, se.WriteCount, se.SkipCount = 0, 0, 0
+	for _, p := range parts {
+		pe, ok, err := rc.Repo.FindStepExecution(ctx, rc.JobExecutionID, PartitionStepName(s.Name, p.Name))
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if ok {
+			se.ReadCount += pe.ReadCount
+			se.WriteCount += pe.WriteCount
+			se.SkipCount += pe.SkipCount
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return rc.Repo.SaveStepExecution(ctx, se)
+}
+
+// plan returns the partitions of this execution: those persisted by an
+// earlier run, or a fresh split that is persisted before any partition runs.
+func (s *PartitionStep) plan(ctx context.Context, rc *StepContext) ([]Partition, error) {
+	se := rc.StepExecution
+	var parts []Partition
+	if len(se.Checkpoint) > 0 {
+		if err := json.Unmarshal(se.Checkpoint, &parts); err != nil {
+			return nil, fmt.Errorf("batch: partition step %q: decode partitions: %w", s.Name, err)
+		}
+		return parts, nil
+	}
+	grid := s.GridSize
+	if grid <= 0 {
+		grid = DefaultGridSize
+	}
+	parts, err := s.Partitioner.Partition(ctx, grid)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(parts))
+	for _, p := range parts {
+		if p.Name == "" || seen[p.Name] {
+			return nil, fmt.Errorf("batch: partition step %q: partition names must be unique and non-empty", s.Name)
+		}
+		seen[p.Name] = true
+	}
+	if se.Checkpoint, err = json.Marshal(parts); err != nil {
+		return nil, err
+	}
+	if err = rc.Repo.SaveStepExecution(ctx, se); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// runPartition runs p locally or through the handler. A partition that
+// completed on an earlier run is not run again.
+func (s *PartitionStep) runPartition(ctx context.Context, rc *StepContext, p Partition) error {
+	name := PartitionStepName(s.Name, p.Name)
+	if s.Handler == nil {
+		_, err := rc.run.runStep(ctx, name, s.Worker(p))
+		return err
+	}
+
+	pe, ok, err := rc.Repo.FindStepExecution(ctx, rc.JobExecutionID, name)
+	if err != nil {
+		return err
+	}
+	if ok && pe.Status == StatusCompleted {
+		return nil
+	}
+	err = s.Handler.Handle(ctx, PartitionRequest{JobExecutionID: rc.JobExecutionID, Step: s.Name, Partition: p})
+	if err != nil {
+		return err
+	}
+	// The repository, not the reply, is the record of what the worker did.
+	pe, ok, err = rc.Repo.FindStepExecution(ctx, rc.JobExecutionID, name)
+	if err != nil {
+		return err
+	}
+	if !ok || pe.Status != StatusCompleted {
+		return fmt.Errorf("batch: worker did not record partition %q as completed", name)
+	}
+	return nil
+}
+
+// PartitionStepName returns the name a partition's [StepExecution] is recorded
+// under.
+func PartitionStepName(step, partition string) string { return step + ":" + partition }
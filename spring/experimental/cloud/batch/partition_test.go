@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/batch"
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// rangePartitioner splits 1..n into gridSize contiguous ranges.
+func rangePartitioner(n int) batch.Partitioner {
+	return batch.PartitionerFunc(func(_ context.Context, grid int) ([]batch.Partition, error) {
+		var parts []batch.Partition
+		size := (n + grid - 1) / grid
+		for i := range grid {
+			lo, hi := i*size, min((i+1)*size, n)
+			parts = append(parts, batch.Partition{
+				Name:   "p" + strconv.Itoa(i),
+				Params: batch.Params{"lo": strconv.Itoa(lo), "hi": strconv.Itoa(hi)},
+			})
+		}
+		return parts, nil
+	})
+}
+
+// rangeReader is a seqReader that starts after lo.
+type rangeReader struct {
+	*seqReader
+	lo int
+}
+
+func (r *rangeReader) Open(ctx context.Context, cp batch.Checkpoint) error {
+	if len(cp) == 0 {
+		r.pos = r.lo
+		return nil
+	}
+	return r.seqReader.Open(ctx, cp)
+}
+
+// sink records written items and fails once item failAt is reached.
+type sink struct {
+	mu     sync.Mutex
+	got    map[int]int
+	failAt int
+}
+
+// worker builds the chunk step of one partition: it reads lo+1..hi.
+func (s *sink) worker(p batch.Partition) batch.Step {
+	lo, _ := strconv.Atoi(p.Params["lo"])
+	hi, _ := strconv.Atoi(p.Params["hi"])
+	return &batch.ChunkStep[int, int]{
+		Name:   "copy",
+		Reader: &rangeReader{seqReader: newSeqReader(hi), lo: lo},
+		Writer: batch.WriterFunc[int](func(_ context.Context, items []int) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, v := range items {
+				if v == s.failAt {
+					return errors.New("boom")
+				}
+			}
+			for _, v := range items {
+				s.got[v]++
+			}
+			return nil
+		}),
+		ChunkSize: 3,
+	}
+}
+
+func TestPartitionStep_LocalRestart(t *testing.T) {
+	ctx := context.Background()
+	repo := batch.NewMemoryRepository()
+	s := &sink{got: map[int]int{}, failAt: 17}
+	build := func() *batch.Job {
+		return &batch.Job{Name: "partitioned", Steps: []batch.Step{&batch.PartitionStep{
+			Name:        "copy",
+			Partitioner: rangePartitioner(40),
+			Worker:      s.worker,
+			Concurrency: 2,
+		}}}
+	}
+
+	je, err := build().Run(ctx, repo, nil)
+	assert.Error(t, err).NotNil()
+	failed, _, _ := repo.FindStepExecution(ctx, je.ID, batch.PartitionStepName("copy", "p1"))
+	assert.That(t, failed.Status).Equal(batch.StatusFailed)
+	done, _, _ := repo.FindStepExecution(ctx, je.ID, batch.PartitionStepName("copy", "p0"))
+	assert.That(t, done.Status).Equal(batch.StatusCompleted)
+
+	s.failAt = 0
+	je, err = build().Run(ctx, repo, nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, je.Status).Equal(batch.StatusCompleted)
+	assert.That(t, len(s.got)).Equal(40)
+	for i := 1; i <= 40; i++ {
+		assert.Number(t, s.got[i]).Equal(1, "item written exactly once")
+	}
+	step, _, _ := repo.FindStepExecution(ctx, je.ID, "copy")
+	assert.Number(t, step.WriteCount).Equal(int64(40))
+}
+
+func TestPartitionStep_Remote(t *testing.T) {
+	ctx := context.Background()
+	binder := messaging.NewMemoryBinder(messaging.MemoryBinderConfig{})
+	defer binder.Close()
+	repo := batch.NewMemoryRepository()
+	s := &sink{got: map[int]int{}}
+
+	// Two worker processes compete for partitions on the same destination.
+	for range 2 {
+		sub, err := binder.NewSubscriber(ctx, "batch.partitions", "workers")
+		assert.Error(t, err).Nil()
+		assert.Error(t, sub.Subscribe(ctx, batch.NewPartitionWorker(binder, repo,
+			map[string]func(batch.Partition) batch.Step{"copy": s.worker}))).Nil()
+	}
+
+	requester := messaging.NewRequester(binder, messaging.RequesterConfig{Timeout: 5 * time.Second})
+	defer requester.Close()
+	job := &batch.Job{Name: "remote", Steps: []batch.Step{&batch.PartitionStep{
+		Name:        "copy",
+		Partitioner: rangePartitioner(20),
+		Handler:     &batch.RemotePartitionHandler{Requester: requester, Destination: "batch.partitions"},
+	}}}
+	je, err := job.Run(ctx, repo, nil)
+	assert.Error(t, err).Nil()
+	assert.That(t, je.Status).Equal(batch.StatusCompleted)
+	assert.That(t, len(s.got)).Equal(20)
+	step, _, _ := repo.FindStepExecution(ctx, je.ID, "copy")
+	assert.Number(t, step.ReadCount).Equal(int64(20))
+
+	// A step the workers do not know fails the partition.
+	job = &batch.Job{Name: "remote-unknown", Steps: []batch.Step{&batch.PartitionStep{
+		Name:        "other",
+		Partitioner: rangePartitioner(4),
+		GridSize:    1,
+		Handler:     &batch.RemotePartitionHandler{Requester: requester, Destination: "batch.partitions"},
+	}}}
+	_, err = job.Run(ctx, repo, nil)
+	assert.Error(t, err).Matches("unknown step")
+}
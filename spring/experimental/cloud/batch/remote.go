@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+)
+
+// RemotePartitionHandler is a [PartitionHandler] that sends each partition as
+// a request to Destination and waits for the worker's reply. Workers
+// subscribe a [NewPartitionWorker] handler to Destination under one group,
+// so each partition goes to one of them, and must share the job's
+// [JobRepository].
+//
+// The Requester's timeout bounds a whole partition run, so it must exceed
+// the slowest partition. A partition whose request timed out may still be
+// running on its worker; restart the job only once it has ended.
+type RemotePartitionHandler struct {
+	Requester   *messaging.Requester
+	Destination string
+	Codec       messaging.Codec // optional; default messaging.JSONCodec
+}
+
+// Handle implements [PartitionHandler].
+func (h *RemotePartitionHandler) Handle(ctx context.Context, req PartitionRequest) error {
+	_, err := messaging.Request[PartitionRequest, struct{}](ctx, h.Requester, h.Destination, req, h.Codec)
+	return err
+}
+
+// NewPartitionWorker returns the handler a worker process subscribes to the
+// destination of a [RemotePartitionHandler]. workers maps a [PartitionStep]
+// name to the function building that step's worker, as PartitionStep.Worker
+// does in the manager. Each partition runs against repo, which must be the
+// manager's repository, and is recorded the same way a local partition is;
+// listeners observe it on the worker side.
+func NewPartitionWorker(b messaging.Binder, repo JobRepository, workers map[string]func(p Partition) Step, listeners ...Listener) messaging.Handler {
+	return messaging.Reply(b, func(ctx context.Context, req PartitionRequest) (struct{}, error) {
+		worker, ok := workers[req.Step]
+		if !ok {
+			return struct{}{}, fmt.Errorf("%w: %q", ErrUnknownStep, req.Step)
+		}
+		run := &jobRun{repo: repo, jobExecutionID: req.JobExecutionID, listeners: listeners}
+		_, err := run.runStep(ctx, PartitionStepName(req.Step, req.Partition.Name), worker(req.Partition))
+		return struct{}{}, err
+	})
+}
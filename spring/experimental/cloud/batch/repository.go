@@ -59,7 +59,7 @@ type StepExecution struct {
 	StepName string
 	// Status is the current lifecycle state.
 	Status BatchStatus
-	// ReadCount is how many items have been read and ReadCount how many written,
+	// ReadCount is how many items have been read and WriteCount how many written,
 	// across all committed chunks so far.
 	ReadCount  int64
 	WriteCount int64
@@ -72,6 +72,13 @@ type StepExecution struct {
 	EndTime   time.Time
 	// FailureMsg carries the error message when Status is StatusFailed.
 	FailureMsg string
+	// ExitStatus is the outcome a [Transition] matches on. The engine sets it to
+	// the Status name when the step ends, unless the step (or an AfterStep
+	// [Listener]) set its own, e.g. "no-data".
+	ExitStatus string
+	// SkipCount is how many items a [SkipPolicy] has skipped across all
+	// committed chunks so far.
+	SkipCount int64
 }
 
 // JobRepository stores job and step execution state. It is the single seam
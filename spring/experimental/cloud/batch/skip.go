@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SkipPolicy lets a [ChunkStep] drop bad items instead of failing. A skippable
+// error from the reader skips that read; from the processor or the writer it
+// skips the item it was raised for, found by re-processing the chunk and
+// writing it one item at a time once the chunk's retries are exhausted. Each
+// skip counts against Limit and is recorded in [StepExecution.SkipCount]
+// with the chunk that held it.
+type SkipPolicy struct {
+	// Limit is the most items the step may skip over its whole execution,
+	// restarts included. Zero disables skipping.
+	Limit int64
+
+	// Errors lists the skippable errors, matched with [errors.Is].
+	Errors []error
+
+	// Match, when set, marks further errors skippable, e.g. by type with
+	// [errors.As]. With neither Errors nor Match every error is skippable.
+	Match func(err error) bool
+}
+
+// skippable reports whether err may be skipped. Cancellation never is.
+func (p SkipPolicy) skippable(ctx context.Context, err error) bool {
+	if p.Limit <= 0 || err == nil || ctx.Err() != nil {
+		return false
+	}
+	if len(p.Errors) == 0 && p.Match == nil {
+		return true
+	}
+	for _, target := range p.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return p.Match != nil && p.Match(err)
+}
+
+// skipped is an item skipped in the chunk being built.
+type skipped struct {
+	item any
+	err  error
+}
+
+// skips collects the skips of one chunk against the policy's remaining budget.
+type skips struct {
+	policy SkipPolicy
+	used   int64 // committed by earlier chunks
+	items  []skipped
+}
+
+// add records a skip of item for err, or returns the error that fails the
+// step: err itself when it is not skippable, wrapped in
+// [ErrSkipLimitExceeded] when the budget is spent.
+func (s *skips) add(ctx context.Context, item any, err error) error {
+	if !s.policy.skippable(ctx, err) {
+		return err
+	}
+	if s.used+int64(len(s.items)) >= s.policy.Limit {
+		return fmt.Errorf("%w (%d): %w", ErrSkipLimitExceeded, s.policy.Limit, err)
+	}
+	s.items = append(s.items, skipped{item: item, err: err})
+	return nil
+}
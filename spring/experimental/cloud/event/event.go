@@ -30,9 +30,10 @@
 // restraint of [go-spring.org/spring/experimental/aspect] (the type-safe interceptor chain)
 // and [go-spring.org/spring/lock] (the interface-as-seam abstraction).
 //
-// This is strictly an in-process bus. It is not a cross-instance broadcast: for
-// fan-out across replicas over a message queue, that is a separate concern
-// (config bus) and does not overlap with this package.
+// [New] returns an in-process bus. Cross-replica fan-out is opt-in: a
+// [RemoteBus] wraps a local bus and forwards the event types listed in a
+// [TypeRegistry] through a messaging.Binder, re-publishing events from other
+// replicas locally, so the same Subscribe API sees both.
 //
 // # Publishing and subscribing
 //
@@ -94,6 +95,8 @@ import (
 	"reflect"
 	"slices"
 	"sync"
+
+	"go-spring.org/spring/experimental/aspect"
 )
 
 // ErrClosed is returned by [Bus.Publish] once [Bus.Close] has been called. A
@@ -135,9 +138,46 @@ type Listener interface {
 type subOptions struct {
 	order   int
 	buffer  int
+	phase   Phase
 	onError func(ctx context.Context, err error)
 }
 
+// Phase binds a subscription to the outcome of the transaction an event is
+// published in, the equivalent of Spring's @TransactionalEventListener. The
+// transaction is the one an [aspect.Transactional] interceptor carries on the
+// publishing context.
+type Phase int
+
+const (
+	// Immediate delivers during Publish. It is the default.
+	Immediate Phase = iota
+
+	// BeforeCommit delivers just before the transaction commits, still
+	// inside it; a synchronous handler's error rolls the transaction back.
+	BeforeCommit
+
+	// AfterCommit delivers once the transaction has committed.
+	AfterCommit
+
+	// AfterRollback delivers once the transaction has rolled back.
+	AfterRollback
+
+	// AfterCompletion delivers once the transaction has committed or rolled
+	// back.
+	AfterCompletion
+)
+
+// WithPhase defers delivery of events published inside a transaction to the
+// given phase; the events are held by the transaction until then, so e.g. an
+// AfterCommit handler never sees an event whose transaction rolled back.
+// Events published outside a transaction are delivered immediately, except
+// to AfterRollback handlers, which never see them. Errors of deferred
+// handlers (other than BeforeCommit) have no Publish to return to and go to
+// [WithErrorHandler].
+func WithPhase(p Phase) SubOption {
+	return func(o *subOptions) { o.phase = p }
+}
+
 // SubOption customizes a single subscription created by [Subscribe] or
 // [SubscribeAsync].
 type SubOption func(*subOptions)
@@ -160,8 +200,9 @@ func WithBuffer(n int) SubOption {
 
 // WithErrorHandler routes the error returned by an asynchronous handler, which
 // cannot propagate back to [Bus.Publish]. Without it, an async handler's error
-// is discarded. It is ignored by synchronous subscriptions, whose errors are
-// aggregated into Publish's return value.
+// is discarded. Synchronous subscriptions use it only for handlers deferred by
+// [WithPhase]; otherwise their errors are aggregated into Publish's return
+// value.
 func WithErrorHandler(fn func(ctx context.Context, err error)) SubOption {
 	return func(o *subOptions) { o.onError = fn }
 }
@@ -183,10 +224,12 @@ type asyncEvent struct {
 
 // entry is one subscription registered on the bus.
 type entry struct {
-	order  int
-	seq    uint64
-	async  bool
-	invoke func(ctx context.Context, event any) error
+	order   int
+	seq     uint64
+	async   bool
+	phase   Phase
+	onError func(ctx context.Context, err error)
+	invoke  func(ctx context.Context, event any) error
 
 	// Async-only machinery. ch carries events to the worker; done is closed to
 	// tell the worker to drain and exit. Senders select on done as well, so once
@@ -226,7 +269,7 @@ func (b *bus) subscribe(typ reflect.Type, invoke func(ctx context.Context, event
 		return func() {}
 	}
 	b.seq++
-	e := &entry{order: o.order, seq: b.seq, async: async, invoke: invoke}
+	e := &entry{order: o.order, seq: b.seq, async: async, phase: o.phase, onError: o.onError, invoke: invoke}
 	if async {
 		buf := o.buffer
 		if buf <= 0 {
@@ -315,23 +358,55 @@ func (b *bus) Publish(ctx context.Context, event any) error {
 
 	var errs []error
 	for _, e := range snapshot {
-		if e.async {
-			select {
-			case e.ch <- asyncEvent{ctx: ctx, event: event}:
-			case <-e.done:
-				// Subscription was cancelled concurrently; skip it.
-			case <-ctx.Done():
-				errs = append(errs, ctx.Err())
-			}
+		if e.phase != Immediate && e.enlist(ctx, event) {
 			continue
 		}
-		if err := e.invoke(ctx, event); err != nil {
+		if e.phase == AfterRollback {
+			continue // no transaction, so nothing can roll back
+		}
+		if err := e.deliver(ctx, event); err != nil {
 			errs = append(errs, err)
 		}
 	}
 	return errors.Join(errs...)
 }
 
+// deliver hands event to the handler: inline for a synchronous entry, through
+// the worker channel for an asynchronous one.
+func (e *entry) deliver(ctx context.Context, event any) error {
+	if !e.async {
+		return e.invoke(ctx, event)
+	}
+	select {
+	case e.ch <- asyncEvent{ctx: ctx, event: event}:
+	case <-e.done:
+		// Subscription was cancelled concurrently; skip it.
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// enlist registers delivery of event at the entry's phase with the
+// transaction carried by ctx, reporting false when there is none.
+func (e *entry) enlist(ctx context.Context, event any) bool {
+	if e.phase == BeforeCommit {
+		return aspect.OnBeforeCommit(ctx, func(ctx context.Context) error {
+			return e.deliver(ctx, event)
+		})
+	}
+	return aspect.OnAfterCompletion(ctx, func(ctx context.Context, committed bool) {
+		switch {
+		case e.phase == AfterCommit && !committed,
+			e.phase == AfterRollback && committed:
+			return
+		}
+		if err := e.deliver(ctx, event); err != nil && e.onError != nil {
+			e.onError(ctx, err)
+		}
+	})
+}
+
 // Close implements [Bus].
 func (b *bus) Close() error {
 	b.mu.Lock()
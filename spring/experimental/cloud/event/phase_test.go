@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-spring.org/spring/experimental/aspect"
+	"go-spring.org/spring/experimental/cloud/event"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type orderPlaced struct{ ID string }
+
+// noopTx is a TxManager with nothing behind it; only the synchronizations of
+// aspect.Transactional matter here.
+type noopTx struct{}
+
+func (noopTx) Begin(ctx context.Context) (context.Context, any, error) { return ctx, nil, nil }
+func (noopTx) Commit(any) error                                        { return nil }
+func (noopTx) Rollback(any) error                                      { return nil }
+
+func TestPhasedSubscriptionsFollowTransactionOutcome(t *testing.T) {
+	bus := event.New()
+	defer bus.Close()
+
+	var got []string
+	record := func(phase string) func(context.Context, orderPlaced) error {
+		return func(_ context.Context, e orderPlaced) error {
+			got = append(got, phase+":"+e.ID)
+			return nil
+		}
+	}
+	event.Subscribe(bus, record("now"))
+	event.Subscribe(bus, record("before"), event.WithPhase(event.BeforeCommit))
+	event.Subscribe(bus, record("commit"), event.WithPhase(event.AfterCommit))
+	event.Subscribe(bus, record("rollback"), event.WithPhase(event.AfterRollback))
+	event.Subscribe(bus, record("done"), event.WithPhase(event.AfterCompletion))
+
+	chain := aspect.NewChain(aspect.Transactional(noopTx{}))
+	run := func(id string, fail error) error {
+		_, err := chain.Run(context.Background(), "place", func(ctx context.Context) (any, error) {
+			if err := bus.Publish(ctx, orderPlaced{ID: id}); err != nil {
+				return nil, err
+			}
+			assert.That(t, got[len(got)-1]).Equal("now:" + id) // the rest is held back
+			return nil, fail
+		})
+		return err
+	}
+
+	assert.Error(t, run("1", nil)).Nil()
+	assert.That(t, got).Equal([]string{"now:1", "before:1", "commit:1", "done:1"})
+
+	got = nil
+	assert.Error(t, run("2", errors.New("out of stock"))).NotNil()
+	assert.That(t, got).Equal([]string{"now:2", "rollback:2", "done:2"})
+
+	// Without a transaction phased handlers run at once, except AfterRollback.
+	got = nil
+	assert.Error(t, bus.Publish(context.Background(), orderPlaced{ID: "3"})).Nil()
+	assert.That(t, got).Equal([]string{"now:3", "before:3", "commit:3", "done:3"})
+}
+
+func TestBeforeCommitErrorRollsBack(t *testing.T) {
+	bus := event.New()
+	defer bus.Close()
+	veto := errors.New("veto")
+	event.Subscribe(bus, func(context.Context, orderPlaced) error { return veto },
+		event.WithPhase(event.BeforeCommit))
+	var committed bool
+	event.Subscribe(bus, func(context.Context, orderPlaced) error { committed = true; return nil },
+		event.WithPhase(event.AfterCommit))
+
+	_, err := aspect.NewChain(aspect.Transactional(noopTx{})).Run(context.Background(), "place",
+		func(ctx context.Context) (any, error) {
+			return nil, bus.Publish(ctx, orderPlaced{ID: "1"})
+		})
+	assert.Error(t, err).Is(veto)
+	assert.That(t, committed).False()
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"sync"
+
+	"go-spring.org/spring/experimental/aspect"
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/stdlib/errutil"
+)
+
+// Header names a [RemoteBus] sets on the messages it publishes.
+const (
+	HeaderEventType   = "event-type"   // the registered name of the event type
+	HeaderEventOrigin = "event-origin" // the Instance of the publishing bus
+)
+
+// TypeRegistry maps wire names to the Go types a [RemoteBus] forwards. Only
+// registered types leave the process; the name, not the Go type path, goes on
+// the wire, so a type can be renamed or moved without breaking replicas that
+// run an older build.
+type TypeRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]*remoteType
+	byType map[reflect.Type]*remoteType
+}
+
+// remoteType is one registered event type.
+type remoteType struct {
+	name string
+	typ  reflect.Type
+	key  func(event any) string // nil means unordered
+}
+
+// NewTypeRegistry returns an empty [TypeRegistry].
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		byName: make(map[string]*remoteType),
+		byType: make(map[reflect.Type]*remoteType),
+	}
+}
+
+// TypeOption customizes a type registered with [RegisterType].
+type TypeOption func(*remoteType)
+
+// Ordered delivers every event of the type in publish order on each replica,
+// by using the type name as the message key. It serializes the type on
+// brokers that order per key.
+func Ordered() TypeOption {
+	return func(t *remoteType) {
+		t.key = func(any) string { return t.name }
+	}
+}
+
+// OrderedBy keys events of type T with fn, so events with the same key keep
+// their publish order while different keys may be delivered in parallel. The
+// default, without an ordering option, sends unkeyed messages.
+func OrderedBy[T any](fn func(event T) string) TypeOption {
+	return func(t *remoteType) {
+		t.key = func(event any) string { return fn(event.(T)) }
+	}
+}
+
+// RegisterType makes events of type T eligible for remote delivery under
+// name. It panics if name is empty or either name or T is already
+// registered, so conflicting wiring fails at startup.
+func RegisterType[T any](r *TypeRegistry, name string, opts ...TypeOption) {
+	if name == "" {
+		panic("event: register remote type with empty name")
+	}
+	t := &remoteType{name: name, typ: reflect.TypeFor[T]()}
+	for _, fn := range opts {
+		fn(t)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byName[name]; ok {
+		panic("event: remote type already registered: " + name)
+	}
+	if _, ok := r.byType[t.typ]; ok {
+		panic("event: remote type already registered: " + t.typ.String())
+	}
+	r.byName[name] = t
+	r.byType[t.typ] = t
+}
+
+func (r *TypeRegistry) lookupType(typ reflect.Type) *remoteType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byType[typ]
+}
+
+func (r *TypeRegistry) lookupName(name string) *remoteType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byName[name]
+}
+
+// RemoteConfig configures a [RemoteBus]. Binder and Types are required.
+type RemoteConfig struct {
+	// Binder carries the events between replicas.
+	Binder messaging.Binder
+
+	// Destination is the topic every replica publishes to and subscribes to.
+	// Default "spring.events".
+	Destination string
+
+	// Types lists the event types that are forwarded.
+	Types *TypeRegistry
+
+	// Codec encodes event values. Default messaging.JSONCodec, which only
+	// sees exported fields.
+	Codec messaging.Codec
+
+	// Instance identifies this replica; events carrying it are not
+	// re-delivered locally. Empty generates a random ID.
+	Instance string
+
+	// OnError receives failures that have no caller to report to: incoming
+	// events that fail to decode or whose local handlers fail, and sends
+	// deferred to a transaction's commit. Optional.
+	OnError func(ctx context.Context, err error)
+}
+
+// RemoteBus decorates a local [Bus] so that events of registered types also
+// reach every other replica. Publish delivers locally as usual, then sends
+// the encoded event to the Destination; events arriving from other replicas
+// are published on the local bus, so handlers registered with [Subscribe] see
+// local and remote events alike. An event published by this replica is never
+// delivered to it twice.
+//
+// Remote delivery follows the binder's guarantees. Handlers of forwarded
+// types should therefore tolerate duplicates and, without an ordering
+// option, reordering.
+type RemoteBus struct {
+	local Bus
+	cfg   RemoteConfig
+	sub   messaging.Subscriber
+
+	mu  sync.Mutex
+	pub messaging.Publisher
+}
+
+// NewRemoteBus wraps local and starts receiving remote events. Each replica
+// subscribes without a group, so every replica sees every event.
+func NewRemoteBus(ctx context.Context, local Bus, cfg RemoteConfig) (*RemoteBus, error) {
+	if cfg.Binder == nil || cfg.Types == nil {
+		return nil, errutil.Explain(nil, "event: remote bus requires a Binder and Types")
+	}
+	if cfg.Destination == "" {
+		cfg.Destination = "spring.events"
+	}
+	if cfg.Codec == nil {
+		cfg.Codec = messaging.JSONCodec{}
+	}
+	if cfg.Instance == "" {
+		var b [8]byte
+		_, _ = rand.Read(b[:])
+		cfg.Instance = hex.EncodeToString(b[:])
+	}
+	b := &RemoteBus{local: local, cfg: cfg}
+	sub, err := cfg.Binder.NewSubscriber(ctx, cfg.Destination, "")
+	if err == nil {
+		err = sub.Subscribe(ctx, b.receive)
+	}
+	if err != nil {
+		return nil, errutil.Explain(err, "event: subscribe to %q", cfg.Destination)
+	}
+	b.sub = sub
+	return b, nil
+}
+
+// Instance returns the origin ID this bus stamps on outgoing events.
+func (b *RemoteBus) Instance() string { return b.cfg.Instance }
+
+// Publish delivers event locally and, if its type is registered, to the
+// other replicas. The local and remote errors are joined. Inside an
+// [aspect.Transactional] transaction the remote send waits for the commit
+// and is skipped on rollback; its error then goes to OnError.
+func (b *RemoteBus) Publish(ctx context.Context, event any) error {
+	err := b.local.Publish(ctx, event)
+	if event == nil || errors.Is(err, ErrClosed) {
+		return err
+	}
+	t := b.cfg.Types.lookupType(reflect.TypeOf(event))
+	if t == nil {
+		return err
+	}
+	if aspect.OnAfterCompletion(ctx, func(ctx context.Context, committed bool) {
+		if !committed {
+			return
+		}
+		if sErr := b.send(ctx, t, event); sErr != nil {
+			b.reportError(ctx, sErr)
+		}
+	}) {
+		return err
+	}
+	return errors.Join(err, b.send(ctx, t, event))
+}
+
+// send encodes event and publishes it to the destination.
+func (b *RemoteBus) send(ctx context.Context, t *remoteType, event any) error {
+	data, err := b.cfg.Codec.Marshal(event)
+	if err != nil {
+		return errutil.Explain(err, "event: encode %s", t.name)
+	}
+	msg := &messaging.Message{Payload: data}
+	msg.SetHeader(HeaderEventType, t.name)
+	msg.SetHeader(HeaderEventOrigin, b.cfg.Instance)
+	if t.key != nil {
+		msg.Key = t.key(event)
+	}
+
+	b.mu.Lock()
+	if b.pub == nil {
+		if b.pub, err = b.cfg.Binder.NewPublisher(ctx, b.cfg.Destination); err != nil {
+			b.mu.Unlock()
+			return errutil.Explain(err, "event: open publisher for %q", b.cfg.Destination)
+		}
+	}
+	pub := b.pub
+	b.mu.Unlock()
+
+	if err = pub.Publish(ctx, msg); err != nil {
+		return errutil.Explain(err, "event: publish %s", t.name)
+	}
+	return nil
+}
+
+// receive re-publishes a remote event on the local bus. Own events and types
+// this build does not know are skipped. Failures go to OnError rather than
+// back to the broker: an event is a notification, and redelivering it would
+// re-run the handlers that did succeed.
+func (b *RemoteBus) receive(ctx context.Context, msg *messaging.Message) error {
+	if msg.Header(HeaderEventOrigin) == b.cfg.Instance {
+		return nil
+	}
+	t := b.cfg.Types.lookupName(msg.Header(HeaderEventType))
+	if t == nil {
+		return nil
+	}
+	v := reflect.New(t.typ)
+	if err := b.cfg.Codec.Unmarshal(msg.Payload, v.Interface()); err != nil {
+		b.reportError(ctx, errutil.Explain(err, "event: decode %s", t.name))
+		return nil
+	}
+	if err := b.local.Publish(ctx, v.Elem().Interface()); err != nil {
+		b.reportError(ctx, err)
+	}
+	return nil
+}
+
+func (b *RemoteBus) reportError(ctx context.Context, err error) {
+	if b.cfg.OnError != nil {
+		b.cfg.OnError(ctx, err)
+	}
+}
+
+// Close stops receiving remote events, releases the publisher and closes the
+// local bus.
+func (b *RemoteBus) Close() error {
+	var errs []error
+	errs = append(errs, b.sub.Close())
+	b.mu.Lock()
+	if b.pub != nil {
+		errs = append(errs, b.pub.Close())
+		b.pub = nil
+	}
+	b.mu.Unlock()
+	errs = append(errs, b.local.Close())
+	return errors.Join(errs...)
+}
+
+// subscribe forwards to the local bus, so [Subscribe] and [SubscribeAsync]
+// accept a *RemoteBus directly.
+func (b *RemoteBus) subscribe(typ reflect.Type, invoke func(ctx context.Context, event any) error, async bool, o subOptions) func() {
+	s, ok := b.local.(subscribable)
+	if !ok {
+		return func() {}
+	}
+	return s.subscribe(typ, invoke, async, o)
+}
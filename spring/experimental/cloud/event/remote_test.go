@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/event"
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type cacheInvalidated struct {
+	Cache string
+	Key   string
+}
+
+type localOnly struct{ N int }
+
+func TestRemoteBusReachesOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	binder := messaging.NewMemoryBinder(messaging.MemoryBinderConfig{})
+	defer binder.Close()
+
+	types := event.NewTypeRegistry()
+	event.RegisterType[cacheInvalidated](types, "cache.invalidated",
+		event.OrderedBy(func(e cacheInvalidated) string { return e.Cache }))
+
+	type replica struct {
+		bus *event.RemoteBus
+		mu  sync.Mutex
+		got []string
+	}
+	newReplica := func(id string) *replica {
+		b, err := event.NewRemoteBus(ctx, event.New(), event.RemoteConfig{
+			Binder:   binder,
+			Types:    types,
+			Instance: id,
+		})
+		assert.Error(t, err).Nil()
+		r := &replica{bus: b}
+		event.Subscribe(b, func(_ context.Context, e cacheInvalidated) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.got = append(r.got, e.Key)
+			return nil
+		})
+		event.Subscribe(b, func(_ context.Context, e localOnly) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.got = append(r.got, "local")
+			return nil
+		})
+		return r
+	}
+	a, b := newReplica("a"), newReplica("b")
+	defer a.bus.Close()
+	defer b.bus.Close()
+
+	assert.Error(t, a.bus.Publish(ctx, cacheInvalidated{Cache: "users", Key: "u1"})).Nil()
+	assert.Error(t, a.bus.Publish(ctx, cacheInvalidated{Cache: "users", Key: "u2"})).Nil()
+	assert.Error(t, a.bus.Publish(ctx, localOnly{N: 1})).Nil() // unregistered: stays local
+
+	wctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	assert.Error(t, binder.WaitIdle(wctx)).Nil()
+
+	assert.That(t, a.got).Equal([]string{"u1", "u2", "local"}) // no echo
+	assert.That(t, b.got).Equal([]string{"u1", "u2"})
+
+	sent := binder.Published("spring.events")
+	assert.That(t, len(sent)).Equal(2)
+	assert.That(t, sent[0].Key).Equal("users")
+	assert.That(t, sent[0].Header(event.HeaderEventType)).Equal("cache.invalidated")
+	assert.That(t, sent[0].Header(event.HeaderEventOrigin)).Equal("a")
+}
+
+func TestRegisterTypeDuplicatePanics(t *testing.T) {
+	types := event.NewTypeRegistry()
+	event.RegisterType[cacheInvalidated](types, "cache.invalidated")
+	assert.Panic(t, func() { event.RegisterType[localOnly](types, "cache.invalidated") }, "already registered")
+	assert.Panic(t, func() { event.RegisterType[cacheInvalidated](types, "other") }, "already registered")
+	assert.Panic(t, func() { event.RegisterType[localOnly](types, "") }, "empty name")
+}
@@ -23,11 +23,14 @@
 // resilience packages:
 //
 //   - [Balancer] is the pluggable selection strategy (round-robin, least-conn,
-//     consistent-hash, weighted, zone-aware). It is pure: given a candidate
-//     endpoint set and a [PickInfo] it returns one [Result].
+//     consistent-hash, weighted, zone-aware, P2C over peak-EWMA latency). It is
+//     pure: given a candidate endpoint set and a [PickInfo] it returns one
+//     [Result].
 //   - [Pool] binds a live discovery source (via [discovery.Resolver]) and a
 //     [Tracker] (outlier ejection) to a Balancer, so the candidate set stays
 //     fresh as instances come and go and unhealthy instances are evicted.
+//   - [Router] narrows that set by metadata routing rules (lanes, weighted
+//     canary splits) before the Balancer picks.
 //
 // The package has zero third-party dependencies; RPC-framework adapters (gRPC
 // balancer.Builder, kitex loadbalance.Loadbalancer, ...) live in their starters
@@ -65,6 +68,11 @@ type PickInfo struct {
 	// prefer endpoints whose Metadata advertises the same zone and only spill
 	// over to remote ones when no local endpoint is available.
 	Zone string
+
+	// Attr looks up a request attribute, such as a header, for the routing
+	// rules of a [Router]. Nil means the request has none besides those on
+	// Ctx.
+	Attr func(name string) string
 }
 
 // Result is the outcome of a [Balancer.Pick]: the chosen endpoint plus an
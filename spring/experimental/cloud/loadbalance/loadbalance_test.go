@@ -17,6 +17,7 @@
 package loadbalance
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -214,16 +215,229 @@ func TestTrackerEjectAndRecover(t *testing.T) {
 	tr.Record("a", true)
 	assert.That(t, tr.Ejected("a")).False()
 
-	// If the trial fails instead, it re-ejects for another window.
+	// If the trial fails instead, it re-ejects for another window. Ejected
+	// again straight away, it now stays out twice as long.
 	tr.Record("a", false)
 	tr.Record("a", false)
 	assert.That(t, tr.Ejected("a")).True()
 	now = now.Add(1100 * time.Millisecond)
+	assert.That(t, tr.Ejected("a")).True()
+	now = now.Add(time.Second)
 	tr.Eligible(eps("a")) // admit trial -> half-open
 	tr.Record("a", false) // trial fails
 	assert.That(t, tr.Ejected("a")).True()
 }
 
+func TestTrackerEjectionTimeGrowsAndDecays(t *testing.T) {
+	now := time.Unix(0, 0)
+	tr := NewTracker(TrackerConfig{Threshold: 1, EjectFor: time.Second, MaxEjectFor: 3 * time.Second, Interval: 10 * time.Second})
+	tr.now = func() time.Time { return now }
+
+	// ejectAndRestore ejects "a" and reports how long it stayed out.
+	ejectAndRestore := func() time.Duration {
+		tr.Record("a", false)
+		start := now
+		for tr.Ejected("a") {
+			now = now.Add(100 * time.Millisecond)
+		}
+		tr.Eligible(eps("a"))
+		tr.Record("a", true)
+		return now.Sub(start)
+	}
+	assert.That(t, ejectAndRestore()).Equal(time.Second)
+	assert.That(t, ejectAndRestore()).Equal(2 * time.Second)
+	assert.That(t, ejectAndRestore()).Equal(3 * time.Second)
+	assert.That(t, ejectAndRestore()).Equal(3 * time.Second) // capped
+
+	// Each interval spent healthy forgives one ejection.
+	now = now.Add(25 * time.Second)
+	assert.That(t, ejectAndRestore()).Equal(2 * time.Second)
+}
+
+func TestTrackerMaxEjectionPercent(t *testing.T) {
+	tr := NewTracker(TrackerConfig{Threshold: 1, MaxEjectionPercent: 50})
+	set := eps("a", "b", "c", "d")
+	tr.Eligible(set)
+	for _, a := range []string{"a", "b", "c"} {
+		tr.Record(a, false)
+	}
+	// Two of four may be out at once; the third failing endpoint stays in.
+	assert.That(t, tr.Ejected("a")).True()
+	assert.That(t, tr.Ejected("b")).True()
+	assert.That(t, tr.Ejected("c")).False()
+	assert.Slice(t, addrs(tr.Eligible(set))).Equal([]string{"c", "d"})
+}
+
+func TestTrackerSuccessRate(t *testing.T) {
+	now := time.Unix(0, 0)
+	tr := NewTracker(TrackerConfig{
+		SuccessRateStdevFactor:   1,
+		SuccessRateMinHosts:      3,
+		SuccessRateRequestVolume: 10,
+	})
+	tr.now = func() time.Time { return now }
+	set := eps("a", "b", "c", "d")
+	tr.Eligible(set)
+
+	// "d" fails half its requests, never twice in a row: only its success rate
+	// gives it away.
+	for i := range 20 {
+		for _, a := range []string{"a", "b", "c"} {
+			tr.Record(a, true)
+		}
+		tr.Record("d", i%2 == 0)
+	}
+	assert.That(t, tr.Ejected("d")).False()
+
+	// The next interval evaluates the last one.
+	now = now.Add(10 * time.Second)
+	assert.Slice(t, addrs(tr.Eligible(set))).Equal([]string{"a", "b", "c"})
+	assert.That(t, tr.Ejected("d")).True()
+
+	// Too little traffic to compare: nobody is ejected.
+	for _, a := range []string{"a", "b", "c"} {
+		tr.Record(a, false)
+	}
+	now = now.Add(10 * time.Second)
+	assert.Slice(t, addrs(tr.Eligible(eps("a", "b", "c")))).Length(3)
+}
+
+func TestP2CAvoidsSlowEndpoint(t *testing.T) {
+	b := newP2C(P2CConfig{})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	set := eps("fast", "slow")
+
+	// serve runs one request to set that takes the picked endpoint's latency.
+	serve := func(set []discovery.Endpoint) string {
+		r, err := b.Pick(set, PickInfo{})
+		assert.Error(t, err).Nil()
+		if r.Endpoint.Addr == "slow" {
+			now = now.Add(time.Second)
+		} else {
+			now = now.Add(time.Millisecond)
+		}
+		r.Done(DoneInfo{})
+		return r.Endpoint.Addr
+	}
+	serve(eps("slow"))
+	serve(eps("fast"))
+
+	// Sampling both endpoints every time, P2C now sends everything to "fast".
+	m := map[string]int{}
+	for range 100 {
+		m[serve(set)]++
+	}
+	assert.That(t, m["fast"]).Equal(100)
+}
+
+func TestP2CCountsInflight(t *testing.T) {
+	b := newP2C(P2CConfig{})
+	set := eps("a", "b")
+
+	// With equal latency, outstanding requests break the tie.
+	r1, err := b.Pick(set, PickInfo{})
+	assert.Error(t, err).Nil()
+	r2, err := b.Pick(set, PickInfo{})
+	assert.Error(t, err).Nil()
+	assert.That(t, r1.Endpoint.Addr != r2.Endpoint.Addr).True()
+	r1.Done(DoneInfo{})
+	r2.Done(DoneInfo{})
+	r2.Done(DoneInfo{}) // a double Done is ignored
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	assert.That(t, b.stats["a"].inflight).Equal(0)
+	assert.That(t, b.stats["b"].inflight).Equal(0)
+}
+
+func TestP2CFastFailureDoesNotLowerCost(t *testing.T) {
+	s := &ewmaStat{}
+	now := time.Unix(0, 0)
+	s.observe(float64(time.Second), false, now, 10*time.Second)
+	s.observe(float64(time.Millisecond), true, now.Add(time.Second), 10*time.Second)
+	assert.That(t, s.cost).Equal(float64(time.Second))
+	s.observe(float64(time.Millisecond), false, now.Add(2*time.Second), 10*time.Second)
+	assert.That(t, s.cost < float64(time.Second)).True()
+}
+
+func TestP2CFailurePenalty(t *testing.T) {
+	b := newP2C(P2CConfig{})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	// A failure returning in 1ms is charged the 150ms default penalty.
+	r, err := b.Pick(eps("a"), PickInfo{})
+	assert.Error(t, err).Nil()
+	now = now.Add(time.Millisecond)
+	r.Done(DoneInfo{Err: errors.New("boom")})
+	assert.That(t, b.stats["a"].cost).Equal(float64(150 * time.Millisecond))
+
+	b = newP2C(P2CConfig{FailurePenalty: -1})
+	b.now = func() time.Time { return now }
+	r, err = b.Pick(eps("a"), PickInfo{})
+	assert.Error(t, err).Nil()
+	now = now.Add(time.Millisecond)
+	r.Done(DoneInfo{Err: errors.New("boom")})
+	assert.That(t, b.stats["a"].cost).Equal(float64(time.Millisecond))
+}
+
+func TestP2CKeepsHistoryAcrossSubsets(t *testing.T) {
+	b := newP2C(P2CConfig{})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	// Two lanes, as a router splitting canary and stable traffic would pass.
+	// Within each lane one endpoint is slow.
+	serve := func(set []discovery.Endpoint) string {
+		r, err := b.Pick(set, PickInfo{})
+		assert.Error(t, err).Nil()
+		if r.Endpoint.Addr == "a-slow" || r.Endpoint.Addr == "b-slow" {
+			now = now.Add(time.Second)
+		} else {
+			now = now.Add(time.Millisecond)
+		}
+		r.Done(DoneInfo{})
+		return r.Endpoint.Addr
+	}
+	for _, addr := range []string{"a-slow", "a-fast", "b-slow", "b-fast"} {
+		serve(eps(addr))
+	}
+
+	m := map[string]int{}
+	for range 50 {
+		m[serve(eps("a-slow", "a-fast"))]++
+		m[serve(eps("b-slow", "b-fast"))]++
+	}
+	assert.That(t, m["a-fast"]).Equal(50)
+	assert.That(t, m["b-fast"]).Equal(50)
+	assert.That(t, len(b.stats)).Equal(4)
+}
+
+func TestP2CPrunesIdleEndpoints(t *testing.T) {
+	b := newP2C(P2CConfig{Decay: time.Second})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	gone, err := b.Pick(eps("gone"), PickInfo{})
+	assert.Error(t, err).Nil()
+	gone.Done(DoneInfo{})
+	busy, err := b.Pick(eps("busy"), PickInfo{})
+	assert.Error(t, err).Nil()
+
+	// "gone" has been idle for over ten decays; "busy" still has a request
+	// in flight, so it stays however old it is.
+	now = now.Add(11 * time.Second)
+	r, err := b.Pick(eps("c"), PickInfo{})
+	assert.Error(t, err).Nil()
+	r.Done(DoneInfo{})
+	_, ok := b.stats["gone"]
+	assert.That(t, ok).False()
+	_, ok = b.stats["busy"]
+	assert.That(t, ok).True()
+	busy.Done(DoneInfo{})
+}
+
 func TestTrackerDisabled(t *testing.T) {
 	tr := NewTracker(TrackerConfig{Threshold: 0})
 	tr.Record("a", false)
@@ -233,6 +447,126 @@ func TestTrackerDisabled(t *testing.T) {
 	assert.Slice(t, addrs(tr.Eligible(eps("a", "b")))).Length(2)
 }
 
+// tagged builds endpoints carrying one metadata key, "" meaning untagged.
+func tagged(key string, addrToVal map[string]string) []discovery.Endpoint {
+	var out []discovery.Endpoint
+	for _, a := range []string{"a", "b", "c", "d"} {
+		v, ok := addrToVal[a]
+		if !ok {
+			continue
+		}
+		ep := discovery.Endpoint{Addr: a}
+		if v != "" {
+			ep.Metadata = map[string]string{key: v}
+		}
+		out = append(out, ep)
+	}
+	return out
+}
+
+func TestRouterWeightedSplit(t *testing.T) {
+	set := tagged("version", map[string]string{"a": "v1", "b": "v1", "c": "v2"})
+	r, err := NewRouter(RoutingConfig{Rules: []RouteRule{{
+		Destinations: []RouteDestination{
+			{Subset: map[string]string{"version": "v1"}, Weight: 95},
+			{Subset: map[string]string{"version": "v2"}, Weight: 5},
+		},
+	}}})
+	assert.Error(t, err).Nil()
+
+	// The draw picks the destination: [0,95) is v1, [95,100) the canary.
+	r.intn = func(int) int { return 94 }
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"a", "b"})
+	r.intn = func(int) int { return 95 }
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"c"})
+
+	// With HashBy the same user always lands on the same subset.
+	assert.Error(t, r.Update(RoutingConfig{Rules: []RouteRule{{
+		HashBy: "uid",
+		Destinations: []RouteDestination{
+			{Subset: map[string]string{"version": "v1"}, Weight: 1},
+			{Subset: map[string]string{"version": "v2"}, Weight: 1},
+		},
+	}}})).Nil()
+	seen := map[string]bool{}
+	for _, uid := range []string{"u1", "u2", "u3", "u4", "u5", "u6", "u7", "u8"} {
+		ctx := WithAttr(context.Background(), "uid", uid)
+		first := addrs(r.Route(set, PickInfo{Ctx: ctx}))
+		for range 5 {
+			assert.That(t, addrs(r.Route(set, PickInfo{Ctx: ctx}))).Equal(first)
+		}
+		seen[first[0]] = true
+	}
+	assert.Number(t, len(seen)).Equal(2)
+}
+
+func TestRouterMatchAndFallback(t *testing.T) {
+	set := tagged("version", map[string]string{"a": "v1", "b": "v2"})
+	r, err := NewRouter(RoutingConfig{Rules: []RouteRule{
+		{
+			Match:        map[string]string{"x-user-type": "internal"},
+			Destinations: []RouteDestination{{Subset: map[string]string{"version": "v3"}}},
+			Fallback:     map[string]string{"version": "v2"},
+		},
+		{
+			Destinations: []RouteDestination{{Subset: map[string]string{"version": "v1"}}},
+		},
+	}})
+	assert.Error(t, err).Nil()
+
+	// The first rule matches a header; v3 is empty so the fallback is used.
+	header := func(name string) string {
+		if name == "x-user-type" {
+			return "internal"
+		}
+		return ""
+	}
+	assert.That(t, addrs(r.Route(set, PickInfo{Attr: header}))).Equal([]string{"b"})
+
+	// Other requests fall through to the catch-all rule.
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"a"})
+
+	// A rule selecting nothing, with no fallback, never empties the set.
+	assert.Error(t, r.Update(RoutingConfig{Rules: []RouteRule{{
+		Destinations: []RouteDestination{{Subset: map[string]string{"version": "v9"}}},
+	}}})).Nil()
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"a", "b"})
+
+	// Invalid rules are rejected and the previous ones stay in effect.
+	err = r.Update(RoutingConfig{Rules: []RouteRule{{}}})
+	assert.Error(t, err).Matches("no destinations")
+	err = r.Update(RoutingConfig{Rules: []RouteRule{{
+		Destinations: []RouteDestination{{Weight: -1}},
+	}}})
+	assert.Error(t, err).Matches("negative weight")
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"a", "b"})
+}
+
+func TestRouterLane(t *testing.T) {
+	set := tagged("lane", map[string]string{"a": "", "b": "", "c": "gray"})
+	r, err := NewRouter(RoutingConfig{LaneKey: "lane"})
+	assert.Error(t, err).Nil()
+
+	// Lane traffic stays on its lane; the rest avoids lane instances.
+	ctx := WithLane(context.Background(), "gray")
+	assert.String(t, LaneFrom(ctx)).Equal("gray")
+	assert.That(t, addrs(r.Route(set, PickInfo{Ctx: ctx}))).Equal([]string{"c"})
+	assert.That(t, addrs(r.Route(set, PickInfo{}))).Equal([]string{"a", "b"})
+
+	// The lane can also come from the inbound header.
+	header := func(name string) string {
+		if name == LaneHeader {
+			return "gray"
+		}
+		return ""
+	}
+	assert.That(t, addrs(r.Route(set, PickInfo{Attr: header}))).Equal([]string{"c"})
+
+	// A lane with no instance of its own uses the baseline.
+	ctx = WithLane(context.Background(), "blue")
+	assert.That(t, addrs(r.Route(set, PickInfo{Ctx: ctx}))).Equal([]string{"a", "b"})
+}
+
 // staticSource is a fixed EndpointSource for pool tests.
 type staticSource []discovery.Endpoint
 
@@ -289,3 +623,20 @@ func TestPoolEmpty(t *testing.T) {
 	assert.Error(t, err).Is(ErrNoAvailable)
 }
 
+func TestPoolRouter(t *testing.T) {
+	src := staticSource(tagged("version", map[string]string{"a": "v1", "b": "v2"}))
+	r, err := NewRouter(RoutingConfig{Rules: []RouteRule{{
+		Match:        map[string]string{"tenant": "beta"},
+		Destinations: []RouteDestination{{Subset: map[string]string{"version": "v2"}}},
+	}}})
+	assert.Error(t, err).Nil()
+	p := NewPool(src, NewRoundRobin(), WithRouter(r))
+
+	ctx := WithAttr(context.Background(), "tenant", "beta")
+	for range 4 {
+		res, err := p.Pick(PickInfo{Ctx: ctx})
+		assert.Error(t, err).Nil()
+		assert.String(t, res.Endpoint.Addr).Equal("b")
+		res.Done(DoneInfo{})
+	}
+}
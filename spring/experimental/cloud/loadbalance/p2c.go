@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go-spring.org/spring/cloud/discovery"
+)
+
+// P2C is the registered name of the power-of-two-choices strategy over
+// peak-EWMA latency, with default settings.
+const P2C = "p2c"
+
+func init() { Register(P2C, func() Balancer { return newP2C(P2CConfig{}) }) }
+
+// P2CConfig tunes a [NewP2C] balancer.
+type P2CConfig struct {
+	// Decay is the time constant of the latency average: a sample's weight
+	// halves roughly every 0.7*Decay. Defaults to 10s, as in Finagle.
+	Decay time.Duration
+
+	// DefaultRTT is the latency assumed for an endpoint with no sample yet.
+	// Defaults to 30ms, as in Linkerd.
+	DefaultRTT time.Duration
+
+	// FailurePenalty is the latency charged for a failed request that
+	// returned faster, so an erroring endpoint loses traffic until its cost
+	// decays. Defaults to 5x DefaultRTT; a negative value disables it.
+	FailurePenalty time.Duration
+}
+
+// NewP2C returns a power-of-two-choices [Balancer] in the style of Finagle and
+// Linkerd: each Pick samples two distinct endpoints at random and sends the
+// request to the one with the lower cost, its peak-EWMA latency times its
+// in-flight requests plus one. "Peak" means a sample above the average
+// replaces it at once while lower samples only pull it down gradually, so one
+// slow pod loses traffic in a single request and wins it back slowly.
+//
+// Latency is measured from Pick to [Result.Done], which the caller MUST invoke
+// when the request finishes; otherwise the in-flight count leaks. A failed
+// request costs at least FailurePenalty and never lowers the cost, so an
+// endpoint that fails fast does not attract traffic. Statistics of addresses
+// idle for ten Decay periods are dropped.
+func NewP2C(cfg P2CConfig) Balancer { return newP2C(cfg) }
+
+func newP2C(cfg P2CConfig) *p2c {
+	if cfg.Decay <= 0 {
+		cfg.Decay = 10 * time.Second
+	}
+	if cfg.DefaultRTT <= 0 {
+		cfg.DefaultRTT = 30 * time.Millisecond
+	}
+	if cfg.FailurePenalty == 0 {
+		cfg.FailurePenalty = 5 * cfg.DefaultRTT
+	}
+	return &p2c{cfg: cfg, now: time.Now, intn: rand.IntN, stats: map[string]*ewmaStat{}}
+}
+
+// p2c keeps latency statistics per endpoint address, so they survive changes
+// of the candidate set for the addresses that remain.
+type p2c struct {
+	cfg  P2CConfig
+	now  func() time.Time
+	intn func(n int) int
+
+	mu    sync.Mutex
+	stats map[string]*ewmaStat
+	swept time.Time // last pruneLocked run
+}
+
+// pruneDecays is how many Decay periods a stat must stay idle to be pruned.
+const pruneDecays = 10
+
+type ewmaStat struct {
+	cost     float64 // peak-EWMA latency in nanoseconds, 0 before any sample
+	stamp    time.Time
+	inflight int
+}
+
+func (b *p2c) Pick(eps []discovery.Endpoint, _ PickInfo) (Result, error) {
+	if len(eps) == 0 {
+		return Result{}, ErrNoAvailable
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	ep := eps[0]
+	if len(eps) > 1 {
+		i := b.intn(len(eps))
+		j := b.intn(len(eps) - 1)
+		if j >= i {
+			j++ // two distinct candidates
+		}
+		ep = eps[i]
+		if b.loadLocked(eps[j].Addr, now) < b.loadLocked(ep.Addr, now) {
+			ep = eps[j]
+		}
+	}
+	s := b.statLocked(ep.Addr)
+	s.inflight++
+	if now.Sub(b.swept) >= b.cfg.Decay {
+		b.pruneLocked(now)
+	}
+	b.mu.Unlock()
+
+	done := false
+	return Result{
+		Endpoint: ep,
+		Done: func(di DoneInfo) {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if done {
+				return // guard against a double Done leaking the counter negative
+			}
+			done = true
+			s.inflight--
+			end := b.now()
+			rtt := float64(end.Sub(now))
+			if di.Err != nil {
+				rtt = max(rtt, float64(b.cfg.FailurePenalty))
+			}
+			s.observe(rtt, di.Err != nil, end, b.cfg.Decay)
+		},
+	}, nil
+}
+
+func (b *p2c) statLocked(addr string) *ewmaStat {
+	s := b.stats[addr]
+	if s == nil {
+		s = &ewmaStat{}
+		b.stats[addr] = s
+	}
+	return s
+}
+
+// pruneLocked drops the statistics of idle addresses whose last sample is
+// older than pruneDecays*Decay, so the map does not grow without bound as
+// instances churn. By then the decayed cost is indistinguishable from no
+// sample at all. It never looks at the candidate set of one Pick: routers
+// and the Tracker hand the balancer subsets, and the endpoints outside one
+// must keep their history.
+func (b *p2c) pruneLocked(now time.Time) {
+	b.swept = now
+	horizon := now.Add(-pruneDecays * b.cfg.Decay)
+	for addr, s := range b.stats {
+		if s.inflight == 0 && s.stamp.Before(horizon) {
+			delete(b.stats, addr)
+		}
+	}
+}
+
+// loadLocked is the cost of sending one more request to addr.
+func (b *p2c) loadLocked(addr string, now time.Time) float64 {
+	s := b.stats[addr]
+	if s == nil {
+		return float64(b.cfg.DefaultRTT)
+	}
+	cost := s.decayed(now, b.cfg.Decay)
+	if cost == 0 {
+		cost = float64(b.cfg.DefaultRTT)
+	}
+	return cost * float64(s.inflight+1)
+}
+
+// decayed is the average as of now, relaxed towards zero since the last
+// sample so that an idle endpoint is tried again eventually.
+func (s *ewmaStat) decayed(now time.Time, decay time.Duration) float64 {
+	elapsed := max(0, float64(now.Sub(s.stamp)))
+	return s.cost * math.Exp(-elapsed/float64(decay))
+}
+
+// observe folds in a request of rtt nanoseconds that ended at now.
+func (s *ewmaStat) observe(rtt float64, failed bool, now time.Time, decay time.Duration) {
+	if s.stamp.IsZero() {
+		s.cost, s.stamp = rtt, now
+		return
+	}
+	elapsed := max(0, float64(now.Sub(s.stamp)))
+	w := math.Exp(-elapsed / float64(decay))
+	switch {
+	case rtt > s.cost:
+		s.cost = rtt // peak: react to a slowdown at once
+	case failed:
+		// A fast failure says nothing good about the endpoint.
+	default:
+		s.cost = s.cost*w + rtt*(1-w)
+	}
+	s.stamp = now
+}
@@ -31,8 +31,9 @@ type EndpointSource interface {
 // Pool is the runtime that ties client-side load balancing together. On every
 // [Pool.Pick] it takes the live endpoint snapshot from an [EndpointSource]
 // (kept fresh by discovery Watch), drops endpoints the naming service marks
-// disabled or unhealthy and that the ejection [Tracker] has evicted, and hands
-// the survivors to a [Balancer] to choose one.
+// disabled or unhealthy, keeps those an optional [Router] routes the request
+// to, drops those the ejection [Tracker] has evicted, and hands the survivors
+// to a [Balancer] to choose one.
 //
 // This is where the two halves of health eviction meet: discovery Watch handles
 // instances coming and going, while the Tracker handles instances that are still
@@ -43,6 +44,7 @@ type Pool struct {
 	src     EndpointSource
 	bal     Balancer
 	tracker *Tracker
+	router  *Router
 }
 
 // PoolOption configures a [Pool].
@@ -54,6 +56,12 @@ func WithTracker(t *Tracker) PoolOption {
 	return func(p *Pool) { p.tracker = t }
 }
 
+// WithRouter attaches a routing-rule [Router] to the pool. It narrows the
+// healthy endpoints before ejection filtering and the balancer see them.
+func WithRouter(r *Router) PoolOption {
+	return func(p *Pool) { p.router = r }
+}
+
 // NewPool builds a [Pool] over src using balancer bal. src is typically a
 // *discovery.Resolver so the candidate set follows the naming service in real
 // time; bal is any strategy from this package.
@@ -85,6 +93,10 @@ func (p *Pool) Pick(info PickInfo) (Result, error) {
 		return Result{}, ErrNoAvailable
 	}
 
+	// Routing rules: keep the lane or canary subset the request is routed to.
+	// Route falls back to its input when the subset is empty.
+	candidates = p.router.Route(candidates, info)
+
 	// Ejection filtering: drop instances the tracker has evicted for repeated
 	// failures. Eligible falls back to its input if everything is evicted.
 	candidates = p.tracker.Eligible(candidates)
@@ -0,0 +1,284 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+
+	"go-spring.org/spring/cloud/discovery"
+)
+
+// LaneHeader is the header (and routing attribute) that carries a request's
+// lane, the tag that keeps a canary or feature-branch call chain on the
+// instances deployed for it.
+const LaneHeader = "x-lane"
+
+type laneKey struct{}
+
+type attrsKey struct{}
+
+// WithLane returns a copy of ctx that carries lane. Transports propagate it to
+// downstream calls as the [LaneHeader] header.
+func WithLane(ctx context.Context, lane string) context.Context {
+	return context.WithValue(ctx, laneKey{}, lane)
+}
+
+// LaneFrom returns the lane carried by ctx, or "" when there is none.
+func LaneFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	lane, _ := ctx.Value(laneKey{}).(string)
+	return lane
+}
+
+// WithAttr returns a copy of ctx carrying a routing attribute that [RouteRule]
+// matches can test, such as a user ID set by an authentication middleware.
+// Context attributes take precedence over [PickInfo.Attr].
+func WithAttr(ctx context.Context, name, value string) context.Context {
+	old, _ := ctx.Value(attrsKey{}).(map[string]string)
+	m := make(map[string]string, len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+	m[name] = value
+	return context.WithValue(ctx, attrsKey{}, m)
+}
+
+// RoutingConfig is the rule set of a [Router]. The value tags let a starter
+// bind it straight from configuration.
+type RoutingConfig struct {
+	// LaneKey is the [discovery.Endpoint.Metadata] key that tags an instance
+	// with a lane. When set, a request with a lane is sent to the instances of
+	// that lane, falling back to untagged ones, and a request without a lane
+	// avoids tagged instances. Empty disables lane routing.
+	LaneKey string `value:"${lane-key:=}"`
+
+	// Rules are tried in order; the first that matches the request selects
+	// its endpoints. A request matching no rule may use any endpoint.
+	Rules []RouteRule `value:"${rules:=}"`
+}
+
+// RouteRule sends matching requests to endpoint subsets selected by metadata.
+type RouteRule struct {
+	// Match lists attribute values the request must all carry. An empty Match
+	// matches every request.
+	Match map[string]string `value:"${match:=}"`
+
+	// HashBy names the attribute, such as a user ID, that picks the
+	// destination, so the same value always lands on the same subset. Requests
+	// without it, or rules without HashBy, pick at random by weight.
+	HashBy string `value:"${hash-by:=}"`
+
+	// Destinations split the matching traffic between subsets by weight, for
+	// example 95 to version=v1 and 5 to version=v2.
+	Destinations []RouteDestination `value:"${destinations:=}"`
+
+	// Fallback selects the endpoints used when the chosen destination has
+	// none. When it selects none either, every endpoint is used.
+	Fallback map[string]string `value:"${fallback:=}"`
+}
+
+// RouteDestination is one weighted subset of a [RouteRule].
+type RouteDestination struct {
+	// Subset lists the metadata values an endpoint must all carry.
+	Subset map[string]string `value:"${subset:=}"`
+
+	// Weight is the destination's share of the rule's traffic. When every
+	// destination of a rule has weight 0 they share it equally.
+	Weight int `value:"${weight:=1}"`
+}
+
+func (c RoutingConfig) validate() error {
+	for i, r := range c.Rules {
+		if len(r.Destinations) == 0 {
+			return fmt.Errorf("loadbalance: route rule %d has no destinations", i)
+		}
+		for _, d := range r.Destinations {
+			if d.Weight < 0 {
+				return fmt.Errorf("loadbalance: route rule %d has a negative weight", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Router narrows the candidate set of a [Pool] by routing rules before the
+// [Balancer] picks, which is how canary releases and lanes work without a
+// service mesh. Its rules can be replaced at any time with [Router.Update],
+// so configuration can be hot-refreshed. A nil *Router routes nothing.
+type Router struct {
+	cfg  atomic.Pointer[RoutingConfig]
+	intn func(n int) int
+}
+
+// NewRouter returns a [Router] with the rules in cfg, or an error when a rule
+// is invalid.
+func NewRouter(cfg RoutingConfig) (*Router, error) {
+	r := &Router{intn: rand.IntN}
+	if err := r.Update(cfg); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update replaces the router's rules. On error the previous rules stay in
+// effect.
+func (r *Router) Update(cfg RoutingConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	r.cfg.Store(&cfg)
+	return nil
+}
+
+// Route returns the endpoints of eps that the request described by info may
+// be sent to. It never returns an empty set for a non-empty eps: every filter
+// falls back to its input rather than black-holing the request.
+func (r *Router) Route(eps []discovery.Endpoint, info PickInfo) []discovery.Endpoint {
+	if r == nil || len(eps) == 0 {
+		return eps
+	}
+	cfg := r.cfg.Load()
+	if cfg.LaneKey != "" {
+		eps = routeLane(eps, cfg.LaneKey, requestLane(info))
+	}
+	for _, rule := range cfg.Rules {
+		if rule.matches(info) {
+			return r.routeRule(eps, rule, info)
+		}
+	}
+	return eps
+}
+
+// routeLane keeps the endpoints of lane, or the untagged baseline when the
+// lane has none.
+func routeLane(eps []discovery.Endpoint, key, lane string) []discovery.Endpoint {
+	if lane != "" {
+		if out := subset(eps, map[string]string{key: lane}); len(out) > 0 {
+			return out
+		}
+	}
+	out := eps[:0:0]
+	for _, ep := range eps {
+		if ep.Metadata[key] == "" {
+			out = append(out, ep)
+		}
+	}
+	if len(out) == 0 {
+		return eps
+	}
+	return out
+}
+
+func (r *Router) routeRule(eps []discovery.Endpoint, rule RouteRule, info PickInfo) []discovery.Endpoint {
+	total := 0
+	for _, d := range rule.Destinations {
+		total += d.Weight
+	}
+	weight := func(d RouteDestination) int { return d.Weight }
+	if total == 0 {
+		total = len(rule.Destinations)
+		weight = func(RouteDestination) int { return 1 }
+	}
+	n := -1
+	if rule.HashBy != "" {
+		if v := attr(info, rule.HashBy); v != "" {
+			n = int(hashKey(v) % uint32(total))
+		}
+	}
+	if n < 0 {
+		n = r.intn(total)
+	}
+	var dest RouteDestination
+	for _, d := range rule.Destinations {
+		if n < weight(d) {
+			dest = d
+			break
+		}
+		n -= weight(d)
+	}
+	if out := subset(eps, dest.Subset); len(out) > 0 {
+		return out
+	}
+	if len(rule.Fallback) > 0 {
+		if out := subset(eps, rule.Fallback); len(out) > 0 {
+			return out
+		}
+	}
+	return eps
+}
+
+func (rule RouteRule) matches(info PickInfo) bool {
+	for k, v := range rule.Match {
+		if attr(info, k) != v {
+			return false
+		}
+	}
+	return true
+}
+
+// subset returns the endpoints whose metadata carries every value in sel.
+func subset(eps []discovery.Endpoint, sel map[string]string) []discovery.Endpoint {
+	out := eps[:0:0]
+	for _, ep := range eps {
+		ok := true
+		for k, v := range sel {
+			if ep.Metadata[k] != v {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, ep)
+		}
+	}
+	return out
+}
+
+// attr looks name up in the context attributes, then through [PickInfo.Attr].
+// The lane attribute also sees the lane carried by the context.
+func attr(info PickInfo, name string) string {
+	if strings.EqualFold(name, LaneHeader) {
+		return requestLane(info)
+	}
+	if info.Ctx != nil {
+		if m, ok := info.Ctx.Value(attrsKey{}).(map[string]string); ok {
+			if v, ok := m[name]; ok {
+				return v
+			}
+		}
+	}
+	if info.Attr != nil {
+		return info.Attr(name)
+	}
+	return ""
+}
+
+func requestLane(info PickInfo) string {
+	if lane := LaneFrom(info.Ctx); lane != "" {
+		return lane
+	}
+	if info.Attr != nil {
+		return info.Attr(LaneHeader)
+	}
+	return ""
+}
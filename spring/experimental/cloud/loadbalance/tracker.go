@@ -17,6 +17,7 @@
 package loadbalance
 
 import (
+	"math"
 	"sync"
 	"time"
 
@@ -36,53 +37,117 @@ import (
 // resilience Executor feeds a Tracker, so the two stay consistent without one
 // depending on the other.
 //
-// A Tracker with Threshold <= 0 is disabled: [Tracker.Eligible] returns every
-// endpoint and [Tracker.Record] is a no-op, so wiring one in stays a transparent
-// pass-through until eviction is configured.
+// On top of consecutive failures it implements Envoy-style outlier detection:
+// an endpoint whose success rate over the last interval falls well below its
+// peers' is ejected too, an endpoint ejected again soon after its last
+// ejection stays out longer each time, and MaxEjectionPercent caps how much of
+// the set may be out at once. Success rates are evaluated lazily on the next
+// Record or Eligible once an interval has passed, so a Tracker owns no
+// goroutine.
+//
+// A Tracker with neither Threshold nor SuccessRateStdevFactor set is disabled:
+// [Tracker.Eligible] returns every endpoint and [Tracker.Record] is a no-op, so
+// wiring one in stays a transparent pass-through until eviction is configured.
 type Tracker struct {
-	threshold int
-	ejectFor  time.Duration
+	cfg TrackerConfig
 
 	// now is the clock, injectable so tests can drive ejection windows
 	// deterministically. Defaults to time.Now.
 	now func() time.Time
 
-	mu     sync.Mutex
-	states map[string]*ejectState
+	mu        sync.Mutex
+	states    map[string]*ejectState
+	hosts     int       // size of the last candidate set seen by Eligible
+	evaluated time.Time // last success-rate evaluation
 }
 
 type ejectState struct {
 	failures  int
 	ejectedAt time.Time
+	ejectFor  time.Duration
 	halfOpen  bool
+
+	// ejections counts recent ejections and scales the next cool-down. It
+	// decays by one per Interval spent back in rotation since restoredAt.
+	ejections  int
+	restoredAt time.Time
+
+	// successes and requests count outcomes in the current interval.
+	successes, requests int
 }
 
-// TrackerConfig configures outlier ejection.
+// TrackerConfig configures outlier ejection. The success-rate fields mirror
+// Envoy's outlier detection; their defaults are Envoy's too.
 type TrackerConfig struct {
 	// Threshold is the number of consecutive failures that ejects an endpoint.
-	// 0 (or negative) disables eviction entirely.
+	// 0 (or negative) disables the consecutive-failure trigger.
 	Threshold int
 
 	// EjectFor is how long an endpoint stays evicted before a half-open trial
-	// request is allowed through. Defaults to 5s when unset (and eviction is
+	// request is allowed through. Each repeated ejection multiplies it (2x,
+	// 3x, ...) up to MaxEjectFor. Defaults to 5s when unset (and eviction is
 	// enabled), matching the resilience breaker default.
 	EjectFor time.Duration
+
+	// MaxEjectFor caps the growing ejection time. Defaults to 300s.
+	MaxEjectFor time.Duration
+
+	// MaxEjectionPercent caps the share (0-100] of known endpoints that may be
+	// ejected at the same time; one endpoint may always be ejected. 0 means
+	// no cap.
+	MaxEjectionPercent int
+
+	// Interval is how often success rates are evaluated, and how long an
+	// endpoint must stay in rotation for its ejection count to drop by one.
+	// Defaults to 10s.
+	Interval time.Duration
+
+	// SuccessRateStdevFactor enables success-rate ejection: an endpoint is
+	// ejected when its success rate over the last interval is below the mean
+	// of all endpoints minus this many standard deviations. Envoy uses 1.9. 0
+	// disables the trigger.
+	SuccessRateStdevFactor float64
+
+	// SuccessRateMinHosts is how many endpoints must each have reached
+	// SuccessRateRequestVolume in the interval for success rates to be
+	// compared at all. Defaults to 5.
+	SuccessRateMinHosts int
+
+	// SuccessRateRequestVolume is how many requests an endpoint needs in the
+	// interval to take part in the comparison. Defaults to 100.
+	SuccessRateRequestVolume int
 }
 
 // NewTracker builds a [Tracker] from cfg.
 func NewTracker(cfg TrackerConfig) *Tracker {
-	ejectFor := cfg.EjectFor
-	if ejectFor <= 0 {
-		ejectFor = 5 * time.Second
+	if cfg.EjectFor <= 0 {
+		cfg.EjectFor = 5 * time.Second
+	}
+	if cfg.MaxEjectFor <= 0 {
+		cfg.MaxEjectFor = 300 * time.Second
+	}
+	cfg.MaxEjectFor = max(cfg.MaxEjectFor, cfg.EjectFor)
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.SuccessRateMinHosts <= 0 {
+		cfg.SuccessRateMinHosts = 5
+	}
+	if cfg.SuccessRateRequestVolume <= 0 {
+		cfg.SuccessRateRequestVolume = 100
 	}
 	return &Tracker{
-		threshold: cfg.Threshold,
-		ejectFor:  ejectFor,
-		now:       time.Now,
-		states:    map[string]*ejectState{},
+		cfg:    cfg,
+		now:    time.Now,
+		states: map[string]*ejectState{},
 	}
 }
 
+// enabled reports whether any ejection trigger is configured.
+func (t *Tracker) enabled() bool {
+	return t != nil && (t.cfg.Threshold > 0 || t.cfg.SuccessRateStdevFactor > 0)
+}
+
 // Eligible returns the subset of eps that may currently receive traffic,
 // dropping endpoints that are ejected and still cooling down. An ejected
 // endpoint whose cool-down has elapsed is admitted (half-open trial) so it can
@@ -93,15 +158,18 @@ func NewTracker(cfg TrackerConfig) *Tracker {
 // black-holing all traffic is worse than probing a degraded instance. The
 // caller (Pool) applies its own final fallback too.
 func (t *Tracker) Eligible(eps []discovery.Endpoint) []discovery.Endpoint {
-	if t == nil || t.threshold <= 0 || len(eps) == 0 {
+	if !t.enabled() || len(eps) == 0 {
 		return eps
 	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	t.hosts = len(eps)
+	now := t.now()
+	t.evaluateLocked(now)
 	out := eps[:0:0]
 	for _, ep := range eps {
-		if t.admitLocked(ep.Addr) {
+		if t.admitLocked(ep.Addr, now) {
 			out = append(out, ep)
 		}
 	}
@@ -114,12 +182,12 @@ func (t *Tracker) Eligible(eps []discovery.Endpoint) []discovery.Endpoint {
 // admitLocked reports whether addr may receive a request, advancing an ejected
 // endpoint into the half-open trial state once its cool-down has elapsed. Caller
 // holds t.mu.
-func (t *Tracker) admitLocked(addr string) bool {
+func (t *Tracker) admitLocked(addr string, now time.Time) bool {
 	s := t.states[addr]
 	if s == nil || s.ejectedAt.IsZero() {
 		return true // never failed, or recovered
 	}
-	if t.now().Sub(s.ejectedAt) < t.ejectFor {
+	if now.Sub(s.ejectedAt) < s.ejectFor {
 		return false // ejected, cooling down
 	}
 	s.halfOpen = true // cool-down elapsed: admit a trial request
@@ -128,42 +196,132 @@ func (t *Tracker) admitLocked(addr string) bool {
 
 // Record folds a request outcome back into the tracker. success=true clears the
 // endpoint's failure state (or closes a half-open trial); success=false counts
-// toward eviction (or re-ejects a failed half-open trial). It is a no-op when
-// the tracker is disabled.
+// toward eviction (or re-ejects a failed half-open trial). Both count toward
+// the endpoint's success rate. It is a no-op when the tracker is disabled.
 func (t *Tracker) Record(addr string, success bool) {
-	if t == nil || t.threshold <= 0 {
+	if !t.enabled() {
 		return
 	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	now := t.now()
+	t.evaluateLocked(now)
 	s := t.states[addr]
 	if s == nil {
 		s = &ejectState{}
 		t.states[addr] = s
 	}
+	s.requests++
 	if success {
+		s.successes++
 		s.failures = 0
-		s.ejectedAt = time.Time{}
-		s.halfOpen = false
+		if !s.ejectedAt.IsZero() {
+			s.ejectedAt, s.halfOpen, s.restoredAt = time.Time{}, false, now
+		}
 		return
 	}
 	if s.halfOpen {
-		// Trial request failed: restart the cool-down window.
+		// Trial request failed: eject again, for longer.
 		s.halfOpen = false
-		s.ejectedAt = t.now()
+		t.ejectLocked(s, now)
 		return
 	}
 	s.failures++
-	if s.failures >= t.threshold {
-		s.ejectedAt = t.now()
+	if t.cfg.Threshold > 0 && s.failures >= t.cfg.Threshold && s.ejectedAt.IsZero() && t.canEjectLocked(now) {
+		t.ejectLocked(s, now)
+	}
+}
+
+// ejectLocked takes s out of rotation for EjectFor times its recent ejection
+// count, capped at MaxEjectFor.
+func (t *Tracker) ejectLocked(s *ejectState, now time.Time) {
+	if !s.restoredAt.IsZero() {
+		decay := int(now.Sub(s.restoredAt) / t.cfg.Interval)
+		s.ejections = max(0, s.ejections-decay)
+		s.restoredAt = time.Time{}
+	}
+	// Like Envoy, stop counting once the cap is reached, so that recovering
+	// from a long outage does not take longer than recovering from a short one.
+	capped := int((t.cfg.MaxEjectFor + t.cfg.EjectFor - 1) / t.cfg.EjectFor)
+	s.ejections = min(s.ejections+1, capped)
+	s.ejectedAt = now
+	s.ejectFor = min(t.cfg.EjectFor*time.Duration(s.ejections), t.cfg.MaxEjectFor)
+	s.failures = 0
+}
+
+// canEjectLocked reports whether one more endpoint may be ejected without
+// exceeding MaxEjectionPercent.
+func (t *Tracker) canEjectLocked(now time.Time) bool {
+	if t.cfg.MaxEjectionPercent <= 0 {
+		return true
+	}
+	ejected := 0
+	for _, s := range t.states {
+		if !s.ejectedAt.IsZero() && now.Sub(s.ejectedAt) < s.ejectFor {
+			ejected++
+		}
+	}
+	if ejected == 0 {
+		return true
+	}
+	hosts := max(t.hosts, len(t.states))
+	return (ejected+1)*100 <= t.cfg.MaxEjectionPercent*hosts
+}
+
+// evaluateLocked runs the success-rate comparison once per Interval and starts
+// a new interval.
+func (t *Tracker) evaluateLocked(now time.Time) {
+	if t.evaluated.IsZero() {
+		t.evaluated = now
+		return
+	}
+	if now.Sub(t.evaluated) < t.cfg.Interval {
+		return
+	}
+	t.evaluated = now
+	defer func() {
+		for _, s := range t.states {
+			s.successes, s.requests = 0, 0
+		}
+	}()
+	if t.cfg.SuccessRateStdevFactor <= 0 {
+		return
+	}
+
+	var rates []float64
+	for _, s := range t.states {
+		if s.requests >= t.cfg.SuccessRateRequestVolume {
+			rates = append(rates, float64(s.successes)/float64(s.requests))
+		}
+	}
+	if len(rates) < t.cfg.SuccessRateMinHosts {
+		return
+	}
+	var mean, variance float64
+	for _, r := range rates {
+		mean += r
+	}
+	mean /= float64(len(rates))
+	for _, r := range rates {
+		variance += (r - mean) * (r - mean)
+	}
+	limit := mean - t.cfg.SuccessRateStdevFactor*math.Sqrt(variance/float64(len(rates)))
+
+	for _, s := range t.states {
+		if s.requests < t.cfg.SuccessRateRequestVolume || !s.ejectedAt.IsZero() {
+			continue
+		}
+		if float64(s.successes)/float64(s.requests) < limit && t.canEjectLocked(now) {
+			t.ejectLocked(s, now)
+		}
 	}
 }
 
 // Ejected reports whether addr is currently evicted and still cooling down. It
 // is primarily a test/inspection helper; routing decisions go through Eligible.
 func (t *Tracker) Ejected(addr string) bool {
-	if t == nil || t.threshold <= 0 {
+	if !t.enabled() {
 		return false
 	}
 	t.mu.Lock()
@@ -172,5 +330,5 @@ func (t *Tracker) Ejected(addr string) bool {
 	if s == nil || s.ejectedAt.IsZero() {
 		return false
 	}
-	return t.now().Sub(s.ejectedAt) < t.ejectFor
+	return t.now().Sub(s.ejectedAt) < s.ejectFor
 }
@@ -35,6 +35,12 @@
 //
 // [Election] builds leader election on top of any Locker, so the same code elects
 // a leader whether it is backed by Redis, etcd, consul or the in-memory locker.
+//
+// Beyond the exclusive mutex, a backend may implement [RWLocker] (shared readers,
+// one writer) and [Semaphore] (at most N concurrent holders of a key). Every
+// bundled backend does; callers discover them with a type assertion. A context
+// carrying an owner ([WithOwner]) makes exclusive acquisitions re-entrant for
+// that owner.
 package lock
 
 import (
@@ -49,6 +55,14 @@ import (
 // caller — it has expired, been lost, or was already released.
 var ErrNotHeld = errors.New("lock: not held by caller")
 
+// ErrUnsupported is returned by a wrapper whose underlying Locker does not
+// implement the requested capability ([RWLocker] or [Semaphore]).
+var ErrUnsupported = errors.New("lock: operation not supported by backend")
+
+// ErrInvalidPermits is returned by [Semaphore] when the permit count is not
+// positive.
+var ErrInvalidPermits = errors.New("lock: permits must be positive")
+
 // ErrLockHeld is returned by [Locker.TryAcquire] via ok=false; it is also usable
 // by backends that need to surface contention as an error.
 var ErrLockHeld = errors.New("lock: already held")
@@ -95,6 +109,52 @@ type Locker interface {
 	Close() error
 }
 
+// RWLocker is implemented by a [Locker] whose backend can share a key between
+// readers. Any number of read holds may coexist; a write hold excludes readers
+// and other writers. Read/write keys live in their own namespace, so key "k"
+// taken through AcquireWrite does not conflict with Acquire("k").
+//
+// There is no writer preference: a steady stream of readers can delay a writer
+// indefinitely, just as contended Acquire calls carry no fairness guarantee.
+type RWLocker interface {
+	Locker
+
+	// AcquireRead blocks until a shared hold on key is granted, ctx is done, or
+	// a non-retriable error occurs.
+	AcquireRead(ctx context.Context, key string, opts ...Option) (Lock, error)
+
+	// TryAcquireRead attempts a shared hold once; ok is false while a writer
+	// holds key.
+	TryAcquireRead(ctx context.Context, key string, opts ...Option) (l Lock, ok bool, err error)
+
+	// AcquireWrite blocks until an exclusive hold on key is granted, ctx is
+	// done, or a non-retriable error occurs.
+	AcquireWrite(ctx context.Context, key string, opts ...Option) (Lock, error)
+
+	// TryAcquireWrite attempts an exclusive hold once; ok is false while any
+	// reader or writer holds key.
+	TryAcquireWrite(ctx context.Context, key string, opts ...Option) (l Lock, ok bool, err error)
+}
+
+// Semaphore is implemented by a [Locker] whose backend can hand out counted
+// permits. Each returned [Lock] is one leased permit with its own token, TTL,
+// renewal and Lost() channel; at most permits of them are held on key at once.
+// Every caller of a key must pass the same permits — the backend does not
+// store it — and an explicit [WithToken] must be unique per permit. Semaphore
+// keys live in their own namespace.
+type Semaphore interface {
+	Locker
+
+	// AcquirePermit blocks until one of permits slots on key is free, ctx is
+	// done, or a non-retriable error occurs. It returns [ErrInvalidPermits]
+	// when permits is not positive.
+	AcquirePermit(ctx context.Context, key string, permits int, opts ...Option) (Lock, error)
+
+	// TryAcquirePermit attempts to take a permit once; ok is false while all
+	// permits are held.
+	TryAcquirePermit(ctx context.Context, key string, permits int, opts ...Option) (l Lock, ok bool, err error)
+}
+
 // Options controls a single acquisition. A zero Options is normalized to the
 // defaults documented on each field.
 type Options struct {
@@ -142,6 +202,30 @@ func Apply(opts ...Option) Options {
 	return o
 }
 
+// Poll calls try until it acquires, fails, or ctx ends, sleeping
+// o.RetryInterval between contended attempts. It turns a TryAcquire-style
+// call into the blocking form, so a backend whose acquisition is a single
+// attempt implements Acquire, AcquireRead, AcquireWrite and AcquirePermit
+// with it.
+func Poll(ctx context.Context, o Options, try func() (Lock, bool, error)) (Lock, error) {
+	for {
+		l, ok, err := try()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return l, nil
+		}
+		t := time.NewTimer(o.RetryInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
 func (o *Options) normalize() {
 	if o.TTL <= 0 {
 		o.TTL = 30 * time.Second
@@ -22,19 +22,30 @@ import (
 	"time"
 )
 
+// Slot prefixes keep read/write and semaphore keys apart from mutex keys.
+const (
+	rwSlot  = "rw\x00"
+	semSlot = "sem\x00"
+)
+
 // MemoryLocker is a bundled, zero-dependency [Locker] whose scope is a single
 // process. It is intended for tests, local development and single-instance
 // deployments; for real multi-replica coordination use a Redis/etcd/consul
 // backed Locker. Prefer [NewMemoryLocker] so its internal maps are initialized.
+// It also implements [RWLocker] and [Semaphore].
 type MemoryLocker struct {
-	mu    sync.Mutex
-	locks map[string]*memoryHold
-	stop  chan struct{}
-	once  sync.Once
+	mu     sync.Mutex
+	locks  map[string]*memoryHold            // mutexes and write locks
+	shared map[string]map[string]*memoryHold // readers and permits, by token
+	stop   chan struct{}
+	once   sync.Once
+
+	reentrancy Reentrancy
 }
 
 type memoryHold struct {
 	token     string
+	shared    bool
 	expireAt  time.Time
 	lost      chan struct{}
 	lostOnce  sync.Once
@@ -48,8 +59,9 @@ func (h *memoryHold) stopRenewLoop() { h.renewOnce.Do(func() { close(h.renew) })
 // NewMemoryLocker returns a ready [MemoryLocker].
 func NewMemoryLocker() *MemoryLocker {
 	return &MemoryLocker{
-		locks: make(map[string]*memoryHold),
-		stop:  make(chan struct{}),
+		locks:  make(map[string]*memoryHold),
+		shared: make(map[string]map[string]*memoryHold),
+		stop:   make(chan struct{}),
 	}
 }
 
@@ -57,58 +69,80 @@ func (m *MemoryLocker) ensure() {
 	if m.locks == nil {
 		m.locks = make(map[string]*memoryHold)
 	}
+	if m.shared == nil {
+		m.shared = make(map[string]map[string]*memoryHold)
+	}
 	if m.stop == nil {
 		m.stop = make(chan struct{})
 	}
 }
 
 // TryAcquire implements [Locker].
-func (m *MemoryLocker) TryAcquire(_ context.Context, key string, opts ...Option) (Lock, bool, error) {
-	o := Apply(opts...)
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.ensure()
-
-	if h, ok := m.locks[key]; ok {
-		if time.Now().Before(h.expireAt) {
-			return nil, false, nil // still held by someone
-		}
-		// Expired: reclaim it, signalling loss to the previous holder.
-		m.dropLocked(key, h)
-	}
-
-	h := &memoryHold{
-		token:    o.Token,
-		expireAt: time.Now().Add(o.TTL),
-		lost:     make(chan struct{}),
-		renew:    make(chan struct{}),
+func (m *MemoryLocker) TryAcquire(ctx context.Context, key string, opts ...Option) (Lock, bool, error) {
+	if l, ok := m.reentrancy.Reenter(ctx, key); ok {
+		return l, true, nil
 	}
-	m.locks[key] = h
-	if o.RenewInterval > 0 {
-		go m.renewLoop(key, h, o.TTL, o.RenewInterval)
+	l, ok := m.tryExclusive(key, key, Apply(opts...))
+	if !ok {
+		return nil, false, nil
 	}
-	return &memoryLock{locker: m, key: key, hold: h}, true, nil
+	return m.reentrancy.Track(ctx, key, l), true, nil
 }
 
 // Acquire implements [Locker]: it polls TryAcquire until it succeeds or ctx ends.
 func (m *MemoryLocker) Acquire(ctx context.Context, key string, opts ...Option) (Lock, error) {
-	o := Apply(opts...)
-	for {
-		l, ok, err := m.TryAcquire(ctx, key, opts...)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			return l, nil
-		}
-		t := time.NewTimer(o.RetryInterval)
-		select {
-		case <-ctx.Done():
-			t.Stop()
-			return nil, ctx.Err()
-		case <-t.C:
-		}
+	return Poll(ctx, Apply(opts...), func() (Lock, bool, error) {
+		return m.TryAcquire(ctx, key, opts...)
+	})
+}
+
+// TryAcquireRead implements [RWLocker].
+func (m *MemoryLocker) TryAcquireRead(_ context.Context, key string, opts ...Option) (Lock, bool, error) {
+	l, ok := m.tryShared(key, rwSlot+key, 0, Apply(opts...))
+	return l, ok, nil
+}
+
+// AcquireRead implements [RWLocker].
+func (m *MemoryLocker) AcquireRead(ctx context.Context, key string, opts ...Option) (Lock, error) {
+	return Poll(ctx, Apply(opts...), func() (Lock, bool, error) {
+		return m.TryAcquireRead(ctx, key, opts...)
+	})
+}
+
+// TryAcquireWrite implements [RWLocker].
+func (m *MemoryLocker) TryAcquireWrite(ctx context.Context, key string, opts ...Option) (Lock, bool, error) {
+	slot := rwSlot + key
+	if l, ok := m.reentrancy.Reenter(ctx, slot); ok {
+		return l, true, nil
 	}
+	l, ok := m.tryExclusive(key, slot, Apply(opts...))
+	if !ok {
+		return nil, false, nil
+	}
+	return m.reentrancy.Track(ctx, slot, l), true, nil
+}
+
+// AcquireWrite implements [RWLocker].
+func (m *MemoryLocker) AcquireWrite(ctx context.Context, key string, opts ...Option) (Lock, error) {
+	return Poll(ctx, Apply(opts...), func() (Lock, bool, error) {
+		return m.TryAcquireWrite(ctx, key, opts...)
+	})
+}
+
+// TryAcquirePermit implements [Semaphore].
+func (m *MemoryLocker) TryAcquirePermit(_ context.Context, key string, permits int, opts ...Option) (Lock, bool, error) {
+	if permits <= 0 {
+		return nil, false, ErrInvalidPermits
+	}
+	l, ok := m.tryShared(key, semSlot+key, permits, Apply(opts...))
+	return l, ok, nil
+}
+
+// AcquirePermit implements [Semaphore].
+func (m *MemoryLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...Option) (Lock, error) {
+	return Poll(ctx, Apply(opts...), func() (Lock, bool, error) {
+		return m.TryAcquirePermit(ctx, key, permits, opts...)
+	})
 }
 
 // Close implements [Locker]: it stops all renew loops. Held handles remain valid
@@ -123,8 +157,96 @@ func (m *MemoryLocker) Close() error {
 	return nil
 }
 
+// tryExclusive grants slot to a single holder when no live hold, exclusive or
+// shared, remains on it.
+func (m *MemoryLocker) tryExclusive(key, slot string, o Options) (Lock, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ensure()
+
+	now := time.Now()
+	if h, ok := m.locks[slot]; ok {
+		if now.Before(h.expireAt) {
+			return nil, false // still held by someone
+		}
+		// Expired: reclaim it, signalling loss to the previous holder.
+		m.dropLocked(slot, h)
+	}
+	if m.pruneLocked(slot, now) > 0 {
+		return nil, false // readers still hold it
+	}
+	return m.holdLocked(key, slot, false, o), true
+}
+
+// tryShared grants one of limit shared holds on slot, or an unlimited number
+// when limit is zero, provided no live exclusive hold remains on it.
+func (m *MemoryLocker) tryShared(key, slot string, limit int, o Options) (Lock, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ensure()
+
+	now := time.Now()
+	if h, ok := m.locks[slot]; ok {
+		if now.Before(h.expireAt) {
+			return nil, false // a writer holds it
+		}
+		m.dropLocked(slot, h)
+	}
+	if n := m.pruneLocked(slot, now); limit > 0 && n >= limit {
+		return nil, false
+	}
+	return m.holdLocked(key, slot, true, o), true
+}
+
+// pruneLocked drops the expired shared holds on slot and returns how many
+// remain. Caller holds m.mu.
+func (m *MemoryLocker) pruneLocked(slot string, now time.Time) int {
+	set := m.shared[slot]
+	for _, h := range set {
+		if !now.Before(h.expireAt) {
+			m.dropLocked(slot, h)
+		}
+	}
+	return len(m.shared[slot])
+}
+
+// holdLocked records a new hold on slot and starts its renew loop. Caller
+// holds m.mu.
+func (m *MemoryLocker) holdLocked(key, slot string, shared bool, o Options) *memoryLock {
+	h := &memoryHold{
+		token:    o.Token,
+		shared:   shared,
+		expireAt: time.Now().Add(o.TTL),
+		lost:     make(chan struct{}),
+		renew:    make(chan struct{}),
+	}
+	if shared {
+		set := m.shared[slot]
+		if set == nil {
+			set = make(map[string]*memoryHold)
+			m.shared[slot] = set
+		}
+		set[h.token] = h
+	} else {
+		m.locks[slot] = h
+	}
+	if o.RenewInterval > 0 {
+		go m.renewLoop(slot, h, o.TTL, o.RenewInterval)
+	}
+	return &memoryLock{locker: m, key: key, slot: slot, hold: h}
+}
+
+// currentLocked reports whether h is still the live hold it was granted as.
+// Caller holds m.mu.
+func (m *MemoryLocker) currentLocked(slot string, h *memoryHold) bool {
+	if h.shared {
+		return m.shared[slot][h.token] == h
+	}
+	return m.locks[slot] == h
+}
+
 // renewLoop extends the lease while the hold is active.
-func (m *MemoryLocker) renewLoop(key string, h *memoryHold, ttl, every time.Duration) {
+func (m *MemoryLocker) renewLoop(slot string, h *memoryHold, ttl, every time.Duration) {
 	ticker := time.NewTicker(every)
 	defer ticker.Stop()
 	for {
@@ -135,38 +257,54 @@ func (m *MemoryLocker) renewLoop(key string, h *memoryHold, ttl, every time.Dura
 			return
 		case <-ticker.C:
 			m.mu.Lock()
-			cur, ok := m.locks[key]
-			if !ok || cur != h {
+			if !m.currentLocked(slot, h) {
 				m.mu.Unlock()
 				return
 			}
-			cur.expireAt = time.Now().Add(ttl)
+			h.expireAt = time.Now().Add(ttl)
 			m.mu.Unlock()
 		}
 	}
 }
 
-// dropLocked removes a hold and signals its loss. Caller holds m.mu.
-func (m *MemoryLocker) dropLocked(key string, h *memoryHold) {
-	delete(m.locks, key)
+// removeLocked deletes a hold and stops its renew loop. Caller holds m.mu.
+func (m *MemoryLocker) removeLocked(slot string, h *memoryHold) {
+	if h.shared {
+		delete(m.shared[slot], h.token)
+		if len(m.shared[slot]) == 0 {
+			delete(m.shared, slot)
+		}
+	} else {
+		delete(m.locks, slot)
+	}
 	h.stopRenewLoop()
+}
+
+// dropLocked removes a hold and signals its loss. Caller holds m.mu.
+func (m *MemoryLocker) dropLocked(slot string, h *memoryHold) {
+	m.removeLocked(slot, h)
 	h.markLost()
 }
 
-// unlock releases key if still owned by token. It does not signal loss (voluntary
+// unlock releases h if it is still live. It does not signal loss (voluntary
 // release); the handle closes its own lost channel so [Lock.Lost] also fires.
-func (m *MemoryLocker) unlock(key, token string) error {
+func (m *MemoryLocker) unlock(slot string, h *memoryHold) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	h, ok := m.locks[key]
+	if h.shared {
+		if m.currentLocked(slot, h) {
+			m.removeLocked(slot, h)
+		}
+		return nil
+	}
+	cur, ok := m.locks[slot]
 	if !ok {
 		return nil // already released or expired
 	}
-	if h.token != token {
+	if cur.token != h.token {
 		return ErrNotHeld // taken over by another owner
 	}
-	delete(m.locks, key)
-	h.stopRenewLoop()
+	m.removeLocked(slot, cur)
 	return nil
 }
 
@@ -174,6 +312,7 @@ func (m *MemoryLocker) unlock(key, token string) error {
 type memoryLock struct {
 	locker *MemoryLocker
 	key    string
+	slot   string
 	hold   *memoryHold
 	once   sync.Once
 }
@@ -185,8 +324,13 @@ func (l *memoryLock) Lost() <-chan struct{} { return l.hold.lost }
 func (l *memoryLock) Unlock(_ context.Context) error {
 	var err error
 	l.once.Do(func() {
-		err = l.locker.unlock(l.key, l.hold.token)
+		err = l.locker.unlock(l.slot, l.hold)
 		l.hold.markLost()
 	})
 	return err
 }
+
+var (
+	_ RWLocker  = (*MemoryLocker)(nil)
+	_ Semaphore = (*MemoryLocker)(nil)
+)
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lock
+
+import "context"
+
+// Observer instruments the acquisitions made through a [Locker] wrapped by
+// [Observe], e.g. a starter that opens otel spans. The package stays free of
+// any tracing dependency; the observer owns that.
+type Observer interface {
+	// Begin is called before an acquisition of key. op names it after the
+	// method: "lock.acquire", "lock.try_acquire", "lock.acquire_read",
+	// "lock.try_acquire_read", "lock.acquire_write", "lock.try_acquire_write",
+	// "lock.acquire_permit" or "lock.try_acquire_permit". permits is the
+	// permit count of a semaphore operation and 0 otherwise. The returned
+	// context is passed to the wrapped locker, and end is called with whether
+	// the lock was acquired and the error, if any.
+	Begin(ctx context.Context, op, key string, permits int) (context.Context, func(acquired bool, err error))
+}
+
+// ObservedLocker is a [Locker] that reports every acquisition to an
+// [Observer]. It implements [RWLocker] and [Semaphore] too, forwarding to the
+// wrapped locker and failing with [ErrUnsupported] when it lacks them.
+type ObservedLocker struct {
+	inner    Locker
+	observer Observer
+}
+
+// Observe wraps inner so every acquisition reports to o. Backend starters
+// use it for their tracing wrapper.
+func Observe(inner Locker, o Observer) *ObservedLocker {
+	return &ObservedLocker{inner: inner, observer: o}
+}
+
+var (
+	_ RWLocker  = (*ObservedLocker)(nil)
+	_ Semaphore = (*ObservedLocker)(nil)
+)
+
+// Acquire implements [Locker].
+func (l *ObservedLocker) Acquire(ctx context.Context, key string, opts ...Option) (Lock, error) {
+	ctx, end := l.observer.Begin(ctx, "lock.acquire", key, 0)
+	held, err := l.inner.Acquire(ctx, key, opts...)
+	end(err == nil, err)
+	return held, err
+}
+
+// TryAcquire implements [Locker].
+func (l *ObservedLocker) TryAcquire(ctx context.Context, key string, opts ...Option) (Lock, bool, error) {
+	ctx, end := l.observer.Begin(ctx, "lock.try_acquire", key, 0)
+	held, ok, err := l.inner.TryAcquire(ctx, key, opts...)
+	end(ok, err)
+	return held, ok, err
+}
+
+// Close implements [Locker] by closing the wrapped locker.
+func (l *ObservedLocker) Close() error { return l.inner.Close() }
+
+// AcquireRead implements [RWLocker].
+func (l *ObservedLocker) AcquireRead(ctx context.Context, key string, opts ...Option) (Lock, error) {
+	rw, ok := l.inner.(RWLocker)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.acquire_read", key, 0)
+	held, err := rw.AcquireRead(ctx, key, opts...)
+	end(err == nil, err)
+	return held, err
+}
+
+// TryAcquireRead implements [RWLocker].
+func (l *ObservedLocker) TryAcquireRead(ctx context.Context, key string, opts ...Option) (Lock, bool, error) {
+	rw, ok := l.inner.(RWLocker)
+	if !ok {
+		return nil, false, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.try_acquire_read", key, 0)
+	held, ok, err := rw.TryAcquireRead(ctx, key, opts...)
+	end(ok, err)
+	return held, ok, err
+}
+
+// AcquireWrite implements [RWLocker].
+func (l *ObservedLocker) AcquireWrite(ctx context.Context, key string, opts ...Option) (Lock, error) {
+	rw, ok := l.inner.(RWLocker)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.acquire_write", key, 0)
+	held, err := rw.AcquireWrite(ctx, key, opts...)
+	end(err == nil, err)
+	return held, err
+}
+
+// TryAcquireWrite implements [RWLocker].
+func (l *ObservedLocker) TryAcquireWrite(ctx context.Context, key string, opts ...Option) (Lock, bool, error) {
+	rw, ok := l.inner.(RWLocker)
+	if !ok {
+		return nil, false, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.try_acquire_write", key, 0)
+	held, ok, err := rw.TryAcquireWrite(ctx, key, opts...)
+	end(ok, err)
+	return held, ok, err
+}
+
+// AcquirePermit implements [Semaphore].
+func (l *ObservedLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...Option) (Lock, error) {
+	sem, ok := l.inner.(Semaphore)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.acquire_permit", key, permits)
+	held, err := sem.AcquirePermit(ctx, key, permits, opts...)
+	end(err == nil, err)
+	return held, err
+}
+
+// TryAcquirePermit implements [Semaphore].
+func (l *ObservedLocker) TryAcquirePermit(ctx context.Context, key string, permits int, opts ...Option) (Lock, bool, error) {
+	sem, ok := l.inner.(Semaphore)
+	if !ok {
+		return nil, false, ErrUnsupported
+	}
+	ctx, end := l.observer.Begin(ctx, "lock.try_acquire_permit", key, permits)
+	held, ok, err := sem.TryAcquirePermit(ctx, key, permits, opts...)
+	end(ok, err)
+	return held, ok, err
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lock_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// recordingObserver logs each observed acquisition as "op key permits acquired".
+type recordingObserver struct{ got []string }
+
+func (o *recordingObserver) Begin(ctx context.Context, op, key string, permits int) (context.Context, func(bool, error)) {
+	return ctx, func(acquired bool, err error) {
+		o.got = append(o.got, fmt.Sprintf("%s %s %d %t", op, key, permits, acquired))
+	}
+}
+
+func TestObserveReportsEveryAcquisition(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	o := &recordingObserver{}
+	l := lock.Observe(m, o)
+	ctx := context.Background()
+	noRenew := lock.WithRenewInterval(-1)
+
+	w, err := l.AcquireWrite(ctx, "k", noRenew)
+	assert.Error(t, err).Nil()
+	_, ok, err := l.TryAcquireRead(ctx, "k", noRenew)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+	assert.Error(t, w.Unlock(ctx)).Nil()
+
+	p, err := l.AcquirePermit(ctx, "s", 1, noRenew)
+	assert.Error(t, err).Nil()
+	_, ok, _ = l.TryAcquirePermit(ctx, "s", 1, noRenew)
+	assert.That(t, ok).False()
+	assert.Error(t, p.Unlock(ctx)).Nil()
+
+	assert.That(t, o.got).Equal([]string{
+		"lock.acquire_write k 0 true",
+		"lock.try_acquire_read k 0 false",
+		"lock.acquire_permit s 1 true",
+		"lock.try_acquire_permit s 1 false",
+	})
+}
+
+// plainLocker hides the shared-hold capabilities of the memory locker.
+type plainLocker struct{ lock.Locker }
+
+func TestObserveUnsupportedCapability(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	l := lock.Observe(plainLocker{m}, &recordingObserver{})
+	_, err := l.AcquireRead(context.Background(), "k")
+	assert.Error(t, err).Is(lock.ErrUnsupported)
+	_, _, err = l.TryAcquirePermit(context.Background(), "k", 2)
+	assert.Error(t, err).Is(lock.ErrUnsupported)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lock
+
+import (
+	"context"
+	"sync"
+)
+
+type ownerKey struct{}
+
+// WithOwner returns a copy of ctx carrying owner. Exclusive acquisitions
+// (Acquire, TryAcquire, AcquireWrite, TryAcquireWrite) made with such a context
+// are re-entrant: while owner holds a key, acquiring it again succeeds at once
+// and returns a handle sharing the original token and Lost() channel. The lock
+// is released when every handle has been unlocked.
+//
+// Re-entry is tracked by the Locker in the calling process, which is where a
+// context can flow; two processes using the same owner string still contend.
+// Read holds and semaphore permits are never re-entrant.
+func WithOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+// OwnerFrom returns the owner carried by ctx, if any.
+func OwnerFrom(ctx context.Context) (string, bool) {
+	owner, ok := ctx.Value(ownerKey{}).(string)
+	return owner, ok && owner != ""
+}
+
+// Reentrancy is the bookkeeping a backend embeds to honour [WithOwner]. Before
+// contacting the backend an acquisition calls Reenter; after a successful
+// acquisition it passes the handle through Track. The zero value is ready to
+// use, and all methods are safe for concurrent use.
+//
+// Keys are opaque to Reentrancy: a backend with several namespaces (mutex,
+// write lock) prefixes them so they do not collide.
+type Reentrancy struct {
+	mu    sync.Mutex
+	holds map[reentryKey]*reentry
+}
+
+type reentryKey struct{ key, owner string }
+
+type reentry struct {
+	lock  Lock
+	count int
+}
+
+// Reenter returns a new handle on the lock ctx's owner already holds on key.
+// ok is false when ctx carries no owner, the owner holds nothing on key, or
+// the held lock has been lost.
+func (r *Reentrancy) Reenter(ctx context.Context, key string) (Lock, bool) {
+	owner, ok := OwnerFrom(ctx)
+	if !ok {
+		return nil, false
+	}
+	k := reentryKey{key, owner}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.holds[k]
+	if !ok {
+		return nil, false
+	}
+	select {
+	case <-e.lock.Lost():
+		delete(r.holds, k) // lost lease: the owner must acquire afresh
+		return nil, false
+	default:
+	}
+	e.count++
+	return &reentrantLock{r: r, k: k, e: e}, true
+}
+
+// Track records l as held by ctx's owner on key and returns the handle to give
+// the caller. Without an owner on ctx it returns l unchanged.
+func (r *Reentrancy) Track(ctx context.Context, key string, l Lock) Lock {
+	owner, ok := OwnerFrom(ctx)
+	if !ok {
+		return l
+	}
+	k := reentryKey{key, owner}
+	e := &reentry{lock: l, count: 1}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.holds == nil {
+		r.holds = make(map[reentryKey]*reentry)
+	}
+	r.holds[k] = e
+	return &reentrantLock{r: r, k: k, e: e}
+}
+
+// release drops one hold on e and reports whether it was the last.
+func (r *Reentrancy) release(k reentryKey, e *reentry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.count--
+	if e.count > 0 {
+		return false
+	}
+	if r.holds[k] == e {
+		delete(r.holds, k)
+	}
+	return true
+}
+
+// reentrantLock is one caller's handle on a shared re-entrant hold.
+type reentrantLock struct {
+	r    *Reentrancy
+	k    reentryKey
+	e    *reentry
+	once sync.Once
+}
+
+func (l *reentrantLock) Key() string           { return l.e.lock.Key() }
+func (l *reentrantLock) Token() string         { return l.e.lock.Token() }
+func (l *reentrantLock) Lost() <-chan struct{} { return l.e.lock.Lost() }
+
+// Unlock releases this handle; the underlying lock is unlocked with the last.
+func (l *reentrantLock) Unlock(ctx context.Context) error {
+	var err error
+	l.once.Do(func() {
+		if l.r.release(l.k, l.e) {
+			err = l.e.lock.Unlock(ctx)
+		}
+	})
+	return err
+}
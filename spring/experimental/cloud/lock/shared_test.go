@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lock_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestMemoryReadWrite(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	ctx := context.Background()
+	noRenew := lock.WithRenewInterval(-1)
+
+	r1, ok, err := m.TryAcquireRead(ctx, "k", noRenew)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	r2, ok, _ := m.TryAcquireRead(ctx, "k", noRenew)
+	assert.That(t, ok).True() // readers share
+	assert.That(t, r1.Token()).NotEqual(r2.Token())
+
+	_, ok, _ = m.TryAcquireWrite(ctx, "k", noRenew)
+	assert.That(t, ok).False() // readers exclude the writer
+
+	// The read/write namespace is separate from plain mutexes.
+	mu, ok, _ := m.TryAcquire(ctx, "k", noRenew)
+	assert.That(t, ok).True()
+	_ = mu.Unlock(ctx)
+
+	_ = r1.Unlock(ctx)
+	_ = r2.Unlock(ctx)
+	w, ok, _ := m.TryAcquireWrite(ctx, "k", noRenew)
+	assert.That(t, ok).True()
+	_, ok, _ = m.TryAcquireRead(ctx, "k", noRenew)
+	assert.That(t, ok).False() // the writer excludes readers
+	_, ok, _ = m.TryAcquireWrite(ctx, "k", noRenew)
+	assert.That(t, ok).False()
+	assert.Error(t, w.Unlock(ctx)).Nil()
+
+	_, ok, _ = m.TryAcquireRead(ctx, "k", noRenew)
+	assert.That(t, ok).True()
+}
+
+func TestMemoryReaderExpiryFreesWriter(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	ctx := context.Background()
+
+	r, _, _ := m.TryAcquireRead(ctx, "k", lock.WithTTL(30*time.Millisecond), lock.WithRenewInterval(-1))
+	time.Sleep(60 * time.Millisecond)
+	w, ok, _ := m.TryAcquireWrite(ctx, "k", lock.WithRenewInterval(-1))
+	assert.That(t, ok).True()
+	select {
+	case <-r.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("expired reader not notified via Lost()")
+	}
+	_ = w.Unlock(ctx)
+}
+
+func TestMemorySemaphore(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	ctx := context.Background()
+
+	_, _, err := m.TryAcquirePermit(ctx, "exports", 0)
+	assert.Error(t, err).Is(lock.ErrInvalidPermits)
+
+	var held []lock.Lock
+	for range 3 {
+		l, ok, err := m.TryAcquirePermit(ctx, "exports", 3, lock.WithRenewInterval(-1))
+		assert.Error(t, err).Nil()
+		assert.That(t, ok).True()
+		held = append(held, l)
+	}
+	_, ok, _ := m.TryAcquirePermit(ctx, "exports", 3, lock.WithRenewInterval(-1))
+	assert.That(t, ok).False() // all permits taken
+
+	assert.Error(t, held[1].Unlock(ctx)).Nil()
+	l, ok, _ := m.TryAcquirePermit(ctx, "exports", 3, lock.WithRenewInterval(-1))
+	assert.That(t, ok).True() // a released permit is reusable
+	_ = l.Unlock(ctx)
+}
+
+func TestMemorySemaphoreBoundsConcurrency(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	ctx := context.Background()
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 12 {
+		wg.Go(func() {
+			l, err := m.AcquirePermit(ctx, "exports", 5, lock.WithRetryInterval(time.Millisecond))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			_ = l.Unlock(ctx)
+		})
+	}
+	wg.Wait()
+	assert.That(t, peak.Load() <= 5).True()
+}
+
+func TestMemoryReentrant(t *testing.T) {
+	m := lock.NewMemoryLocker()
+	defer m.Close()
+	ctx := lock.WithOwner(context.Background(), "export-42")
+	noRenew := lock.WithRenewInterval(-1)
+
+	outer, ok, _ := m.TryAcquire(ctx, "k", noRenew)
+	assert.That(t, ok).True()
+	inner, ok, _ := m.TryAcquire(ctx, "k", noRenew)
+	assert.That(t, ok).True() // same owner re-enters
+	assert.That(t, inner.Token()).Equal(outer.Token())
+
+	_, ok, _ = m.TryAcquire(lock.WithOwner(context.Background(), "other"), "k", noRenew)
+	assert.That(t, ok).False()
+	_, ok, _ = m.TryAcquire(context.Background(), "k", noRenew)
+	assert.That(t, ok).False() // no owner, no re-entry
+
+	assert.Error(t, inner.Unlock(ctx)).Nil()
+	_, ok, _ = m.TryAcquire(context.Background(), "k", noRenew)
+	assert.That(t, ok).False() // the outer hold remains
+
+	assert.Error(t, outer.Unlock(ctx)).Nil()
+	select {
+	case <-inner.Lost():
+	default:
+		t.Fatal("Lost() not closed after the last Unlock")
+	}
+	l, ok, _ := m.TryAcquire(context.Background(), "k", noRenew)
+	assert.That(t, ok).True()
+	_ = l.Unlock(ctx)
+
+	// Write locks are re-entrant too; read holds are not tracked.
+	w, _, _ := m.TryAcquireWrite(ctx, "k", noRenew)
+	w2, ok, _ := m.TryAcquireWrite(ctx, "k", noRenew)
+	assert.That(t, ok).True()
+	_ = w2.Unlock(ctx)
+	_ = w.Unlock(ctx)
+}
@@ -0,0 +1,500 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"maps"
+	"sync"
+	"time"
+
+	"go-spring.org/stdlib/errutil"
+)
+
+// ErrBinderClosed is returned by a [MemoryBinder] used after Close.
+var ErrBinderClosed = errors.New("messaging: binder closed")
+
+func init() {
+	b := NewMemoryBinder(MemoryBinderConfig{})
+	b.shared = true
+	RegisterBinder("memory", b)
+}
+
+// MemoryBinderConfig configures a [MemoryBinder]. The tags let it be bound
+// from configuration, e.g. gs.TagArg("${spring.messaging.memory}").
+type MemoryBinderConfig struct {
+	// Buffer is the queue capacity of each consumer. Publish blocks while
+	// the chosen consumer's queue is full. Default 256.
+	Buffer int `value:"${buffer:=256}"`
+
+	// MaxRedeliveries is how many times a message is redelivered after its
+	// handler fails before it is dropped. Zero means 3; negative disables
+	// redelivery.
+	MaxRedeliveries int `value:"${max-redeliveries:=3}"`
+
+	// RedeliveryDelay is the pause before each redelivery. Default 0.
+	RedeliveryDelay time.Duration `value:"${redelivery-delay:=0s}"`
+}
+
+// MemoryBinder is an in-process [Binder] for tests and single-process
+// deployments. Destinations are topics created on first use:
+//
+//   - every consumer group of a topic receives each message, and a message
+//     is handled by one consumer of the group (competing consumers);
+//   - an empty group means broadcast: the subscriber gets its own group;
+//   - messages with the same Key go to the same consumer of a group and are
+//     handled in publish order; unkeyed messages are spread round-robin;
+//   - a failed handler is retried in place, up to MaxRedeliveries, before
+//     the message is dropped.
+//
+// A group exists while it has consumers; messages published to a topic with
+// no groups are recorded but not delivered. When a consumer closes, its
+// queued messages move to the remaining consumers of its group, which may
+// reorder them relative to newer messages for the same key.
+//
+// Published, Dropped and WaitIdle make consumer code testable without a
+// broker. The binder registered as "memory" is a process-wide instance with
+// default settings, shared by every caller of GetBinder; its Close is a
+// no-op so one caller cannot break it for the others. To tune the settings,
+// bind a dedicated instance from spring.messaging.memory.* (see
+// starter-messaging-memory).
+type MemoryBinder struct {
+	cfg    MemoryBinderConfig
+	shared bool // the registered instance: Close does nothing
+
+	mu        sync.Mutex
+	closed    bool
+	topics    map[string][]*memoryGroup // topic -> groups
+	published map[string][]Message
+	dropped   map[string][]Message
+	pending   int // messages enqueued but not yet handled or dropped
+	waiters   []chan struct{}
+}
+
+// NewMemoryBinder returns an empty [MemoryBinder], applying defaults.
+func NewMemoryBinder(cfg MemoryBinderConfig) *MemoryBinder {
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 256
+	}
+	if cfg.MaxRedeliveries == 0 {
+		cfg.MaxRedeliveries = 3
+	}
+	return &MemoryBinder{
+		cfg:       cfg,
+		topics:    make(map[string][]*memoryGroup),
+		published: make(map[string][]Message),
+		dropped:   make(map[string][]Message),
+	}
+}
+
+var _ Binder = (*MemoryBinder)(nil)
+
+// NewPublisher returns a Publisher bound to the topic destination.
+func (b *MemoryBinder) NewPublisher(_ context.Context, destination string) (Publisher, error) {
+	return &memoryPublisher{b: b, topic: destination}, nil
+}
+
+// NewSubscriber returns a Subscriber bound to the topic source. Subscribers
+// sharing a non-empty group compete for messages.
+func (b *MemoryBinder) NewSubscriber(_ context.Context, source, group string) (Subscriber, error) {
+	return &memorySubscriber{b: b, topic: source, group: group}, nil
+}
+
+// Close stops every consumer and makes further use fail with
+// [ErrBinderClosed]. Messages still queued are dropped. Close on the
+// registered "memory" binder does nothing.
+func (b *MemoryBinder) Close() error {
+	if b.shared {
+		return nil
+	}
+	b.mu.Lock()
+	b.closed = true
+	var consumers []*memoryConsumer
+	for _, groups := range b.topics {
+		for _, g := range groups {
+			consumers = append(consumers, g.consumers...)
+		}
+	}
+	b.mu.Unlock()
+	for _, c := range consumers {
+		c.close()
+	}
+	return nil
+}
+
+// Published returns copies of the messages published to destination so far,
+// in publish order.
+func (b *MemoryBinder) Published(destination string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return cloneMessages(b.published[destination])
+}
+
+// Dropped returns copies of the messages published to destination that a
+// consumer group gave up on after exhausting redeliveries, or that lost
+// their last consumer before being handled.
+func (b *MemoryBinder) Dropped(destination string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return cloneMessages(b.dropped[destination])
+}
+
+// Reset forgets the recorded published and dropped messages.
+func (b *MemoryBinder) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.published)
+	clear(b.dropped)
+}
+
+// WaitIdle blocks until every message published so far has been handled or
+// dropped by all the groups it was delivered to, or until ctx is done.
+func (b *MemoryBinder) WaitIdle(ctx context.Context) error {
+	b.mu.Lock()
+	if b.pending == 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	b.waiters = append(b.waiters, ch)
+	b.mu.Unlock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func cloneMessages(msgs []Message) []Message {
+	out := make([]Message, len(msgs))
+	for i, m := range msgs {
+		out[i] = m
+		out[i].Headers = maps.Clone(m.Headers)
+	}
+	return out
+}
+
+// publish records msg and enqueues a copy for every group of topic.
+func (b *MemoryBinder) publish(ctx context.Context, topic string, msg *Message) error {
+	m := *msg
+	m.Headers = maps.Clone(msg.Headers)
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBinderClosed
+	}
+	b.published[topic] = append(b.published[topic], m)
+	targets := make([]*memoryConsumer, 0, len(b.topics[topic]))
+	for _, g := range b.topics[topic] {
+		targets = append(targets, g.pick(m.Key))
+	}
+	b.pending += len(targets)
+	b.mu.Unlock()
+
+	for i, c := range targets {
+		cp := m
+		cp.Headers = maps.Clone(m.Headers)
+		if err := c.send(ctx, &cp); err != nil {
+			for range targets[i:] {
+				b.finish()
+			}
+			return errutil.Explain(err, "messaging: publish to %q", topic)
+		}
+	}
+	return nil
+}
+
+// redispatch hands msg to another consumer of g, or drops it when g has none
+// left.
+func (b *MemoryBinder) redispatch(g *memoryGroup, msg *Message) {
+	b.mu.Lock()
+	if len(g.consumers) == 0 || b.closed {
+		b.mu.Unlock()
+		b.drop(g.topic, msg)
+		return
+	}
+	c := g.pick(msg.Key)
+	b.mu.Unlock()
+	_ = c.send(context.Background(), msg)
+}
+
+// drop records msg as dropped and completes it.
+func (b *MemoryBinder) drop(topic string, msg *Message) {
+	b.mu.Lock()
+	b.dropped[topic] = append(b.dropped[topic], *msg)
+	b.mu.Unlock()
+	b.finish()
+}
+
+// finish marks one enqueued message as done and wakes WaitIdle callers once
+// nothing is pending.
+func (b *MemoryBinder) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending--; b.pending == 0 {
+		for _, ch := range b.waiters {
+			close(ch)
+		}
+		b.waiters = nil
+	}
+}
+
+// subscribe adds a consumer running h to the group of topic.
+func (b *MemoryBinder) subscribe(ctx context.Context, topic, group string, h Handler) (*memoryConsumer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrBinderClosed
+	}
+	var g *memoryGroup
+	if group != "" {
+		for _, x := range b.topics[topic] {
+			if x.name == group {
+				g = x
+				break
+			}
+		}
+	}
+	if g == nil {
+		g = &memoryGroup{topic: topic, name: group}
+		b.topics[topic] = append(b.topics[topic], g)
+	}
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c := &memoryConsumer{
+		b:       b,
+		g:       g,
+		handler: h,
+		ctx:     ctx,
+		cancel:  cancel,
+		queue:   make(chan *Message, b.cfg.Buffer),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	g.consumers = append(g.consumers, c)
+	go c.run()
+	return c, nil
+}
+
+// unsubscribe removes c from its group, and the group from its topic once it
+// has no consumers left.
+func (b *MemoryBinder) unsubscribe(c *memoryConsumer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	g := c.g
+	for i, x := range g.consumers {
+		if x == c {
+			g.consumers = append(g.consumers[:i], g.consumers[i+1:]...)
+			break
+		}
+	}
+	if len(g.consumers) > 0 {
+		return
+	}
+	groups := b.topics[g.topic]
+	for i, x := range groups {
+		if x == g {
+			b.topics[g.topic] = append(groups[:i], groups[i+1:]...)
+			break
+		}
+	}
+	if len(b.topics[g.topic]) == 0 {
+		delete(b.topics, g.topic)
+	}
+}
+
+// memoryGroup is the set of consumers competing for one topic's messages.
+// Its fields are guarded by the binder's mutex.
+type memoryGroup struct {
+	topic     string
+	name      string
+	consumers []*memoryConsumer
+	next      int
+}
+
+// pick chooses the consumer for a message: by key hash when keyed, so a key
+// sticks to one consumer, and round-robin otherwise.
+func (g *memoryGroup) pick(key string) *memoryConsumer {
+	n := len(g.consumers)
+	if key != "" {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(key))
+		return g.consumers[h.Sum32()%uint32(n)]
+	}
+	g.next = (g.next + 1) % n
+	return g.consumers[g.next]
+}
+
+// memoryConsumer handles the messages routed to one subscriber, one at a
+// time and in arrival order.
+type memoryConsumer struct {
+	b       *MemoryBinder
+	g       *memoryGroup
+	handler Handler
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	queue   chan *Message
+	done    chan struct{} // closed when the consumer starts closing
+	stopped chan struct{} // closed when run returns
+
+	mu        sync.RWMutex // held for reading while sending to queue
+	closed    bool
+	closeOnce sync.Once
+}
+
+// send enqueues msg, blocking while the queue is full. If the consumer is
+// closing, msg goes to another consumer of the group instead.
+func (c *memoryConsumer) send(ctx context.Context, msg *Message) error {
+	c.mu.RLock()
+	if c.closed {
+		c.mu.RUnlock()
+		c.b.redispatch(c.g, msg)
+		return nil
+	}
+	select {
+	case c.queue <- msg:
+		c.mu.RUnlock()
+		return nil
+	case <-c.done:
+		c.mu.RUnlock()
+		c.b.redispatch(c.g, msg)
+		return nil
+	case <-ctx.Done():
+		c.mu.RUnlock()
+		return ctx.Err()
+	}
+}
+
+func (c *memoryConsumer) run() {
+	defer close(c.stopped)
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.queue:
+			c.handle(msg)
+		}
+	}
+}
+
+// handle runs the handler, redelivering on failure. A message interrupted by
+// closing is handed to another consumer rather than dropped.
+func (c *memoryConsumer) handle(msg *Message) {
+	for attempt := 0; ; attempt++ {
+		m := *msg
+		m.Headers = maps.Clone(msg.Headers)
+		if c.handler(c.ctx, &m) == nil {
+			c.b.finish()
+			return
+		}
+		if c.ctx.Err() != nil {
+			c.b.redispatch(c.g, msg)
+			return
+		}
+		if c.b.cfg.MaxRedeliveries < 0 || attempt >= c.b.cfg.MaxRedeliveries {
+			c.b.drop(c.g.topic, msg)
+			return
+		}
+		if d := c.b.cfg.RedeliveryDelay; d > 0 {
+			t := time.NewTimer(d)
+			select {
+			case <-t.C:
+			case <-c.done:
+				t.Stop()
+				c.b.redispatch(c.g, msg)
+				return
+			}
+		}
+	}
+}
+
+// close stops the consumer and moves its queued messages to the rest of the
+// group.
+func (c *memoryConsumer) close() {
+	c.closeOnce.Do(func() {
+		c.b.unsubscribe(c)
+		c.cancel()
+		close(c.done)
+		<-c.stopped
+		c.mu.Lock() // wait out in-flight sends
+		c.closed = true
+		c.mu.Unlock()
+		for {
+			select {
+			case msg := <-c.queue:
+				c.b.redispatch(c.g, msg)
+			default:
+				return
+			}
+		}
+	})
+}
+
+type memoryPublisher struct {
+	b     *MemoryBinder
+	topic string
+}
+
+// Publish enqueues a copy of msg for every consumer group of the topic.
+func (p *memoryPublisher) Publish(ctx context.Context, msg *Message) error {
+	return p.b.publish(ctx, p.topic, msg)
+}
+
+// Close is a no-op.
+func (p *memoryPublisher) Close() error { return nil }
+
+type memorySubscriber struct {
+	b     *MemoryBinder
+	topic string
+	group string
+
+	mu       sync.Mutex
+	consumer *memoryConsumer
+}
+
+// Subscribe starts delivering to handler. It may be called only once.
+func (s *memorySubscriber) Subscribe(ctx context.Context, handler Handler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.consumer != nil {
+		return errutil.Explain(nil, "messaging: subscriber for %q already subscribed", s.topic)
+	}
+	c, err := s.b.subscribe(ctx, s.topic, s.group, handler)
+	if err != nil {
+		return err
+	}
+	s.consumer = c
+	return nil
+}
+
+// Close stops delivery; queued messages move to the rest of the group.
+func (s *memorySubscriber) Close() error {
+	s.mu.Lock()
+	c := s.consumer
+	s.mu.Unlock()
+	if c != nil {
+		c.close()
+	}
+	return nil
+}
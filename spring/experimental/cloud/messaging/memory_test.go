@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func waitIdle(t *testing.T, b *MemoryBinder) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Error(t, b.WaitIdle(ctx)).Nil()
+}
+
+func TestMemoryBinder_GroupsAndBroadcast(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBinder(MemoryBinderConfig{})
+	defer b.Close()
+
+	var (
+		mu  sync.Mutex
+		got = map[string][]string{}
+	)
+	subscribe := func(name, group string) {
+		sub, err := b.NewSubscriber(ctx, "orders", group)
+		assert.Error(t, err).Nil()
+		assert.Error(t, sub.Subscribe(ctx, func(_ context.Context, msg *Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got[name] = append(got[name], string(msg.Payload))
+			return nil
+		})).Nil()
+	}
+	subscribe("w1", "workers")
+	subscribe("w2", "workers")
+	subscribe("audit", "")
+
+	pub, _ := b.NewPublisher(ctx, "orders")
+	for i := range 10 {
+		assert.Error(t, pub.Publish(ctx, &Message{Payload: fmt.Appendf(nil, "%d", i)})).Nil()
+	}
+	waitIdle(t, b)
+
+	assert.That(t, len(got["audit"])).Equal(10)
+	assert.That(t, len(got["w1"])+len(got["w2"])).Equal(10) // competing
+	assert.That(t, len(got["w1"]) > 0 && len(got["w2"]) > 0).True()
+	assert.That(t, len(b.Published("orders"))).Equal(10)
+
+	b.Reset()
+	assert.That(t, len(b.Published("orders"))).Equal(0)
+}
+
+func TestMemoryBinder_KeyOrderAndRedelivery(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBinder(MemoryBinderConfig{MaxRedeliveries: 2, Buffer: 4})
+	defer b.Close()
+
+	var (
+		mu       sync.Mutex
+		byKey    = map[string][]string{}
+		failures int
+	)
+	for range 3 {
+		sub, _ := b.NewSubscriber(ctx, "orders", "workers")
+		_ = sub.Subscribe(ctx, func(_ context.Context, msg *Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if string(msg.Payload) == "a1" && failures < 2 {
+				failures++
+				return errors.New("transient")
+			}
+			if string(msg.Payload) == "poison" {
+				return errors.New("permanent")
+			}
+			byKey[msg.Key] = append(byKey[msg.Key], string(msg.Payload))
+			return nil
+		})
+	}
+
+	pub, _ := b.NewPublisher(ctx, "orders")
+	for i := 1; i <= 5; i++ {
+		for _, k := range []string{"a", "b", "c"} {
+			_ = pub.Publish(ctx, &Message{Key: k, Payload: fmt.Appendf(nil, "%s%d", k, i)})
+		}
+	}
+	_ = pub.Publish(ctx, &Message{Payload: []byte("poison")})
+	waitIdle(t, b)
+
+	assert.That(t, byKey["a"]).Equal([]string{"a1", "a2", "a3", "a4", "a5"})
+	assert.That(t, byKey["b"]).Equal([]string{"b1", "b2", "b3", "b4", "b5"})
+	assert.That(t, failures).Equal(2)
+	dropped := b.Dropped("orders")
+	assert.That(t, len(dropped)).Equal(1)
+	assert.That(t, string(dropped[0].Payload)).Equal("poison")
+}
+
+func TestMemoryBinder_CloseMovesQueuedMessages(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBinder(MemoryBinderConfig{})
+
+	release := make(chan struct{})
+	var (
+		mu    sync.Mutex
+		total int
+	)
+	slow, _ := b.NewSubscriber(ctx, "jobs", "g")
+	_ = slow.Subscribe(ctx, func(ctx context.Context, msg *Message) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		mu.Lock()
+		total++
+		mu.Unlock()
+		return nil
+	})
+	fast, _ := b.NewSubscriber(ctx, "jobs", "g")
+	_ = fast.Subscribe(ctx, func(context.Context, *Message) error {
+		mu.Lock()
+		total++
+		mu.Unlock()
+		return nil
+	})
+
+	pub, _ := b.NewPublisher(ctx, "jobs")
+	for range 6 {
+		_ = pub.Publish(ctx, &Message{})
+	}
+	assert.Error(t, slow.Close()).Nil() // in-flight and queued work moves to fast
+	close(release)
+	waitIdle(t, b)
+	assert.That(t, total).Equal(6)
+
+	assert.Error(t, b.Close()).Nil()
+	assert.Error(t, pub.Publish(ctx, &Message{})).Is(ErrBinderClosed)
+}
+
+func TestMemoryBinder_Registered(t *testing.T) {
+	b, err := MustGetBinder("memory")
+	assert.Error(t, err).Nil()
+	mb, ok := b.(*MemoryBinder)
+	assert.That(t, ok).True()
+
+	// Closing the shared instance must not break it for other callers.
+	ctx := context.Background()
+	assert.Error(t, mb.Close()).Nil()
+	pub, err := mb.NewPublisher(ctx, "registered-close")
+	assert.Error(t, err).Nil()
+	assert.Error(t, pub.Publish(ctx, &Message{})).Nil()
+	assert.That(t, len(mb.Published("registered-close"))).Equal(1)
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/resilience"
+	"go-spring.org/stdlib/errutil"
+)
+
+// Header names written and read by the consumer middleware.
+const (
+	// HeaderMessageID is the producer-assigned unique ID that
+	// [WithIdempotency] deduplicates on.
+	HeaderMessageID = "message-id"
+
+	// HeaderDeadLetterError carries the final handler error on a message
+	// forwarded by [WithDeadLetter].
+	HeaderDeadLetterError = "x-dead-letter-error"
+
+	// HeaderDeadLetterAttempts carries the number of delivery attempts made
+	// before the message was dead-lettered.
+	HeaderDeadLetterAttempts = "x-dead-letter-attempts"
+)
+
+// Middleware decorates a [Handler]. Middleware is broker-neutral: it runs
+// inside the handler a binder delivers to, so it behaves the same on every
+// broker starter.
+type Middleware func(Handler) Handler
+
+// Chain wraps h with mws; the first middleware is the outermost. A typical
+// consumer stack is
+//
+//	messaging.Chain(h,
+//	    messaging.WithIdempotency(store),
+//	    messaging.WithDeadLetter(dlq),
+//	    messaging.WithRetry(exec, "orders"),
+//	)
+//
+// so retries run first, an exhausted message is dead-lettered, and a message
+// that was handled or dead-lettered is remembered as done.
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// DeliveryError is returned by [WithRetry] when every attempt failed. It
+// records how many attempts were made so [WithDeadLetter] can report them.
+type DeliveryError struct {
+	Err      error
+	Attempts int
+}
+
+func (e *DeliveryError) Error() string {
+	return "messaging: delivery failed after " + strconv.Itoa(e.Attempts) + " attempts: " + e.Err.Error()
+}
+
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// WithRetry re-runs the handler under exec for resource, so the bounded
+// retries, backoff, timeout and circuit breaking come from the executor's
+// [resilience.Policy]. When every attempt fails the error is wrapped in a
+// [DeliveryError]. A nil exec leaves the handler unchanged.
+func WithRetry(exec resilience.Executor, resource string) Middleware {
+	return func(next Handler) Handler {
+		if exec == nil {
+			return next
+		}
+		return func(ctx context.Context, msg *Message) error {
+			attempts := 0
+			err := exec.Execute(ctx, resource, func(ctx context.Context) error {
+				attempts++
+				return next(ctx, msg)
+			})
+			if err != nil {
+				return &DeliveryError{Err: err, Attempts: max(attempts, 1)}
+			}
+			return nil
+		}
+	}
+}
+
+// WithDeadLetter forwards a message whose handler failed to pub, typically a
+// publisher bound to a dead-letter destination. The forwarded copy carries
+// the error and attempt count in [HeaderDeadLetterError] and
+// [HeaderDeadLetterAttempts]. Once the dead-letter publish succeeds the
+// failure is swallowed, so the broker acknowledges the original; if it fails
+// too, both errors are returned and the broker redelivers.
+func WithDeadLetter(pub Publisher) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			err := next(ctx, msg)
+			if err == nil {
+				return nil
+			}
+			attempts := 1
+			var de *DeliveryError
+			if errors.As(err, &de) {
+				attempts = de.Attempts
+			}
+			dead := *msg
+			dead.Headers = maps.Clone(msg.Headers)
+			dead.SetHeader(HeaderDeadLetterError, err.Error())
+			dead.SetHeader(HeaderDeadLetterAttempts, strconv.Itoa(attempts))
+			if perr := pub.Publish(ctx, &dead); perr != nil {
+				return errors.Join(err, errutil.Explain(perr, "messaging: publish dead letter"))
+			}
+			return nil
+		}
+	}
+}
+
+// IdempotencyStore remembers the IDs of messages that were already handled.
+// Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Seen reports whether id was remembered.
+	Seen(ctx context.Context, id string) (bool, error)
+
+	// Remember records id as handled.
+	Remember(ctx context.Context, id string) error
+}
+
+// WithIdempotency skips messages whose [HeaderMessageID] is already in store
+// and remembers the ID once the handler succeeds. Messages without the
+// header are always handled. The check and the record are not atomic, so
+// two concurrent deliveries of the same message may both run; the guard
+// targets broker redelivery, not racing consumers.
+func WithIdempotency(store IdempotencyStore) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			id := msg.Header(HeaderMessageID)
+			if id == "" {
+				return next(ctx, msg)
+			}
+			seen, err := store.Seen(ctx, id)
+			if err != nil {
+				return errutil.Explain(err, "messaging: check message id %q", id)
+			}
+			if seen {
+				return nil
+			}
+			if err = next(ctx, msg); err != nil {
+				return err
+			}
+			if err = store.Remember(ctx, id); err != nil {
+				return errutil.Explain(err, "messaging: remember message id %q", id)
+			}
+			return nil
+		}
+	}
+}
+
+// NewMemoryIdempotencyStore returns an in-process [IdempotencyStore] that
+// forgets IDs after ttl (0 keeps them forever). It suits tests and single
+// consumers; replicas sharing a group need a shared store such as
+// [NewCacheIdempotencyStore].
+func NewMemoryIdempotencyStore(ttl time.Duration) IdempotencyStore {
+	return &memoryIdempotencyStore{ttl: ttl, ids: make(map[string]time.Time)}
+}
+
+type memoryIdempotencyStore struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	ids       map[string]time.Time // id -> expiry, zero for never
+	nextSweep time.Time
+}
+
+func (s *memoryIdempotencyStore) Seen(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exp, ok := s.ids[id]
+	if ok && !exp.IsZero() && time.Now().After(exp) {
+		delete(s.ids, id)
+		return false, nil
+	}
+	return ok, nil
+}
+
+func (s *memoryIdempotencyStore) Remember(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var exp time.Time
+	if s.ttl > 0 {
+		now := time.Now()
+		if now.After(s.nextSweep) { // drop expired IDs at most once per ttl
+			s.nextSweep = now.Add(s.ttl)
+			for k, e := range s.ids {
+				if now.After(e) {
+					delete(s.ids, k)
+				}
+			}
+		}
+		exp = now.Add(s.ttl)
+	}
+	s.ids[id] = exp
+	return nil
+}
+
+// IdempotencyCache is the slice of a key-value cache that
+// [NewCacheIdempotencyStore] needs. Every data/cache.Cache satisfies it; the
+// interface is declared here so this package does not depend on data/cache.
+type IdempotencyCache interface {
+	GetBytes(ctx context.Context, key string) ([]byte, error)
+	SetBytes(ctx context.Context, key string, val []byte, ttl time.Duration) error
+}
+
+// NewCacheIdempotencyStore returns an [IdempotencyStore] backed by c, storing
+// each ID under prefix+id for ttl (non-positive never expires). miss is the
+// error c reports for an absent key, cache.ErrMiss for a data/cache.Cache.
+// Any shared cache backend makes the guard work across replicas.
+func NewCacheIdempotencyStore(c IdempotencyCache, miss error, prefix string, ttl time.Duration) IdempotencyStore {
+	return &cacheIdempotencyStore{c: c, miss: miss, prefix: prefix, ttl: ttl}
+}
+
+type cacheIdempotencyStore struct {
+	c      IdempotencyCache
+	miss   error
+	prefix string
+	ttl    time.Duration
+}
+
+func (s *cacheIdempotencyStore) Seen(ctx context.Context, id string) (bool, error) {
+	_, err := s.c.GetBytes(ctx, s.prefix+id)
+	if err != nil && errors.Is(err, s.miss) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *cacheIdempotencyStore) Remember(ctx context.Context, id string) error {
+	return s.c.SetBytes(ctx, s.prefix+id, []byte{1}, s.ttl)
+}
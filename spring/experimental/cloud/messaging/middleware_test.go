@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/resilience"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestMiddleware_RetryThenDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	b := newMemBinder()
+	var dead []*Message
+	dlqSub, _ := b.NewSubscriber(ctx, "orders.dlq", "")
+	_ = dlqSub.Subscribe(ctx, func(_ context.Context, msg *Message) error {
+		dead = append(dead, msg)
+		return nil
+	})
+	dlq, _ := b.NewPublisher(ctx, "orders.dlq")
+
+	d, err := resilience.MustGetDriver("default")
+	assert.Error(t, err).Nil()
+	exec, err := d.NewExecutor(resilience.Policy{MaxRetries: 2, RetryBackoff: time.Millisecond})
+	assert.Error(t, err).Nil()
+
+	var calls int
+	h := Chain(func(_ context.Context, msg *Message) error {
+		calls++
+		if string(msg.Payload) == "poison" {
+			return errors.New("cannot parse")
+		}
+		return nil
+	}, WithDeadLetter(dlq), WithRetry(exec, "orders"))
+
+	assert.Error(t, h(ctx, &Message{Payload: []byte("ok")})).Nil()
+	assert.That(t, calls).Equal(1)
+
+	msg := &Message{Payload: []byte("poison")}
+	assert.Error(t, h(ctx, msg)).Nil() // dead-lettered, so acknowledged
+	assert.That(t, calls).Equal(4)
+	assert.That(t, len(dead)).Equal(1)
+	assert.That(t, dead[0].Header(HeaderDeadLetterAttempts)).Equal("3")
+	assert.That(t, dead[0].Header(HeaderDeadLetterError)).Equal("messaging: delivery failed after 3 attempts: cannot parse")
+	assert.That(t, msg.Headers).Equal(map[string]string(nil)) // original untouched
+}
+
+func TestMiddleware_DeadLetterPublishFailure(t *testing.T) {
+	boom := errors.New("boom")
+	h := WithDeadLetter(failingPublisher{})(func(context.Context, *Message) error { return boom })
+	err := h(context.Background(), &Message{})
+	assert.Error(t, err).Is(boom)
+	assert.Error(t, err).Matches("publish dead letter")
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, *Message) error { return errors.New("dlq down") }
+func (failingPublisher) Close() error                            { return nil }
+
+func TestMiddleware_Idempotency(t *testing.T) {
+	for name, store := range map[string]IdempotencyStore{
+		"memory": NewMemoryIdempotencyStore(time.Minute),
+		"cache":  NewCacheIdempotencyStore(newMapCache(), errMiss, "msg:", time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			var calls int
+			fail := true
+			h := WithIdempotency(store)(func(context.Context, *Message) error {
+				calls++
+				if fail {
+					fail = false
+					return errors.New("transient")
+				}
+				return nil
+			})
+			msg := &Message{}
+			msg.SetHeader(HeaderMessageID, "m-1")
+
+			assert.Error(t, h(ctx, msg)).NotNil() // failure is not remembered
+			assert.Error(t, h(ctx, msg)).Nil()
+			assert.Error(t, h(ctx, msg)).Nil() // duplicate skipped
+			assert.That(t, calls).Equal(2)
+
+			assert.Error(t, h(ctx, &Message{})).Nil() // no ID: always handled
+			assert.Error(t, h(ctx, &Message{})).Nil()
+			assert.That(t, calls).Equal(4)
+		})
+	}
+}
+
+func TestMemoryIdempotencyStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryIdempotencyStore(10 * time.Millisecond)
+	assert.Error(t, s.Remember(ctx, "a")).Nil()
+	seen, _ := s.Seen(ctx, "a")
+	assert.That(t, seen).True()
+	time.Sleep(20 * time.Millisecond)
+	seen, _ = s.Seen(ctx, "a")
+	assert.That(t, seen).False()
+}
+
+var errMiss = errors.New("miss")
+
+// mapCache is a minimal IdempotencyCache over a map, ignoring ttl.
+type mapCache struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
+
+func newMapCache() *mapCache { return &mapCache{m: map[string][]byte{}} }
+
+func (c *mapCache) GetBytes(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.m[key]; ok {
+		return v, nil
+	}
+	return nil, errMiss
+}
+
+func (c *mapCache) SetBytes(_ context.Context, key string, val []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = val
+	return nil
+}
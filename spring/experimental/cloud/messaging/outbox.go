@@ -0,0 +1,494 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/stdlib/errutil"
+)
+
+// OutboxRecord is one message waiting in the transactional outbox. ID is
+// assigned by the store on Append and increases in insertion order; the relay
+// publishes records in ID order.
+type OutboxRecord struct {
+	ID          int64
+	Destination string
+	Message     Message
+	CreatedAt   time.Time
+	SentAt      time.Time // zero while the record is pending
+	ParkedAt    time.Time // set once the relay gave up on the record
+	Attempts    int       // failed publish attempts so far
+	LastError   string    // error of the last failed attempt
+}
+
+// OutboxStore persists outbox records. Append must join the database
+// transaction carried by ctx, if any, so the message commits or rolls back
+// together with the caller's business writes; how a transaction travels in
+// ctx is defined by each implementation (e.g. the gorm store's WithTx).
+//
+// Implementations must be safe for concurrent use.
+type OutboxStore interface {
+	// Append stores records as pending and assigns their IDs. IDs taken
+	// before commit can become visible out of order; a store must keep two
+	// transactions appending the same destination and key from both
+	// committing out of ID order, or the relay's per-key order is lost.
+	Append(ctx context.Context, records ...*OutboxRecord) error
+
+	// Pending returns up to limit pending records in ID order. Sent and
+	// parked records are not pending.
+	Pending(ctx context.Context, limit int) ([]OutboxRecord, error)
+
+	// MarkSent marks the records as published.
+	MarkSent(ctx context.Context, ids ...int64) error
+
+	// MarkFailed records a failed publish attempt; the record stays pending.
+	MarkFailed(ctx context.Context, id int64, cause string) error
+
+	// Park takes a record the relay gave up on out of the pending set, so it
+	// no longer holds back the records behind it. Parked records are kept
+	// for inspection and never purged.
+	Park(ctx context.Context, id int64) error
+
+	// Purge deletes records published before the given time and returns how
+	// many were removed. Pending and parked records are never purged.
+	Purge(ctx context.Context, sentBefore time.Time) (int, error)
+}
+
+// OutboxTailer is an optional [OutboxStore] extension for stores that can
+// follow the outbox instead of being polled, e.g. over PostgreSQL
+// LISTEN/NOTIFY or a binlog reader. The relay type-asserts for it and, between
+// batches, wakes as soon as Tail signals; PollInterval then only paces the
+// fallback poll that catches anything a signal missed.
+type OutboxTailer interface {
+	// Tail returns a channel that receives a value whenever committed records
+	// may be waiting, until ctx is done. Signals may be coalesced.
+	Tail(ctx context.Context) (<-chan struct{}, error)
+}
+
+// NewOutboxPublisher returns a [Publisher] that writes to store instead of the
+// broker: Publish appends a record for destination inside the transaction
+// carried by ctx, and an [OutboxRelay] delivers it after commit. A rollback
+// discards the message with the rest of the transaction, so neither lost
+// messages nor phantoms are possible. Delivery is at-least-once.
+func NewOutboxPublisher(store OutboxStore, destination string) Publisher {
+	return &outboxPublisher{store: store, destination: destination}
+}
+
+type outboxPublisher struct {
+	store       OutboxStore
+	destination string
+}
+
+// Publish appends msg to the outbox. The message is copied, so the caller
+// may reuse it after Publish returns.
+func (p *outboxPublisher) Publish(ctx context.Context, msg *Message) error {
+	m := *msg
+	m.Headers = maps.Clone(msg.Headers)
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+	return p.store.Append(ctx, &OutboxRecord{
+		Destination: p.destination,
+		Message:     m,
+		CreatedAt:   time.Now(),
+	})
+}
+
+// Close is a no-op: the publisher holds nothing but a store reference.
+func (p *outboxPublisher) Close() error { return nil }
+
+// OutboxRelayConfig configures an [OutboxRelay]. Store and Binder are required.
+type OutboxRelayConfig struct {
+	// Store is the outbox to drain.
+	Store OutboxStore
+
+	// Binder opens the broker publishers records are delivered through.
+	Binder Binder
+
+	// BatchSize is the maximum number of records read per poll. Default 100.
+	BatchSize int
+
+	// PollInterval is the pause between polls when the outbox is drained.
+	// [OutboxRelay.Notify] cuts it short. Default 1s.
+	PollInterval time.Duration
+
+	// Retention is how long published records are kept before Purge removes
+	// them. Zero means 7 days; negative disables cleanup.
+	Retention time.Duration
+
+	// CleanupInterval paces the retention cleanup. Default 1h.
+	CleanupInterval time.Duration
+
+	// MaxAttempts is the number of failed publishes after which a record is
+	// parked, so a poison record cannot hold back its key, or fill every
+	// batch, forever. Zero means 10; negative retries forever.
+	MaxAttempts int
+
+	// Locker, when set, elects a single relaying replica through
+	// [lock.Election] on LockKey; without it every replica relays, which is
+	// only safe for a single instance.
+	Locker  lock.Locker
+	LockKey string // Default "messaging:outbox:relay"
+
+	// OnError is called when a record fails to publish. rec.Attempts does not
+	// yet count the failure. Optional.
+	OnError func(rec OutboxRecord, err error)
+
+	// OnPark is called after a record is parked. Optional.
+	OnPark func(rec OutboxRecord)
+
+	// OnRelayError is called when the relay cannot read, mark, park or purge
+	// records. Optional.
+	OnRelayError func(err error)
+}
+
+// OutboxRelay delivers outbox records to the broker. It publishes records in
+// ID order and preserves order per [Message.Key]: once a record fails, later
+// records with the same key wait for the next poll, while other keys proceed.
+// Unkeyed records are independent of each other.
+type OutboxRelay struct {
+	cfg    OutboxRelayConfig
+	notify chan struct{}
+
+	mu         sync.Mutex
+	publishers map[string]Publisher
+}
+
+// NewOutboxRelay builds an [OutboxRelay], applying defaults. It panics if Store
+// or Binder is unset, since such a relay could never deliver anything.
+func NewOutboxRelay(cfg OutboxRelayConfig) *OutboxRelay {
+	if cfg.Store == nil {
+		panic("messaging: outbox relay requires a Store")
+	}
+	if cfg.Binder == nil {
+		panic("messaging: outbox relay requires a Binder")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.CleanupInterval <= 0 {
+		cfg.CleanupInterval = time.Hour
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.LockKey == "" {
+		cfg.LockKey = "messaging:outbox:relay"
+	}
+	return &OutboxRelay{
+		cfg:        cfg,
+		notify:     make(chan struct{}, 1),
+		publishers: make(map[string]Publisher),
+	}
+}
+
+// Notify wakes the relay for an immediate poll, e.g. right after a local
+// transaction that wrote to the outbox has committed. It never blocks.
+func (r *OutboxRelay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run relays until ctx is done, then closes the publishers it opened and
+// returns ctx.Err(). With a Locker it relays only while this replica is the
+// elected leader.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	defer r.closePublishers()
+	if r.cfg.Locker == nil {
+		r.loop(ctx)
+		return ctx.Err()
+	}
+	return lock.NewElection(lock.ElectionConfig{
+		Locker:    r.cfg.Locker,
+		Key:       r.cfg.LockKey,
+		OnElected: r.loop,
+	}).Run(ctx)
+}
+
+// loop polls, or tails, the outbox until ctx is done.
+func (r *OutboxRelay) loop(ctx context.Context) {
+	var tail <-chan struct{}
+	if t, ok := r.cfg.Store.(OutboxTailer); ok {
+		ch, err := t.Tail(ctx)
+		if err != nil {
+			r.reportError(errutil.Explain(err, "messaging: tail outbox, falling back to polling"))
+		}
+		tail = ch
+	}
+
+	var lastCleanup time.Time
+	for ctx.Err() == nil {
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			r.reportError(err)
+		}
+		if r.cfg.Retention > 0 && time.Since(lastCleanup) >= r.cfg.CleanupInterval {
+			lastCleanup = time.Now()
+			if _, err = r.cfg.Store.Purge(ctx, lastCleanup.Add(-r.cfg.Retention)); err != nil {
+				r.reportError(errutil.Explain(err, "messaging: purge outbox"))
+			}
+		}
+		if err == nil && n == r.cfg.BatchSize {
+			continue // a full batch went out, more may be waiting
+		}
+		t := time.NewTimer(r.cfg.PollInterval)
+		select {
+		case <-ctx.Done():
+		case <-r.notify:
+		case _, ok := <-tail:
+			if !ok {
+				tail = nil // the tail ended; keep polling
+			}
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+// reportError passes err to OnRelayError, if set.
+func (r *OutboxRelay) reportError(err error) {
+	if r.cfg.OnRelayError != nil {
+		r.cfg.OnRelayError(err)
+	}
+}
+
+// RelayOnce publishes one batch of pending records and returns how many were
+// published. Failed records stay pending and block later records with the
+// same key until the next call; a record that reaches MaxAttempts failures is
+// parked instead, releasing its key.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	records, err := r.cfg.Store.Pending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, errutil.Explain(err, "messaging: read outbox")
+	}
+
+	var (
+		sent    []int64
+		blocked = make(map[string]struct{})
+	)
+	for _, rec := range records {
+		if ctx.Err() != nil {
+			break
+		}
+		if rec.Message.Key != "" {
+			if _, ok := blocked[rec.Message.Key]; ok {
+				continue
+			}
+		}
+		if err = r.publish(ctx, rec); err != nil {
+			if rec.Message.Key != "" {
+				blocked[rec.Message.Key] = struct{}{}
+			}
+			if r.cfg.OnError != nil {
+				r.cfg.OnError(rec, err)
+			}
+			r.fail(ctx, rec, err)
+			continue
+		}
+		sent = append(sent, rec.ID)
+	}
+	if len(sent) > 0 {
+		if err = r.cfg.Store.MarkSent(ctx, sent...); err != nil {
+			return 0, errutil.Explain(err, "messaging: mark outbox records sent")
+		}
+	}
+	return len(sent), nil
+}
+
+// fail records a failed attempt on rec and parks it once it has used up
+// MaxAttempts.
+func (r *OutboxRelay) fail(ctx context.Context, rec OutboxRecord, cause error) {
+	if err := r.cfg.Store.MarkFailed(ctx, rec.ID, cause.Error()); err != nil {
+		r.reportError(errutil.Explain(err, "messaging: mark outbox record %d failed", rec.ID))
+		return
+	}
+	rec.Attempts++
+	rec.LastError = cause.Error()
+	if r.cfg.MaxAttempts < 0 || rec.Attempts < r.cfg.MaxAttempts {
+		return
+	}
+	if err := r.cfg.Store.Park(ctx, rec.ID); err != nil {
+		r.reportError(errutil.Explain(err, "messaging: park outbox record %d", rec.ID))
+		return
+	}
+	if r.cfg.OnPark != nil {
+		r.cfg.OnPark(rec)
+	}
+}
+
+// publish delivers one record through a publisher cached per destination.
+func (r *OutboxRelay) publish(ctx context.Context, rec OutboxRecord) error {
+	r.mu.Lock()
+	pub, ok := r.publishers[rec.Destination]
+	r.mu.Unlock()
+	if !ok {
+		var err error
+		if pub, err = r.cfg.Binder.NewPublisher(ctx, rec.Destination); err != nil {
+			return err
+		}
+		r.mu.Lock()
+		r.publishers[rec.Destination] = pub
+		r.mu.Unlock()
+	}
+	msg := rec.Message
+	return pub.Publish(ctx, &msg)
+}
+
+func (r *OutboxRelay) closePublishers() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, pub := range r.publishers {
+		_ = pub.Close()
+		delete(r.publishers, k)
+	}
+}
+
+// MemoryOutboxStore is an in-process [OutboxStore]. It has no transactions,
+// so it only suits tests and single-process setups where losing the outbox on
+// restart is acceptable.
+type MemoryOutboxStore struct {
+	mu      sync.Mutex
+	nextID  int64
+	records map[int64]*OutboxRecord
+	tails   map[chan struct{}]struct{}
+}
+
+// NewMemoryOutboxStore returns an empty [MemoryOutboxStore].
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{records: make(map[int64]*OutboxRecord)}
+}
+
+var (
+	_ OutboxStore  = (*MemoryOutboxStore)(nil)
+	_ OutboxTailer = (*MemoryOutboxStore)(nil)
+)
+
+// Append stores copies of records and assigns their IDs.
+func (s *MemoryOutboxStore) Append(_ context.Context, records ...*OutboxRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rec := range records {
+		s.nextID++
+		rec.ID = s.nextID
+		c := *rec
+		s.records[c.ID] = &c
+	}
+	// The store has no transactions, so appended records are visible at once.
+	for ch := range s.tails {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Tail signals after every Append until ctx is done.
+func (s *MemoryOutboxStore) Tail(ctx context.Context) (<-chan struct{}, error) {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	if s.tails == nil {
+		s.tails = make(map[chan struct{}]struct{})
+	}
+	s.tails[ch] = struct{}{}
+	s.mu.Unlock()
+	context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.tails, ch)
+		close(ch)
+	})
+	return ch, nil
+}
+
+// Pending returns up to limit pending records in ID order.
+func (s *MemoryOutboxStore) Pending(_ context.Context, limit int) ([]OutboxRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []OutboxRecord
+	for _, id := range slices.Sorted(maps.Keys(s.records)) {
+		if len(out) >= limit {
+			break
+		}
+		if rec := s.records[id]; rec.SentAt.IsZero() && rec.ParkedAt.IsZero() {
+			out = append(out, *rec)
+		}
+	}
+	return out, nil
+}
+
+// MarkSent marks the records as published now.
+func (s *MemoryOutboxStore) MarkSent(_ context.Context, ids ...int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, id := range ids {
+		if rec, ok := s.records[id]; ok {
+			rec.SentAt = now
+		}
+	}
+	return nil
+}
+
+// MarkFailed increments the attempt count of the record and keeps cause.
+func (s *MemoryOutboxStore) MarkFailed(_ context.Context, id int64, cause string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[id]; ok {
+		rec.Attempts++
+		rec.LastError = cause
+	}
+	return nil
+}
+
+// Park marks the record as parked now.
+func (s *MemoryOutboxStore) Park(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[id]; ok {
+		rec.ParkedAt = time.Now()
+	}
+	return nil
+}
+
+// Purge deletes records published before sentBefore.
+func (s *MemoryOutboxStore) Purge(_ context.Context, sentBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for id, rec := range s.records {
+		if !rec.SentAt.IsZero() && rec.SentAt.Before(sentBefore) {
+			delete(s.records, id)
+			n++
+		}
+	}
+	return n, nil
+}
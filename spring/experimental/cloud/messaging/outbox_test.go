@@ -0,0 +1,235 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestOutbox_PublishAndRelay(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryOutboxStore()
+	b := newMemBinder()
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	sub, _ := b.NewSubscriber(ctx, "orders", "")
+	_ = sub.Subscribe(ctx, func(_ context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if string(msg.Payload) == "a2" && !slices.Contains(got, "retried") {
+			got = append(got, "retried")
+			return errors.New("broker unavailable")
+		}
+		got = append(got, string(msg.Payload))
+		return nil
+	})
+
+	pub := NewOutboxPublisher(store, "orders")
+	for _, p := range []string{"a1", "a2", "b1", "a3"} {
+		msg := &Message{Key: p[:1], Payload: []byte(p)}
+		assert.Error(t, pub.Publish(ctx, msg)).Nil()
+	}
+	assert.That(t, got).Equal([]string(nil)) // nothing reaches the broker before the relay runs
+
+	var failures int
+	relay := NewOutboxRelay(OutboxRelayConfig{
+		Store:   store,
+		Binder:  b,
+		OnError: func(OutboxRecord, error) { failures++ },
+	})
+
+	// a2 fails: a3 must wait behind it, b1 is independent.
+	n, err := relay.RelayOnce(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(2)
+	assert.That(t, failures).Equal(1)
+	assert.That(t, got).Equal([]string{"a1", "retried", "b1"})
+
+	pending, _ := store.Pending(ctx, 10)
+	assert.That(t, len(pending)).Equal(2)
+	assert.That(t, pending[0].Attempts).Equal(1)
+	assert.String(t, pending[0].LastError).Equal("broker unavailable")
+
+	n, err = relay.RelayOnce(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(2)
+	assert.That(t, got).Equal([]string{"a1", "retried", "b1", "a2", "a3"})
+
+	removed, _ := store.Purge(ctx, time.Now().Add(time.Second))
+	assert.That(t, removed).Equal(4)
+}
+
+func TestOutbox_RelayRunWithElection(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	b := newMemBinder()
+
+	delivered := make(chan string, 1)
+	sub, _ := b.NewSubscriber(context.Background(), "orders", "")
+	_ = sub.Subscribe(context.Background(), func(_ context.Context, msg *Message) error {
+		delivered <- string(msg.Payload)
+		return nil
+	})
+
+	relay := NewOutboxRelay(OutboxRelayConfig{
+		Store:        store,
+		Binder:       b,
+		PollInterval: time.Hour,
+		Locker:       lock.NewMemoryLocker(),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- relay.Run(ctx) }()
+
+	pub := NewOutboxPublisher(store, "orders")
+	assert.Error(t, pub.Publish(ctx, &Message{Payload: []byte("hello")})).Nil()
+	relay.Notify()
+
+	select {
+	case p := <-delivered:
+		assert.String(t, p).Equal("hello")
+	case <-time.After(5 * time.Second):
+		t.Fatal("outbox record was not relayed")
+	}
+	cancel()
+	assert.Error(t, <-done).Is(context.Canceled)
+}
+
+func TestOutbox_PoisonRecordIsParked(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryOutboxStore()
+	b := newMemBinder()
+
+	var got []string
+	sub, _ := b.NewSubscriber(ctx, "orders", "")
+	_ = sub.Subscribe(ctx, func(_ context.Context, msg *Message) error {
+		if string(msg.Payload) == "poison" {
+			return errors.New("rejected")
+		}
+		got = append(got, string(msg.Payload))
+		return nil
+	})
+
+	pub := NewOutboxPublisher(store, "orders")
+	assert.Error(t, pub.Publish(ctx, &Message{Key: "k", Payload: []byte("poison")})).Nil()
+	assert.Error(t, pub.Publish(ctx, &Message{Key: "k", Payload: []byte("next")})).Nil()
+
+	var parked []OutboxRecord
+	relay := NewOutboxRelay(OutboxRelayConfig{
+		Store:       store,
+		Binder:      b,
+		MaxAttempts: 2,
+		OnPark:      func(rec OutboxRecord) { parked = append(parked, rec) },
+	})
+
+	_, _ = relay.RelayOnce(ctx)
+	assert.That(t, len(parked)).Equal(0)
+	assert.That(t, got).Equal([]string(nil)) // "next" waits behind the poison record
+
+	_, _ = relay.RelayOnce(ctx)
+	assert.That(t, len(parked)).Equal(1)
+	assert.That(t, parked[0].Attempts).Equal(2)
+	assert.String(t, parked[0].LastError).Equal("rejected")
+
+	// With the poison record parked, its key moves on.
+	n, err := relay.RelayOnce(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(1)
+	assert.That(t, got).Equal([]string{"next"})
+
+	pending, _ := store.Pending(ctx, 10)
+	assert.That(t, len(pending)).Equal(0)
+	removed, _ := store.Purge(ctx, time.Now().Add(time.Second))
+	assert.That(t, removed).Equal(1) // the parked record is kept
+}
+
+// failingStore fails every read, to exercise OnRelayError.
+type failingStore struct{ *MemoryOutboxStore }
+
+func (failingStore) Pending(context.Context, int) ([]OutboxRecord, error) {
+	return nil, errors.New("database is down")
+}
+
+func TestOutbox_RelayReportsStoreErrors(t *testing.T) {
+	errs := make(chan error, 1)
+	relay := NewOutboxRelay(OutboxRelayConfig{
+		Store:        failingStore{NewMemoryOutboxStore()},
+		Binder:       newMemBinder(),
+		PollInterval: time.Hour,
+		OnRelayError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = relay.Run(ctx) }()
+
+	select {
+	case err := <-errs:
+		assert.Error(t, err).Matches("database is down")
+	case <-time.After(5 * time.Second):
+		t.Fatal("store error was not reported")
+	}
+}
+
+func TestOutbox_RelayTailsStore(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	b := newMemBinder()
+
+	delivered := make(chan string, 1)
+	sub, _ := b.NewSubscriber(context.Background(), "orders", "")
+	_ = sub.Subscribe(context.Background(), func(_ context.Context, msg *Message) error {
+		delivered <- string(msg.Payload)
+		return nil
+	})
+
+	// PollInterval is an hour and nobody calls Notify: only the store's tail
+	// can wake the relay.
+	relay := NewOutboxRelay(OutboxRelayConfig{Store: store, Binder: b, PollInterval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = relay.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond) // let the first, empty poll finish
+	pub := NewOutboxPublisher(store, "orders")
+	assert.Error(t, pub.Publish(ctx, &Message{Payload: []byte("tailed")})).Nil()
+
+	select {
+	case p := <-delivered:
+		assert.String(t, p).Equal("tailed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("appended record was not relayed")
+	}
+}
+
+func TestOutbox_RelayRequiresStoreAndBinder(t *testing.T) {
+	assert.Panic(t, func() { NewOutboxRelay(OutboxRelayConfig{Binder: newMemBinder()}) }, "requires a Store")
+	assert.Panic(t, func() { NewOutboxRelay(OutboxRelayConfig{Store: NewMemoryOutboxStore()}) }, "requires a Binder")
+}
@@ -0,0 +1,451 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"sync"
+	"time"
+
+	"go-spring.org/stdlib/errutil"
+)
+
+// Header names used by request/reply.
+const (
+	// HeaderCorrelationID ties a reply to its request.
+	HeaderCorrelationID = "correlation-id"
+
+	// HeaderReplyTo is the destination a responder publishes replies to.
+	HeaderReplyTo = "reply-to"
+
+	// HeaderReplyError carries the responder's error message; a reply with
+	// this header is returned to the requester as a [*ReplyError].
+	HeaderReplyError = "x-reply-error"
+
+	// HeaderStreamEnd marks the last reply of a stream.
+	HeaderStreamEnd = "x-stream-end"
+)
+
+// ReplyError is the error a responder returned, reconstructed on the
+// requesting side.
+type ReplyError struct {
+	Message string
+}
+
+func (e *ReplyError) Error() string { return "messaging: remote error: " + e.Message }
+
+// NativeRequester is implemented by a [Binder] whose broker has built-in
+// request/reply (e.g. NATS Request with inboxes). [Requester] prefers it for
+// unary requests; streams always use the portable reply subscription.
+//
+// A responder built with [NewReplyHandler] must still be able to answer
+// native requests, so such a binder delivers the broker's reply address in
+// [HeaderReplyTo] and accepts it as a publish destination.
+type NativeRequester interface {
+	Request(ctx context.Context, destination string, msg *Message) (*Message, error)
+}
+
+// RequesterConfig configures a [Requester].
+type RequesterConfig struct {
+	// ReplyTo is the destination replies are delivered to. Each requester
+	// needs its own; empty generates a unique "_reply.<id>" name.
+	ReplyTo string
+
+	// Timeout bounds a request whose ctx has no deadline. Default 30s.
+	Timeout time.Duration
+
+	// StreamBuffer is how many replies a [Stream] holds before its reader
+	// takes them. A stream whose buffer overflows fails with
+	// [ErrStreamOverflow] rather than stalling the reply subscription that
+	// every request of the Requester shares. Default 64.
+	StreamBuffer int
+}
+
+// ErrStreamOverflow is returned by [Stream.Recv] once replies arrived faster
+// than the stream was read and its buffer overflowed.
+var ErrStreamOverflow = errors.New("messaging: stream reply buffer overflowed")
+
+// Requester sends requests over a [Binder] and correlates the replies. The
+// portable path tags each request with [HeaderCorrelationID] and
+// [HeaderReplyTo] and matches replies arriving on a reply subscription that
+// is opened on first use and closed by Close.
+//
+// A Requester is safe for concurrent use.
+type Requester struct {
+	binder Binder
+	cfg    RequesterConfig
+
+	mu      sync.Mutex
+	sub     Subscriber
+	waiting map[string]*waiter
+}
+
+// waiter receives the replies of one request. For a stream, overflow is
+// closed when a reply found ch full.
+type waiter struct {
+	ch       chan *Message
+	stream   bool
+	overflow chan struct{}
+}
+
+// NewRequester returns a [Requester] over b, applying defaults.
+func NewRequester(b Binder, cfg RequesterConfig) *Requester {
+	if cfg.ReplyTo == "" {
+		cfg.ReplyTo = "_reply." + newID()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	if cfg.StreamBuffer <= 0 {
+		cfg.StreamBuffer = 64
+	}
+	return &Requester{binder: b, cfg: cfg, waiting: make(map[string]*waiter)}
+}
+
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Request sends msg to destination and waits for one reply, until ctx is
+// done or the configured Timeout elapses. A reply carrying
+// [HeaderReplyError] is returned as a [*ReplyError].
+func (r *Requester) Request(ctx context.Context, destination string, msg *Message) (*Message, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+	}
+
+	var reply *Message
+	if n, ok := r.binder.(NativeRequester); ok {
+		var err error
+		if reply, err = n.Request(ctx, destination, msg); err != nil {
+			return nil, errutil.Explain(err, "messaging: request to %q", destination)
+		}
+	} else {
+		id, w, err := r.send(ctx, destination, msg, false)
+		if err != nil {
+			return nil, err
+		}
+		defer r.forget(id)
+		select {
+		case reply = <-w.ch:
+		case <-ctx.Done():
+			return nil, errutil.Explain(ctx.Err(), "messaging: request to %q", destination)
+		}
+	}
+	if s := reply.Header(HeaderReplyError); s != "" {
+		return nil, &ReplyError{Message: s}
+	}
+	return reply, nil
+}
+
+// Stream sends msg to destination and returns the replies of a responder
+// built with [NewStreamHandler]. ctx bounds the whole stream; without a
+// deadline the configured Timeout applies.
+func (r *Requester) Stream(ctx context.Context, destination string, msg *Message) (*Stream, error) {
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok {
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+	}
+	id, w, err := r.send(ctx, destination, msg, true)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Stream{ctx: ctx, ch: w.ch, overflow: w.overflow, close: func() { cancel(); r.forget(id) }}, nil
+}
+
+// Close closes the reply subscription. Pending requests time out.
+func (r *Requester) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sub == nil {
+		return nil
+	}
+	err := r.sub.Close()
+	r.sub = nil
+	return err
+}
+
+// send registers a waiter and publishes msg tagged for correlation.
+func (r *Requester) send(ctx context.Context, destination string, msg *Message, stream bool) (string, *waiter, error) {
+	if err := r.subscribe(ctx); err != nil {
+		return "", nil, err
+	}
+	id := newID()
+	w := &waiter{ch: make(chan *Message, 1), stream: stream, overflow: make(chan struct{})}
+	if stream {
+		w.ch = make(chan *Message, r.cfg.StreamBuffer)
+	}
+	r.mu.Lock()
+	r.waiting[id] = w
+	r.mu.Unlock()
+
+	m := *msg
+	m.Headers = maps.Clone(msg.Headers)
+	m.SetHeader(HeaderCorrelationID, id)
+	m.SetHeader(HeaderReplyTo, r.cfg.ReplyTo)
+	pub, err := r.binder.NewPublisher(ctx, destination)
+	if err == nil {
+		err = pub.Publish(ctx, &m)
+		_ = pub.Close()
+	}
+	if err != nil {
+		r.forget(id)
+		return "", nil, errutil.Explain(err, "messaging: request to %q", destination)
+	}
+	return id, w, nil
+}
+
+// subscribe opens the reply subscription on first use.
+func (r *Requester) subscribe(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sub != nil {
+		return nil
+	}
+	ctx = context.WithoutCancel(ctx) // outlives the request that opened it
+	sub, err := r.binder.NewSubscriber(ctx, r.cfg.ReplyTo, "")
+	if err == nil {
+		err = sub.Subscribe(ctx, r.dispatch)
+	}
+	if err != nil {
+		return errutil.Explain(err, "messaging: subscribe to replies on %q", r.cfg.ReplyTo)
+	}
+	r.sub = sub
+	return nil
+}
+
+func (r *Requester) forget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.waiting, id)
+}
+
+// dispatch routes a reply to its waiter. It never blocks: the reply
+// subscription is shared by every request, so one slow reader must not hold
+// up the others. Replies nobody waits for any more (late or duplicate) are
+// discarded, and a stream whose buffer is full is failed and forgotten.
+func (r *Requester) dispatch(_ context.Context, msg *Message) error {
+	id := msg.Header(HeaderCorrelationID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.waiting[id]
+	if !ok {
+		return nil
+	}
+	m := *msg
+	select {
+	case w.ch <- &m:
+	default:
+		if w.stream {
+			delete(r.waiting, id)
+			close(w.overflow)
+		}
+	}
+	return nil
+}
+
+// Stream is the receiving side of a streamed reply.
+type Stream struct {
+	ctx      context.Context
+	ch       chan *Message
+	overflow chan struct{}
+	close    func()
+	done     bool
+}
+
+// Recv returns the next reply. It returns io.EOF after the last one, a
+// [*ReplyError] if the responder failed, [ErrStreamOverflow] once the
+// buffered replies are read after an overflow, and ctx.Err() when the
+// stream's context ends first.
+func (s *Stream) Recv() (*Message, error) {
+	for !s.done {
+		select {
+		case m := <-s.ch:
+			if err := s.take(m); err != nil || !s.done {
+				return m, err
+			}
+		case <-s.overflow:
+			// Hand out what was buffered before the overflow first.
+			select {
+			case m := <-s.ch:
+				if err := s.take(m); err != nil || !s.done {
+					return m, err
+				}
+			default:
+				s.done = true
+				return nil, ErrStreamOverflow
+			}
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+	return nil, io.EOF
+}
+
+// take records whether m ends the stream and returns the responder's error
+// if it does with one.
+func (s *Stream) take(m *Message) error {
+	if m.Header(HeaderStreamEnd) == "" {
+		return nil
+	}
+	s.done = true
+	if e := m.Header(HeaderReplyError); e != "" {
+		return &ReplyError{Message: e}
+	}
+	return nil
+}
+
+// Close stops receiving; replies still in flight are discarded.
+func (s *Stream) Close() {
+	s.close()
+}
+
+// ReplyFunc answers one request.
+type ReplyFunc func(ctx context.Context, req *Message) (*Message, error)
+
+// NewReplyHandler turns fn into a [Handler] for a subscription serving
+// requests: the reply (or fn's error, as [HeaderReplyError]) is published
+// through b to the request's [HeaderReplyTo] with its correlation ID.
+// Requests without a reply address are handled and the result is dropped.
+func NewReplyHandler(b Binder, fn ReplyFunc) Handler {
+	return func(ctx context.Context, req *Message) error {
+		resp, err := fn(ctx, req)
+		switch {
+		case err != nil:
+			resp = &Message{Headers: map[string]string{HeaderReplyError: err.Error()}}
+		case resp == nil:
+			resp = &Message{}
+		}
+		return reply(ctx, b, req, resp)
+	}
+}
+
+// StreamFunc answers one request with any number of replies passed to send.
+type StreamFunc func(ctx context.Context, req *Message, send func(*Message) error) error
+
+// NewStreamHandler turns fn into a [Handler] serving [Requester.Stream]
+// requests. Every reply carries the request's correlation ID and uses it as
+// Key, so brokers that order per key keep the replies in order; a final
+// reply with [HeaderStreamEnd] (and fn's error, if any) closes the stream.
+func NewStreamHandler(b Binder, fn StreamFunc) Handler {
+	return func(ctx context.Context, req *Message) error {
+		err := fn(ctx, req, func(resp *Message) error {
+			return reply(ctx, b, req, resp)
+		})
+		end := &Message{Headers: map[string]string{HeaderStreamEnd: "true"}}
+		if err != nil {
+			end.SetHeader(HeaderReplyError, err.Error())
+		}
+		return reply(ctx, b, req, end)
+	}
+}
+
+// reply publishes resp to the reply address of req.
+func reply(ctx context.Context, b Binder, req, resp *Message) error {
+	to := req.Header(HeaderReplyTo)
+	if to == "" {
+		return nil
+	}
+	m := *resp
+	m.Headers = maps.Clone(resp.Headers)
+	if id := req.Header(HeaderCorrelationID); id != "" {
+		m.SetHeader(HeaderCorrelationID, id)
+		if m.Key == "" {
+			m.Key = id
+		}
+	}
+	pub, err := b.NewPublisher(ctx, to)
+	if err != nil {
+		return errutil.Explain(err, "messaging: reply to %q", to)
+	}
+	defer func() { _ = pub.Close() }()
+	if err = pub.Publish(ctx, &m); err != nil {
+		return errutil.Explain(err, "messaging: reply to %q", to)
+	}
+	return nil
+}
+
+// Codec converts typed request and reply values to and from payloads.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the default [Codec].
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func pickCodec(codec []Codec) Codec {
+	if len(codec) > 0 && codec[0] != nil {
+		return codec[0]
+	}
+	return JSONCodec{}
+}
+
+// Request is the typed form of [Requester.Request]: req is encoded with
+// codec (default [JSONCodec]) and the reply decoded into Resp.
+func Request[Req, Resp any](ctx context.Context, r *Requester, destination string, req Req, codec ...Codec) (Resp, error) {
+	var resp Resp
+	c := pickCodec(codec)
+	b, err := c.Marshal(req)
+	if err != nil {
+		return resp, errutil.Explain(err, "messaging: encode request")
+	}
+	m, err := r.Request(ctx, destination, &Message{Payload: b})
+	if err != nil {
+		return resp, err
+	}
+	if err = c.Unmarshal(m.Payload, &resp); err != nil {
+		return resp, errutil.Explain(err, "messaging: decode reply")
+	}
+	return resp, nil
+}
+
+// Reply is the typed form of [NewReplyHandler]: requests are decoded into
+// Req and fn's result encoded as the reply, with codec (default
+// [JSONCodec]).
+func Reply[Req, Resp any](b Binder, fn func(ctx context.Context, req Req) (Resp, error), codec ...Codec) Handler {
+	c := pickCodec(codec)
+	return NewReplyHandler(b, func(ctx context.Context, m *Message) (*Message, error) {
+		var req Req
+		if err := c.Unmarshal(m.Payload, &req); err != nil {
+			return nil, errutil.Explain(err, "messaging: decode request")
+		}
+		resp, err := fn(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		data, err := c.Marshal(resp)
+		if err != nil {
+			return nil, errutil.Explain(err, "messaging: encode reply")
+		}
+		return &Message{Payload: data}, nil
+	})
+}
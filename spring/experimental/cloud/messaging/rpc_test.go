@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type addReq struct{ A, B int }
+type addResp struct{ Sum int }
+
+func serve(t *testing.T, b Binder, dest string, h Handler) {
+	t.Helper()
+	ctx := context.Background()
+	sub, err := b.NewSubscriber(ctx, dest, "servers")
+	assert.Error(t, err).Nil()
+	assert.Error(t, sub.Subscribe(ctx, h)).Nil()
+	t.Cleanup(func() { _ = sub.Close() })
+}
+
+func TestRequest_Typed(t *testing.T) {
+	b := NewMemoryBinder(MemoryBinderConfig{MaxRedeliveries: -1})
+	defer b.Close()
+	serve(t, b, "math.add", Reply(b, func(_ context.Context, req addReq) (addResp, error) {
+		if req.A < 0 {
+			return addResp{}, errors.New("negative")
+		}
+		return addResp{Sum: req.A + req.B}, nil
+	}))
+
+	r := NewRequester(b, RequesterConfig{})
+	defer r.Close()
+	ctx := context.Background()
+
+	resp, err := Request[addReq, addResp](ctx, r, "math.add", addReq{A: 2, B: 3})
+	assert.Error(t, err).Nil()
+	assert.That(t, resp.Sum).Equal(5)
+
+	_, err = Request[addReq, addResp](ctx, r, "math.add", addReq{A: -1})
+	var re *ReplyError
+	assert.That(t, errors.As(err, &re)).True()
+	assert.That(t, re.Message).Equal("negative")
+}
+
+func TestRequest_Timeout(t *testing.T) {
+	b := NewMemoryBinder(MemoryBinderConfig{})
+	defer b.Close()
+	serve(t, b, "silent", func(context.Context, *Message) error { return nil })
+
+	r := NewRequester(b, RequesterConfig{Timeout: 20 * time.Millisecond})
+	defer r.Close()
+	_, err := r.Request(context.Background(), "silent", &Message{})
+	assert.Error(t, err).Is(context.DeadlineExceeded)
+}
+
+func TestRequest_Stream(t *testing.T) {
+	b := NewMemoryBinder(MemoryBinderConfig{MaxRedeliveries: -1})
+	defer b.Close()
+	serve(t, b, "count", NewStreamHandler(b, func(_ context.Context, req *Message, send func(*Message) error) error {
+		for i := range 3 {
+			if err := send(&Message{Payload: fmt.Appendf(nil, "%d", i)}); err != nil {
+				return err
+			}
+		}
+		if string(req.Payload) == "fail" {
+			return errors.New("broken")
+		}
+		return nil
+	}))
+
+	r := NewRequester(b, RequesterConfig{})
+	defer r.Close()
+	ctx := context.Background()
+
+	s, err := r.Stream(ctx, "count", &Message{})
+	assert.Error(t, err).Nil()
+	var got []string
+	for {
+		m, err := s.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Error(t, err).Nil()
+		got = append(got, string(m.Payload))
+	}
+	s.Close()
+	assert.That(t, got).Equal([]string{"0", "1", "2"})
+
+	s, err = r.Stream(ctx, "count", &Message{Payload: []byte("fail")})
+	assert.Error(t, err).Nil()
+	defer s.Close()
+	for range 3 {
+		_, err = s.Recv()
+		assert.Error(t, err).Nil()
+	}
+	_, err = s.Recv()
+	assert.Error(t, err).Matches("remote error: broken")
+}
+
+func TestRequest_StreamOverflow(t *testing.T) {
+	b := NewMemoryBinder(MemoryBinderConfig{MaxRedeliveries: -1})
+	defer b.Close()
+	serve(t, b, "flood", NewStreamHandler(b, func(_ context.Context, _ *Message, send func(*Message) error) error {
+		for i := range 10 {
+			if err := send(&Message{Payload: fmt.Appendf(nil, "%d", i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	serve(t, b, "echo", NewReplyHandler(b, func(_ context.Context, req *Message) (*Message, error) {
+		return &Message{Payload: req.Payload}, nil
+	}))
+
+	r := NewRequester(b, RequesterConfig{StreamBuffer: 2})
+	defer r.Close()
+	ctx := context.Background()
+
+	s, err := r.Stream(ctx, "flood", &Message{})
+	assert.Error(t, err).Nil()
+	defer s.Close()
+
+	// Nobody reads the stream, yet other requests still get their replies.
+	m, err := r.Request(ctx, "echo", &Message{Payload: []byte("hi")})
+	assert.Error(t, err).Nil()
+	assert.That(t, string(m.Payload)).Equal("hi")
+
+	for {
+		r.mu.Lock()
+		n := len(r.waiting)
+		r.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, want := range []string{"0", "1"} {
+		m, err = s.Recv()
+		assert.Error(t, err).Nil()
+		assert.That(t, string(m.Payload)).Equal(want)
+	}
+	_, err = s.Recv()
+	assert.Error(t, err).Is(ErrStreamOverflow)
+	_, err = s.Recv()
+	assert.Error(t, err).Is(io.EOF)
+}
+
+// nativeBinder advertises NativeRequester and answers without any broker.
+type nativeBinder struct{ *memBinder }
+
+func (nativeBinder) Request(_ context.Context, dest string, msg *Message) (*Message, error) {
+	return &Message{Payload: append([]byte(dest+":"), msg.Payload...)}, nil
+}
+
+func TestRequest_NativeFastPath(t *testing.T) {
+	r := NewRequester(nativeBinder{newMemBinder()}, RequesterConfig{})
+	m, err := r.Request(context.Background(), "echo", &Message{Payload: []byte("hi")})
+	assert.Error(t, err).Nil()
+	assert.That(t, string(m.Payload)).Equal("echo:hi")
+}
@@ -0,0 +1,316 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registration is the shared provider-side lifecycle that publishes
+// this process to a service registry through a [discovery.Registrar].
+//
+// A registry starter (etcd, Consul, Nacos, ZooKeeper, ...) only implements the
+// Registrar for its backend and exports an [AutoRegistration] built around it
+// as a gs.Server. The lifecycle is then the same everywhere:
+//
+//   - the instance is registered once the application is ready, so discovery
+//     never hands out a process that cannot serve yet;
+//   - it is deregistered in PreStop, before the pre-stop delay and before any
+//     server stops, so consumers drop it while in-flight requests drain;
+//   - a periodic heartbeat publishes the status aggregated from the
+//     application's [health.Indicator] beans, taking a failing instance out
+//     of the healthy set without deregistering it;
+//   - Kubernetes Pod metadata and labels from [podinfo.PodInfo], when that
+//     bean exists, are published with the instance so consumers can route on
+//     them.
+package registration
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go-spring.org/log"
+	"go-spring.org/spring/cloud/actuator/health"
+	"go-spring.org/spring/cloud/discovery"
+	"go-spring.org/spring/experimental/actuator/podinfo"
+	"go-spring.org/spring/gs"
+)
+
+var registrationTag = log.RegisterAppTag("registry", "registration")
+
+// Config binds the instance to advertise under ${spring.registry}. It
+// describes the instance itself and is the same whichever registry backend
+// is in use.
+type Config struct {
+	// ServiceName is the logical name consumers resolve. Required.
+	ServiceName string `value:"${service-name:=}"`
+
+	// Addr is the connectable "host:port" advertised to clients. Required;
+	// it is never guessed, so a misconfiguration fails at startup.
+	Addr string `value:"${addr:=}"`
+
+	// ID overrides the instance id within the service; empty derives a
+	// stable one from ServiceName and Addr so restarts replace the same entry.
+	ID string `value:"${id:=}"`
+
+	// Scheme is the transport scheme advertised to clients ("", "tls", ...).
+	Scheme string `value:"${scheme:=}"`
+
+	// Weight is the load-balancing weight; 0 means the backend default.
+	Weight int `value:"${weight:=0}"`
+
+	// Metadata is published with the instance (zone, unit, version, ...). It
+	// overrides Pod metadata and labels with the same key.
+	Metadata map[string]string `value:"${metadata:=}"`
+
+	// HeartbeatInterval is how often the health status is re-evaluated and
+	// published. 0 disables the heartbeat.
+	HeartbeatInterval time.Duration `value:"${heartbeat-interval:=10s}"`
+}
+
+// AutoRegistration registers the instance described by Config when the
+// application is ready and deregisters it when shutdown begins. It opens no
+// port. Its exported fields are populated by the container.
+type AutoRegistration struct {
+	// Config is bound from ${spring.registry}.
+	Config Config `value:"${spring.registry}"`
+
+	// Pod, when registered as a bean, contributes Pod metadata and labels.
+	Pod *podinfo.PodInfo `autowire:"?"`
+
+	// Indicators decide the advertised status: a critical readiness
+	// indicator that fails marks the instance DOWN.
+	Indicators []health.Indicator `autowire:"?"`
+
+	registrar discovery.Registrar
+
+	// mu guards the fields below but is never held across a registry call;
+	// the call in flight is tracked instead so deregister can end it first.
+	mu         sync.Mutex
+	inst       discovery.Instance
+	registered bool
+	stopped    bool
+	cancelCall context.CancelFunc // cancels the register or heartbeat in flight
+	callDone   chan struct{}      // closed when that call has returned
+}
+
+// New returns an [AutoRegistration] that publishes through r. A registry
+// starter exports the result as a gs.Server.
+func New(r discovery.Registrar) *AutoRegistration {
+	return &AutoRegistration{registrar: r}
+}
+
+// Run validates the configuration before signalling readiness, registers the
+// instance once the application is ready, then heartbeats until shutdown.
+func (a *AutoRegistration) Run(ctx context.Context, sig gs.ReadySignal) error {
+	inst, err := a.instance(ctx)
+	if err != nil {
+		return err
+	}
+
+	<-sig.TriggerAndWait()
+
+	inst.Status = a.status(ctx)
+	if err := a.register(ctx, inst); err != nil {
+		return err
+	}
+	if a.Config.HeartbeatInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(a.Config.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.heartbeat(ctx)
+		}
+	}
+}
+
+// PreStop deregisters the instance as soon as shutdown begins, so discovery
+// removes it while in-flight requests keep being served.
+func (a *AutoRegistration) PreStop(ctx context.Context) {
+	a.deregister(ctx)
+}
+
+// Stop deregisters as a fallback should PreStop not have run.
+func (a *AutoRegistration) Stop() error {
+	a.deregister(context.Background())
+	return nil
+}
+
+// instance builds the instance to publish from Config and the Pod bean.
+func (a *AutoRegistration) instance(ctx context.Context) (discovery.Instance, error) {
+	c := a.Config
+	if c.ServiceName == "" || c.Addr == "" {
+		return discovery.Instance{}, errors.New("registry: ${spring.registry.service-name} and ${spring.registry.addr} are required")
+	}
+	meta := map[string]string{}
+	if a.Pod != nil {
+		labels, err := a.Pod.Labels()
+		if err != nil {
+			log.Warnf(ctx, registrationTag, "read pod labels: %v", err)
+		}
+		maps.Copy(meta, labels)
+		maps.Copy(meta, a.Pod.Metadata())
+	}
+	maps.Copy(meta, c.Metadata)
+	return discovery.Instance{
+		ServiceName: c.ServiceName,
+		ID:          c.ID,
+		Addr:        c.Addr,
+		Scheme:      c.Scheme,
+		Weight:      c.Weight,
+		Metadata:    meta,
+	}, nil
+}
+
+// status aggregates the critical readiness indicators.
+func (a *AutoRegistration) status(ctx context.Context) discovery.Status {
+	timeout := a.Config.HeartbeatInterval
+	if timeout <= 0 || timeout > 5*time.Second {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for _, ind := range a.Indicators {
+		if !ind.IsCritical() || !readiness(ind) {
+			continue
+		}
+		if err := ind.CheckHealth(ctx); err != nil {
+			log.Warnf(ctx, registrationTag, "health %s is down: %v", ind.HealthName(), err)
+			return discovery.StatusDown
+		}
+	}
+	return discovery.StatusUp
+}
+
+// readiness reports whether ind contributes to the readiness probe, which is
+// the default for an indicator that declares no groups.
+func readiness(ind health.Indicator) bool {
+	groups := ind.HealthGroups()
+	return len(groups) == 0 || slices.Contains(groups, health.GroupReadiness)
+}
+
+func (a *AutoRegistration) register(ctx context.Context, inst discovery.Instance) error {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return nil
+	}
+	ctx = a.beginCallLocked(ctx)
+	a.mu.Unlock()
+
+	log.Debugf(ctx, registrationTag, "registering service=%s id=%s addr=%s status=%s", inst.ServiceName, discovery.InstanceID(inst), inst.Addr, inst.Status)
+	err := a.registrar.Register(ctx, inst)
+
+	a.mu.Lock()
+	if err == nil {
+		a.inst, a.registered = inst, true
+	}
+	stopped := a.stopped
+	a.endCallLocked()
+	a.mu.Unlock()
+
+	if err != nil {
+		if stopped { // cancelled by shutdown
+			return nil
+		}
+		log.Errorf(ctx, registrationTag, "register service=%s failed: %v", inst.ServiceName, err)
+		return err
+	}
+	log.Infof(ctx, registrationTag, "registered %q at %s", inst.ServiceName, inst.Addr)
+	return nil
+}
+
+// heartbeat publishes the current status. A failed heartbeat is logged and
+// retried on the next tick; the backend's own lease or TTL keeps the entry
+// consistent meanwhile.
+func (a *AutoRegistration) heartbeat(ctx context.Context) {
+	status := a.status(ctx)
+	a.mu.Lock()
+	if a.stopped || !a.registered {
+		a.mu.Unlock()
+		return
+	}
+	prev := a.inst.Status
+	a.inst.Status = status
+	inst := a.inst
+	ctx = a.beginCallLocked(ctx)
+	a.mu.Unlock()
+
+	if status != prev {
+		log.Infof(ctx, registrationTag, "service %q status %s -> %s", inst.ServiceName, prev, status)
+	}
+	err := a.registrar.Heartbeat(ctx, inst)
+
+	a.mu.Lock()
+	stopped := a.stopped
+	a.endCallLocked()
+	a.mu.Unlock()
+	if err != nil && !stopped {
+		log.Warnf(ctx, registrationTag, "heartbeat %q: %v", inst.ServiceName, err)
+	}
+}
+
+// deregister is idempotent; once it has run no heartbeat or late
+// registration publishes the instance again. A register or heartbeat still
+// in flight is cancelled and awaited first, so it cannot land after the
+// instance was removed.
+func (a *AutoRegistration) deregister(ctx context.Context) {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return
+	}
+	a.stopped = true
+	cancel, done := a.cancelCall, a.callDone
+	a.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	a.mu.Lock()
+	inst, registered := a.inst, a.registered
+	a.mu.Unlock()
+	if !registered {
+		return
+	}
+	if err := a.registrar.Deregister(ctx, inst); err != nil {
+		log.Warnf(ctx, registrationTag, "deregister %q: %v", inst.ServiceName, err)
+	}
+}
+
+// beginCallLocked records a registry call about to be made and returns the
+// context to make it with. It must be called with mu held, and the call
+// closed with endCallLocked. Run makes one call at a time.
+func (a *AutoRegistration) beginCallLocked(ctx context.Context) context.Context {
+	ctx, a.cancelCall = context.WithCancel(ctx)
+	a.callDone = make(chan struct{})
+	return ctx
+}
+
+// endCallLocked marks the call started by beginCallLocked as returned.
+func (a *AutoRegistration) endCallLocked() {
+	a.cancelCall()
+	close(a.callDone)
+	a.cancelCall, a.callDone = nil, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/cloud/actuator/health"
+	"go-spring.org/spring/cloud/discovery"
+	"go-spring.org/spring/experimental/actuator/podinfo"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// readySignal is a ReadySignal whose readiness the test releases.
+type readySignal chan struct{}
+
+func (s readySignal) TriggerAndWait() <-chan struct{} { return s }
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAutoRegistrationLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	labels := filepath.Join(dir, "labels")
+	assert.Error(t, os.WriteFile(labels, []byte("version=\"v2\"\nzone=\"z1\"\n"), 0o644)).Nil()
+
+	reg := discovery.NewMemoryRegistry()
+	var failing atomic.Bool
+	a := New(reg)
+	a.Config = Config{
+		ServiceName:       "orders",
+		Addr:              "10.0.0.1:80",
+		Metadata:          map[string]string{"zone": "z9"},
+		HeartbeatInterval: 5 * time.Millisecond,
+	}
+	a.Pod = &podinfo.PodInfo{Name: "orders-0", LabelsPath: labels}
+	a.Indicators = []health.Indicator{
+		health.NewIndicator("db", func(context.Context) error {
+			if failing.Load() {
+				return errors.New("down")
+			}
+			return nil
+		}),
+		// A non-critical failure never changes the status.
+		health.NewIndicator("cache", func(context.Context) error { return errors.New("down") }, health.NonCritical()),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(readySignal)
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx, sig) }()
+
+	// Nothing is published before the application is ready.
+	time.Sleep(10 * time.Millisecond)
+	assert.Number(t, len(reg.Instances("orders"))).Equal(0)
+
+	close(sig)
+	waitFor(t, func() bool { return len(reg.Instances("orders")) == 1 })
+	inst := reg.Instances("orders")[0]
+	assert.That(t, inst.Status).Equal(discovery.StatusUp)
+	// Labels, Pod metadata and configured metadata, the latter winning.
+	assert.String(t, inst.Metadata["version"]).Equal("v2")
+	assert.String(t, inst.Metadata["pod.name"]).Equal("orders-0")
+	assert.String(t, inst.Metadata["zone"]).Equal("z9")
+
+	// A failing readiness indicator marks the instance down, and back up.
+	failing.Store(true)
+	waitFor(t, func() bool { return reg.Instances("orders")[0].Status == discovery.StatusDown })
+	failing.Store(false)
+	waitFor(t, func() bool { return reg.Instances("orders")[0].Status == discovery.StatusUp })
+
+	// PreStop deregisters; later heartbeats do not bring it back.
+	a.PreStop(context.Background())
+	assert.Number(t, len(reg.Instances("orders"))).Equal(0)
+	time.Sleep(20 * time.Millisecond)
+	assert.Number(t, len(reg.Instances("orders"))).Equal(0)
+	assert.Error(t, a.Stop()).Nil()
+
+	cancel()
+	assert.Error(t, <-done).Nil()
+}
+
+// stuckRegistrar is a registry whose heartbeats hang until their context ends.
+type stuckRegistrar struct {
+	*discovery.MemoryRegistry
+	beating chan struct{}
+}
+
+func (r *stuckRegistrar) Heartbeat(ctx context.Context, _ discovery.Instance) error {
+	select {
+	case r.beating <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestAutoRegistrationPreStopEndsHeartbeat(t *testing.T) {
+	reg := &stuckRegistrar{MemoryRegistry: discovery.NewMemoryRegistry(), beating: make(chan struct{}, 1)}
+	a := New(reg)
+	a.Config = Config{ServiceName: "orders", Addr: "10.0.0.1:80", HeartbeatInterval: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(readySignal)
+	close(sig)
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx, sig) }()
+
+	// A hung heartbeat neither blocks PreStop nor outlives the deregistration.
+	<-reg.beating
+	a.PreStop(context.Background())
+	assert.Number(t, len(reg.Instances("orders"))).Equal(0)
+
+	cancel()
+	assert.Error(t, <-done).Nil()
+}
+
+func TestAutoRegistrationRequiresConfig(t *testing.T) {
+	a := New(discovery.NewMemoryRegistry())
+	err := a.Run(context.Background(), make(readySignal))
+	assert.Error(t, err).Matches("service-name.*addr.*required")
+}
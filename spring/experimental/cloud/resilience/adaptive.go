@@ -0,0 +1,438 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrConcurrencyLimited is returned (or wrapped) by an [Executor] when an
+// operation is rejected because its resource is at the adaptive concurrency
+// limit (see [AdaptiveLimitPolicy]).
+var ErrConcurrencyLimited = errors.New("resilience: concurrency limited")
+
+// Priority ranks an operation for the adaptive concurrency limiter. Under
+// overload lower priorities are rejected first; the zero value is
+// [PriorityNormal].
+type Priority int
+
+const (
+	// PrioritySheddable traffic (prefetches, analytics, retries of optional
+	// work) may only use half of the current limit, so it is shed before
+	// normal traffic feels any pressure.
+	PrioritySheddable Priority = -1
+
+	// PriorityNormal traffic may use the whole limit.
+	PriorityNormal Priority = 0
+
+	// PriorityCritical traffic (health checks, control-plane calls) bypasses
+	// the limit: it is neither counted nor sampled, so a saturated resource
+	// can still answer its probes. Keep it cheap and rare.
+	PriorityCritical Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying p for the adaptive limiter.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFrom returns the priority carried by ctx, or [PriorityNormal].
+func PriorityFrom(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// AdaptiveAlgorithm names a built-in [LimitAlgorithm].
+type AdaptiveAlgorithm string
+
+const (
+	// Vegas estimates the queue from the ratio of the no-load RTT to the
+	// sampled RTT, growing the limit while the queue is short (see
+	// [NewVegasLimit]).
+	Vegas AdaptiveAlgorithm = "vegas"
+
+	// Gradient2 follows the gradient between a long-term and the current RTT
+	// (see [NewGradient2Limit]).
+	Gradient2 AdaptiveAlgorithm = "gradient2"
+
+	// AIMD adds one on success and backs off multiplicatively on a drop (see
+	// [NewAIMDLimit]).
+	AIMD AdaptiveAlgorithm = "aimd"
+)
+
+// AdaptiveLimitPolicy asks the executor for an adaptive concurrency limit per
+// resource, in the style of Netflix concurrency-limits: the limit follows the
+// observed latency and drops instead of being fixed like
+// Policy.MaxConcurrent. Each attempt is one sample. A zero value disables the
+// stage.
+type AdaptiveLimitPolicy struct {
+	// Algorithm selects the limit algorithm; empty disables the stage.
+	Algorithm AdaptiveAlgorithm
+
+	// InitialLimit, MinLimit and MaxLimit bound the limit. Zero values take
+	// the algorithm's defaults.
+	InitialLimit, MinLimit, MaxLimit int
+}
+
+// NewLimitAlgorithm builds the built-in algorithm p names, with its default
+// tuning. It returns nil for a zero policy.
+func NewLimitAlgorithm(p AdaptiveLimitPolicy) (LimitAlgorithm, error) {
+	b := LimitBounds{Initial: p.InitialLimit, Min: p.MinLimit, Max: p.MaxLimit}
+	switch p.Algorithm {
+	case "":
+		return nil, nil
+	case Vegas:
+		return NewVegasLimit(VegasConfig{LimitBounds: b}), nil
+	case Gradient2:
+		return NewGradient2Limit(Gradient2Config{LimitBounds: b}), nil
+	case AIMD:
+		return NewAIMDLimit(AIMDConfig{LimitBounds: b}), nil
+	default:
+		return nil, fmt.Errorf("resilience: unknown adaptive limit algorithm %q", p.Algorithm)
+	}
+}
+
+// LimitAlgorithm computes a concurrency limit from latency samples. The
+// [AdaptiveLimiter] serialises calls, so implementations need no locking.
+type LimitAlgorithm interface {
+	// Limit returns the current limit.
+	Limit() int
+
+	// Update folds in one sample and returns the new limit. rtt is the
+	// operation's duration, inflight the number of operations in flight when
+	// it started, and dropped whether it timed out or was shed downstream.
+	Update(rtt time.Duration, inflight int, dropped bool) int
+}
+
+// LimitBounds are the bounds shared by the built-in algorithms.
+type LimitBounds struct {
+	// Initial is the limit before any sample. Defaults to 20.
+	Initial int
+
+	// Min and Max clamp the limit. They default to 1 and 1000.
+	Min, Max int
+}
+
+func (b LimitBounds) withDefaults() LimitBounds {
+	if b.Min <= 0 {
+		b.Min = 1
+	}
+	if b.Max <= 0 {
+		b.Max = 1000
+	}
+	b.Max = max(b.Max, b.Min)
+	if b.Initial <= 0 {
+		b.Initial = 20
+	}
+	b.Initial = min(max(b.Initial, b.Min), b.Max)
+	return b
+}
+
+func (b LimitBounds) clamp(v float64) float64 {
+	return min(max(v, float64(b.Min)), float64(b.Max))
+}
+
+// log10Floor is the log10 step Vegas scales its thresholds by, never below 1
+// so a small limit still moves.
+func log10Floor(v float64) float64 { return max(1, math.Log10(v)) }
+
+// VegasConfig tunes [NewVegasLimit].
+type VegasConfig struct {
+	LimitBounds
+
+	// Smoothing in (0, 1] weights the new limit against the old one. Defaults
+	// to 1 (no smoothing).
+	Smoothing float64
+
+	// ProbeMultiplier resets the no-load RTT every ProbeMultiplier*limit
+	// samples, so a permanently slower downstream becomes the new baseline.
+	// Defaults to 30.
+	ProbeMultiplier int
+}
+
+// NewVegasLimit returns a TCP-Vegas style algorithm. It treats the smallest
+// RTT seen as the no-load latency and estimates the queue as
+// limit*(1-noLoad/rtt). With a queue below 3*log10(limit) the limit grows, above
+// 6*log10(limit) it shrinks, and a drop always shrinks it by log10(limit).
+// Samples taken while less than half the limit is in use leave it unchanged.
+func NewVegasLimit(c VegasConfig) LimitAlgorithm {
+	c.LimitBounds = c.LimitBounds.withDefaults()
+	if c.Smoothing <= 0 || c.Smoothing > 1 {
+		c.Smoothing = 1
+	}
+	if c.ProbeMultiplier <= 0 {
+		c.ProbeMultiplier = 30
+	}
+	return &vegasLimit{cfg: c, limit: float64(c.Initial)}
+}
+
+type vegasLimit struct {
+	cfg     VegasConfig
+	limit   float64
+	noLoad  time.Duration
+	samples int
+}
+
+func (v *vegasLimit) Limit() int { return int(v.limit) }
+
+func (v *vegasLimit) Update(rtt time.Duration, inflight int, dropped bool) int {
+	if rtt <= 0 {
+		return v.Limit()
+	}
+	v.samples++
+	if v.noLoad == 0 || rtt < v.noLoad || v.samples >= v.cfg.ProbeMultiplier*v.Limit() {
+		// A probe re-baselines at the current RTT; the limit holds until the
+		// next sample compares against it.
+		v.noLoad, v.samples = rtt, 0
+		return v.Limit()
+	}
+	step := log10Floor(v.limit)
+	next := v.limit
+	switch queue := math.Ceil(v.limit * (1 - float64(v.noLoad)/float64(rtt))); {
+	case dropped:
+		next = v.limit - step
+	case float64(inflight)*2 < v.limit:
+		return v.Limit() // application limited: no signal
+	case queue <= step:
+		next = v.limit + 6*step
+	case queue < 3*step:
+		next = v.limit + step
+	case queue > 6*step:
+		next = v.limit - step
+	}
+	next = v.cfg.clamp(next)
+	v.limit = (1-v.cfg.Smoothing)*v.limit + v.cfg.Smoothing*next
+	return v.Limit()
+}
+
+// Gradient2Config tunes [NewGradient2Limit].
+type Gradient2Config struct {
+	LimitBounds
+
+	// Smoothing in (0, 1] weights the new limit against the old one. Defaults
+	// to 0.2.
+	Smoothing float64
+
+	// RTTTolerance is how much slower than the long-term RTT a sample may be
+	// before the limit shrinks. Defaults to 1.5.
+	RTTTolerance float64
+
+	// LongWindow is the number of samples the long-term RTT averages over.
+	// Defaults to 600.
+	LongWindow int
+}
+
+// NewGradient2Limit returns the Gradient2 algorithm. It keeps an exponential
+// average of RTT over LongWindow samples and scales the limit by
+// RTTTolerance*longRTT/rtt, clamped to [0.5, 1], plus a queue allowance of
+// sqrt(limit). Drops carry no extra signal: their latency already shows in
+// the gradient. Samples taken while less than half the limit is in use leave
+// it unchanged.
+func NewGradient2Limit(c Gradient2Config) LimitAlgorithm {
+	c.LimitBounds = c.LimitBounds.withDefaults()
+	if c.Smoothing <= 0 || c.Smoothing > 1 {
+		c.Smoothing = 0.2
+	}
+	if c.RTTTolerance < 1 {
+		c.RTTTolerance = 1.5
+	}
+	if c.LongWindow <= 0 {
+		c.LongWindow = 600
+	}
+	return &gradient2Limit{cfg: c, limit: float64(c.Initial)}
+}
+
+type gradient2Limit struct {
+	cfg     Gradient2Config
+	limit   float64
+	longRTT float64 // nanoseconds
+	samples int
+}
+
+func (g *gradient2Limit) Limit() int { return int(g.limit) }
+
+func (g *gradient2Limit) Update(rtt time.Duration, inflight int, _ bool) int {
+	if rtt <= 0 {
+		return g.Limit()
+	}
+	short := float64(rtt)
+	// The long-term average warms up as a plain mean, then decays.
+	if g.samples < g.cfg.LongWindow {
+		g.samples++
+		g.longRTT += (short - g.longRTT) / float64(g.samples)
+	} else {
+		g.longRTT += (short - g.longRTT) * 2 / float64(g.cfg.LongWindow+1)
+	}
+	if g.longRTT/short > 2 {
+		// Latency recovered well below the long-term average: pull the average
+		// down faster so the limit can grow again.
+		g.longRTT *= 0.95
+	}
+	if float64(inflight) < g.limit/2 {
+		return g.Limit() // application limited: no signal
+	}
+	gradient := max(0.5, min(1, g.cfg.RTTTolerance*g.longRTT/short))
+	next := g.cfg.clamp(g.limit*gradient + math.Sqrt(g.limit))
+	g.limit = g.cfg.clamp((1-g.cfg.Smoothing)*g.limit + g.cfg.Smoothing*next)
+	return g.Limit()
+}
+
+// AIMDConfig tunes [NewAIMDLimit].
+type AIMDConfig struct {
+	LimitBounds
+
+	// BackoffRatio in (0, 1) multiplies the limit on a drop. Defaults to 0.9.
+	BackoffRatio float64
+
+	// Timeout, when positive, turns a sample slower than it into a drop.
+	Timeout time.Duration
+}
+
+// NewAIMDLimit returns an additive-increase / multiplicative-decrease
+// algorithm: the limit grows by one per sample taken while at least half of
+// it is in use and is multiplied by BackoffRatio on each drop.
+func NewAIMDLimit(c AIMDConfig) LimitAlgorithm {
+	c.LimitBounds = c.LimitBounds.withDefaults()
+	if c.BackoffRatio <= 0 || c.BackoffRatio >= 1 {
+		c.BackoffRatio = 0.9
+	}
+	return &aimdLimit{cfg: c, limit: float64(c.Initial)}
+}
+
+type aimdLimit struct {
+	cfg   AIMDConfig
+	limit float64
+}
+
+func (a *aimdLimit) Limit() int { return int(a.limit) }
+
+func (a *aimdLimit) Update(rtt time.Duration, inflight int, dropped bool) int {
+	switch {
+	case dropped || (a.cfg.Timeout > 0 && rtt > a.cfg.Timeout):
+		a.limit = a.cfg.clamp(a.limit * a.cfg.BackoffRatio)
+	case float64(inflight)*2 >= a.limit:
+		a.limit = a.cfg.clamp(a.limit + 1)
+	}
+	return a.Limit()
+}
+
+// LimitOutcome tells the [AdaptiveLimiter] how an admitted operation ended.
+type LimitOutcome int
+
+const (
+	// OutcomeSuccess samples the operation's latency.
+	OutcomeSuccess LimitOutcome = iota
+
+	// OutcomeDropped samples the operation as a drop: it timed out or was
+	// shed downstream, a congestion signal.
+	OutcomeDropped
+
+	// OutcomeIgnored releases the slot without sampling, for failures whose
+	// latency says nothing about load (a validation error, a panic, ...).
+	OutcomeIgnored
+)
+
+// AdaptiveLimiter admits operations while fewer than the algorithm's limit are
+// in flight, honoring the [Priority] on each operation's context. It is the
+// stage the builtin executor applies per resource for a
+// [AdaptiveLimitPolicy]; a server or another driver may use it directly.
+type AdaptiveLimiter struct {
+	alg LimitAlgorithm
+
+	mu       sync.Mutex
+	limit    int
+	inflight int
+}
+
+// NewAdaptiveLimiter returns a limiter driven by alg.
+func NewAdaptiveLimiter(alg LimitAlgorithm) *AdaptiveLimiter {
+	return &AdaptiveLimiter{alg: alg, limit: max(1, alg.Limit())}
+}
+
+// Acquire admits one operation at the priority carried by ctx. When ok is true
+// the caller must call release exactly once with the outcome; the latency
+// sample is measured from Acquire to release.
+func (l *AdaptiveLimiter) Acquire(ctx context.Context) (release func(LimitOutcome), ok bool) {
+	p := PriorityFrom(ctx)
+	if p >= PriorityCritical {
+		return func(LimitOutcome) {}, true
+	}
+	l.mu.Lock()
+	capacity := l.limit
+	if p < PriorityNormal {
+		capacity = max(1, capacity/2)
+	}
+	if l.inflight >= capacity {
+		l.mu.Unlock()
+		return nil, false
+	}
+	l.inflight++
+	inflight := l.inflight
+	l.mu.Unlock()
+
+	start := time.Now()
+	var once sync.Once
+	return func(o LimitOutcome) {
+		once.Do(func() { l.release(o, time.Since(start), inflight) })
+	}, true
+}
+
+func (l *AdaptiveLimiter) release(o LimitOutcome, rtt time.Duration, inflight int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if o != OutcomeIgnored {
+		l.limit = max(1, l.alg.Update(rtt, inflight, o == OutcomeDropped))
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Inflight returns the number of admitted operations not yet released.
+func (l *AdaptiveLimiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+// LimitOutcomeOf classifies an operation's error for the limiter: nil is a
+// success, a deadline or a neutral rejection from a downstream stage is a
+// drop, and any other error is ignored.
+func LimitOutcomeOf(err error) LimitOutcome {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrRateLimited),
+		errors.Is(err, ErrBulkheadFull), errors.Is(err, ErrCircuitOpen),
+		errors.Is(err, ErrConcurrencyLimited):
+		return OutcomeDropped
+	default:
+		return OutcomeIgnored
+	}
+}
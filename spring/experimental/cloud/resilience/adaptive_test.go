@@ -0,0 +1,180 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestAIMDLimit(t *testing.T) {
+	a := NewAIMDLimit(AIMDConfig{LimitBounds: LimitBounds{Initial: 10, Max: 11}, Timeout: time.Second})
+	assert.That(t, a.Update(time.Millisecond, 2, false)).Equal(10) // app limited
+	assert.That(t, a.Update(time.Millisecond, 5, false)).Equal(11)
+	assert.That(t, a.Update(time.Millisecond, 11, false)).Equal(11) // clamped
+	assert.That(t, a.Update(time.Millisecond, 11, true)).Equal(9)   // 11 * 0.9
+	assert.That(t, a.Update(2*time.Second, 11, false)).Equal(8)     // slow is a drop
+}
+
+func TestVegasLimit(t *testing.T) {
+	v := NewVegasLimit(VegasConfig{LimitBounds: LimitBounds{Initial: 10}})
+	assert.That(t, v.Update(10*time.Millisecond, 10, false)).Equal(10) // baseline
+
+	// No queue at the baseline RTT: grow by 6*log10(10).
+	assert.That(t, v.Update(10*time.Millisecond, 10, false)).Equal(16)
+
+	// Half the limit is used at most: no signal.
+	assert.That(t, v.Update(10*time.Millisecond, 4, false)).Equal(16)
+
+	// Twice the baseline RTT estimates a queue of 8 > 6*log10(16): shrink.
+	assert.That(t, v.Update(20*time.Millisecond, 16, false)).Equal(14)
+
+	// A drop always shrinks.
+	assert.That(t, v.Update(10*time.Millisecond, 1, true)).Equal(13)
+}
+
+func TestGradient2Limit(t *testing.T) {
+	g := NewGradient2Limit(Gradient2Config{LimitBounds: LimitBounds{Initial: 16}, Smoothing: 1})
+	// Steady latency at full use: gradient 1, so the queue allowance grows it.
+	assert.That(t, g.Update(10*time.Millisecond, 16, false)).Equal(20)
+
+	// Latency far above the long-term average halves it (plus the allowance).
+	for range 3 {
+		g.Update(10*time.Millisecond, 64, false)
+	}
+	before := g.Limit()
+	after := g.Update(time.Second, before, false)
+	assert.That(t, after < before).True()
+}
+
+// fixedLimit is a LimitAlgorithm that never moves.
+type fixedLimit int
+
+func (f fixedLimit) Limit() int                          { return int(f) }
+func (f fixedLimit) Update(time.Duration, int, bool) int { return int(f) }
+
+func TestAdaptiveLimiterPriorities(t *testing.T) {
+	l := NewAdaptiveLimiter(fixedLimit(4))
+	shed := WithPriority(context.Background(), PrioritySheddable)
+	critical := WithPriority(context.Background(), PriorityCritical)
+
+	var releases []func(LimitOutcome)
+	for range 2 {
+		r, ok := l.Acquire(shed)
+		assert.That(t, ok).True()
+		releases = append(releases, r)
+	}
+	_, ok := l.Acquire(shed) // sheddable gets half the limit
+	assert.That(t, ok).False()
+
+	for range 2 {
+		r, ok := l.Acquire(context.Background())
+		assert.That(t, ok).True()
+		releases = append(releases, r)
+	}
+	_, ok = l.Acquire(context.Background())
+	assert.That(t, ok).False()
+
+	// Critical traffic bypasses the limit and is not counted.
+	_, ok = l.Acquire(critical)
+	assert.That(t, ok).True()
+	assert.That(t, l.Inflight()).Equal(4)
+
+	for _, r := range releases {
+		r(OutcomeSuccess)
+		r(OutcomeSuccess) // a second release is a no-op
+	}
+	assert.That(t, l.Inflight()).Equal(0)
+}
+
+func TestLimitOutcomeOf(t *testing.T) {
+	assert.That(t, LimitOutcomeOf(nil)).Equal(OutcomeSuccess)
+	assert.That(t, LimitOutcomeOf(context.DeadlineExceeded)).Equal(OutcomeDropped)
+	assert.That(t, LimitOutcomeOf(ErrRateLimited)).Equal(OutcomeDropped)
+	assert.That(t, LimitOutcomeOf(errors.New("bad request"))).Equal(OutcomeIgnored)
+}
+
+func TestExecuteAdaptiveLimit(t *testing.T) {
+	e := newBuiltin(t, Policy{AdaptiveLimit: AdaptiveLimitPolicy{Algorithm: AIMD, InitialLimit: 1, MaxLimit: 1}})
+
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_ = e.Execute(context.Background(), "svc", func(context.Context) error {
+			close(entered)
+			<-release
+			return nil
+		})
+	})
+	<-entered
+
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(ErrConcurrencyLimited)
+	critical := WithPriority(context.Background(), PriorityCritical)
+	assert.Error(t, e.Execute(critical, "svc", func(context.Context) error { return nil })).Nil()
+
+	close(release)
+	wg.Wait()
+	assert.Error(t, e.Execute(context.Background(), "svc", func(context.Context) error { return nil })).Nil()
+
+	d, err := MustGetDriver("default")
+	assert.Error(t, err).Nil()
+	_, err = d.NewExecutor(Policy{AdaptiveLimit: AdaptiveLimitPolicy{Algorithm: "bbr"}})
+	assert.Error(t, err).Matches("unknown adaptive limit algorithm")
+}
+
+func TestHandlerShedsByPriority(t *testing.T) {
+	e := newBuiltin(t, Policy{AdaptiveLimit: AdaptiveLimitPolicy{Algorithm: Vegas, InitialLimit: 1, MaxLimit: 1}})
+	release := make(chan struct{})
+	entered := make(chan struct{}, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/work", func(http.ResponseWriter, *http.Request) {
+		entered <- struct{}{}
+		<-release
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) })
+	h := NewPriorityHandler(NewHandler(mux, e, func(*http.Request) string { return "server" }),
+		func(r *http.Request) Priority {
+			if r.URL.Path == "/healthz" {
+				return PriorityCritical
+			}
+			return PriorityNormal
+		})
+
+	var wg sync.WaitGroup
+	wg.Go(func() { h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/work", nil)) })
+	<-entered
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/work", nil))
+	assert.That(t, rec.Code).Equal(http.StatusTooManyRequests)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.That(t, rec.Code).Equal(http.StatusOK)
+
+	close(release)
+	wg.Wait()
+}
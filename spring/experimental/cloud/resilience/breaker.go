@@ -0,0 +1,450 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CircuitState is the state of one resource's circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through and records its outcome.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every call with [ErrCircuitOpen] until
+	// Policy.OpenDuration has elapsed.
+	CircuitOpen
+
+	// CircuitHalfOpen admits Policy.PermittedCallsInHalfOpen trial calls whose
+	// outcome closes or re-opens the breaker; further calls are rejected.
+	CircuitHalfOpen
+)
+
+// String returns the Resilience4j-style name of the state, e.g. "HALF_OPEN".
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "CLOSED"
+	case CircuitOpen:
+		return "OPEN"
+	case CircuitHalfOpen:
+		return "HALF_OPEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SlidingWindowType selects how a breaker's sliding window is measured.
+type SlidingWindowType string
+
+const (
+	// CountBasedWindow aggregates the outcome of the last SlidingWindowSize
+	// calls. It is the default.
+	CountBasedWindow SlidingWindowType = "count"
+
+	// TimeBasedWindow aggregates the outcome of the calls made in the last
+	// SlidingWindowSize seconds.
+	TimeBasedWindow SlidingWindowType = "time"
+)
+
+// BreakerState is a point-in-time snapshot of one resource's breaker.
+type BreakerState struct {
+	// Executor is the Policy.Name of the executor owning the breaker, or a
+	// generated "executor-N" when the policy has no name, so breakers of two
+	// executors never report the same series.
+	Executor string
+
+	// Resource is the resource the breaker guards.
+	Resource string
+
+	// State is the breaker's current state.
+	State CircuitState
+
+	// Calls is the number of calls in the sliding window. It is 0 for a
+	// breaker driven by ErrorThreshold alone, which keeps no window.
+	Calls int
+
+	// FailureRate and SlowCallRate are the percentages of failed and slow
+	// calls in the window, 0 while it is empty.
+	FailureRate, SlowCallRate float64
+}
+
+var (
+	reportMu  sync.Mutex
+	reportSeq uint64
+	reporters = map[uint64]BreakerReporter{}
+
+	anonymousSeq atomic.Uint64
+)
+
+// anonymousExecutorName names an executor whose policy has none.
+func anonymousExecutorName() string {
+	return "executor-" + strconv.FormatUint(anonymousSeq.Add(1), 10)
+}
+
+// ReportBreakers adds r to the set read by [Breakers] until the returned
+// cancel is called. The builtin executor registers itself when its policy
+// enables the breaker and cancels on Close; other drivers may do the same.
+func ReportBreakers(r BreakerReporter) (cancel func()) {
+	reportMu.Lock()
+	defer reportMu.Unlock()
+	reportSeq++
+	id := reportSeq
+	reporters[id] = r
+	return func() {
+		reportMu.Lock()
+		defer reportMu.Unlock()
+		delete(reporters, id)
+	}
+}
+
+// Breakers returns a snapshot of every breaker of every registered reporter,
+// sorted by executor name and resource. It is what health indicators and
+// metrics endpoints read.
+func Breakers() []BreakerState {
+	reportMu.Lock()
+	rs := make([]BreakerReporter, 0, len(reporters))
+	for _, r := range reporters {
+		rs = append(rs, r)
+	}
+	reportMu.Unlock()
+
+	var out []BreakerState
+	for _, r := range rs {
+		out = append(out, r.BreakerStates()...)
+	}
+	slices.SortFunc(out, func(a, b BreakerState) int {
+		if c := strings.Compare(a.Executor, b.Executor); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Resource, b.Resource)
+	})
+	return out
+}
+
+// breakerEnabled reports whether p asks for a circuit breaker at all.
+func breakerEnabled(p Policy) bool {
+	return p.ErrorThreshold > 0 || p.FailureRateThreshold > 0 || p.SlowCallRateThreshold > 0
+}
+
+// circuitBreaker combines a consecutive-failure trigger (ErrorThreshold) with
+// Resilience4j-style failure-rate and slow-call-rate triggers over a sliding
+// window. Outcomes are only recorded while closed; half-open trials are
+// tallied separately and decide the next state once all of them are back.
+type circuitBreaker struct {
+	resource string
+	policy   Policy
+	openFor  time.Duration
+	minCalls int
+	permits  int
+	now      func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	openedAt    time.Time
+	consecutive int
+	window      outcomeWindow // nil without a rate threshold
+	admitted    int           // trial calls let through while half-open
+	trial       bucket
+}
+
+func newCircuitBreaker(resource string, p Policy) *circuitBreaker {
+	c := &circuitBreaker{
+		resource: resource,
+		policy:   p,
+		openFor:  p.OpenDuration,
+		minCalls: p.MinimumNumberOfCalls,
+		permits:  p.PermittedCallsInHalfOpen,
+		now:      time.Now,
+	}
+	if c.openFor <= 0 {
+		c.openFor = 5 * time.Second
+	}
+	if c.minCalls <= 0 {
+		c.minCalls = 100
+	}
+	if c.permits <= 0 {
+		c.permits = 1
+	}
+	if c.rated() {
+		size := p.SlidingWindowSize
+		if p.SlidingWindowType == TimeBasedWindow {
+			if size <= 0 {
+				size = 60
+			}
+			c.window = &timeWindow{buckets: make([]timeBucket, size)}
+		} else {
+			if size <= 0 {
+				size = 100
+			}
+			c.minCalls = min(c.minCalls, size)
+			c.window = &countWindow{outcomes: make([]outcome, size)}
+		}
+	}
+	return c
+}
+
+// rated reports whether a rate threshold is configured.
+func (c *circuitBreaker) rated() bool {
+	return c.policy.FailureRateThreshold > 0 || c.policy.SlowCallRateThreshold > 0
+}
+
+// allow reports whether a request may proceed given the current breaker state.
+func (c *circuitBreaker) allow() bool {
+	c.mu.Lock()
+	from := c.state
+	ok := c.allowLocked()
+	to := c.state
+	c.mu.Unlock()
+	c.notify(from, to)
+	return ok
+}
+
+func (c *circuitBreaker) allowLocked() bool {
+	switch c.state {
+	case CircuitOpen:
+		if c.now().Sub(c.openedAt) < c.openFor {
+			return false // open, cooling down
+		}
+		// Cool-down elapsed: admit a bounded number of trials (half-open).
+		c.state, c.admitted, c.trial = CircuitHalfOpen, 0, bucket{}
+		fallthrough
+	case CircuitHalfOpen:
+		if c.admitted >= c.permits {
+			return false
+		}
+		c.admitted++
+		return true
+	default:
+		return true
+	}
+}
+
+// record folds an attempt's outcome back into the breaker state. elapsed is
+// the attempt's duration, used to classify slow calls.
+func (c *circuitBreaker) record(failed bool, elapsed time.Duration) {
+	slow := c.policy.SlowCallDurationThreshold > 0 && elapsed > c.policy.SlowCallDurationThreshold
+	c.mu.Lock()
+	from := c.state
+	c.recordLocked(failed, slow)
+	to := c.state
+	c.mu.Unlock()
+	c.notify(from, to)
+}
+
+func (c *circuitBreaker) recordLocked(failed, slow bool) {
+	switch c.state {
+	case CircuitOpen:
+		// A call admitted before the breaker opened; its outcome is stale.
+	case CircuitHalfOpen:
+		c.trial.add(failed, slow)
+		if !c.rated() {
+			// Consecutive-failure mode: any failed trial re-opens at once.
+			if failed {
+				c.open()
+			} else if c.trial.calls >= c.permits {
+				c.close()
+			}
+			return
+		}
+		if c.trial.calls >= c.permits {
+			if c.tripped(c.trial) {
+				c.open()
+			} else {
+				c.close()
+			}
+		}
+	default:
+		if failed {
+			c.consecutive++
+		} else {
+			c.consecutive = 0
+		}
+		if c.policy.ErrorThreshold > 0 && c.consecutive >= c.policy.ErrorThreshold {
+			c.open()
+			return
+		}
+		if c.window != nil {
+			now := c.now()
+			c.window.add(now, failed, slow)
+			if b := c.window.totals(now); b.calls >= c.minCalls && c.tripped(b) {
+				c.open()
+			}
+		}
+	}
+}
+
+// ignore gives back the half-open trial slot of an admitted call whose outcome
+// is discarded, so another call can take the trial instead.
+func (c *circuitBreaker) ignore() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.state == CircuitHalfOpen && c.admitted > c.trial.calls {
+		c.admitted--
+	}
+}
+
+// tripped reports whether the outcomes in b reach a configured rate threshold.
+func (c *circuitBreaker) tripped(b bucket) bool {
+	if b.calls == 0 {
+		return false
+	}
+	if t := c.policy.FailureRateThreshold; t > 0 && b.rate(b.failed) >= t {
+		return true
+	}
+	if t := c.policy.SlowCallRateThreshold; t > 0 && b.rate(b.slow) >= t {
+		return true
+	}
+	return false
+}
+
+func (c *circuitBreaker) open() {
+	c.state, c.openedAt, c.consecutive = CircuitOpen, c.now(), 0
+}
+
+// close starts a fresh window so the outcomes that tripped the breaker cannot
+// trip it again straight away.
+func (c *circuitBreaker) close() {
+	c.state, c.consecutive = CircuitClosed, 0
+	if c.window != nil {
+		c.window.reset()
+	}
+}
+
+func (c *circuitBreaker) notify(from, to CircuitState) {
+	if from != to && c.policy.StateListener != nil {
+		c.policy.StateListener.OnStateChange(c.resource, from, to)
+	}
+}
+
+// snapshot returns the breaker's state and window statistics.
+func (c *circuitBreaker) snapshot() BreakerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := BreakerState{Executor: c.policy.Name, Resource: c.resource, State: c.state}
+	if c.window != nil {
+		b := c.window.totals(c.now())
+		s.Calls, s.FailureRate, s.SlowCallRate = b.calls, b.rate(b.failed), b.rate(b.slow)
+	}
+	return s
+}
+
+// bucket tallies call outcomes.
+type bucket struct {
+	calls, failed, slow int
+}
+
+func (b *bucket) add(failed, slow bool) {
+	b.calls++
+	if failed {
+		b.failed++
+	}
+	if slow {
+		b.slow++
+	}
+}
+
+// rate returns n as a percentage of the bucket's calls.
+func (b bucket) rate(n int) float64 {
+	if b.calls == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(b.calls)
+}
+
+// outcomeWindow is the sliding window a rated breaker evaluates.
+type outcomeWindow interface {
+	add(now time.Time, failed, slow bool)
+	totals(now time.Time) bucket
+	reset()
+}
+
+// outcome is one call's result in a count-based window.
+type outcome struct {
+	failed, slow bool
+}
+
+// countWindow is a ring of the last len(outcomes) calls with running totals,
+// so both add and totals are O(1).
+type countWindow struct {
+	outcomes []outcome
+	next     int
+	sum      bucket
+}
+
+func (w *countWindow) add(_ time.Time, failed, slow bool) {
+	if w.sum.calls == len(w.outcomes) {
+		old := w.outcomes[w.next]
+		w.sum.calls--
+		if old.failed {
+			w.sum.failed--
+		}
+		if old.slow {
+			w.sum.slow--
+		}
+	}
+	w.outcomes[w.next] = outcome{failed: failed, slow: slow}
+	w.next = (w.next + 1) % len(w.outcomes)
+	w.sum.add(failed, slow)
+}
+
+func (w *countWindow) totals(time.Time) bucket { return w.sum }
+
+func (w *countWindow) reset() { w.next, w.sum = 0, bucket{} }
+
+// timeBucket tallies the calls of one epoch second.
+type timeBucket struct {
+	sec int64
+	bucket
+}
+
+// timeWindow is a ring of per-second buckets; a bucket is reused once its
+// second has slid out of the window.
+type timeWindow struct {
+	buckets []timeBucket
+}
+
+func (w *timeWindow) add(now time.Time, failed, slow bool) {
+	sec := now.Unix()
+	b := &w.buckets[sec%int64(len(w.buckets))]
+	if b.sec != sec {
+		*b = timeBucket{sec: sec}
+	}
+	b.add(failed, slow)
+}
+
+func (w *timeWindow) totals(now time.Time) bucket {
+	sec := now.Unix()
+	var sum bucket
+	for _, b := range w.buckets {
+		if sec-b.sec < int64(len(w.buckets)) {
+			sum.calls += b.calls
+			sum.failed += b.failed
+			sum.slow += b.slow
+		}
+	}
+	return sum
+}
+
+func (w *timeWindow) reset() { clear(w.buckets) }
@@ -0,0 +1,247 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// fakeClock drives a breaker's time without sleeping.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(p Policy) (*circuitBreaker, *fakeClock) {
+	clk := &fakeClock{t: time.Unix(1_000_000, 0)}
+	b := newCircuitBreaker("svc", p)
+	b.now = clk.now
+	return b, clk
+}
+
+func TestFailureRateTripsInterleavedFailures(t *testing.T) {
+	// 40% failures interleaved with successes never trip a consecutive
+	// breaker, but do trip a failure-rate one once the window is full.
+	e := newBuiltin(t, Policy{FailureRateThreshold: 40, SlidingWindowSize: 10, OpenDuration: time.Minute})
+	boom := errors.New("boom")
+	for i := range 10 {
+		err := e.Execute(context.Background(), "svc", func(context.Context) error {
+			if i%5 == 1 || i%5 == 3 {
+				return boom
+			}
+			return nil
+		})
+		if i%5 == 1 || i%5 == 3 {
+			assert.Error(t, err).Is(boom)
+		} else {
+			assert.Error(t, err).Nil()
+		}
+	}
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(ErrCircuitOpen)
+}
+
+func TestMinimumNumberOfCalls(t *testing.T) {
+	b, _ := newTestBreaker(Policy{FailureRateThreshold: 50, MinimumNumberOfCalls: 4})
+	for range 3 {
+		b.record(true, 0)
+	}
+	assert.That(t, b.snapshot().State).Equal(CircuitClosed)
+	b.record(true, 0)
+	assert.That(t, b.snapshot().State).Equal(CircuitOpen)
+}
+
+func TestSlowCallRateTrips(t *testing.T) {
+	b, _ := newTestBreaker(Policy{
+		SlowCallRateThreshold:     50,
+		SlowCallDurationThreshold: 100 * time.Millisecond,
+		SlidingWindowSize:         4,
+	})
+	b.record(false, 10*time.Millisecond)
+	b.record(false, 200*time.Millisecond)
+	b.record(false, 10*time.Millisecond)
+	assert.That(t, b.snapshot().State).Equal(CircuitClosed)
+	b.record(false, 300*time.Millisecond) // 2 of 4 slow: 50%
+	s := b.snapshot()
+	assert.That(t, s.State).Equal(CircuitOpen)
+	assert.That(t, s.SlowCallRate).Equal(50.0)
+	assert.That(t, s.FailureRate).Equal(0.0)
+}
+
+func TestTimeWindowSlides(t *testing.T) {
+	b, clk := newTestBreaker(Policy{
+		FailureRateThreshold: 50,
+		SlidingWindowType:    TimeBasedWindow,
+		SlidingWindowSize:    10,
+		MinimumNumberOfCalls: 4,
+	})
+	for range 3 {
+		b.record(true, 0)
+	}
+	// The failures slide out of the 10s window before the fourth call.
+	clk.advance(11 * time.Second)
+	b.record(true, 0)
+	s := b.snapshot()
+	assert.That(t, s.State).Equal(CircuitClosed)
+	assert.That(t, s.Calls).Equal(1)
+
+	for range 3 {
+		clk.advance(time.Second)
+		b.record(true, 0)
+	}
+	assert.That(t, b.snapshot().State).Equal(CircuitOpen)
+}
+
+func TestHalfOpenPermitsAndTransitions(t *testing.T) {
+	var seen []string
+	b, clk := newTestBreaker(Policy{
+		ErrorThreshold:           1,
+		OpenDuration:             time.Second,
+		PermittedCallsInHalfOpen: 2,
+		StateListener: NewStateListener(func(resource string, from, to CircuitState) {
+			seen = append(seen, resource+":"+from.String()+"->"+to.String())
+		}),
+	})
+	b.record(true, 0)
+	assert.That(t, b.allow()).False()
+
+	clk.advance(time.Second)
+	assert.That(t, b.allow()).True()
+	assert.That(t, b.allow()).True()
+	assert.That(t, b.allow()).False() // both trial slots taken
+
+	b.record(false, 0)
+	assert.That(t, b.snapshot().State).Equal(CircuitHalfOpen)
+	b.record(false, 0)
+	assert.That(t, b.snapshot().State).Equal(CircuitClosed)
+	assert.That(t, seen).Equal([]string{
+		"svc:CLOSED->OPEN", "svc:OPEN->HALF_OPEN", "svc:HALF_OPEN->CLOSED",
+	})
+}
+
+func TestHalfOpenRateDecidesOnAllTrials(t *testing.T) {
+	b, clk := newTestBreaker(Policy{
+		FailureRateThreshold:     50,
+		SlidingWindowSize:        2,
+		PermittedCallsInHalfOpen: 4,
+	})
+	b.record(true, 0)
+	b.record(true, 0)
+	assert.That(t, b.snapshot().State).Equal(CircuitOpen)
+
+	// One failure in four trials is below 50%: the breaker closes.
+	clk.advance(5 * time.Second)
+	for i := range 4 {
+		assert.That(t, b.allow()).True()
+		b.record(i == 0, 0)
+	}
+	s := b.snapshot()
+	assert.That(t, s.State).Equal(CircuitClosed)
+	assert.That(t, s.Calls).Equal(0) // fresh window after closing
+}
+
+func TestIsFailureClassifiesErrors(t *testing.T) {
+	notFound := errors.New("not found")
+	e := newBuiltin(t, Policy{
+		ErrorThreshold: 1,
+		OpenDuration:   time.Minute,
+		FailureClassifier: NewFailureClassifier(func(err error) bool {
+			return !errors.Is(err, notFound)
+		}),
+	})
+	for range 3 {
+		err := e.Execute(context.Background(), "svc", func(context.Context) error { return notFound })
+		assert.Error(t, err).Is(notFound)
+	}
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { return errors.New("boom") })
+	assert.Error(t, err).String("boom")
+	err = e.Execute(context.Background(), "svc", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(ErrCircuitOpen)
+}
+
+func TestPanicCountsAsFailure(t *testing.T) {
+	e := newBuiltin(t, Policy{ErrorThreshold: 1, OpenDuration: time.Minute})
+	assert.Panic(t, func() {
+		_ = e.Execute(context.Background(), "svc", func(context.Context) error { panic("boom") })
+	}, "boom")
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(ErrCircuitOpen)
+}
+
+func TestInvalidBreakerPolicy(t *testing.T) {
+	d, err := MustGetDriver("default")
+	assert.Error(t, err).Nil()
+	_, err = d.NewExecutor(Policy{FailureRateThreshold: 120})
+	assert.Error(t, err).Matches("out of")
+	_, err = d.NewExecutor(Policy{SlowCallRateThreshold: 50})
+	assert.Error(t, err).Matches("slow call duration")
+	_, err = d.NewExecutor(Policy{ErrorThreshold: 1, SlidingWindowType: "hourly"})
+	assert.Error(t, err).Matches("unknown sliding window type")
+}
+
+func TestBreakersHealthAndMetrics(t *testing.T) {
+	e := newBuiltin(t, Policy{Name: "orders-client", ErrorThreshold: 1, OpenDuration: time.Minute})
+	_ = e.Execute(context.Background(), "orders", func(context.Context) error { return errors.New("boom") })
+
+	mine := func() []BreakerState {
+		return slices.DeleteFunc(Breakers(), func(s BreakerState) bool { return s.Executor != "orders-client" })
+	}
+	got := mine()
+	assert.That(t, len(got)).Equal(1)
+	assert.That(t, got[0].Resource).Equal("orders")
+	assert.That(t, got[0].State).Equal(CircuitOpen)
+
+	ind := NewBreakerIndicator()
+	assert.That(t, ind.IsCritical()).False()
+	assert.Error(t, ind.CheckHealth(context.Background())).Matches("orders-client/orders")
+
+	m := NewBreakerMetrics()
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, m.Path(), nil))
+	assert.String(t, rec.Body.String()).Contains(
+		`resilience_circuit_breaker_state{executor="orders-client",resource="orders",state="open"} 1`)
+
+	// Closing withdraws the executor from every report.
+	assert.Error(t, e.Close()).Nil()
+	assert.That(t, len(mine())).Equal(0)
+}
+
+func TestUnnamedExecutorsReportDistinctNames(t *testing.T) {
+	p := Policy{ErrorThreshold: 1, OpenDuration: time.Minute}
+	a, b := newBuiltin(t, p), newBuiltin(t, p)
+	defer func() { _ = a.Close(); _ = b.Close() }()
+	fail := func(context.Context) error { return errors.New("boom") }
+	_ = a.Execute(context.Background(), "shared", fail)
+	_ = b.Execute(context.Background(), "shared", fail)
+
+	names := map[string]bool{}
+	for _, s := range Breakers() {
+		if s.Resource == "shared" {
+			names[s.Executor] = true
+		}
+	}
+	assert.That(t, len(names)).Equal(2)
+	assert.That(t, names[""]).False()
+}
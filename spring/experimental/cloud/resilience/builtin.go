@@ -18,8 +18,10 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,7 +38,40 @@ func (builtinDriver) NewExecutor(p Policy) (Executor, error) {
 	if p.RateLimit < 0 {
 		return nil, fmt.Errorf("resilience: negative rate limit %v", p.RateLimit)
 	}
-	return &builtinExecutor{policy: p, states: map[string]*resourceState{}}, nil
+	if p.FailureRateThreshold < 0 || p.FailureRateThreshold > 100 {
+		return nil, fmt.Errorf("resilience: failure rate threshold %v out of (0, 100]", p.FailureRateThreshold)
+	}
+	if p.SlowCallRateThreshold < 0 || p.SlowCallRateThreshold > 100 {
+		return nil, fmt.Errorf("resilience: slow call rate threshold %v out of (0, 100]", p.SlowCallRateThreshold)
+	}
+	if p.SlowCallRateThreshold > 0 && p.SlowCallDurationThreshold <= 0 {
+		return nil, fmt.Errorf("resilience: slow call rate threshold set without a slow call duration threshold")
+	}
+	if _, err := NewLimitAlgorithm(p.AdaptiveLimit); err != nil {
+		return nil, err
+	}
+	if p.RetryJitter < 0 || p.RetryJitter > 1 {
+		return nil, fmt.Errorf("resilience: retry jitter %v out of [0, 1]", p.RetryJitter)
+	}
+	if p.RetryBudget.Percent < 0 || p.RetryBudget.MinRetriesPerSecond < 0 {
+		return nil, fmt.Errorf("resilience: negative retry budget")
+	}
+	if err := validateHedge(p); err != nil {
+		return nil, err
+	}
+	switch p.SlidingWindowType {
+	case "", CountBasedWindow, TimeBasedWindow:
+	default:
+		return nil, fmt.Errorf("resilience: unknown sliding window type %q", p.SlidingWindowType)
+	}
+	if breakerEnabled(p) && p.Name == "" {
+		p.Name = anonymousExecutorName()
+	}
+	e := &builtinExecutor{policy: p, states: map[string]*resourceState{}}
+	if breakerEnabled(p) {
+		e.unreport = ReportBreakers(e)
+	}
+	return e, nil
 }
 
 // builtinExecutor keeps per-resource limiter and breaker state so that one
@@ -45,12 +80,17 @@ type builtinExecutor struct {
 	policy Policy
 	mu     sync.Mutex
 	states map[string]*resourceState
+
+	unreport func() // nil when the policy has no breaker
 }
 
 type resourceState struct {
 	bucket  *tokenBucket
 	breaker *circuitBreaker
 	sem     chan struct{}
+	limiter *AdaptiveLimiter
+	budget  *RetryBudget
+	latency *latencyTracker // only with a percentile hedge delay
 }
 
 func (e *builtinExecutor) state(resource string) *resourceState {
@@ -72,12 +112,15 @@ func (e *builtinExecutor) state(resource string) *resourceState {
 		}
 		s.bucket = newTokenBucket(e.policy.RateLimit, burst)
 	}
-	if e.policy.ErrorThreshold > 0 {
-		open := e.policy.OpenDuration
-		if open <= 0 {
-			open = 5 * time.Second
-		}
-		s.breaker = &circuitBreaker{threshold: e.policy.ErrorThreshold, openFor: open}
+	if breakerEnabled(e.policy) {
+		s.breaker = newCircuitBreaker(resource, e.policy)
+	}
+	if alg, _ := NewLimitAlgorithm(e.policy.AdaptiveLimit); alg != nil {
+		s.limiter = NewAdaptiveLimiter(alg)
+	}
+	s.budget = NewRetryBudget(e.policy.RetryBudget)
+	if e.policy.Hedge.Percentile > 0 {
+		s.latency = &latencyTracker{percentile: e.policy.Hedge.Percentile}
 	}
 	if e.policy.MaxConcurrent > 0 {
 		// A buffered channel is a non-blocking counting semaphore: a full buffer
@@ -103,31 +146,254 @@ func (e *builtinExecutor) Execute(ctx context.Context, resource string, fn func(
 		}
 	}
 
+	if s.budget != nil {
+		s.budget.Deposit()
+	}
+	if e.policy.Hedge.enabled() && hedgeAllowed(ctx) {
+		return e.hedge(ctx, s, fn)
+	}
+
 	attempts := e.policy.MaxRetries + 1
 	var err error
-	for range attempts {
-		if s.bucket != nil && !s.bucket.allow() {
-			return ErrRateLimited
+	for i := range attempts {
+		if i > 0 && !e.retry(ctx, s, err, i) {
+			break
+		}
+		release, rejected := e.admit(ctx, s)
+		if rejected != nil {
+			return rejected
+		}
+		err = e.attempt(ctx, s, release, nil, fn)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// admit runs one attempt past the rate limiter, the adaptive limiter and the
+// breaker, returning the limiter's release func (nil without a limiter) or the
+// rejection.
+func (e *builtinExecutor) admit(ctx context.Context, s *resourceState) (func(LimitOutcome), error) {
+	if s.bucket != nil && !s.bucket.allow() {
+		return nil, ErrRateLimited
+	}
+	var release func(LimitOutcome)
+	if s.limiter != nil {
+		var ok bool
+		if release, ok = s.limiter.Acquire(ctx); !ok {
+			return nil, ErrConcurrencyLimited
 		}
-		if s.breaker != nil && !s.breaker.allow() {
-			return ErrCircuitOpen
+	}
+	if s.breaker != nil && !s.breaker.allow() {
+		if release != nil {
+			release(OutcomeIgnored)
 		}
+		return nil, ErrCircuitOpen
+	}
+	return release, nil
+}
+
+// retry decides whether retry number n (1-based) may follow the failure err:
+// ctx must be live, err retryable and the budget not exhausted. It sleeps the
+// backoff before returning true.
+func (e *builtinExecutor) retry(ctx context.Context, s *resourceState, err error, n int) bool {
+	if ctx.Err() != nil || !IsRetryable(e.policy, err) {
+		return false
+	}
+	if s.budget != nil && !s.budget.Withdraw() {
+		return false
+	}
+	d := RetryDelay(e.policy, n)
+	if d <= 0 {
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
 
-		err = e.runOnce(ctx, fn)
+// hedge runs fn as up to Hedge.MaxAttempts concurrent attempts: the next one
+// starts when the latest has run for the hedge delay, or at once when an
+// attempt fails with a retryable error. The first success wins and every other
+// attempt still running is cancelled, and so is the winner's context once its
+// result has been handed back, unless the winning fn claimed it with
+// holdAttempt for a result read after return (an HTTP body). A
+// non-retryable failure is returned at once. Hedges beyond the first attempt
+// draw on the retry budget and pass the same admission checks as retries.
+func (e *builtinExecutor) hedge(ctx context.Context, s *resourceState, fn func(context.Context) error) error {
+	type result struct {
+		i   int
+		err error
+	}
+	maxAttempts := e.policy.Hedge.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 2
+	}
+	results := make(chan result, maxAttempts)
+	cancels := make([]context.CancelFunc, 0, maxAttempts)
+	losts := make([]*atomic.Bool, 0, maxAttempts)
+	holds := make([]*attemptHold, 0, maxAttempts)
+	winner := -1
+	defer func() {
+		for i, cancel := range cancels {
+			if i == winner {
+				// The winner has returned, so reading its hold is race-free.
+				if !holds[i].held {
+					cancel()
+				}
+				continue
+			}
+			losts[i].Store(true)
+			cancel()
+		}
+	}()
 
-		if s.breaker != nil {
-			s.breaker.record(err == nil)
+	launch := func() error {
+		release, rejected := e.admit(ctx, s)
+		if rejected != nil {
+			return rejected
 		}
-		if err == nil {
-			return nil
+		attemptCtx, cancel := context.WithCancel(ctx)
+		hold := &attemptHold{cancel: cancel}
+		attemptCtx = context.WithValue(attemptCtx, attemptHoldKey{}, hold)
+		lost := new(atomic.Bool)
+		i := len(cancels)
+		cancels, losts, holds = append(cancels, cancel), append(losts, lost), append(holds, hold)
+		go func() { results <- result{i, e.attempt(attemptCtx, s, release, lost, fn)} }()
+		return nil
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	var hedgeC <-chan time.Time
+	// arm schedules the next hedge after the current delay, if one remains.
+	arm := func() {
+		timer.Stop()
+		hedgeC = nil
+		if len(cancels) < maxAttempts {
+			if d := e.hedgeDelay(s); d > 0 {
+				timer.Reset(d)
+				hedgeC = timer.C
+			}
 		}
-		if ctx.Err() != nil {
-			break
+	}
+
+	if err := launch(); err != nil {
+		return err
+	}
+	arm()
+	running := 1
+	var last error
+	for {
+		select {
+		case r := <-results:
+			running--
+			if r.err == nil {
+				winner = r.i
+				return nil
+			}
+			last = r.err
+			if ctx.Err() != nil || !IsRetryable(e.policy, r.err) {
+				return r.err
+			}
+			if len(cancels) < maxAttempts && (s.budget == nil || s.budget.Withdraw()) {
+				if err := launch(); err != nil {
+					if running == 0 {
+						return err
+					}
+				} else {
+					running++
+					arm()
+				}
+			}
+			if running == 0 {
+				return last
+			}
+		case <-hedgeC:
+			hedgeC = nil
+			if ctx.Err() != nil || (s.budget != nil && !s.budget.Withdraw()) {
+				continue
+			}
+			// A rejected hedge is not fatal: the running attempts carry on.
+			if launch() == nil {
+				running++
+				arm()
+			}
+		}
+	}
+}
+
+// hedgeDelay is how long the latest attempt may run before the next starts: the
+// tracked latency percentile once warm, Hedge.Delay otherwise.
+func (e *builtinExecutor) hedgeDelay(s *resourceState) time.Duration {
+	if s.latency != nil {
+		if d := s.latency.delay(); d > 0 {
+			return d
 		}
 	}
+	return e.policy.Hedge.Delay
+}
+
+// attempt runs one attempt and records its outcome and duration with the
+// resource's breaker, latency tracker and limiter release, if any. A panicking
+// fn is recorded as a breaker failure and an ignored limiter sample before the
+// panic propagates, so neither a half-open trial slot nor a limiter slot is
+// ever leaked. An attempt marked lost by a hedge, or whose fn reports
+// errHedgeLost, is recorded as ignored: its outcome says nothing about the
+// resource.
+func (e *builtinExecutor) attempt(ctx context.Context, s *resourceState, release func(LimitOutcome), lost *atomic.Bool, fn func(context.Context) error) (err error) {
+	if s.breaker == nil && release == nil && s.latency == nil {
+		return e.runOnce(ctx, fn)
+	}
+	start := time.Now()
+	returned := false
+	defer func() {
+		elapsed := time.Since(start)
+		if (lost != nil && lost.Load()) || (returned && errors.Is(err, errHedgeLost)) {
+			if s.breaker != nil {
+				s.breaker.ignore()
+			}
+			if release != nil {
+				release(OutcomeIgnored)
+			}
+			return
+		}
+		if s.breaker != nil {
+			s.breaker.record(!returned || e.isFailure(err), elapsed)
+		}
+		if returned && err == nil && s.latency != nil {
+			s.latency.observe(elapsed)
+		}
+		if release != nil {
+			if returned {
+				release(LimitOutcomeOf(err))
+			} else {
+				release(OutcomeIgnored)
+			}
+		}
+	}()
+	err = e.runOnce(ctx, fn)
+	returned = true
 	return err
 }
 
+// isFailure applies the policy's error classification.
+func (e *builtinExecutor) isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if e.policy.FailureClassifier != nil {
+		return e.policy.FailureClassifier.IsFailure(err)
+	}
+	return true
+}
+
 // runOnce applies the per-attempt timeout, if any, around fn.
 func (e *builtinExecutor) runOnce(ctx context.Context, fn func(context.Context) error) error {
 	if e.policy.Timeout <= 0 {
@@ -138,7 +404,30 @@ func (e *builtinExecutor) runOnce(ctx context.Context, fn func(context.Context)
 	return fn(attemptCtx)
 }
 
-func (e *builtinExecutor) Close() error { return nil }
+// BreakerStates implements [BreakerReporter].
+func (e *builtinExecutor) BreakerStates() []BreakerState {
+	e.mu.Lock()
+	breakers := make([]*circuitBreaker, 0, len(e.states))
+	for _, s := range e.states {
+		if s.breaker != nil {
+			breakers = append(breakers, s.breaker)
+		}
+	}
+	e.mu.Unlock()
+	out := make([]BreakerState, 0, len(breakers))
+	for _, b := range breakers {
+		out = append(out, b.snapshot())
+	}
+	return out
+}
+
+// Close withdraws the executor from [Breakers].
+func (e *builtinExecutor) Close() error {
+	if e.unreport != nil {
+		e.unreport()
+	}
+	return nil
+}
 
 // tokenBucket is a minimal, dependency-free rate limiter. Tokens refill
 // continuously at rate per second up to burst.
@@ -174,53 +463,3 @@ func (b *tokenBucket) allow() bool {
 	b.tokens--
 	return true
 }
-
-// circuitBreaker is a consecutive-failure breaker with a half-open trial. It
-// opens after threshold consecutive failures and, once openFor has elapsed,
-// admits a single trial request whose outcome closes or re-opens it.
-type circuitBreaker struct {
-	threshold int
-	openFor   time.Duration
-
-	mu       sync.Mutex
-	failures int
-	openedAt time.Time
-	halfOpen bool
-}
-
-// allow reports whether a request may proceed given the current breaker state.
-func (c *circuitBreaker) allow() bool {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if c.openedAt.IsZero() {
-		return true // closed
-	}
-	if time.Since(c.openedAt) < c.openFor {
-		return false // open, cooling down
-	}
-	// Cool-down elapsed: admit one trial request (half-open).
-	c.halfOpen = true
-	return true
-}
-
-// record folds an attempt's outcome back into the breaker state.
-func (c *circuitBreaker) record(success bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if success {
-		c.failures = 0
-		c.openedAt = time.Time{}
-		c.halfOpen = false
-		return
-	}
-	if c.halfOpen {
-		// Trial failed: re-open the cool-down window.
-		c.halfOpen = false
-		c.openedAt = time.Now()
-		return
-	}
-	c.failures++
-	if c.failures >= c.threshold {
-		c.openedAt = time.Now()
-	}
-}
@@ -19,6 +19,7 @@ package resilience
 import (
 	"context"
 	"net"
+	"sync"
 )
 
 // DialFunc is the shape of the connection-establishing hook exposed by common
@@ -44,6 +45,10 @@ type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
 // so the breaker trips on connection failures (refused, timed out) rather than
 // on per-request errors of an already-open connection. That is exactly the level
 // at which a discovery-backed dialer operates.
+//
+// Under a hedging policy a slow dial is raced by another (which, with a
+// discovery-backed base, usually picks another endpoint); the first connection
+// wins and any later one is closed.
 func NewDialer(base DialFunc, exec Executor, resource string) DialFunc {
 	if exec == nil {
 		return base
@@ -52,12 +57,21 @@ func NewDialer(base DialFunc, exec Executor, resource string) DialFunc {
 		base = (&net.Dialer{}).DialContext
 	}
 	return func(ctx context.Context, network, addr string) (net.Conn, error) {
-		var conn net.Conn
+		var (
+			mu   sync.Mutex
+			conn net.Conn
+		)
 		err := exec.Execute(ctx, resource, func(ctx context.Context) error {
 			c, err := base(ctx, network, addr)
 			if err != nil {
 				return err
 			}
+			mu.Lock()
+			defer mu.Unlock()
+			if conn != nil {
+				_ = c.Close()
+				return errHedgeLost
+			}
 			conn = c
 			return nil
 		})
@@ -30,8 +30,11 @@ import (
 // an http.ServeMux, to guard a whole server, or wrap a single route.
 //
 // Rejections are translated to HTTP status codes so the seam stays transparent
-// to clients: [ErrRateLimited] and [ErrBulkheadFull] become 429 Too Many
-// Requests, [ErrCircuitOpen] becomes 503 Service Unavailable. A response whose
+// to clients: [ErrRateLimited], [ErrBulkheadFull] and [ErrConcurrencyLimited]
+// become 429 Too Many Requests, [ErrCircuitOpen] becomes 503 Service
+// Unavailable. With an adaptive limit in the policy this is load shedding:
+// wrap the result in [NewPriorityHandler] so health checks and critical
+// routes are admitted while sheddable ones are rejected first. A response whose
 // status is 5xx counts as a failure for the breaker, so a resource that keeps
 // failing inbound is shed. When exec is nil next is returned unchanged.
 //
@@ -82,11 +85,34 @@ func writeRejection(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, ErrCircuitOpen):
 		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
-	default: // ErrRateLimited, ErrBulkheadFull
+	default: // ErrRateLimited, ErrBulkheadFull, ErrConcurrencyLimited
 		http.Error(w, "too many requests", http.StatusTooManyRequests)
 	}
 }
 
+// NewPriorityHandler stamps each request's context with the [Priority] chosen
+// by classify before calling next, so an adaptive limit downstream (typically
+// in a [NewHandler] wrapped by it) sheds low-priority requests first and never
+// rejects critical ones. When classify is nil next is returned unchanged.
+//
+// Example:
+//
+//	h := resilience.NewPriorityHandler(resilience.NewHandler(mux, exec, nil),
+//	    func(r *http.Request) resilience.Priority {
+//	        if r.URL.Path == "/healthz" {
+//	            return resilience.PriorityCritical
+//	        }
+//	        return resilience.PriorityNormal
+//	    })
+func NewPriorityHandler(next http.Handler, classify func(*http.Request) Priority) http.Handler {
+	if classify == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithPriority(r.Context(), classify(r))))
+	})
+}
+
 // statusRecorder captures the status code so the breaker can see whether the
 // handler failed, while writes pass straight through to the real writer.
 type statusRecorder struct {
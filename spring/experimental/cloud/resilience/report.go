@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go-spring.org/spring/cloud/actuator/endpoint"
+	"go-spring.org/spring/cloud/actuator/health"
+)
+
+// NewBreakerIndicator reports the breakers read by [Breakers] as a
+// health.Indicator named "resilience:circuit-breakers". It is DOWN while any
+// breaker is open and lists those breakers; half-open counts as up. The
+// indicator is non-critical by default — one tripped downstream rarely means
+// the whole pod should leave rotation — and opts may override that.
+func NewBreakerIndicator(opts ...health.IndicatorOption) health.Indicator {
+	opts = append([]health.IndicatorOption{health.NonCritical()}, opts...)
+	return health.NewIndicator("resilience:circuit-breakers", func(context.Context) error {
+		var open []string
+		for _, b := range Breakers() {
+			if b.State == CircuitOpen {
+				open = append(open, breakerID(b))
+			}
+		}
+		if len(open) > 0 {
+			return fmt.Errorf("resilience: circuit open for %s", strings.Join(open, ", "))
+		}
+		return nil
+	}, opts...)
+}
+
+func breakerID(b BreakerState) string {
+	if b.Executor == "" {
+		return b.Resource
+	}
+	return b.Executor + "/" + b.Resource
+}
+
+// NewBreakerMetrics contributes GET /resilience/metrics to the actuator
+// management server (endpoint.Endpoint seam), rendering the breakers read by
+// [Breakers] in Prometheus text format. Like the gateway's endpoint it needs
+// no prometheus client.
+func NewBreakerMetrics() endpoint.Endpoint { return breakerMetrics{} }
+
+type breakerMetrics struct{}
+
+func (breakerMetrics) Path() string { return "/resilience/metrics" }
+
+func (breakerMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	breakers := Breakers()
+	var b strings.Builder
+	b.WriteString("# HELP resilience_circuit_breaker_state Circuit breaker state; 1 for the current state.\n")
+	b.WriteString("# TYPE resilience_circuit_breaker_state gauge\n")
+	for _, s := range breakers {
+		for _, st := range []CircuitState{CircuitClosed, CircuitOpen, CircuitHalfOpen} {
+			v := 0
+			if s.State == st {
+				v = 1
+			}
+			fmt.Fprintf(&b, "resilience_circuit_breaker_state{executor=%q,resource=%q,state=%q} %d\n",
+				s.Executor, s.Resource, strings.ToLower(st.String()), v)
+		}
+	}
+	gauge(&b, breakers, "calls", "Calls in the sliding window.",
+		func(s BreakerState) float64 { return float64(s.Calls) })
+	gauge(&b, breakers, "failure_rate", "Percentage of failed calls in the sliding window.",
+		func(s BreakerState) float64 { return s.FailureRate })
+	gauge(&b, breakers, "slow_call_rate", "Percentage of slow calls in the sliding window.",
+		func(s BreakerState) float64 { return s.SlowCallRate })
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func gauge(b *strings.Builder, breakers []BreakerState, name, help string, value func(BreakerState) float64) {
+	fmt.Fprintf(b, "# HELP resilience_circuit_breaker_%s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE resilience_circuit_breaker_%s gauge\n", name)
+	for _, s := range breakers {
+		fmt.Fprintf(b, "resilience_circuit_breaker_%s{executor=%q,resource=%q} %g\n",
+			name, s.Executor, s.Resource, value(s))
+	}
+}
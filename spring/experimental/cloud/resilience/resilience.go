@@ -74,14 +74,63 @@ type Policy struct {
 	Burst int
 
 	// ErrorThreshold is the number of consecutive failures that trips the
-	// circuit breaker open. 0 disables circuit breaking.
+	// circuit breaker open. 0 disables the consecutive-failure trigger; the
+	// breaker is enabled when this or either rate threshold below is set.
 	ErrorThreshold int
 
-	// OpenDuration is how long the circuit stays open before a trial request is
-	// allowed through (half-open). Ignored when ErrorThreshold is 0; defaults to
-	// a few seconds when unset.
+	// OpenDuration is how long the circuit stays open before trial requests are
+	// allowed through (half-open). Ignored when the breaker is disabled;
+	// defaults to a few seconds when unset.
 	OpenDuration time.Duration
 
+	// FailureRateThreshold trips the breaker when the percentage (0-100] of
+	// failed calls in the sliding window reaches it. 0 disables the trigger.
+	FailureRateThreshold float64
+
+	// SlowCallDurationThreshold marks a call slower than it as slow, whether
+	// it failed or not. It only matters with SlowCallRateThreshold set.
+	SlowCallDurationThreshold time.Duration
+
+	// SlowCallRateThreshold trips the breaker when the percentage (0-100] of
+	// slow calls in the sliding window reaches it. 0 disables the trigger.
+	SlowCallRateThreshold float64
+
+	// SlidingWindowType selects how the window the rates are computed over is
+	// measured: [CountBasedWindow] (the default) or [TimeBasedWindow].
+	SlidingWindowType SlidingWindowType
+
+	// SlidingWindowSize is the window length: the last N calls for a
+	// count-based window, the last N seconds for a time-based one. Defaults to
+	// 100 calls or 60 seconds.
+	SlidingWindowSize int
+
+	// MinimumNumberOfCalls is how many calls the window must hold before the
+	// rates are evaluated, so a single early failure cannot trip the breaker.
+	// Defaults to 100, capped at the window size for a count-based window.
+	MinimumNumberOfCalls int
+
+	// PermittedCallsInHalfOpen is the number of trial calls admitted once
+	// OpenDuration has elapsed; their outcome closes or re-opens the breaker.
+	// Defaults to 1.
+	PermittedCallsInHalfOpen int
+
+	// FailureClassifier decides which errors returned by the operation count
+	// as breaker failures; the others count as successes (a "not found" that
+	// is a legitimate answer, a caller cancellation, ...). Nil counts every
+	// error. Classification affects only the breaker; retry still sees every
+	// error.
+	FailureClassifier FailureClassifier
+
+	// StateListener, when set, is told after a resource's breaker changes
+	// state.
+	StateListener StateListener
+
+	// Name labels this executor's breakers in [Breakers] reports (health and
+	// metrics), telling apart two executors that guard the same resource. It
+	// is optional; the builtin driver generates a unique "executor-N" when it
+	// is empty.
+	Name string
+
 	// MaxConcurrent caps the number of operations allowed to run against a
 	// resource at the same time (the bulkhead / isolation stage). Excess calls
 	// are rejected with [ErrBulkheadFull] rather than queued, so a slow
@@ -89,11 +138,45 @@ type Policy struct {
 	// disables the bulkhead.
 	MaxConcurrent int
 
+	// AdaptiveLimit adds a concurrency limit per resource that adapts to the
+	// observed latency and drops, rejecting excess attempts with
+	// [ErrConcurrencyLimited] according to the [Priority] on their context.
+	// Unlike MaxConcurrent it counts attempts, not whole Execute calls. A zero
+	// value disables it.
+	AdaptiveLimit AdaptiveLimitPolicy
+
 	// MaxRetries is the number of extra attempts after the first failure. 0
 	// means a single attempt with no retry. Retries respect the circuit breaker
 	// and rate limiter.
 	MaxRetries int
 
+	// RetryBackoff is the pause before the first retry; each further retry
+	// doubles it, up to MaxRetryBackoff. 0 retries immediately. The pause is
+	// cut short when the caller's context ends.
+	RetryBackoff time.Duration
+
+	// MaxRetryBackoff caps the exponential backoff. 0 means no cap.
+	MaxRetryBackoff time.Duration
+
+	// RetryJitter randomises each backoff pause by up to this fraction in
+	// either direction (0.2 pauses between 80% and 120% of it), so callers
+	// failing together do not retry in lockstep. It must be in [0, 1].
+	RetryJitter float64
+
+	// RetryClassifier decides which errors are retried or hedged; any other
+	// error is returned at once. Nil retries every error.
+	RetryClassifier RetryClassifier
+
+	// RetryBudget caps retries and hedges per resource at a share of recent
+	// requests. An exhausted budget returns the last error instead of
+	// retrying. A zero value means no budget.
+	RetryBudget RetryBudgetPolicy
+
+	// Hedge starts extra concurrent attempts when one is slow. It cannot be
+	// combined with MaxRetries; a hedge already retries a failed attempt
+	// while attempts remain. A zero value disables hedging.
+	Hedge HedgePolicy
+
 	// Timeout bounds each individual attempt via a derived context. 0 means no
 	// per-attempt timeout is imposed by the executor.
 	Timeout time.Duration
@@ -114,6 +197,54 @@ type Executor interface {
 	Close() error
 }
 
+// FailureClassifier classifies operation errors for the circuit breaker. It is
+// an interface rather than a func field so that Policy stays comparable.
+type FailureClassifier interface {
+	// IsFailure reports whether err, never nil, counts as a failure.
+	IsFailure(err error) bool
+}
+
+// NewFailureClassifier adapts f to a [FailureClassifier].
+func NewFailureClassifier(f func(err error) bool) FailureClassifier {
+	return &failureClassifier{f}
+}
+
+// failureClassifier is used through a pointer so that comparing two policies
+// never panics on an uncomparable func value.
+type failureClassifier struct{ f func(error) bool }
+
+func (c *failureClassifier) IsFailure(err error) bool { return c.f(err) }
+
+// StateListener observes circuit breaker transitions.
+type StateListener interface {
+	// OnStateChange is called after resource's breaker moves from one state
+	// to another. It runs on the goroutine that caused the change, outside the
+	// breaker's lock, and must not block.
+	OnStateChange(resource string, from, to CircuitState)
+}
+
+// NewStateListener adapts f to a [StateListener].
+func NewStateListener(f func(resource string, from, to CircuitState)) StateListener {
+	return &stateListener{f}
+}
+
+type stateListener struct {
+	f func(resource string, from, to CircuitState)
+}
+
+func (l *stateListener) OnStateChange(resource string, from, to CircuitState) {
+	l.f(resource, from, to)
+}
+
+// BreakerReporter is implemented by an [Executor] that can report the state of
+// its circuit breakers. Drivers opt in; callers type-assert, or read every
+// registered executor at once through [Breakers].
+type BreakerReporter interface {
+	// BreakerStates returns a snapshot of every resource breaker the executor
+	// has created so far.
+	BreakerStates() []BreakerState
+}
+
 // Driver builds an [Executor] from a [Policy]. Backends implement it and
 // register under a name via [RegisterDriver].
 type Driver interface {
@@ -95,6 +95,25 @@ func TestRetrySucceedsAfterTransientFailure(t *testing.T) {
 	assert.That(t, attempts).Equal(3)
 }
 
+func TestRetryBackoffGrowsAndStopsOnCancel(t *testing.T) {
+	// 10ms, then 20ms capped to 15ms: two retries take at least 25ms.
+	e := newBuiltin(t, Policy{MaxRetries: 2, RetryBackoff: 10 * time.Millisecond, MaxRetryBackoff: 15 * time.Millisecond})
+	boom := errors.New("boom")
+	start := time.Now()
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { return boom })
+	assert.Error(t, err).Is(boom)
+	assert.That(t, time.Since(start) >= 25*time.Millisecond).True()
+
+	// A cancelled context ends the wait and the retry loop with the last error.
+	e = newBuiltin(t, Policy{MaxRetries: 5, RetryBackoff: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var attempts int
+	err = e.Execute(ctx, "svc2", func(context.Context) error { attempts++; return boom })
+	assert.Error(t, err).Is(boom)
+	assert.That(t, attempts).Equal(1)
+}
+
 func TestExecutePerAttemptTimeout(t *testing.T) {
 	e := newBuiltin(t, Policy{Timeout: 20 * time.Millisecond})
 	err := e.Execute(context.Background(), "svc", func(ctx context.Context) error {
@@ -0,0 +1,276 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RetryClassifier decides which errors are worth another attempt. Like
+// [FailureClassifier] it is an interface so that Policy stays comparable.
+type RetryClassifier interface {
+	// IsRetryable reports whether err, never nil, may be retried or hedged.
+	IsRetryable(err error) bool
+}
+
+// NewRetryClassifier adapts f to a [RetryClassifier].
+func NewRetryClassifier(f func(err error) bool) RetryClassifier {
+	return &retryClassifier{f}
+}
+
+type retryClassifier struct{ f func(error) bool }
+
+func (c *retryClassifier) IsRetryable(err error) bool { return c.f(err) }
+
+// RetryBudgetPolicy caps retries (and hedges) at a share of recent requests
+// per resource, in the style of Finagle and gRPC retry throttling, so an
+// outage is not amplified by every caller retrying every request. A zero
+// value disables the budget.
+type RetryBudgetPolicy struct {
+	// Percent is the number of retries allowed per 100 requests in Window. 0
+	// disables the budget.
+	Percent float64
+
+	// MinRetriesPerSecond is a floor that lets a low-traffic resource still
+	// retry. 0 means no floor.
+	MinRetriesPerSecond float64
+
+	// Window is how far back requests and retries are counted. Defaults to 10
+	// seconds.
+	Window time.Duration
+}
+
+// HedgePolicy sends extra concurrent attempts when the first is slow and takes
+// whichever succeeds first, cancelling the rest. It trades extra load for
+// tail latency, so it only suits idempotent operations, and the operation
+// must be safe to run concurrently. A zero value disables hedging.
+type HedgePolicy struct {
+	// Delay is how long an attempt may run before the next one starts. With
+	// Percentile set it is only used until enough latencies are observed; 0
+	// then means no hedging until then.
+	Delay time.Duration
+
+	// Percentile in (0, 100) derives the delay from the resource's recent
+	// successful latencies, e.g. 95 hedges the slowest 5%.
+	Percentile float64
+
+	// MaxAttempts caps the concurrent attempts, the first included. Defaults
+	// to 2.
+	MaxAttempts int
+}
+
+// enabled reports whether p asks for hedging at all.
+func (p HedgePolicy) enabled() bool { return p.Delay > 0 || p.Percentile > 0 }
+
+// errHedgeLost is returned by an adapter's operation that completed after
+// another attempt of the same call had already produced the result. The
+// attempt is then recorded as ignored rather than as a failure.
+var errHedgeLost = errors.New("resilience: lost hedge")
+
+type noHedgeKey struct{}
+
+// withoutHedging marks ctx so that the builtin driver does not hedge the call,
+// e.g. because the request cannot safely be sent twice.
+func withoutHedging(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noHedgeKey{}, true)
+}
+
+func hedgeAllowed(ctx context.Context) bool {
+	return ctx.Value(noHedgeKey{}) == nil
+}
+
+type attemptHoldKey struct{}
+
+// attemptHold lets the operation of a hedged attempt keep its context alive
+// past the call, for a result that is read after Execute returns.
+type attemptHold struct {
+	cancel context.CancelFunc
+	held   bool
+}
+
+// holdAttempt marks the hedged attempt running with ctx as owning a result
+// that still uses ctx, e.g. an HTTP body. The winning attempt's context is
+// then not cancelled when the call returns; the returned func cancels it and
+// must be called once the result is done with. Outside a hedged attempt it
+// returns a no-op.
+func holdAttempt(ctx context.Context) func() {
+	h, ok := ctx.Value(attemptHoldKey{}).(*attemptHold)
+	if !ok {
+		return func() {}
+	}
+	h.held = true
+	return h.cancel
+}
+
+// validateHedge checks the hedge settings of p.
+func validateHedge(p Policy) error {
+	h := p.Hedge
+	switch {
+	case h.Delay < 0:
+		return fmt.Errorf("resilience: negative hedge delay %v", h.Delay)
+	case h.Percentile < 0 || h.Percentile >= 100:
+		return fmt.Errorf("resilience: hedge percentile %v out of (0, 100)", h.Percentile)
+	case h.MaxAttempts < 0:
+		return fmt.Errorf("resilience: negative hedge attempts %d", h.MaxAttempts)
+	case h.enabled() && p.MaxRetries > 0:
+		return fmt.Errorf("resilience: hedging cannot be combined with MaxRetries")
+	}
+	return nil
+}
+
+// RetryBudget counts requests and retries of one resource in per-second
+// buckets over [RetryBudgetPolicy.Window]. Drivers keep one per resource, call
+// Deposit once per request and Withdraw before each retry or hedge. It is safe
+// for concurrent use.
+type RetryBudget struct {
+	policy RetryBudgetPolicy
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets []budgetBucket
+}
+
+type budgetBucket struct {
+	sec               int64
+	requests, retries int
+}
+
+// NewRetryBudget returns the budget for p, or nil for a zero policy.
+func NewRetryBudget(p RetryBudgetPolicy) *RetryBudget {
+	if p.Percent <= 0 && p.MinRetriesPerSecond <= 0 {
+		return nil
+	}
+	if p.Window <= 0 {
+		p.Window = 10 * time.Second
+	}
+	secs := max(1, int(p.Window/time.Second))
+	return &RetryBudget{policy: p, now: time.Now, buckets: make([]budgetBucket, secs)}
+}
+
+func (b *RetryBudget) bucketLocked(sec int64) *budgetBucket {
+	bk := &b.buckets[sec%int64(len(b.buckets))]
+	if bk.sec != sec {
+		*bk = budgetBucket{sec: sec}
+	}
+	return bk
+}
+
+// Deposit records one request.
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucketLocked(b.now().Unix()).requests++
+}
+
+// Withdraw records one retry and reports true if the budget allows it.
+func (b *RetryBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sec := b.now().Unix()
+	var requests, retries int
+	for _, bk := range b.buckets {
+		if sec-bk.sec < int64(len(b.buckets)) {
+			requests += bk.requests
+			retries += bk.retries
+		}
+	}
+	allowed := b.policy.Percent*float64(requests)/100 +
+		b.policy.MinRetriesPerSecond*float64(len(b.buckets))
+	// Each retry spends a whole token, so half a token buys nothing yet.
+	if float64(retries+1) > allowed {
+		return false
+	}
+	b.bucketLocked(sec).retries++
+	return true
+}
+
+// RetryDelay returns the pause before retry number n (1-based) under p:
+// RetryBackoff doubled per retry, capped at MaxRetryBackoff, then spread by
+// RetryJitter.
+func RetryDelay(p Policy, n int) time.Duration {
+	d := p.RetryBackoff
+	if d <= 0 {
+		return 0
+	}
+	for range n - 1 {
+		if d *= 2; p.MaxRetryBackoff > 0 && d >= p.MaxRetryBackoff {
+			d = p.MaxRetryBackoff
+			break
+		}
+	}
+	if p.RetryJitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.RetryJitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// IsRetryable applies p's RetryClassifier to err.
+func IsRetryable(p Policy, err error) bool {
+	return p.RetryClassifier == nil || p.RetryClassifier.IsRetryable(err)
+}
+
+// latencyTracker keeps the last latencySamples successful latencies of a
+// resource and caches the percentile the hedge delay is derived from.
+type latencyTracker struct {
+	percentile float64
+
+	mu      sync.Mutex
+	samples [latencySamples]time.Duration
+	n       int // samples recorded, saturating at len(samples)
+	next    int
+	stale   int // samples since the cached value was computed
+	cached  time.Duration
+}
+
+const (
+	latencySamples = 256
+	// minLatencySamples is how many samples a percentile needs to be trusted.
+	minLatencySamples = 20
+	// latencyRefresh is how many new samples invalidate the cached value.
+	latencyRefresh = 16
+)
+
+func (t *latencyTracker) observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.samples[t.next] = d
+	t.next = (t.next + 1) % len(t.samples)
+	t.n = min(t.n+1, len(t.samples))
+	t.stale++
+}
+
+// delay returns the tracked percentile, or 0 before enough samples exist.
+func (t *latencyTracker) delay() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.n < minLatencySamples {
+		return 0
+	}
+	if t.cached == 0 || t.stale >= latencyRefresh {
+		sorted := slices.Clone(t.samples[:t.n])
+		slices.Sort(sorted)
+		i := min(t.n-1, int(float64(t.n)*t.percentile/100))
+		t.cached, t.stale = sorted[i], 0
+	}
+	return t.cached
+}
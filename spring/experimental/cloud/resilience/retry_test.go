@@ -0,0 +1,223 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestRetryBudgetCapsRetries(t *testing.T) {
+	b := NewRetryBudget(RetryBudgetPolicy{Percent: 20, Window: 2 * time.Second})
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+
+	// Ten requests buy two retries.
+	for range 10 {
+		b.Deposit()
+	}
+	assert.That(t, b.Withdraw()).True()
+	assert.That(t, b.Withdraw()).True()
+	assert.That(t, b.Withdraw()).False()
+
+	// Once the window has slid past them the requests no longer count.
+	now = now.Add(2 * time.Second)
+	b.Deposit()
+	assert.That(t, b.Withdraw()).False()
+
+	// The floor lets a quiet resource retry anyway.
+	b = NewRetryBudget(RetryBudgetPolicy{MinRetriesPerSecond: 1, Window: 2 * time.Second})
+	assert.That(t, b.Withdraw()).True()
+	assert.That(t, b.Withdraw()).True()
+	assert.That(t, b.Withdraw()).False()
+
+	assert.That(t, NewRetryBudget(RetryBudgetPolicy{}) == nil).True()
+}
+
+func TestExecuteStopsWhenBudgetExhausted(t *testing.T) {
+	e := newBuiltin(t, Policy{MaxRetries: 3, RetryBudget: RetryBudgetPolicy{Percent: 50}})
+	boom := errors.New("boom")
+	var attempts int
+	fail := func(context.Context) error { attempts++; return boom }
+
+	// One request buys half a retry: none yet.
+	assert.Error(t, e.Execute(context.Background(), "svc", fail)).Is(boom)
+	assert.That(t, attempts).Equal(1)
+
+	// Two requests buy one retry, which the second request spends.
+	attempts = 0
+	assert.Error(t, e.Execute(context.Background(), "svc", fail)).Is(boom)
+	assert.That(t, attempts).Equal(2)
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	p := Policy{RetryBackoff: 100 * time.Millisecond, MaxRetryBackoff: 300 * time.Millisecond}
+	assert.That(t, RetryDelay(p, 1)).Equal(100 * time.Millisecond)
+	assert.That(t, RetryDelay(p, 2)).Equal(200 * time.Millisecond)
+	assert.That(t, RetryDelay(p, 3)).Equal(300 * time.Millisecond)
+
+	p.RetryJitter = 0.5
+	for range 100 {
+		d := RetryDelay(p, 2)
+		assert.That(t, d >= 100*time.Millisecond && d <= 300*time.Millisecond).True()
+	}
+}
+
+func TestRetryClassifier(t *testing.T) {
+	fatal := errors.New("fatal")
+	e := newBuiltin(t, Policy{
+		MaxRetries:      3,
+		RetryClassifier: NewRetryClassifier(func(err error) bool { return !errors.Is(err, fatal) }),
+	})
+	var attempts int
+	err := e.Execute(context.Background(), "svc", func(context.Context) error { attempts++; return fatal })
+	assert.Error(t, err).Is(fatal)
+	assert.That(t, attempts).Equal(1)
+}
+
+func TestHedgeAfterDelay(t *testing.T) {
+	e := newBuiltin(t, Policy{Hedge: HedgePolicy{Delay: 10 * time.Millisecond}})
+	var (
+		attempts  atomic.Int32
+		cancelled atomic.Bool
+	)
+	done := make(chan struct{})
+	err := e.Execute(context.Background(), "svc", func(ctx context.Context) error {
+		if attempts.Add(1) == 1 {
+			// The slow first attempt is abandoned once the hedge wins.
+			defer close(done)
+			<-ctx.Done()
+			cancelled.Store(true)
+			return ctx.Err()
+		}
+		return nil
+	})
+	assert.Error(t, err).Nil()
+	<-done
+	assert.That(t, attempts.Load()).Equal(int32(2))
+	assert.That(t, cancelled.Load()).True()
+}
+
+func TestHedgeReleasesWinner(t *testing.T) {
+	e := newBuiltin(t, Policy{Hedge: HedgePolicy{Delay: time.Second}})
+
+	// The winner's context ends once its result has been handed back ...
+	var won context.Context
+	err := e.Execute(context.Background(), "svc", func(ctx context.Context) error {
+		won = ctx
+		return nil
+	})
+	assert.Error(t, err).Nil()
+	assert.Error(t, won.Err()).Is(context.Canceled)
+
+	// ... unless fn holds it for a result read after return.
+	var release func()
+	err = e.Execute(context.Background(), "svc", func(ctx context.Context) error {
+		won, release = ctx, holdAttempt(ctx)
+		return nil
+	})
+	assert.Error(t, err).Nil()
+	assert.Error(t, won.Err()).Nil()
+	release()
+	assert.Error(t, won.Err()).Is(context.Canceled)
+}
+
+func TestHedgeOnFailure(t *testing.T) {
+	// Without a delay a hedge starts only when an attempt fails.
+	e := newBuiltin(t, Policy{Hedge: HedgePolicy{Percentile: 90, MaxAttempts: 3}})
+	boom := errors.New("boom")
+	var attempts atomic.Int32
+	err := e.Execute(context.Background(), "svc", func(context.Context) error {
+		if attempts.Add(1) < 3 {
+			return boom
+		}
+		return nil
+	})
+	assert.Error(t, err).Nil()
+	assert.That(t, attempts.Load()).Equal(int32(3))
+
+	// Every attempt failing returns the last error.
+	attempts.Store(0)
+	err = e.Execute(context.Background(), "svc", func(context.Context) error { attempts.Add(1); return boom })
+	assert.Error(t, err).Is(boom)
+	assert.That(t, attempts.Load()).Equal(int32(3))
+}
+
+func TestHedgeDelayTracksPercentile(t *testing.T) {
+	tr := &latencyTracker{percentile: 90}
+	assert.That(t, tr.delay()).Equal(time.Duration(0))
+	for i := range 100 {
+		tr.observe(time.Duration(i+1) * time.Millisecond)
+	}
+	assert.That(t, tr.delay()).Equal(91 * time.Millisecond)
+}
+
+func TestHedgePolicyValidation(t *testing.T) {
+	d, _ := MustGetDriver("default")
+	for _, p := range []Policy{
+		{Hedge: HedgePolicy{Delay: time.Millisecond}, MaxRetries: 1},
+		{Hedge: HedgePolicy{Percentile: 100}},
+		{RetryJitter: 2},
+		{RetryBudget: RetryBudgetPolicy{Percent: -1}},
+	} {
+		_, err := d.NewExecutor(p)
+		assert.Error(t, err).NotNil()
+	}
+}
+
+func TestRoundTripperHedgesIdempotentRequests(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 && r.Method == http.MethodGet {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("fast"))
+	}))
+	defer srv.Close()
+
+	e := newBuiltin(t, Policy{Hedge: HedgePolicy{Delay: 20 * time.Millisecond}})
+	client := &http.Client{Transport: NewRoundTripper(nil, e, nil)}
+
+	start := time.Now()
+	resp, err := client.Get(srv.URL)
+	assert.Error(t, err).Nil()
+	body, err := io.ReadAll(resp.Body)
+	assert.Error(t, err).Nil()
+	_ = resp.Body.Close()
+	assert.That(t, string(body)).Equal("fast")
+	assert.That(t, time.Since(start) < time.Second).True()
+
+	// A POST without an idempotency key is never sent twice.
+	hits.Store(0)
+	resp, err = client.Post(srv.URL, "text/plain", strings.NewReader("x"))
+	assert.Error(t, err).Nil()
+	_ = resp.Body.Close()
+	assert.That(t, hits.Load()).Equal(int32(1))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 // ResourceFunc derives the resilience resource key from a request. Requests to
@@ -41,6 +42,12 @@ type ResourceFunc func(*http.Request) string
 // the net/http client arranges for standard body types). Transport errors
 // always count as failures. The final response or error is returned to the
 // caller unchanged.
+//
+// A hedging policy hedges only requests that are safe to send twice: an
+// idempotent method (GET, HEAD, OPTIONS, TRACE) or an Idempotency-Key header,
+// and a body that is absent or rewindable. Other requests run as one attempt.
+// Of concurrent attempts only the first response is kept; a later one is
+// closed and discarded.
 func NewRoundTripper(base http.RoundTripper, exec Executor, resource ResourceFunc) http.RoundTripper {
 	if exec == nil {
 		return base
@@ -61,8 +68,15 @@ type roundTripper struct {
 }
 
 func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	var resp *http.Response
-	err := rt.exec.Execute(req.Context(), rt.resource(req), func(ctx context.Context) error {
+	var (
+		mu   sync.Mutex
+		resp *http.Response
+	)
+	ctx := req.Context()
+	if !replayable(req) {
+		ctx = withoutHedging(ctx)
+	}
+	err := rt.exec.Execute(ctx, rt.resource(req), func(ctx context.Context) error {
 		// Rewind the body for each attempt so retries send the full payload;
 		// requests without a rewindable body simply run once (the executor's
 		// retry loop stops on the first success).
@@ -85,15 +99,74 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 			_ = r.Body.Close()
 			return fmt.Errorf("resilience: upstream returned %d", r.StatusCode)
 		}
+		mu.Lock()
+		defer mu.Unlock()
+		if resp != nil {
+			// A hedged attempt already answered.
+			_ = r.Body.Close()
+			return errHedgeLost
+		}
+		// The body is read after Execute returns, so keep the attempt's
+		// context alive until the caller closes it.
+		r.Body = holdBody(r.Body, holdAttempt(ctx))
 		resp = r
 		return nil
 	})
 	if err != nil {
+		mu.Lock()
+		defer mu.Unlock()
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
 		return nil, err
 	}
 	return resp, nil
 }
 
+// holdBody wraps body so closing it calls release. The body of a 101
+// Switching Protocols response stays writable.
+func holdBody(body io.ReadCloser, release func()) io.ReadCloser {
+	if rw, ok := body.(io.ReadWriteCloser); ok {
+		return &heldRWBody{heldBody{ReadCloser: rw, release: release}, rw}
+	}
+	return &heldBody{ReadCloser: body, release: release}
+}
+
+// heldBody releases the attempt that produced it once it is closed.
+type heldBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *heldBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// heldRWBody is a heldBody over an upgraded, writable connection.
+type heldRWBody struct {
+	heldBody
+	w io.Writer
+}
+
+func (b *heldRWBody) Write(p []byte) (int, error) { return b.w.Write(p) }
+
+// replayable reports whether req may be sent more than once concurrently,
+// following the rules net/http applies before retrying a request.
+func replayable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	_, key := req.Header["Idempotency-Key"]
+	_, xkey := req.Header["X-Idempotency-Key"]
+	return key || xkey
+}
+
 // Close lets an owner (e.g. a starter's destroy hook) release the underlying
 // executor by type-asserting the transport to io.Closer.
 func (rt *roundTripper) Close() error { return rt.exec.Close() }
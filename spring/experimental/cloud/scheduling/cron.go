@@ -23,24 +23,29 @@ import (
 	"time"
 )
 
-// cronSpec is a parsed 5-field cron expression. Each field is a bitmask of the
-// values that match; a set bit at index i means "i matches". dom/dow also record
+// cronSpec is a parsed cron expression. Each field is a bitmask of the values
+// that match; a set bit at index i means "i matches". dom/dow also record
 // whether the field was restricted (anything other than "*"), which drives the
-// classic day-of-month / day-of-week OR rule.
+// classic day-of-month / day-of-week OR rule; hourRestricted selects the
+// fixed-time DST rules described on [ParseCron].
 type cronSpec struct {
+	second uint64 // bits 0..59
 	minute uint64 // bits 0..59
 	hour   uint64 // bits 0..23
 	dom    uint64 // bits 1..31
 	month  uint64 // bits 1..12
 	dow    uint64 // bits 0..6 (Sunday=0)
 
-	domRestricted bool
-	dowRestricted bool
+	domRestricted  bool
+	dowRestricted  bool
+	hourRestricted bool
+
+	loc  *time.Location // from a CRON_TZ= prefix; nil means the reference's
+	expr string
 }
 
-// Cron returns a [Trigger] for a standard 5-field cron expression
-// ("minute hour day-of-month month day-of-week"). It panics on a malformed
-// expression; use [ParseCron] to handle the error instead.
+// Cron returns a [Trigger] for a cron expression (see [ParseCron]). It panics
+// on a malformed expression; use [ParseCron] to handle the error instead.
 func Cron(expr string) Trigger {
 	t, err := ParseCron(expr)
 	if err != nil {
@@ -49,11 +54,13 @@ func Cron(expr string) Trigger {
 	return t
 }
 
-// ParseCron parses a standard 5-field cron expression and returns a [Trigger].
+// ParseCron parses a standard 5-field cron expression, or a 6-field one with
+// a leading seconds field, and returns a [Trigger].
 //
 // Each field supports "*", a single value, ranges "a-b", steps "*/n" and
 // "a-b/n", and comma-separated lists of these. Fields and their ranges:
 //
+//	second        0-59 (6-field form only; the 5-field form fires at :00)
 //	minute        0-59
 //	hour          0-23
 //	day-of-month  1-31
@@ -64,11 +71,29 @@ func Cron(expr string) Trigger {
 // @weekly, @daily (@midnight) and @hourly.
 //
 // When both day-of-month and day-of-week are restricted, a day matches if
-// *either* matches — the traditional Vixie-cron behaviour. Cron times are
-// evaluated in the location of the reference time passed to Next (the scheduler
-// uses the local time zone).
+// *either* matches — the traditional Vixie-cron behaviour.
+//
+// Cron times are evaluated in the location of the reference time passed to
+// Next (the scheduler uses the local time zone, or the task's [WithLocation]).
+// A "CRON_TZ=<zone>" (or "TZ=<zone>") prefix pins the expression to an IANA
+// zone instead. Daylight-saving transitions follow Vixie cron: a job with a
+// restricted hour field fires once at the first instant after a
+// spring-forward gap that swallowed its time, and only on the first pass
+// through a repeated fall-back hour; jobs with a wildcard hour keep their
+// real-time cadence through both transitions.
 func ParseCron(expr string) (Trigger, error) {
 	expr = strings.TrimSpace(expr)
+	s := cronSpec{expr: expr}
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		tz, rest, _ := strings.Cut(expr, " ")
+		_, name, _ := strings.Cut(tz, "=")
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("scheduling: cron time zone %q: %w", name, err)
+		}
+		s.loc = loc
+		expr = strings.TrimSpace(rest)
+	}
 	if strings.HasPrefix(expr, "@") {
 		if m, ok := cronMacros[expr]; ok {
 			expr = m
@@ -78,25 +103,31 @@ func ParseCron(expr string) (Trigger, error) {
 	}
 
 	fields := strings.Fields(expr)
-	if len(fields) != 5 {
-		return nil, fmt.Errorf("scheduling: cron expression %q must have 5 fields, got %d", expr, len(fields))
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("scheduling: cron expression %q must have 5 or 6 fields, got %d", expr, len(fields))
 	}
 
-	var s cronSpec
 	var err error
-	if s.minute, _, err = parseCronField(fields[0], 0, 59, "minute"); err != nil {
+	if s.second, _, err = parseCronField(fields[0], 0, 59, "second"); err != nil {
 		return nil, err
 	}
-	if s.hour, _, err = parseCronField(fields[1], 0, 23, "hour"); err != nil {
+	if s.minute, _, err = parseCronField(fields[1], 0, 59, "minute"); err != nil {
 		return nil, err
 	}
-	if s.dom, s.domRestricted, err = parseCronField(fields[2], 1, 31, "day-of-month"); err != nil {
+	if s.hour, s.hourRestricted, err = parseCronField(fields[2], 0, 23, "hour"); err != nil {
 		return nil, err
 	}
-	if s.month, _, err = parseCronField(fields[3], 1, 12, "month"); err != nil {
+	if s.dom, s.domRestricted, err = parseCronField(fields[3], 1, 31, "day-of-month"); err != nil {
 		return nil, err
 	}
-	if s.dow, s.dowRestricted, err = parseCronField(fields[4], 0, 7, "day-of-week"); err != nil {
+	if s.month, _, err = parseCronField(fields[4], 1, 12, "month"); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowRestricted, err = parseCronField(fields[5], 0, 7, "day-of-week"); err != nil {
 		return nil, err
 	}
 	// Normalize Sunday: 7 -> 0 so day matching only consults bits 0..6.
@@ -173,41 +204,102 @@ func parseCronPart(part string, min, max int) (lo, hi, step int, err error) {
 	return lo, hi, step, nil
 }
 
-// Next implements [Trigger]. It returns the next matching minute strictly after
-// the later of tc.Now and tc.LastScheduled, or the zero time if no time within a
-// five-year horizon matches (an impossible expression such as Feb 30).
+// String returns the expression the trigger was parsed from.
+func (s *cronSpec) String() string { return s.expr }
+
+// Next implements [Trigger]. It returns the next matching second strictly
+// after the later of tc.Now and tc.LastScheduled, or the zero time if no time
+// within a five-year horizon matches (an impossible expression such as Feb 30).
+//
+// The walk advances by wall-clock fields but measures hours, minutes and
+// seconds in real time, so it never loops or goes backwards across a DST
+// transition; see [ParseCron] for how such transitions are resolved.
 func (s *cronSpec) Next(tc TriggerContext) time.Time {
 	ref := tc.Now
 	if !tc.LastScheduled.IsZero() && !tc.LastScheduled.Before(ref) {
 		ref = tc.LastScheduled
 	}
-	// Start at the next whole minute after ref (seconds/nanos zeroed).
-	t := ref.Truncate(time.Minute).Add(time.Minute)
+	loc := ref.Location()
+	if s.loc != nil {
+		loc = s.loc
+	}
+	// Start at the next whole second after ref.
+	t := ref.In(loc).Truncate(time.Second).Add(time.Second)
 
 	limit := t.AddDate(5, 0, 0)
 	for t.Before(limit) {
-		if s.month&(1<<uint(t.Month())) == 0 {
+		var n time.Time
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
 			// Advance to the first day of the next month.
-			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, 1, 0)
-			continue
-		}
-		if !s.dayMatches(t) {
-			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1)
-			continue
-		}
-		if s.hour&(1<<uint(t.Hour())) == 0 {
-			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(time.Hour)
-			continue
+			n = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			n = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			n = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			n = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case s.second&(1<<uint(t.Second())) == 0 || s.repeated(t):
+			n = t.Add(time.Second)
+		default:
+			return t
 		}
-		if s.minute&(1<<uint(t.Minute())) == 0 {
-			t = t.Add(time.Minute)
-			continue
+		if g, ok := s.gapFire(t, n); ok {
+			return g
 		}
-		return t
+		t = n
 	}
 	return time.Time{}
 }
 
+// repeated reports whether t is the second pass through a wall-clock time
+// repeated by a fall-back transition. Only fixed-time specs skip it.
+func (s *cronSpec) repeated(t time.Time) bool {
+	if !s.hourRestricted {
+		return false
+	}
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return false
+	}
+	_, off := t.Zone()
+	_, before := start.Add(-time.Nanosecond).Zone()
+	return before > off && t.Sub(start) < time.Duration(before-off)*time.Second
+}
+
+// gapFire reports whether the walk from t to n crossed a spring-forward gap
+// that swallowed a matching wall-clock time; if so, a fixed-time spec fires at
+// the transition instant instead.
+func (s *cronSpec) gapFire(t, n time.Time) (time.Time, bool) {
+	if !s.hourRestricted {
+		return time.Time{}, false
+	}
+	start, _ := n.ZoneBounds()
+	if start.IsZero() || !start.After(t) || start.After(n) {
+		return time.Time{}, false
+	}
+	_, after := n.Zone()
+	_, before := start.Add(-time.Nanosecond).Zone()
+	if after <= before {
+		return time.Time{}, false
+	}
+	// Walk the missing wall-clock seconds, encoded as UTC.
+	w := time.Unix(start.Unix()+int64(before), 0).UTC()
+	for end := w.Add(time.Duration(after-before) * time.Second); w.Before(end); w = w.Add(time.Second) {
+		if s.matches(w) {
+			return start.In(n.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// matches reports whether every field of t matches the spec.
+func (s *cronSpec) matches(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0 && s.dayMatches(t) &&
+		s.hour&(1<<uint(t.Hour())) != 0 && s.minute&(1<<uint(t.Minute())) != 0 &&
+		s.second&(1<<uint(t.Second())) != 0
+}
+
 // dayMatches applies the classic day-of-month / day-of-week rule: if both fields
 // are restricted a day matches when *either* matches; otherwise the restricted
 // one (or both being "*") decides.
@@ -17,8 +17,10 @@
 package scheduling_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"go-spring.org/spring/experimental/cloud/scheduling"
 	"go-spring.org/stdlib/testing/assert"
@@ -35,18 +37,20 @@ func nextAfter(t *testing.T, expr string, ref time.Time) time.Time {
 
 func TestParseCronErrors(t *testing.T) {
 	cases := []string{
-		"",            // empty
-		"* * * *",     // too few fields
-		"* * * * * *", // too many fields
-		"60 * * * *",  // minute out of range
-		"* 24 * * *",  // hour out of range
-		"* * 0 * *",   // day-of-month below range
-		"* * * 13 *",  // month out of range
-		"* * * * 8",   // day-of-week out of range
-		"*/0 * * * *", // zero step
-		"5-1 * * * *", // inverted range
-		"a * * * *",   // non-numeric
-		"@bogus",      // unknown macro
+		"",                               // empty
+		"* * * *",                        // too few fields
+		"* * * * * * *",                  // too many fields
+		"60 * * * * *",                   // second out of range
+		"CRON_TZ=Nowhere/City * * * * *", // unknown zone
+		"60 * * * *",                     // minute out of range
+		"* 24 * * *",                     // hour out of range
+		"* * 0 * *",                      // day-of-month below range
+		"* * * 13 *",                     // month out of range
+		"* * * * 8",                      // day-of-week out of range
+		"*/0 * * * *",                    // zero step
+		"5-1 * * * *",                    // inverted range
+		"a * * * *",                      // non-numeric
+		"@bogus",                         // unknown macro
 	}
 	for _, c := range cases {
 		_, err := scheduling.ParseCron(c)
@@ -110,3 +114,54 @@ func TestCronRespectsLastScheduled(t *testing.T) {
 	got := tr.Next(scheduling.TriggerContext{Now: now, LastScheduled: last})
 	assert.That(t, got).Equal(time.Date(2026, 7, 18, 10, 32, 0, 0, time.UTC))
 }
+
+func TestCronSecondsField(t *testing.T) {
+	ref := time.Date(2026, 7, 18, 10, 30, 15, 0, time.UTC)
+	got := nextAfter(t, "*/20 * * * * *", ref)
+	assert.That(t, got).Equal(time.Date(2026, 7, 18, 10, 30, 20, 0, time.UTC))
+
+	got = nextAfter(t, "30 0 9 * * *", ref)
+	assert.That(t, got).Equal(time.Date(2026, 7, 19, 9, 0, 30, 0, time.UTC))
+}
+
+func TestCronTimeZone(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	assert.Error(t, err).Nil()
+	// 09:00 in Shanghai is 01:00 UTC, whatever zone the reference is in.
+	ref := time.Date(2026, 7, 18, 0, 0, 0, 0, time.UTC)
+	got := nextAfter(t, "CRON_TZ=Asia/Shanghai 0 9 * * *", ref)
+	assert.That(t, got.Equal(time.Date(2026, 7, 18, 1, 0, 0, 0, time.UTC))).True()
+	assert.That(t, got.Location()).Equal(shanghai)
+	assert.That(t, scheduling.Cron("CRON_TZ=Asia/Shanghai 0 9 * * *").(fmt.Stringer).String()).
+		Equal("CRON_TZ=Asia/Shanghai 0 9 * * *")
+}
+
+func TestCronDaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Error(t, err).Nil()
+
+	// 2026-03-08: 02:00 EST jumps to 03:00 EDT. A 02:30 job fires once at the
+	// transition instead of being lost.
+	ref := time.Date(2026, 3, 8, 1, 0, 0, 0, ny)
+	got := nextAfter(t, "30 2 * * *", ref)
+	assert.That(t, got.Equal(time.Date(2026, 3, 8, 3, 0, 0, 0, ny))).True()
+	got = nextAfter(t, "30 2 * * *", got)
+	assert.That(t, got.Equal(time.Date(2026, 3, 9, 2, 30, 0, 0, ny))).True()
+
+	// A wildcard-hour job keeps its real-time cadence: 01:30 EST, then 03:30
+	// EDT an hour later.
+	got = nextAfter(t, "30 * * * *", time.Date(2026, 3, 8, 1, 30, 0, 0, ny))
+	assert.That(t, got.Sub(time.Date(2026, 3, 8, 1, 30, 0, 0, ny))).Equal(time.Hour)
+
+	// 2026-11-01: 02:00 EDT falls back to 01:00 EST. A 01:30 job fires on the
+	// first pass only.
+	ref = time.Date(2026, 11, 1, 0, 0, 0, 0, ny)
+	first := nextAfter(t, "30 1 * * *", ref)
+	assert.That(t, first.Equal(time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC))).True()
+	got = nextAfter(t, "30 1 * * *", first)
+	assert.That(t, got.Equal(time.Date(2026, 11, 2, 1, 30, 0, 0, ny))).True()
+
+	// Hourly runs through both passes of the repeated hour.
+	got = nextAfter(t, "30 * * * *", first)
+	assert.That(t, got.Sub(first)).Equal(time.Hour)
+}
@@ -18,22 +18,31 @@ package scheduling
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Event describes one scheduled fire, delivered to an [Observer] for metrics or
-// logging. A run that was skipped (concurrency policy or a lock held by another
-// replica) has Skipped set and Reason populated; Start/Duration/Err are zero.
+// logging. A run that was skipped (concurrency policy, a lock held by another
+// replica, a paused task, or no shard owned here) has Skipped set and Reason populated;
+// Start/Duration/Err are zero. A failing [JobStore] is reported as an event
+// with Reason "store" and Err set, which is not a fire.
 type Event struct {
 	Name      string        // task name
 	Scheduled time.Time     // the fire time this event corresponds to
+	Shard     Shard         // the shard that ran; zero when unsharded
 	Start     time.Time     // when the run started (zero if skipped)
 	Duration  time.Duration // how long the run took (zero if skipped)
 	Err       error         // the job's error, if any
 	Skipped   bool          // true if the fire did not run
-	Reason    string        // "policy" or "lock" when Skipped
+	Reason    string        // "policy", "lock", "paused" or "shard" when Skipped; "store"
 }
 
 // Observer receives an [Event] after each fire. It must not block.
@@ -48,6 +57,22 @@ func WithObserver(o Observer) SchedulerOption {
 	return func(s *scheduler) { s.observer = o }
 }
 
+// WithJobStore persists task state and run history in store, so a restarted
+// scheduler applies each task's [MisfirePolicy] to the fires it missed and
+// the history outlives the process. Without it a [MemoryJobStore] is used.
+func WithJobStore(store JobStore) SchedulerOption {
+	return func(s *scheduler) { s.store = store }
+}
+
+// WithFlushInterval sets how often each task's state and run history are
+// written to the [JobStore]. Changes in between are batched: a task that fires
+// every few milliseconds still costs one state write and one history write per
+// interval. A task also flushes when it stops, and before [SchedulerAdmin.History]
+// reads it, so only a crash loses up to one interval. Defaults to one second.
+func WithFlushInterval(d time.Duration) SchedulerOption {
+	return func(s *scheduler) { s.flushEvery = d }
+}
+
 // serialTrigger marks a trigger whose next fire depends on the previous run
 // having finished (fixed-delay). The scheduler runs such tasks strictly one at a
 // time, so their [ConcurrencyPolicy] is irrelevant.
@@ -61,6 +86,12 @@ func NewScheduler(opts ...SchedulerOption) Scheduler {
 	for _, o := range opts {
 		o(s)
 	}
+	if s.store == nil {
+		s.store = NewMemoryJobStore(0)
+	}
+	if s.flushEvery <= 0 {
+		s.flushEvery = time.Second
+	}
 	return s
 }
 
@@ -68,6 +99,9 @@ type scheduler struct {
 	mu       sync.Mutex
 	tasks    map[string]*task
 	observer Observer
+	store    JobStore
+
+	flushEvery time.Duration
 
 	started bool
 	stopped bool
@@ -105,6 +139,12 @@ func (s *scheduler) Schedule(name string, trigger Trigger, job Job, opts ...Opti
 		job:      job,
 		opts:     o,
 		observer: s.observer,
+		store:    s.store,
+		loc:      o.Location,
+		kick:     make(chan struct{}, 1),
+	}
+	if t.loc == nil {
+		t.loc = time.Local
 	}
 	_, t.isSerial = trigger.(serialTrigger)
 	s.tasks[name] = t
@@ -155,9 +195,35 @@ func (s *scheduler) Start(ctx context.Context) error {
 	for _, t := range s.tasks {
 		s.launch(t)
 	}
+	s.loopWg.Go(func() {
+		s.flushLoop(s.ctx)
+	})
 	return nil
 }
 
+// flushLoop flushes every task once per flush interval until the scheduler
+// stops. Each task loop flushes its remainder itself as it returns.
+func (s *scheduler) flushLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.flushEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.mu.Lock()
+		tasks := make([]*task, 0, len(s.tasks))
+		for _, t := range s.tasks {
+			tasks = append(tasks, t)
+		}
+		s.mu.Unlock()
+		for _, t := range tasks {
+			t.flush(ctx)
+		}
+	}
+}
+
 // Stop implements [Scheduler]. It stops all loops, waits for in-flight runs to
 // drain, and reports ctx.Err() if the deadline elapses first.
 func (s *scheduler) Stop(ctx context.Context) error {
@@ -198,6 +264,91 @@ func (s *scheduler) Stop(ctx context.Context) error {
 	}
 }
 
+var _ SchedulerAdmin = (*scheduler)(nil)
+
+// lookup returns the named task or ErrUnknownTask.
+func (s *scheduler) lookup(name string) (*task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTask, name)
+	}
+	return t, nil
+}
+
+// Tasks implements [SchedulerAdmin].
+func (s *scheduler) Tasks() []TaskInfo {
+	s.mu.Lock()
+	tasks := make([]*task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	s.mu.Unlock()
+
+	infos := make([]TaskInfo, 0, len(tasks))
+	for _, t := range tasks {
+		infos = append(infos, t.info())
+	}
+	slices.SortFunc(infos, func(a, b TaskInfo) int { return strings.Compare(a.Name, b.Name) })
+	return infos
+}
+
+// Pause implements [SchedulerAdmin].
+func (s *scheduler) Pause(name string) error {
+	return s.setPaused(name, true)
+}
+
+// Resume implements [SchedulerAdmin].
+func (s *scheduler) Resume(name string) error {
+	return s.setPaused(name, false)
+}
+
+func (s *scheduler) setPaused(name string, paused bool) error {
+	t, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.paused = paused
+	t.pausedSet = true
+	t.dirty = true
+	t.mu.Unlock()
+	t.flush(context.Background())
+	return nil
+}
+
+// RunNow implements [SchedulerAdmin]. A request made while another is still
+// queued is folded into it.
+func (s *scheduler) RunNow(name string) error {
+	t, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	running := s.started && !s.stopped
+	s.mu.Unlock()
+	if !running {
+		return ErrNotStarted
+	}
+	select {
+	case t.kick <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// History implements [SchedulerAdmin]. It flushes the task first, so the
+// executions still batched in memory are included.
+func (s *scheduler) History(ctx context.Context, name string, limit int) ([]Execution, error) {
+	t, err := s.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	t.flush(ctx)
+	return s.store.History(ctx, name, limit)
+}
+
 // task is one scheduled job and its runtime state.
 type task struct {
 	name     string
@@ -205,58 +356,269 @@ type task struct {
 	job      Job
 	opts     Options
 	observer Observer
+	store    JobStore
+	loc      *time.Location
 	isSerial bool
 
+	kick       chan struct{} // RunNow requests
+	inFlight   atomic.Int32
+	saveMu     sync.Mutex // orders flushes
 	loopCancel context.CancelFunc
 	runWg      sync.WaitGroup // in-flight runs, awaited on Stop
 
 	mu             sync.Mutex
 	lastScheduled  time.Time
 	lastCompletion time.Time
+	nextFire       time.Time
+	paused         bool
+	last           *Execution
 	running        bool
 	queued         bool
 	replaceCancel  context.CancelFunc // cancels the in-flight run under Replace
+
+	// Batched store writes, see flush.
+	dirty     bool        // the state changed since the last save
+	pending   []Execution // executions not yet recorded, oldest first
+	version   int64       // the stored version the state was last read or saved at
+	pausedSet bool        // paused was changed here since the last save
 }
 
 // loop computes each fire time from the trigger, waits for it, then dispatches
 // the run. For a serial (fixed-delay) trigger it runs synchronously so the next
 // fire is measured from completion; otherwise it dispatches per concurrency
-// policy without blocking the loop.
+// policy without blocking the loop. RunNow requests are served between fires.
 func (t *task) loop(ctx context.Context) {
+	defer func() {
+		// Let the runs this loop dispatched finish, so the last flush
+		// includes their completion and history.
+		t.runWg.Wait()
+		t.flush(ctx)
+	}()
+	t.restore(ctx)
 	for {
 		t.mu.Lock()
 		tc := TriggerContext{
-			Now:            time.Now(),
-			LastScheduled:  t.lastScheduled,
-			LastCompletion: t.lastCompletion,
+			Now:            time.Now().In(t.loc),
+			LastScheduled:  t.lastScheduled.In(t.loc),
+			LastCompletion: t.lastCompletion.In(t.loc),
 		}
+		next := t.trigger.Next(tc)
+		t.nextFire = next
+		t.dirty = true
 		t.mu.Unlock()
 
-		next := t.trigger.Next(tc)
-		if next.IsZero() {
-			return // never fires again
+		// A trigger that never fires again still serves RunNow.
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !next.IsZero() {
+			d := time.Until(next)
+			if t.opts.Jitter > 0 {
+				d += rand.N(t.opts.Jitter)
+			}
+			timer = time.NewTimer(max(d, 0))
+			due = timer.C
 		}
-
-		d := time.Until(next)
-		d = max(d, 0)
-		timer := time.NewTimer(d)
 		select {
 		case <-ctx.Done():
-			timer.Stop()
+			stopTimer(timer)
 			return
-		case <-timer.C:
+		case <-t.kick:
+			stopTimer(timer)
+			t.fire(ctx, time.Now().In(t.loc))
+			continue
+		case <-due:
 		}
 
 		t.mu.Lock()
 		t.lastScheduled = next
+		paused := t.paused
+		t.mu.Unlock()
+
+		if paused {
+			t.emitSkip(next, "paused")
+			continue
+		}
+		t.fire(ctx, next)
+	}
+}
+
+func stopTimer(timer *time.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
+
+// fire runs a serial task synchronously and dispatches any other.
+func (t *task) fire(ctx context.Context, scheduled time.Time) {
+	if t.isSerial {
+		t.runOnce(ctx, scheduled) // blocks; updates lastCompletion
+	} else {
+		t.dispatch(ctx, scheduled)
+	}
+}
+
+// restore loads the task's persisted state and applies the misfire policy to
+// a fire that came due while no process was running the task. Missed fires
+// run synchronously, in order, before the regular schedule resumes.
+func (t *task) restore(ctx context.Context) {
+	st, ok, err := t.store.LoadState(ctx, t.name)
+	if err != nil {
+		t.storeError(err)
+		return
+	}
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	t.lastScheduled = st.LastScheduled
+	t.lastCompletion = st.LastCompletion
+	t.paused = t.paused || st.Paused
+	t.version = st.Version
+	t.mu.Unlock()
+
+	now := time.Now()
+	if st.NextFire.IsZero() || st.NextFire.After(now) {
+		return
+	}
+	switch t.opts.Misfire {
+	case MisfireSkip:
+		t.mu.Lock()
+		t.lastScheduled, t.lastCompletion = time.Time{}, time.Time{}
 		t.mu.Unlock()
+	case MisfireFireAll:
+		at := st.NextFire.In(t.loc)
+		for i := 0; i < MaxCatchUp && !at.IsZero() && !at.After(now) && ctx.Err() == nil; i++ {
+			t.catchUp(ctx, at)
+			at = t.trigger.Next(TriggerContext{Now: at, LastScheduled: at, LastCompletion: at})
+		}
+	default:
+		t.catchUp(ctx, st.NextFire.In(t.loc))
+	}
+}
+
+// catchUp runs one missed fire, or records it as skipped if the task is
+// paused.
+func (t *task) catchUp(ctx context.Context, scheduled time.Time) {
+	t.mu.Lock()
+	t.lastScheduled = scheduled
+	paused := t.paused
+	t.mu.Unlock()
+	if paused {
+		t.emitSkip(scheduled, "paused")
+		return
+	}
+	t.runOnce(ctx, scheduled)
+}
+
+// flush writes the executions and state change batched since the last flush.
+// Flushes are serialized and each takes its batch once it holds saveMu, so a
+// slow flush never overwrites a newer one. A state that fails to save stays
+// dirty for the next flush; executions that fail to record are dropped.
+func (t *task) flush(ctx context.Context) {
+	t.saveMu.Lock()
+	defer t.saveMu.Unlock()
+	t.mu.Lock()
+	pending, dirty := t.pending, t.dirty
+	t.pending, t.dirty = nil, false
+	t.mu.Unlock()
+	if len(pending) == 0 && !dirty {
+		return
+	}
 
-		if t.isSerial {
-			t.runOnce(ctx, next) // blocks; updates lastCompletion
-		} else {
-			t.dispatch(ctx, next)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+	if len(pending) > 0 {
+		if err := recordAll(ctx, t.store, pending); err != nil {
+			t.storeError(err)
 		}
 	}
+	if dirty {
+		if err := t.save(ctx); err != nil {
+			t.mu.Lock()
+			t.dirty = true
+			t.mu.Unlock()
+			t.storeError(err)
+		}
+	}
+}
+
+// save stores the task's state at the version it was last read or saved at.
+// If another replica saved in between, its state is merged in and the save
+// is retried once. Caller holds saveMu.
+func (t *task) save(ctx context.Context) error {
+	for attempt := 0; ; attempt++ {
+		t.mu.Lock()
+		st := TaskState{
+			Name:           t.name,
+			NextFire:       t.nextFire,
+			LastScheduled:  t.lastScheduled,
+			LastCompletion: t.lastCompletion,
+			Paused:         t.paused,
+			Version:        t.version,
+		}
+		t.mu.Unlock()
+		err := t.store.SaveState(ctx, st)
+		if err == nil {
+			t.mu.Lock()
+			t.version = st.Version + 1
+			if t.paused == st.Paused {
+				t.pausedSet = false
+			}
+			t.mu.Unlock()
+			return nil
+		}
+		if !errors.Is(err, ErrStateConflict) || attempt > 0 {
+			return err
+		}
+		stored, ok, err := t.store.LoadState(ctx, t.name)
+		if err != nil {
+			return err
+		}
+		t.merge(stored, ok)
+	}
+}
+
+// merge folds a state saved by another replica into the task: each time keeps
+// the later value, and the paused flag follows the store unless it was changed
+// here since the last save. NextFire stays the one this loop waits for.
+func (t *task) merge(stored TaskState, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !ok { // deleted under us; insert afresh
+		t.version = 0
+		return
+	}
+	t.version = stored.Version
+	if stored.LastScheduled.After(t.lastScheduled) {
+		t.lastScheduled = stored.LastScheduled
+	}
+	if stored.LastCompletion.After(t.lastCompletion) {
+		t.lastCompletion = stored.LastCompletion
+	}
+	if !t.pausedSet {
+		t.paused = stored.Paused
+	}
+}
+
+// info returns a snapshot of the task.
+func (t *task) info() TaskInfo {
+	trigger := fmt.Sprintf("%T", t.trigger)
+	if s, ok := t.trigger.(fmt.Stringer); ok {
+		trigger = s.String()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return TaskInfo{
+		Name:           t.name,
+		Trigger:        trigger,
+		Location:       t.loc.String(),
+		Paused:         t.paused,
+		Running:        t.inFlight.Load() > 0,
+		NextFire:       t.nextFire,
+		LastScheduled:  t.lastScheduled,
+		LastCompletion: t.lastCompletion,
+		Last:           t.last,
+	}
 }
 
 // dispatch runs a non-serial fire according to the concurrency policy.
@@ -326,9 +688,54 @@ func (t *task) queueWorker(ctx context.Context, scheduled time.Time) {
 	}
 }
 
-// runOnce performs a single execution: optional distributed lock, optional
-// per-run timeout, the job itself (panic-guarded), and completion bookkeeping.
+// runOnce performs one fire. An unsharded task runs once; a sharded one asks
+// its assigner which shards this replica owns and runs each of them in
+// parallel. Completion bookkeeping happens once all of them are done.
 func (t *task) runOnce(parent context.Context, scheduled time.Time) {
+	shards := []Shard{{}}
+	if t.opts.Shards > 0 {
+		owned, err := t.opts.Assigner.Assign(parent, t.name, t.opts.Shards)
+		if err != nil {
+			t.emit(Event{Name: t.name, Scheduled: scheduled, Err: err, Skipped: true, Reason: "shard"})
+			return
+		}
+		if len(owned) == 0 {
+			// Every shard is owned by other replicas this time.
+			t.emitSkip(scheduled, "shard")
+			return
+		}
+		shards = shards[:0]
+		for _, i := range owned {
+			shards = append(shards, Shard{Index: i, Total: t.opts.Shards})
+		}
+	}
+
+	var (
+		wg  sync.WaitGroup
+		ran atomic.Bool
+	)
+	for _, sh := range shards {
+		wg.Go(func() {
+			if t.execute(parent, scheduled, sh) {
+				ran.Store(true)
+			}
+		})
+	}
+	wg.Wait()
+	if !ran.Load() {
+		return
+	}
+
+	t.mu.Lock()
+	t.lastCompletion = time.Now()
+	t.dirty = true
+	t.mu.Unlock()
+}
+
+// execute runs the job for one shard (the zero Shard when unsharded):
+// optional distributed lock, optional per-run timeout, the job itself
+// (panic-guarded) and its event. It reports whether the job ran.
+func (t *task) execute(parent context.Context, scheduled time.Time, sh Shard) bool {
 	ctx := parent
 	if t.opts.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -337,46 +744,83 @@ func (t *task) runOnce(parent context.Context, scheduled time.Time) {
 	}
 
 	if t.opts.Locker != nil {
-		l, ok, err := t.opts.Locker.TryAcquire(ctx, t.opts.LockKey)
+		key := t.opts.LockKey
+		if sh.Total > 0 {
+			// Per-shard keys keep a shard from running twice while replicas
+			// disagree about its owner during a rebalance.
+			key += "/" + strconv.Itoa(sh.Index)
+		}
+		l, ok, err := t.opts.Locker.TryAcquire(ctx, key)
 		if err != nil {
-			t.emit(Event{Name: t.name, Scheduled: scheduled, Err: err, Skipped: true, Reason: "lock"})
-			return
+			t.emit(Event{Name: t.name, Scheduled: scheduled, Shard: sh, Err: err, Skipped: true, Reason: "lock"})
+			return false
 		}
 		if !ok {
 			// Another replica holds the lock; this replica skips this fire.
-			t.emitSkip(scheduled, "lock")
-			return
+			t.emit(Event{Name: t.name, Scheduled: scheduled, Shard: sh, Skipped: true, Reason: "lock"})
+			return false
 		}
 		defer func() { _ = l.Unlock(context.WithoutCancel(ctx)) }()
 	}
+	if sh.Total > 0 {
+		ctx = context.WithValue(ctx, shardKey{}, sh)
+	}
 
+	t.inFlight.Add(1)
 	start := time.Now()
 	err := safeRun(ctx, t.job)
 	end := time.Now()
-
-	t.mu.Lock()
-	t.lastCompletion = end
-	t.mu.Unlock()
+	t.inFlight.Add(-1)
 
 	t.emit(Event{
 		Name:      t.name,
 		Scheduled: scheduled,
+		Shard:     sh,
 		Start:     start,
 		Duration:  end.Sub(start),
 		Err:       err,
 	})
+	return true
 }
 
 func (t *task) emitSkip(scheduled time.Time, reason string) {
 	t.emit(Event{Name: t.name, Scheduled: scheduled, Skipped: true, Reason: reason})
 }
 
+// storeTimeout bounds each flush of a task, so a slow or unreachable store
+// delays the next flush (or Stop) by at most this much.
+const storeTimeout = 5 * time.Second
+
+// emit queues ev for the task's history and hands it to the observer.
 func (t *task) emit(ev Event) {
+	e := Execution{
+		Name:      ev.Name,
+		Scheduled: ev.Scheduled,
+		Shard:     ev.Shard,
+		Start:     ev.Start,
+		Duration:  ev.Duration,
+		Skipped:   ev.Skipped,
+		Reason:    ev.Reason,
+	}
+	if ev.Err != nil {
+		e.Error = ev.Err.Error()
+	}
+	t.mu.Lock()
+	t.last = &e
+	t.pending = append(t.pending, e)
+	t.mu.Unlock()
 	if t.observer != nil {
 		t.observer(ev)
 	}
 }
 
+// storeError reports a JobStore failure to the observer.
+func (t *task) storeError(err error) {
+	if t.observer != nil {
+		t.observer(Event{Name: t.name, Err: err, Reason: "store"})
+	}
+}
+
 // safeRun invokes job, converting a panic into an error so one bad run cannot
 // kill the scheduler goroutine.
 func safeRun(ctx context.Context, job Job) (err error) {
@@ -32,13 +32,21 @@
 //     the task's [ConcurrencyPolicy].
 //   - [FixedDelay]: fire a fixed interval after the previous run *finishes*. Runs
 //     never overlap.
-//   - [Cron]: fire on a standard 5-field cron expression (see [ParseCron]).
+//   - [Cron]: fire on a 5-field cron expression, or 6 fields with seconds (see
+//     [ParseCron]), evaluated DST-correctly in the task's time zone.
 //
 // The abstraction is deliberately split from any backend. A [Scheduler] runs
 // entirely in-process; when the same job must run on only one replica of a
 // multi-replica deployment, attach a distributed lock with [WithLock] (see
 // [go-spring.org/spring/lock]) so only the lock holder executes each fire.
 //
+// By default a [Scheduler] keeps its state in memory, so fires missed while the
+// process was down are simply gone. Attach a [JobStore] with [WithJobStore] to
+// persist each task's next fire time and run history; on restart the task's
+// [MisfirePolicy] decides what happens to the fires it missed. The scheduler
+// also lists, pauses, resumes and manually runs its tasks through the optional
+// [SchedulerAdmin] interface, which is what an admin endpoint builds on.
+//
 // Cron parsing lives in this package on purpose: keeping it here preserves the
 // zero-dependency contract of stdlib and avoids pulling a third-party cron
 // library into the foundation layer.
@@ -159,6 +167,25 @@ type Options struct {
 	// one replica at a time.
 	Locker  Locker
 	LockKey string
+
+	// Location is the time zone the trigger computes fire times in. Nil means
+	// the process-local zone. A cron expression's CRON_TZ= prefix wins over it.
+	Location *time.Location
+
+	// Jitter, when positive, delays each fire by a random duration in
+	// [0, Jitter) so replicas sharing a schedule do not hit a backend at the
+	// same instant. The nominal fire time, which anchors the next one, is
+	// unchanged.
+	Jitter time.Duration
+
+	// Misfire decides what happens to fires missed while the process was down.
+	// It only applies with a [JobStore]. Defaults to MisfireFireOnce.
+	Misfire MisfirePolicy
+
+	// Shards and Assigner, when set via [WithShards], split each fire into
+	// Shards parts and run only those Assigner gives this replica.
+	Shards   int
+	Assigner ShardAssigner
 }
 
 // Option mutates [Options].
@@ -184,6 +211,58 @@ func WithLock(locker Locker, key string) Option {
 	}
 }
 
+// WithLocation computes the task's fire times in loc, e.g. so "0 9 * * *"
+// means 09:00 in the customer's zone rather than the server's.
+func WithLocation(loc *time.Location) Option {
+	return func(o *Options) { o.Location = loc }
+}
+
+// WithJitter delays each fire by a random duration in [0, d).
+func WithJitter(d time.Duration) Option {
+	return func(o *Options) { o.Jitter = d }
+}
+
+// WithMisfirePolicy sets how fires missed during downtime are handled.
+func WithMisfirePolicy(p MisfirePolicy) Option {
+	return func(o *Options) { o.Misfire = p }
+}
+
+// MisfirePolicy decides what a restarted scheduler does with the fires a task
+// missed while the process was down, as recorded by a [JobStore].
+type MisfirePolicy int
+
+const (
+	// MisfireFireOnce runs the task once immediately, however many fires were
+	// missed, then resumes the normal schedule. This is the default.
+	MisfireFireOnce MisfirePolicy = iota
+
+	// MisfireFireAll runs every missed fire in order, one after another, then
+	// resumes the normal schedule. At most [MaxCatchUp] fires are replayed.
+	MisfireFireAll
+
+	// MisfireSkip drops the missed fires and resumes as if the task had just
+	// been scheduled.
+	MisfireSkip
+)
+
+// MaxCatchUp bounds the fires [MisfireFireAll] replays, so a task that fires
+// every second does not replay a week of downtime.
+const MaxCatchUp = 1000
+
+// String returns the policy name.
+func (p MisfirePolicy) String() string {
+	switch p {
+	case MisfireFireOnce:
+		return "fire-once"
+	case MisfireFireAll:
+		return "fire-all"
+	case MisfireSkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
 // Errors returned by [Scheduler.Schedule].
 var (
 	// ErrNoTrigger is returned when Schedule is called with a nil trigger. A task
@@ -197,6 +276,13 @@ var (
 	// ErrDuplicateName is returned when Schedule is called with a name already in
 	// use on the same scheduler.
 	ErrDuplicateName = errors.New("scheduling: duplicate task name")
+
+	// ErrUnknownTask is returned by the task management methods for a name
+	// that is not scheduled.
+	ErrUnknownTask = errors.New("scheduling: unknown task")
+
+	// ErrNotStarted is returned by RunNow before Start or after Stop.
+	ErrNotStarted = errors.New("scheduling: scheduler not running")
 )
 
 // Scheduler runs jobs against their triggers. Implementations must be safe for
@@ -221,3 +307,28 @@ type Scheduler interface {
 	// run drains. After Stop the scheduler cannot be restarted.
 	Stop(ctx context.Context) error
 }
+
+// SchedulerAdmin is an optional [Scheduler] extension for operators,
+// implemented by the scheduler [NewScheduler] returns. Admin endpoints
+// type-assert for it, so other Scheduler implementations keep working without
+// it.
+type SchedulerAdmin interface {
+	// Tasks returns a snapshot of every scheduled task, sorted by name.
+	Tasks() []TaskInfo
+
+	// Pause stops a task from running: fires that come due while it is paused
+	// are skipped. With a [JobStore] the paused state survives a restart.
+	Pause(name string) error
+
+	// Resume lets a paused task run again from its next fire.
+	Resume(name string) error
+
+	// RunNow runs a task once, outside its schedule, even if it is paused. The
+	// run goes through the task's loop, so concurrency policy, lock and timeout
+	// apply as for a scheduled fire; RunNow returns once it is queued.
+	RunNow(name string) error
+
+	// History returns a task's most recent executions, newest first, from the
+	// [JobStore] or, without one, from memory.
+	History(ctx context.Context, name string, limit int) ([]Execution, error)
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling
+
+import "context"
+
+// Shard identifies the slice of a sharded task's work one run covers. A job
+// scheduled with [WithShards] reads it with [ShardFrom] and processes only the
+// items whose hash modulo Total equals Index (or any other split keyed on
+// the pair). Total is zero for an unsharded run.
+type Shard struct {
+	Index int // 0 <= Index < Total
+	Total int // the shard count the task declared
+}
+
+// ShardAssigner decides which shards of a task this replica runs. It is
+// consulted on every fire, so an implementation that tracks live replicas
+// rebalances on the next fire after membership changes. Assignments across
+// replicas should be disjoint and cover every shard; during a rebalance they
+// may briefly overlap or leave a gap, which [WithLock] can close.
+//
+// The sharding sub-package provides a consistent-hash implementation over
+// discovery- or lock-based membership.
+type ShardAssigner interface {
+	// Assign returns the shard indexes in [0, total) this replica owns for
+	// task. An empty result means another replica owns them all.
+	Assign(ctx context.Context, task string, total int) ([]int, error)
+}
+
+// WithShards splits every fire of the task into total shards and runs the
+// ones assigner gives this replica, in parallel, each with its [Shard] in
+// the job's context. A run event is emitted per shard. With [WithLock] each
+// shard takes its own lock, the key suffixed with "/<index>".
+//
+// It panics if total is not positive or assigner is nil.
+func WithShards(total int, assigner ShardAssigner) Option {
+	if total <= 0 {
+		panic("scheduling: WithShards total must be positive")
+	}
+	if assigner == nil {
+		panic("scheduling: WithShards with nil assigner")
+	}
+	return func(o *Options) {
+		o.Shards = total
+		o.Assigner = assigner
+	}
+}
+
+type shardKey struct{}
+
+// ShardFrom returns the shard the current run covers. ok is false outside
+// a sharded task's job.
+func ShardFrom(ctx context.Context) (s Shard, ok bool) {
+	s, ok = ctx.Value(shardKey{}).(Shard)
+	return s, ok
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/scheduling"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type staticAssigner []int
+
+func (a staticAssigner) Assign(context.Context, string, int) ([]int, error) { return a, nil }
+
+func TestShardedRun(t *testing.T) {
+	s := scheduling.NewScheduler()
+	var (
+		mu  sync.Mutex
+		got []scheduling.Shard
+	)
+	_, err := s.Schedule("reindex", scheduling.FixedRate(time.Hour), func(ctx context.Context) error {
+		sh, ok := scheduling.ShardFrom(ctx)
+		assert.That(t, ok).True()
+		mu.Lock()
+		got = append(got, sh)
+		mu.Unlock()
+		return nil
+	}, scheduling.WithShards(4, staticAssigner{1, 3}))
+	assert.Error(t, err).Nil()
+	_, err = s.Schedule("elsewhere", scheduling.FixedRate(time.Hour),
+		func(context.Context) error { t.Error("ran a shard owned elsewhere"); return nil },
+		scheduling.WithShards(4, staticAssigner{}))
+	assert.Error(t, err).Nil()
+
+	assert.Error(t, s.Start(context.Background())).Nil()
+	defer stop(t, s)
+	assert.Error(t, admin(s).RunNow("reindex")).Nil()
+	assert.Error(t, admin(s).RunNow("elsewhere")).Nil()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	slices.SortFunc(got, func(a, b scheduling.Shard) int { return a.Index - b.Index })
+	assert.That(t, got).Equal([]scheduling.Shard{{Index: 1, Total: 4}, {Index: 3, Total: 4}})
+	mu.Unlock()
+
+	h, _ := admin(s).History(context.Background(), "reindex", 0)
+	assert.That(t, len(h)).Equal(2) // one execution per shard
+	h, _ = admin(s).History(context.Background(), "elsewhere", 0)
+	assert.That(t, len(h)).Equal(1)
+	assert.That(t, h[0].Skipped).True()
+	assert.That(t, h[0].Reason).Equal("shard")
+
+	_, ok := scheduling.ShardFrom(context.Background())
+	assert.That(t, ok).False()
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sharding
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+)
+
+// LockMembershipConfig configures a [LockMembership]. Locker is required.
+type LockMembershipConfig struct {
+	// Locker holds the membership slots. Every replica must share it.
+	Locker lock.Locker
+
+	// Prefix is prepended to the slot index to form each slot's lock key.
+	// Default "scheduling/members/".
+	Prefix string
+
+	// Slots bounds the number of replicas that can join. Each refresh probes
+	// every slot once, so keep it near the expected replica count. Default 64.
+	Slots int
+
+	// TTL is the lease on a held slot: a crashed replica leaves the
+	// membership at most TTL later. Default 15s.
+	TTL time.Duration
+
+	// Refresh is how often the membership is re-read. Default 5s.
+	Refresh time.Duration
+}
+
+// LockMembership is a [Membership] for deployments without service
+// discovery. Each replica claims the lowest free of a fixed set of lock slots
+// and holds it for as long as it runs; the members are the slots currently
+// held, named "slot-<n>". Run keeps the claim alive and re-reads the slots
+// every Refresh by probing each one with TryAcquire, releasing it at once if
+// it was free.
+//
+// Two replicas probing the same free slot at the same instant each see it
+// held. A slot therefore joins the membership only after it was seen held on
+// two consecutive refreshes, so a replica owns no shards until its second
+// refresh after joining, and other replicas pick it up one refresh later.
+type LockMembership struct {
+	cfg LockMembershipConfig
+
+	mu      sync.RWMutex
+	self    string
+	members []string
+}
+
+// NewLockMembership returns a [LockMembership]. It panics if Locker is unset.
+// The replica does not join until [LockMembership.Run] is called.
+func NewLockMembership(cfg LockMembershipConfig) *LockMembership {
+	if cfg.Locker == nil {
+		panic("sharding: lock membership requires a Locker")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "scheduling/members/"
+	}
+	if cfg.Slots <= 0 {
+		cfg.Slots = 64
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 15 * time.Second
+	}
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = 5 * time.Second
+	}
+	return &LockMembership{cfg: cfg}
+}
+
+// Self implements [Membership]. It is "" until the replica's slot has been
+// confirmed by two refreshes.
+func (m *LockMembership) Self() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.self
+}
+
+// Members implements [Membership]. It returns the view of the last refresh
+// and never fails; before the replica has joined the view is empty.
+func (m *LockMembership) Members(context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.members, nil
+}
+
+// Run claims a slot and maintains the membership view until ctx is done, then
+// releases the slot and returns ctx.Err(). If the slot's lease is lost it
+// leaves the membership and claims a slot again. Run blocks; register it as a
+// background runner next to the scheduler.
+func (m *LockMembership) Run(ctx context.Context) error {
+	for {
+		held, slot, ok := m.join(ctx)
+		if !ok {
+			return ctx.Err()
+		}
+		m.serve(ctx, held, slot)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// join takes the lowest free slot, waiting a refresh between rounds while
+// all slots are held or the backend fails.
+func (m *LockMembership) join(ctx context.Context) (lock.Lock, int, bool) {
+	for {
+		for i := range m.cfg.Slots {
+			l, ok, err := m.cfg.Locker.TryAcquire(ctx, m.key(i), lock.WithTTL(m.cfg.TTL))
+			if err != nil {
+				break
+			}
+			if ok {
+				return l, i, true
+			}
+		}
+		if !sleep(ctx, m.cfg.Refresh) {
+			return nil, 0, false
+		}
+	}
+}
+
+// serve refreshes the view while slot is held, and leaves the membership
+// when the lease is lost or ctx ends.
+func (m *LockMembership) serve(ctx context.Context, held lock.Lock, slot int) {
+	defer func() {
+		m.mu.Lock()
+		m.self, m.members = "", nil
+		m.mu.Unlock()
+		_ = held.Unlock(context.WithoutCancel(ctx))
+	}()
+
+	var prev map[int]bool
+	for {
+		cur, err := m.probe(ctx, slot)
+		if err == nil {
+			m.publish(slot, prev, cur)
+			prev = cur
+		}
+		// Jitter keeps replicas from probing the same free slots in lockstep.
+		d := m.cfg.Refresh + rand.N(m.cfg.Refresh/5+1)
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-held.Lost():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// probe returns the slots other than own that are held right now. A free
+// slot is released immediately; the short lease without renewal bounds how
+// long it stays taken if that release fails.
+func (m *LockMembership) probe(ctx context.Context, own int) (map[int]bool, error) {
+	held := map[int]bool{own: true}
+	for i := range m.cfg.Slots {
+		if i == own {
+			continue
+		}
+		l, ok, err := m.cfg.Locker.TryAcquire(ctx, m.key(i),
+			lock.WithTTL(m.cfg.TTL), lock.WithRenewInterval(-1))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			_ = l.Unlock(context.WithoutCancel(ctx))
+			continue
+		}
+		held[i] = true
+	}
+	return held, nil
+}
+
+// publish admits the slots held in both prev and cur. A nil prev is the
+// first refresh after joining and publishes nothing yet.
+func (m *LockMembership) publish(own int, prev, cur map[int]bool) {
+	if prev == nil {
+		return
+	}
+	var members []string
+	for i := range m.cfg.Slots {
+		if prev[i] && cur[i] {
+			members = append(members, slotName(i))
+		}
+	}
+	m.mu.Lock()
+	m.self, m.members = slotName(own), members
+	m.mu.Unlock()
+}
+
+func (m *LockMembership) key(slot int) string { return m.cfg.Prefix + strconv.Itoa(slot) }
+
+func slotName(slot int) string { return "slot-" + strconv.Itoa(slot) }
+
+// sleep waits for d or until ctx is done; it returns false if ctx ended first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
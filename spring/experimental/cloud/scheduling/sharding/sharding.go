@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sharding assigns the shards of a [scheduling.WithShards] task to
+// the live replicas of a deployment.
+//
+// An [Assigner] places every shard on a consistent-hash ring built from the
+// current [Membership], so each replica computes the same owner for each
+// shard without talking to the others, and a replica joining or leaving only
+// moves the shards it gains or held. Membership comes either from service
+// discovery ([NewDiscoveryMembership]) or, for deployments without a
+// registry, from a set of lock slots the replicas hold ([LockMembership]).
+package sharding
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go-spring.org/spring/cloud/discovery"
+	"go-spring.org/spring/experimental/cloud/loadbalance"
+	"go-spring.org/spring/experimental/cloud/scheduling"
+)
+
+// Membership reports the replicas shards are spread across. Every replica
+// must see the same member IDs for the assignment to be disjoint.
+type Membership interface {
+	// Self returns this replica's member ID, or "" while it has not joined.
+	Self() string
+
+	// Members returns the IDs of the live replicas, including this one.
+	Members(ctx context.Context) ([]string, error)
+}
+
+// NewDiscoveryMembership returns a [Membership] over the instances src
+// reports, identified by address. self is this replica's registered address.
+// A [discovery.Resolver] for the application's own service is the usual
+// src. Instances follow the load balancer's rule: healthy ones, or every
+// instance not disabled when none is healthy.
+func NewDiscoveryMembership(src loadbalance.EndpointSource, self string) Membership {
+	return &discoveryMembership{src: src, self: self}
+}
+
+type discoveryMembership struct {
+	src  loadbalance.EndpointSource
+	self string
+}
+
+func (m *discoveryMembership) Self() string { return m.self }
+
+func (m *discoveryMembership) Members(context.Context) ([]string, error) {
+	eps := m.src.Endpoints()
+	var healthy, enabled []string
+	for _, ep := range eps {
+		if ep.Disabled {
+			continue
+		}
+		enabled = append(enabled, ep.Addr)
+		if ep.Healthy {
+			healthy = append(healthy, ep.Addr)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy, nil
+	}
+	return enabled, nil
+}
+
+// Assigner is a [scheduling.ShardAssigner] that hashes shard i of task onto
+// a ring of the current members with [loadbalance.NewConsistentHash] and
+// keeps the shards that land on this replica. The ring is rebuilt only when
+// membership changes, so assignment costs one ring lookup per shard.
+type Assigner struct {
+	m      Membership
+	ring   loadbalance.Balancer
+	onMove func(task string, shards []int)
+
+	mu    sync.Mutex
+	owned map[string][]int
+}
+
+var _ scheduling.ShardAssigner = (*Assigner)(nil)
+
+// Option configures an [Assigner].
+type Option func(*Assigner)
+
+// WithRebalanceHook calls fn whenever the shards this replica owns for a
+// task differ from the previous fire, including the first. It runs on the
+// task's loop before the run, so it should be quick, e.g. a log line or a
+// cache reset.
+func WithRebalanceHook(fn func(task string, shards []int)) Option {
+	return func(a *Assigner) { a.onMove = fn }
+}
+
+// NewAssigner returns an [Assigner] over m.
+func NewAssigner(m Membership, opts ...Option) *Assigner {
+	a := &Assigner{
+		m:     m,
+		ring:  loadbalance.NewConsistentHash(0),
+		owned: make(map[string][]int),
+	}
+	for _, fn := range opts {
+		fn(a)
+	}
+	return a
+}
+
+// Assign implements [scheduling.ShardAssigner]. A replica that is not among
+// the members, for instance one still joining, owns nothing.
+func (a *Assigner) Assign(ctx context.Context, task string, total int) ([]int, error) {
+	members, err := a.m.Members(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var owned []int
+	if self := a.m.Self(); self != "" && slices.Contains(members, self) {
+		eps := make([]discovery.Endpoint, len(members))
+		for i, id := range members {
+			eps[i] = discovery.Endpoint{Addr: id}
+		}
+		for i := range total {
+			r, err := a.ring.Pick(eps, loadbalance.PickInfo{HashKey: task + "#" + strconv.Itoa(i)})
+			if err != nil {
+				return nil, err
+			}
+			if r.Endpoint.Addr == self {
+				owned = append(owned, i)
+			}
+		}
+	}
+	a.notify(task, owned)
+	return owned, nil
+}
+
+func (a *Assigner) notify(task string, owned []int) {
+	a.mu.Lock()
+	prev, seen := a.owned[task]
+	changed := !seen || !slices.Equal(prev, owned)
+	a.owned[task] = owned
+	a.mu.Unlock()
+	if changed && a.onMove != nil {
+		a.onMove(task, owned)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sharding_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/cloud/discovery"
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/spring/experimental/cloud/scheduling/sharding"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+type staticMembers struct {
+	self    string
+	members *[]string
+}
+
+func (m staticMembers) Self() string { return m.self }
+
+func (m staticMembers) Members(context.Context) ([]string, error) { return *m.members, nil }
+
+type endpoints []discovery.Endpoint
+
+func (e endpoints) Endpoints() []discovery.Endpoint { return e }
+
+func TestAssignerPartitionsAndRebalances(t *testing.T) {
+	ctx := context.Background()
+	members := []string{"a", "b", "c"}
+	var (
+		mu    sync.Mutex
+		moves []string
+	)
+	assigners := map[string]*sharding.Assigner{}
+	for _, id := range members {
+		assigners[id] = sharding.NewAssigner(staticMembers{id, &members},
+			sharding.WithRebalanceHook(func(task string, _ []int) {
+				mu.Lock()
+				moves = append(moves, id)
+				mu.Unlock()
+			}))
+	}
+	assign := func() map[string][]int {
+		out := map[string][]int{}
+		for id, a := range assigners {
+			shards, err := a.Assign(ctx, "reindex", 32)
+			assert.Error(t, err).Nil()
+			out[id] = shards
+		}
+		return out
+	}
+	cover := func(owned map[string][]int) []int {
+		var all []int
+		for _, shards := range owned {
+			all = append(all, shards...)
+		}
+		slices.Sort(all)
+		return all
+	}
+	want := make([]int, 32)
+	for i := range want {
+		want[i] = i
+	}
+
+	before := assign()
+	assert.That(t, cover(before)).Equal(want) // disjoint and complete
+	assert.That(t, len(moves)).Equal(3)
+	assign()
+	assert.That(t, len(moves)).Equal(3) // stable membership, no rebalance
+
+	// "c" leaves: only its shards move, and "c" owns nothing.
+	members = []string{"a", "b"}
+	after := assign()
+	assert.That(t, cover(after)).Equal(want)
+	assert.That(t, len(after["c"])).Equal(0)
+	for _, id := range []string{"a", "b"} {
+		for _, i := range before[id] {
+			assert.That(t, slices.Contains(after[id], i)).True()
+		}
+	}
+}
+
+func TestDiscoveryMembership(t *testing.T) {
+	m := sharding.NewDiscoveryMembership(endpoints{
+		{Addr: "10.0.0.1:80", Healthy: true},
+		{Addr: "10.0.0.2:80", Healthy: false},
+		{Addr: "10.0.0.3:80", Healthy: true, Disabled: true},
+	}, "10.0.0.1:80")
+	got, err := m.Members(context.Background())
+	assert.Error(t, err).Nil()
+	assert.That(t, got).Equal([]string{"10.0.0.1:80"})
+
+	// A replica that is not a member owns nothing.
+	a := sharding.NewAssigner(sharding.NewDiscoveryMembership(endpoints{{Addr: "x", Healthy: true}}, "y"))
+	shards, err := a.Assign(context.Background(), "t", 8)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(shards)).Equal(0)
+}
+
+func TestLockMembership(t *testing.T) {
+	assert.Panic(t, func() {
+		sharding.NewLockMembership(sharding.LockMembershipConfig{})
+	}, "requires a Locker")
+
+	locker := lock.NewMemoryLocker()
+	defer locker.Close()
+	cfg := sharding.LockMembershipConfig{
+		Locker:  locker,
+		Slots:   4,
+		TTL:     time.Second,
+		Refresh: 10 * time.Millisecond,
+	}
+	a := sharding.NewLockMembership(cfg)
+	b := sharding.NewLockMembership(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctxB, cancelB := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Go(func() { _ = a.Run(ctx) })
+	wg.Go(func() { _ = b.Run(ctxB) })
+
+	waitMembers := func(m *sharding.LockMembership, n int) []string {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if got, _ := m.Members(ctx); len(got) == n && m.Self() != "" {
+				return got
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("membership did not reach %d members", n)
+		return nil
+	}
+	got := waitMembers(a, 2)
+	assert.That(t, got).Equal(waitMembers(b, 2))
+	assert.That(t, a.Self() != b.Self()).True()
+
+	cancelB()
+	waitMembers(a, 1)
+	assert.That(t, b.Self()).Equal("")
+	cancel()
+	wg.Wait()
+}
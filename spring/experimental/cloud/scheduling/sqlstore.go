@@ -0,0 +1,278 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLJobStoreConfig configures a [SQLJobStore].
+type SQLJobStoreConfig struct {
+	// StateTable holds one row per task. Default "scheduling_task".
+	StateTable string
+
+	// HistoryTable holds one row per execution. Default
+	// "scheduling_execution".
+	HistoryTable string
+
+	// Numbered selects PostgreSQL-style $1, $2 placeholders instead of ?.
+	Numbered bool
+}
+
+// SQLJobStore is a [JobStore] over database/sql, so state can be shared by
+// replicas and survives restarts. It works with any driver the application
+// imports; times are stored as Unix nanoseconds and the statements avoid
+// dialect-specific upserts, so the same schema runs on MySQL, PostgreSQL and
+// SQLite. Create the tables with [SQLJobStore.Migrate] or from this DDL:
+//
+//	CREATE TABLE scheduling_task (
+//	    name            VARCHAR(255) PRIMARY KEY,
+//	    next_fire       BIGINT NOT NULL,
+//	    last_scheduled  BIGINT NOT NULL,
+//	    last_completion BIGINT NOT NULL,
+//	    paused          SMALLINT NOT NULL,
+//	    version         BIGINT NOT NULL
+//	);
+//	CREATE TABLE scheduling_execution (
+//	    name         VARCHAR(255) NOT NULL,
+//	    scheduled_at BIGINT NOT NULL,
+//	    started_at   BIGINT NOT NULL,
+//	    duration     BIGINT NOT NULL,
+//	    error        TEXT NOT NULL,
+//	    skipped      SMALLINT NOT NULL,
+//	    reason       VARCHAR(32) NOT NULL,
+//	    shard_index  INTEGER NOT NULL,
+//	    shard_total  INTEGER NOT NULL
+//	);
+//	CREATE INDEX scheduling_execution_name ON scheduling_execution (name, scheduled_at);
+//
+// History grows without bound; call [SQLJobStore.Prune] periodically, for
+// example from a scheduled task.
+type SQLJobStore struct {
+	db  *sql.DB
+	cfg SQLJobStoreConfig
+}
+
+// NewSQLJobStore returns a [SQLJobStore] over db.
+func NewSQLJobStore(db *sql.DB, cfg SQLJobStoreConfig) *SQLJobStore {
+	if cfg.StateTable == "" {
+		cfg.StateTable = "scheduling_task"
+	}
+	if cfg.HistoryTable == "" {
+		cfg.HistoryTable = "scheduling_execution"
+	}
+	return &SQLJobStore{db: db, cfg: cfg}
+}
+
+// Migrate creates the tables and index if they do not exist.
+func (s *SQLJobStore) Migrate(ctx context.Context) error {
+	stmts := []string{
+		"CREATE TABLE IF NOT EXISTS " + s.cfg.StateTable + " (name VARCHAR(255) PRIMARY KEY, " +
+			"next_fire BIGINT NOT NULL, last_scheduled BIGINT NOT NULL, last_completion BIGINT NOT NULL, " +
+			"paused SMALLINT NOT NULL, version BIGINT NOT NULL)",
+		"CREATE TABLE IF NOT EXISTS " + s.cfg.HistoryTable + " (name VARCHAR(255) NOT NULL, " +
+			"scheduled_at BIGINT NOT NULL, started_at BIGINT NOT NULL, duration BIGINT NOT NULL, " +
+			"error TEXT NOT NULL, skipped SMALLINT NOT NULL, reason VARCHAR(32) NOT NULL, " +
+			"shard_index INTEGER NOT NULL, shard_total INTEGER NOT NULL)",
+	}
+	for _, q := range stmts {
+		if _, err := s.db.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("scheduling: migrate job store: %w", err)
+		}
+	}
+	// CREATE INDEX IF NOT EXISTS is not portable; an existing index is fine.
+	_, _ = s.db.ExecContext(ctx, "CREATE INDEX "+s.cfg.HistoryTable+"_name ON "+
+		s.cfg.HistoryTable+" (name, scheduled_at)")
+	return nil
+}
+
+// LoadState implements [JobStore].
+func (s *SQLJobStore) LoadState(ctx context.Context, name string) (TaskState, bool, error) {
+	var next, last, done, version int64
+	var paused int
+	err := s.db.QueryRowContext(ctx, s.bind("SELECT next_fire, last_scheduled, last_completion, paused, version FROM "+
+		s.cfg.StateTable+" WHERE name = ?"), name).Scan(&next, &last, &done, &paused, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TaskState{}, false, nil
+	}
+	if err != nil {
+		return TaskState{}, false, fmt.Errorf("scheduling: load state of %q: %w", name, err)
+	}
+	return TaskState{
+		Name:           name,
+		NextFire:       fromNanos(next),
+		LastScheduled:  fromNanos(last),
+		LastCompletion: fromNanos(done),
+		Paused:         paused != 0,
+		Version:        version,
+	}, true, nil
+}
+
+// SaveState implements [JobStore]. The first save inserts the row at version
+// 1; later ones update it only where the version still matches, and bump it.
+// Since every update changes the version, a zero affected-row count always
+// means the row moved on (or was deleted), never MySQL's "matched but
+// unchanged".
+func (s *SQLJobStore) SaveState(ctx context.Context, st TaskState) error {
+	args := []any{toNanos(st.NextFire), toNanos(st.LastScheduled), toNanos(st.LastCompletion), boolInt(st.Paused), st.Name}
+	if st.Version == 0 {
+		_, err := s.db.ExecContext(ctx, s.bind("INSERT INTO "+s.cfg.StateTable+
+			" (next_fire, last_scheduled, last_completion, paused, name, version) VALUES (?, ?, ?, ?, ?, 1)"), args...)
+		if err == nil {
+			return nil
+		}
+		// A duplicate key means another writer inserted the row first.
+		var one int
+		if s.db.QueryRowContext(ctx, s.bind("SELECT 1 FROM "+s.cfg.StateTable+" WHERE name = ?"), st.Name).Scan(&one) == nil {
+			return fmt.Errorf("%w: %q", ErrStateConflict, st.Name)
+		}
+		return fmt.Errorf("scheduling: save state of %q: %w", st.Name, err)
+	}
+	res, err := s.db.ExecContext(ctx, s.bind("UPDATE "+s.cfg.StateTable+
+		" SET next_fire = ?, last_scheduled = ?, last_completion = ?, paused = ?, version = version + 1"+
+		" WHERE name = ? AND version = ?"), append(args, st.Version)...)
+	if err != nil {
+		return fmt.Errorf("scheduling: save state of %q: %w", st.Name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("scheduling: save state of %q: %w", st.Name, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %q", ErrStateConflict, st.Name)
+	}
+	return nil
+}
+
+// Record implements [JobStore].
+func (s *SQLJobStore) Record(ctx context.Context, e Execution) error {
+	return s.RecordBatch(ctx, []Execution{e})
+}
+
+// recordBatchRows bounds the rows of one INSERT, keeping its placeholders
+// under SQLite's default limit of 999.
+const recordBatchRows = 100
+
+// RecordBatch implements [BatchRecorder] with multi-row INSERTs.
+func (s *SQLJobStore) RecordBatch(ctx context.Context, es []Execution) error {
+	for len(es) > 0 {
+		n := min(len(es), recordBatchRows)
+		var b strings.Builder
+		b.WriteString("INSERT INTO " + s.cfg.HistoryTable +
+			" (name, scheduled_at, started_at, duration, error, skipped, reason, shard_index, shard_total) VALUES ")
+		args := make([]any, 0, n*9)
+		for i, e := range es[:n] {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, e.Name, toNanos(e.Scheduled), toNanos(e.Start), int64(e.Duration), e.Error,
+				boolInt(e.Skipped), e.Reason, e.Shard.Index, e.Shard.Total)
+		}
+		if _, err := s.db.ExecContext(ctx, s.bind(b.String()), args...); err != nil {
+			return fmt.Errorf("scheduling: record execution of %q: %w", es[0].Name, err)
+		}
+		es = es[n:]
+	}
+	return nil
+}
+
+var _ BatchRecorder = (*SQLJobStore)(nil)
+
+// History implements [JobStore].
+func (s *SQLJobStore) History(ctx context.Context, name string, limit int) ([]Execution, error) {
+	q := "SELECT scheduled_at, started_at, duration, error, skipped, reason, shard_index, shard_total FROM " + s.cfg.HistoryTable +
+		" WHERE name = ? ORDER BY scheduled_at DESC, started_at DESC"
+	if limit > 0 {
+		q += " LIMIT " + strconv.Itoa(limit)
+	}
+	rows, err := s.db.QueryContext(ctx, s.bind(q), name)
+	if err != nil {
+		return nil, fmt.Errorf("scheduling: load history of %q: %w", name, err)
+	}
+	defer rows.Close()
+	var out []Execution
+	for rows.Next() {
+		var sched, start, d int64
+		var skipped int
+		e := Execution{Name: name}
+		if err = rows.Scan(&sched, &start, &d, &e.Error, &skipped, &e.Reason, &e.Shard.Index, &e.Shard.Total); err != nil {
+			return nil, fmt.Errorf("scheduling: load history of %q: %w", name, err)
+		}
+		e.Scheduled, e.Start, e.Duration, e.Skipped = fromNanos(sched), fromNanos(start), time.Duration(d), skipped != 0
+		out = append(out, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("scheduling: load history of %q: %w", name, err)
+	}
+	return out, nil
+}
+
+// Prune deletes executions scheduled before the given time and reports how
+// many were removed.
+func (s *SQLJobStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, s.bind("DELETE FROM "+s.cfg.HistoryTable+" WHERE scheduled_at < ?"), toNanos(before))
+	if err != nil {
+		return 0, fmt.Errorf("scheduling: prune history: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// bind rewrites ? placeholders to $n when the store is configured for them.
+func (s *SQLJobStore) bind(q string) string {
+	if !s.cfg.Numbered {
+		return q
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func toNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromNanos(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/scheduling"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// fakeStoreDriver is a minimal database/sql driver over the job store
+// tables. It keeps the task-state rows, enforces the primary key on insert,
+// and counts the history rows and INSERT statements it receives.
+type fakeStoreDriver struct {
+	mu          sync.Mutex
+	rows        map[string][5]int64 // next_fire, last_scheduled, last_completion, paused, version
+	history     int
+	historyStmt int
+}
+
+func (d *fakeStoreDriver) Open(string) (driver.Conn, error) { return fakeStoreConn{d}, nil }
+
+type fakeStoreConn struct{ d *fakeStoreDriver }
+
+func (c fakeStoreConn) Prepare(q string) (driver.Stmt, error) { return fakeStoreStmt{c.d, q}, nil }
+func (c fakeStoreConn) Close() error                          { return nil }
+func (c fakeStoreConn) Begin() (driver.Tx, error)             { return nil, errors.New("not supported") }
+
+type fakeStoreStmt struct {
+	d *fakeStoreDriver
+	q string
+}
+
+func (s fakeStoreStmt) Close() error  { return nil }
+func (s fakeStoreStmt) NumInput() int { return -1 }
+
+func (s fakeStoreStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if strings.HasPrefix(s.q, "INSERT INTO scheduling_execution") {
+		s.d.history += len(args) / 9
+		s.d.historyStmt++
+		return driver.RowsAffected(int64(len(args) / 9)), nil
+	}
+	name := args[4].(string)
+	v := [5]int64{args[0].(int64), args[1].(int64), args[2].(int64), args[3].(int64), 1}
+	old, ok := s.d.rows[name]
+	switch {
+	case strings.HasPrefix(s.q, "UPDATE"):
+		if !ok || old[4] != args[5].(int64) {
+			return driver.RowsAffected(0), nil
+		}
+		v[4] = old[4] + 1
+	case strings.HasPrefix(s.q, "INSERT"):
+		if ok {
+			return nil, errors.New("duplicate entry for key 'PRIMARY'")
+		}
+	default:
+		return nil, errors.New("unexpected statement: " + s.q)
+	}
+	s.d.rows[name] = v
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStoreStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	v, ok := s.d.rows[args[0].(string)]
+	r := &fakeStoreRows{}
+	switch {
+	case strings.HasPrefix(s.q, "SELECT 1 "):
+		r.cols = []string{"1"}
+		if ok {
+			r.vals = [][]driver.Value{{int64(1)}}
+		}
+	case strings.HasPrefix(s.q, "SELECT next_fire"):
+		r.cols = []string{"next_fire", "last_scheduled", "last_completion", "paused", "version"}
+		if ok {
+			r.vals = [][]driver.Value{{v[0], v[1], v[2], v[3], v[4]}}
+		}
+	default:
+		return nil, errors.New("unexpected query: " + s.q)
+	}
+	return r, nil
+}
+
+type fakeStoreRows struct {
+	cols []string
+	vals [][]driver.Value
+}
+
+func (r *fakeStoreRows) Columns() []string { return r.cols }
+func (r *fakeStoreRows) Close() error      { return nil }
+
+func (r *fakeStoreRows) Next(dest []driver.Value) error {
+	if len(r.vals) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.vals[0])
+	r.vals = r.vals[1:]
+	return nil
+}
+
+var fakeStore = &fakeStoreDriver{rows: map[string][5]int64{}}
+
+func init() {
+	sql.Register("scheduling-fake-store", fakeStore)
+}
+
+func TestSQLJobStoreSaveStateVersion(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("scheduling-fake-store", "")
+	assert.Error(t, err).Nil()
+	defer db.Close()
+	store := scheduling.NewSQLJobStore(db, scheduling.SQLJobStoreConfig{})
+	fakeStore.mu.Lock()
+	clear(fakeStore.rows)
+	fakeStore.mu.Unlock()
+
+	st := scheduling.TaskState{Name: "report", NextFire: time.Unix(100, 0)}
+	assert.Error(t, store.SaveState(ctx, st)).Nil()
+	// A second first-save lost the race to insert.
+	assert.Error(t, store.SaveState(ctx, st)).Is(scheduling.ErrStateConflict)
+
+	got, ok, err := store.LoadState(ctx, "report")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	assert.That(t, got.Version).Equal(int64(1))
+
+	// Saving an unchanged state still moves the version on.
+	assert.Error(t, store.SaveState(ctx, got)).Nil()
+	got.Paused = true
+	assert.Error(t, store.SaveState(ctx, got)).Is(scheduling.ErrStateConflict)
+
+	got, _, _ = store.LoadState(ctx, "report")
+	assert.That(t, got.Version).Equal(int64(2))
+	assert.That(t, got.Paused).False()
+	got.Paused = true
+	assert.Error(t, store.SaveState(ctx, got)).Nil()
+	got, _, _ = store.LoadState(ctx, "report")
+	assert.That(t, got.Paused).True()
+	assert.That(t, got.NextFire.Equal(st.NextFire)).True()
+}
+
+func TestSQLJobStoreRecordBatch(t *testing.T) {
+	db, err := sql.Open("scheduling-fake-store", "")
+	assert.Error(t, err).Nil()
+	defer db.Close()
+	store := scheduling.NewSQLJobStore(db, scheduling.SQLJobStoreConfig{})
+
+	es := make([]scheduling.Execution, 250)
+	for i := range es {
+		es[i] = scheduling.Execution{Name: "report", Scheduled: time.Unix(int64(i), 0)}
+	}
+	fakeStore.mu.Lock()
+	rows, stmts := fakeStore.history, fakeStore.historyStmt
+	fakeStore.mu.Unlock()
+	assert.Error(t, store.RecordBatch(context.Background(), es)).Nil()
+	fakeStore.mu.Lock()
+	defer fakeStore.mu.Unlock()
+	assert.That(t, fakeStore.history-rows).Equal(250)
+	assert.That(t, fakeStore.historyStmt-stmts).Equal(3)
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrStateConflict is returned by [JobStore.SaveState] when the stored state
+// is no longer the version the caller read, because another writer saved it
+// in between.
+var ErrStateConflict = errors.New("scheduling: task state changed by another writer")
+
+// TaskState is the part of a task's runtime state a [JobStore] persists, so a
+// restarted scheduler resumes the schedule where the previous process left
+// it. A zero time means "not yet".
+type TaskState struct {
+	Name           string
+	NextFire       time.Time // the fire the task was waiting for
+	LastScheduled  time.Time // see [TriggerContext]
+	LastCompletion time.Time // see [TriggerContext]
+	Paused         bool
+
+	// Version is the stored revision this state was read at: 0 before the
+	// first save, then bumped by each successful [JobStore.SaveState].
+	Version int64
+}
+
+// Execution is one entry of a task's run history: a fire that ran, or one that
+// was skipped and why. It is the persisted form of an [Event].
+type Execution struct {
+	Name      string
+	Scheduled time.Time     // the fire time; the request time for RunNow
+	Shard     Shard         // zero when unsharded
+	Start     time.Time     // zero if skipped
+	Duration  time.Duration // zero if skipped
+	Error     string        // the job's error message, if any
+	Skipped   bool
+	Reason    string // "policy", "lock", "paused" or "shard" when Skipped
+}
+
+// TaskInfo is a snapshot of a scheduled task returned by [Scheduler.Tasks].
+type TaskInfo struct {
+	Name           string
+	Trigger        string // e.g. "*/5 * * * *" or "fixed-rate 10s"
+	Location       string // the zone fire times are computed in
+	Paused         bool
+	Running        bool // a run is in progress
+	NextFire       time.Time
+	LastScheduled  time.Time
+	LastCompletion time.Time
+	Last           *Execution // the most recent execution, nil before the first
+}
+
+// JobStore persists task state and run history. A scheduler built without
+// [WithJobStore] uses a [MemoryJobStore], so nothing survives a restart.
+// Implementations must be safe for concurrent use.
+//
+// Several replicas may share one store. SaveState is a compare-and-set on
+// [TaskState.Version], so a replica that saves over a newer state gets
+// [ErrStateConflict] and the scheduler merges the stored state in before
+// retrying, instead of silently overwriting it.
+type JobStore interface {
+	// LoadState returns the persisted state of a task; ok is false if the
+	// store has none.
+	LoadState(ctx context.Context, name string) (s TaskState, ok bool, err error)
+
+	// SaveState persists a task's state if the stored version still equals
+	// s.Version (0 meaning no state is stored) and stores it as version
+	// s.Version+1; otherwise it returns an error wrapping [ErrStateConflict].
+	SaveState(ctx context.Context, s TaskState) error
+
+	// Record appends an execution to a task's history.
+	Record(ctx context.Context, e Execution) error
+
+	// History returns a task's most recent executions, newest first, at most
+	// limit of them.
+	History(ctx context.Context, name string, limit int) ([]Execution, error)
+}
+
+// BatchRecorder is implemented by a [JobStore] that can append several
+// executions in one write. The scheduler batches history between flushes and
+// uses it when available, falling back to one Record per execution.
+type BatchRecorder interface {
+	RecordBatch(ctx context.Context, es []Execution) error
+}
+
+// recordAll appends es to the store's history, in one write if the store is a
+// [BatchRecorder].
+func recordAll(ctx context.Context, store JobStore, es []Execution) error {
+	if b, ok := store.(BatchRecorder); ok {
+		return b.RecordBatch(ctx, es)
+	}
+	var errs []error
+	for _, e := range es {
+		if err := store.Record(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MemoryJobStore is an in-process [JobStore]. It keeps the most recent
+// executions of each task, bounded per task.
+type MemoryJobStore struct {
+	limit int
+
+	mu      sync.Mutex
+	states  map[string]TaskState
+	history map[string][]Execution // oldest first
+}
+
+// NewMemoryJobStore returns a [MemoryJobStore] keeping at most historyLimit
+// executions per task; non-positive means 100.
+func NewMemoryJobStore(historyLimit int) *MemoryJobStore {
+	if historyLimit <= 0 {
+		historyLimit = 100
+	}
+	return &MemoryJobStore{
+		limit:   historyLimit,
+		states:  make(map[string]TaskState),
+		history: make(map[string][]Execution),
+	}
+}
+
+// LoadState implements [JobStore].
+func (m *MemoryJobStore) LoadState(_ context.Context, name string) (TaskState, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.states[name]
+	return s, ok, nil
+}
+
+// SaveState implements [JobStore].
+func (m *MemoryJobStore) SaveState(_ context.Context, s TaskState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.states[s.Name].Version != s.Version {
+		return fmt.Errorf("%w: %q", ErrStateConflict, s.Name)
+	}
+	s.Version++
+	m.states[s.Name] = s
+	return nil
+}
+
+// Record implements [JobStore].
+func (m *MemoryJobStore) Record(_ context.Context, e Execution) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := append(m.history[e.Name], e)
+	if len(h) > m.limit {
+		h = append(h[:0:0], h[len(h)-m.limit:]...)
+	}
+	m.history[e.Name] = h
+	return nil
+}
+
+// History implements [JobStore].
+func (m *MemoryJobStore) History(_ context.Context, name string, limit int) ([]Execution, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h := m.history[name]
+	if limit <= 0 || limit > len(h) {
+		limit = len(h)
+	}
+	out := make([]Execution, 0, limit)
+	for i := len(h) - 1; i >= len(h)-limit; i-- {
+		out = append(out, h[i])
+	}
+	return out, nil
+}
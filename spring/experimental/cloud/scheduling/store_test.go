@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduling_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/scheduling"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// admin returns the management view of a scheduler built by NewScheduler.
+func admin(s scheduling.Scheduler) scheduling.SchedulerAdmin {
+	return s.(scheduling.SchedulerAdmin)
+}
+
+func stop(t *testing.T, s scheduling.Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, s.Stop(ctx)).Nil()
+}
+
+func TestMisfirePolicies(t *testing.T) {
+	now := time.Now()
+	cases := map[scheduling.MisfirePolicy]int64{
+		scheduling.MisfireFireOnce: 1,
+		scheduling.MisfireFireAll:  3, // -2.5h, -1.5h and -0.5h
+		scheduling.MisfireSkip:     0,
+	}
+	for policy, want := range cases {
+		t.Run(policy.String(), func(t *testing.T) {
+			// The previous process last fired 3.5h ago and was waiting for the
+			// fire 2.5h ago when it went down.
+			store := scheduling.NewMemoryJobStore(0)
+			_ = store.SaveState(context.Background(), scheduling.TaskState{
+				Name:          "report",
+				LastScheduled: now.Add(-210 * time.Minute),
+				NextFire:      now.Add(-150 * time.Minute),
+			})
+			s := scheduling.NewScheduler(scheduling.WithJobStore(store))
+			var runs atomic.Int64
+			_, err := s.Schedule("report", scheduling.FixedRate(time.Hour),
+				func(context.Context) error { runs.Add(1); return nil },
+				scheduling.WithMisfirePolicy(policy))
+			assert.Error(t, err).Nil()
+			assert.Error(t, s.Start(context.Background())).Nil()
+			time.Sleep(50 * time.Millisecond)
+			stop(t, s)
+
+			assert.That(t, runs.Load()).Equal(want)
+			st, ok, _ := store.LoadState(context.Background(), "report")
+			assert.That(t, ok).True()
+			assert.That(t, st.NextFire.After(now)).True()
+			h, _ := admin(s).History(context.Background(), "report", 0)
+			assert.That(t, len(h)).Equal(int(want))
+		})
+	}
+}
+
+func TestPauseResumeAndRunNow(t *testing.T) {
+	store := scheduling.NewMemoryJobStore(0)
+	s := scheduling.NewScheduler(scheduling.WithJobStore(store))
+	var runs atomic.Int64
+	_, err := s.Schedule("sync", scheduling.FixedRate(10*time.Millisecond),
+		func(context.Context) error { runs.Add(1); return nil })
+	assert.Error(t, err).Nil()
+
+	assert.Error(t, admin(s).RunNow("sync")).Is(scheduling.ErrNotStarted)
+	assert.Error(t, admin(s).Pause("missing")).Is(scheduling.ErrUnknownTask)
+	assert.Error(t, admin(s).Pause("sync")).Nil()
+	assert.Error(t, s.Start(context.Background())).Nil()
+	defer stop(t, s)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.That(t, runs.Load()).Equal(int64(0))
+	info := admin(s).Tasks()[0]
+	assert.That(t, info.Paused).True()
+	assert.That(t, info.Trigger).Equal("fixed-rate 10ms")
+	assert.That(t, info.Last.Reason).Equal("paused")
+	st, _, _ := store.LoadState(context.Background(), "sync")
+	assert.That(t, st.Paused).True() // survives a restart
+
+	// A manual run ignores the pause.
+	assert.Error(t, admin(s).RunNow("sync")).Nil()
+	time.Sleep(20 * time.Millisecond)
+	assert.That(t, runs.Load()).Equal(int64(1))
+	h, err := admin(s).History(context.Background(), "sync", 0)
+	assert.Error(t, err).Nil()
+	var ran int
+	for _, e := range h {
+		if !e.Skipped {
+			ran++
+		}
+	}
+	assert.That(t, ran).Equal(1)
+
+	assert.Error(t, admin(s).Resume("sync")).Nil()
+	time.Sleep(50 * time.Millisecond)
+	assert.That(t, runs.Load() > 2).True()
+}
+
+func TestMemoryJobStoreHistoryLimit(t *testing.T) {
+	ctx := context.Background()
+	store := scheduling.NewMemoryJobStore(3)
+	base := time.Date(2026, 7, 18, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		_ = store.Record(ctx, scheduling.Execution{Name: "a", Scheduled: base.Add(time.Duration(i) * time.Minute)})
+	}
+	h, _ := store.History(ctx, "a", 0)
+	assert.That(t, len(h)).Equal(3)
+	assert.That(t, h[0].Scheduled).Equal(base.Add(4 * time.Minute)) // newest first
+	h, _ = store.History(ctx, "a", 2)
+	assert.That(t, len(h)).Equal(2)
+}
+
+// countingStore counts the writes that reach a MemoryJobStore.
+type countingStore struct {
+	*scheduling.MemoryJobStore
+	saves, batches atomic.Int64
+}
+
+func (c *countingStore) SaveState(ctx context.Context, s scheduling.TaskState) error {
+	c.saves.Add(1)
+	return c.MemoryJobStore.SaveState(ctx, s)
+}
+
+func (c *countingStore) RecordBatch(ctx context.Context, es []scheduling.Execution) error {
+	c.batches.Add(1)
+	for _, e := range es {
+		_ = c.MemoryJobStore.Record(ctx, e)
+	}
+	return nil
+}
+
+func TestSchedulerBatchesStoreWrites(t *testing.T) {
+	store := &countingStore{MemoryJobStore: scheduling.NewMemoryJobStore(1000)}
+	s := scheduling.NewScheduler(scheduling.WithJobStore(store), scheduling.WithFlushInterval(time.Hour))
+	var runs atomic.Int64
+	_, err := s.Schedule("tick", scheduling.FixedRate(time.Millisecond),
+		func(context.Context) error { runs.Add(1); return nil })
+	assert.Error(t, err).Nil()
+	assert.Error(t, s.Start(context.Background())).Nil()
+	time.Sleep(50 * time.Millisecond)
+	stop(t, s)
+
+	// Dozens of fires, one write of each kind when the task stopped.
+	assert.That(t, runs.Load() > 10).True()
+	assert.That(t, store.saves.Load()).Equal(int64(1))
+	assert.That(t, store.batches.Load()).Equal(int64(1))
+	h, _ := store.History(context.Background(), "tick", 0)
+	var ran int64
+	for _, e := range h {
+		if !e.Skipped {
+			ran++
+		}
+	}
+	assert.That(t, ran).Equal(runs.Load())
+}
+
+func TestSchedulerMergesConcurrentState(t *testing.T) {
+	ctx := context.Background()
+	store := scheduling.NewMemoryJobStore(0)
+	var (
+		mu        sync.Mutex
+		storeErrs []error
+	)
+	s := scheduling.NewScheduler(scheduling.WithJobStore(store),
+		scheduling.WithFlushInterval(5*time.Millisecond),
+		scheduling.WithObserver(func(ev scheduling.Event) {
+			if ev.Reason == "store" {
+				mu.Lock()
+				storeErrs = append(storeErrs, ev.Err)
+				mu.Unlock()
+			}
+		}))
+	_, err := s.Schedule("report", scheduling.FixedRate(time.Hour), func(context.Context) error { return nil })
+	assert.Error(t, err).Nil()
+	assert.Error(t, s.Start(ctx)).Nil()
+	defer stop(t, s)
+	time.Sleep(20 * time.Millisecond)
+
+	// Another replica pauses the task behind this scheduler's back.
+	st, ok, _ := store.LoadState(ctx, "report")
+	assert.That(t, ok).True()
+	st.Paused = true
+	assert.Error(t, store.SaveState(ctx, st)).Nil()
+	// A stale write is refused rather than silently replacing it.
+	assert.Error(t, store.SaveState(ctx, st)).Is(scheduling.ErrStateConflict)
+
+	// The next save from this scheduler conflicts, merges and keeps both.
+	assert.Error(t, admin(s).RunNow("report")).Nil()
+	time.Sleep(30 * time.Millisecond)
+	st, _, _ = store.LoadState(ctx, "report")
+	assert.That(t, st.Paused).True()
+	assert.That(t, st.LastCompletion.IsZero()).False()
+	assert.That(t, admin(s).Tasks()[0].Paused).True()
+	mu.Lock()
+	assert.That(t, len(storeErrs)).Equal(0)
+	mu.Unlock()
+}
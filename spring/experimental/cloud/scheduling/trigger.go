@@ -16,7 +16,10 @@
 
 package scheduling
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // FixedRate returns a [Trigger] that fires every d, measured from each scheduled
 // fire time rather than from when a run finishes. The gap between fires stays d
@@ -34,6 +37,8 @@ func FixedRate(d time.Duration) Trigger {
 
 type fixedRate struct{ d time.Duration }
 
+func (f fixedRate) String() string { return fmt.Sprintf("fixed-rate %s", f.d) }
+
 func (f fixedRate) Next(tc TriggerContext) time.Time {
 	if tc.LastScheduled.IsZero() {
 		return tc.Now.Add(f.d)
@@ -63,6 +68,8 @@ func FixedDelay(d time.Duration) Trigger {
 
 type fixedDelay struct{ d time.Duration }
 
+func (f fixedDelay) String() string { return fmt.Sprintf("fixed-delay %s", f.d) }
+
 func (f fixedDelay) Next(tc TriggerContext) time.Time {
 	if tc.LastCompletion.IsZero() {
 		return tc.Now.Add(f.d)
@@ -0,0 +1,305 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package admin is the operator surface over the saga, TCC and AT
+// coordinators: it lists the transactions they still hold, shows per-step or
+// per-branch state and errors, and lets an operator force a retry, a
+// compensation or mark a transaction resolved — every action recorded in an
+// audit trail.
+//
+// Each pattern is plugged in through a [Source] adapter over its own seams —
+// [NewSagaSource] over a transaction.Store, [NewTCCSource] over a tcc.Store,
+// [NewATSource] over an at.Inspector — so the coordinators stay unaware of
+// it. [Admin] aggregates the sources and is served as an actuator endpoint
+// (endpoint.Endpoint) under /transactions/. [Metrics] wraps the coordinators'
+// Observers to count phases and adds in-flight and recovery-lag gauges at
+// scrape time, in Prometheus text format with no client library.
+//
+// Like the rest of the transaction packages it has no dependency beyond the
+// standard library and the Go-Spring foundation.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kinds of transaction a [Source] reports.
+const (
+	KindSaga = "saga"
+	KindTCC  = "tcc"
+	KindAT   = "at"
+)
+
+var (
+	// ErrNotFound is returned for a transaction its source no longer holds.
+	ErrNotFound = errors.New("admin: transaction not found")
+
+	// ErrConflict is returned for an action the transaction's state does not
+	// allow, e.g. compensating a TCC transaction whose commit was decided.
+	ErrConflict = errors.New("admin: action conflicts with transaction state")
+
+	// ErrUnsupported is returned for an action a kind of transaction cannot
+	// take, e.g. retrying a saga forward.
+	ErrUnsupported = errors.New("admin: action not supported for this kind")
+)
+
+// DefaultStaleAfter is the default of [WithStaleAfter]; it matches the saga
+// RecoveryWorker's default.
+const DefaultStaleAfter = 5 * time.Minute
+
+// SourceOption configures [NewSagaSource] and [NewTCCSource].
+type SourceOption func(*sourceOptions)
+
+type sourceOptions struct {
+	staleAfter time.Duration
+}
+
+// WithStaleAfter sets how long an in-flight transaction's log must go
+// unwritten before an operator action may take it over. Until then a live
+// replica may still be driving it, so the action is refused with
+// [ErrConflict]. Use the same value as the recovery worker.
+func WithStaleAfter(d time.Duration) SourceOption {
+	return func(o *sourceOptions) {
+		if d > 0 {
+			o.staleAfter = d
+		}
+	}
+}
+
+func applySourceOptions(opts []SourceOption) sourceOptions {
+	o := sourceOptions{staleAfter: DefaultStaleAfter}
+	for _, fn := range opts {
+		fn(&o)
+	}
+	return o
+}
+
+// stillLive reports whether a log written at updatedAt may still be driven
+// by a live replica.
+func (o sourceOptions) stillLive(updatedAt time.Time) bool {
+	return time.Since(updatedAt) < o.staleAfter
+}
+
+// Action is an operator action on one transaction.
+type Action string
+
+const (
+	// ActionRetry re-drives the transaction in the direction its coordinator
+	// already chose: a failed confirm or cancel runs again, a stale pending
+	// transaction is recovered now instead of at the next scan. Sagas only
+	// recover backward and return [ErrUnsupported]; compensate them instead.
+	ActionRetry Action = "retry"
+
+	// ActionCompensate forces the backward direction: compensate a saga,
+	// cancel a TCC transaction, roll back an AT transaction.
+	ActionCompensate Action = "compensate"
+
+	// ActionResolve marks the transaction resolved by hand: its log is dropped
+	// without running anything, so recovery never touches it again.
+	ActionResolve Action = "resolve"
+)
+
+// Step is the state of one saga step, TCC participant or AT branch.
+type Step struct {
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Result any    `json:"result,omitempty"`
+}
+
+// Transaction is the operator's view of one transaction, whatever its kind.
+type Transaction struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Method string `json:"method,omitempty"`
+	Status string `json:"status"`
+
+	// Pending is true while the coordinator or its recovery will still move
+	// the transaction on; false for a terminal one that only an operator
+	// action changes.
+	Pending bool `json:"pending"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	Steps     []Step    `json:"steps,omitempty"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// Source adapts one coordinator kind for [Admin]. Implementations must be safe
+// for concurrent use.
+type Source interface {
+	// Kind is the source's kind, e.g. [KindSaga].
+	Kind() string
+
+	// List returns the transactions the source holds.
+	List(ctx context.Context) ([]Transaction, error)
+
+	// Get returns one transaction, or [ErrNotFound].
+	Get(ctx context.Context, id string) (Transaction, error)
+
+	// Retry, Compensate and Resolve apply the [Action] of the same name and
+	// return the transaction's state afterwards. They return [ErrNotFound] for
+	// an unknown id, [ErrConflict] when the state forbids the action and
+	// [ErrUnsupported] when the kind cannot take it.
+	Retry(ctx context.Context, id string) (Transaction, error)
+	Compensate(ctx context.Context, id string) (Transaction, error)
+	Resolve(ctx context.Context, id string) (Transaction, error)
+}
+
+// Filter narrows [Admin.List]. The zero value matches everything.
+type Filter struct {
+	Kind      string        // exact kind, e.g. "tcc"
+	Status    string        // status, case-insensitive, e.g. "confirmfailed"
+	OlderThan time.Duration // last updated at least this long ago
+}
+
+// Config configures an [Admin].
+type Config struct {
+	// Sources are the coordinators to administer, at most one per kind.
+	Sources []Source
+
+	// Audit records every action. Default a [MemoryAuditLog] of 256 entries.
+	Audit AuditLog
+
+	// Metrics, when set, contributes its phase counters to the metrics
+	// endpoint. Optional.
+	Metrics *Metrics
+}
+
+// Admin aggregates the [Source]s and applies operator actions with an audit
+// trail. It implements endpoint.Endpoint (see ServeHTTP).
+type Admin struct {
+	sources map[string]Source
+	kinds   []string
+	audit   AuditLog
+	metrics *Metrics
+
+	muxOnce sync.Once
+	mux     *http.ServeMux
+}
+
+// New builds an [Admin], applying defaults. It panics on two sources of the
+// same kind.
+func New(cfg Config) *Admin {
+	a := &Admin{sources: make(map[string]Source), audit: cfg.Audit, metrics: cfg.Metrics}
+	for _, s := range cfg.Sources {
+		if _, dup := a.sources[s.Kind()]; dup {
+			panic(fmt.Sprintf("admin: duplicate source for kind %q", s.Kind()))
+		}
+		a.sources[s.Kind()] = s
+		a.kinds = append(a.kinds, s.Kind())
+	}
+	slices.Sort(a.kinds)
+	if a.audit == nil {
+		a.audit = NewMemoryAuditLog(256)
+	}
+	return a
+}
+
+// List returns the matching transactions of every source, oldest first.
+func (a *Admin) List(ctx context.Context, f Filter) ([]Transaction, error) {
+	var cutoff time.Time
+	if f.OlderThan > 0 {
+		cutoff = time.Now().Add(-f.OlderThan)
+	}
+	out := []Transaction{}
+	for _, kind := range a.kinds {
+		if f.Kind != "" && f.Kind != kind {
+			continue
+		}
+		txs, err := a.sources[kind].List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("admin: list %s transactions: %w", kind, err)
+		}
+		for _, tx := range txs {
+			if f.Status != "" && !strings.EqualFold(f.Status, tx.Status) {
+				continue
+			}
+			if !cutoff.IsZero() && tx.UpdatedAt.After(cutoff) {
+				continue
+			}
+			out = append(out, tx)
+		}
+	}
+	slices.SortStableFunc(out, func(x, y Transaction) int { return x.UpdatedAt.Compare(y.UpdatedAt) })
+	return out, nil
+}
+
+// Get returns one transaction, or [ErrNotFound] for an unknown kind or id.
+func (a *Admin) Get(ctx context.Context, kind, id string) (Transaction, error) {
+	s, ok := a.sources[kind]
+	if !ok {
+		return Transaction{}, fmt.Errorf("%w: unknown kind %q", ErrNotFound, kind)
+	}
+	return s.Get(ctx, id)
+}
+
+// Do applies action to one transaction on behalf of operator and records it,
+// successful or not, in the audit log. reason is mandatory: an audit entry
+// that does not say why is of little use.
+func (a *Admin) Do(ctx context.Context, kind, id string, action Action, operator, reason string) (Transaction, error) {
+	if strings.TrimSpace(reason) == "" {
+		return Transaction{}, errors.New("admin: a reason is required")
+	}
+	s, ok := a.sources[kind]
+	if !ok {
+		return Transaction{}, fmt.Errorf("%w: unknown kind %q", ErrNotFound, kind)
+	}
+	var apply func(context.Context, string) (Transaction, error)
+	switch action {
+	case ActionRetry:
+		apply = s.Retry
+	case ActionCompensate:
+		apply = s.Compensate
+	case ActionResolve:
+		apply = s.Resolve
+	default:
+		return Transaction{}, fmt.Errorf("admin: unknown action %q", action)
+	}
+
+	before, err := s.Get(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	after, err := apply(ctx, id)
+	entry := AuditEntry{
+		Time:     time.Now(),
+		Kind:     kind,
+		ID:       id,
+		Action:   action,
+		Operator: operator,
+		Reason:   reason,
+		Before:   before.Status,
+		After:    after.Status,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if aerr := a.audit.Append(ctx, entry); aerr != nil {
+		return after, errors.Join(err, fmt.Errorf("admin: record audit entry: %w", aerr))
+	}
+	return after, err
+}
+
+// Audit returns up to limit of the most recent audit entries, newest first.
+func (a *Admin) Audit(ctx context.Context, limit int) ([]AuditEntry, error) {
+	return a.audit.Entries(ctx, limit)
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
+	"go-spring.org/spring/experimental/cloud/transaction/at"
+	"go-spring.org/spring/experimental/cloud/transaction/tcc"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// flaky fails until ok is set, standing in for a downstream that recovers
+// after an operator fixes it.
+type flaky struct{ ok bool }
+
+func (f *flaky) call() error {
+	if f.ok {
+		return nil
+	}
+	return errors.New("downstream unavailable")
+}
+
+// sagaFixture runs a saga whose refund compensation fails, leaving it
+// compensation-failed in the store.
+func sagaFixture(t *testing.T, m *admin.Metrics) (admin.Source, *transaction.MemoryStore, *flaky) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	refund := &flaky{}
+	steps := []transaction.Step{
+		{Name: "charge", Action: func(context.Context) (any, error) { return "c1", nil },
+			Compensate: func(context.Context, any) error { return refund.call() }},
+		{Name: "ship", Action: func(context.Context) (any, error) { return nil, errors.New("no stock") }},
+	}
+	reg := transaction.NewStepRegistry()
+	reg.Register("Order.Place", steps...)
+	coord := transaction.NewCoordinator(transaction.WithStore(store),
+		transaction.WithObserver(m.SagaObserver(nil)))
+	res, _ := coord.Execute(ctx, transaction.Saga{ID: "s1", Method: "Order.Place", Steps: steps})
+	assert.That(t, res.Status).Equal(transaction.StatusCompensationFailed)
+	return admin.NewSagaSource(store, coord, reg), store, refund
+}
+
+func TestAdmin_SagaRetryWithAudit(t *testing.T) {
+	ctx := context.Background()
+	src, store, refund := sagaFixture(t, admin.NewMetrics())
+	a := admin.New(admin.Config{Sources: []admin.Source{src}})
+
+	txs, err := a.List(ctx, admin.Filter{Status: "compensationfailed"})
+	assert.Error(t, err).Nil()
+	assert.That(t, len(txs)).Equal(1)
+	tx := txs[0]
+	assert.That(t, tx.Pending).False()
+	assert.That(t, tx.Steps).Equal([]admin.Step{{Name: "charge", State: "completed", Result: "c1"}})
+	assert.That(t, len(tx.Errors)).Equal(2) // the failed action, then the failed compensation
+
+	_, err = a.Do(ctx, admin.KindSaga, "s1", admin.ActionCompensate, "alice", "")
+	assert.Error(t, err).Matches("reason is required")
+
+	// A saga never recovers forward: a retry is refused, not turned into a
+	// rollback.
+	_, err = a.Do(ctx, admin.KindSaga, "s1", admin.ActionRetry, "alice", "try again")
+	assert.Error(t, err).Is(admin.ErrUnsupported)
+
+	refund.ok = true
+	tx, err = a.Do(ctx, admin.KindSaga, "s1", admin.ActionCompensate, "alice", "refund service is back")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("Compensated")
+
+	// A compensated saga has nothing left to compensate.
+	_, err = a.Do(ctx, admin.KindSaga, "s1", admin.ActionCompensate, "alice", "again")
+	assert.That(t, errors.Is(err, admin.ErrConflict)).True()
+
+	tx, err = a.Do(ctx, admin.KindSaga, "s1", admin.ActionResolve, "alice", "closed the ticket")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("Resolved")
+	_, err = store.Load(ctx, "s1")
+	assert.That(t, errors.Is(err, transaction.ErrSnapshotNotFound)).True()
+
+	entries, err := a.Audit(ctx, 0)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(entries)).Equal(4)
+	assert.That(t, entries[0].Action).Equal(admin.ActionResolve)
+	assert.That(t, entries[1].Error).NotEqual("")
+	assert.That(t, entries[3].Action).Equal(admin.ActionRetry)
+	assert.That(t, entries[3].Error).NotEqual("")
+	assert.That(t, entries[2]).Equal(admin.AuditEntry{
+		Time: entries[2].Time, Kind: admin.KindSaga, ID: "s1", Action: admin.ActionCompensate,
+		Operator: "alice", Reason: "refund service is back", Before: "CompensationFailed", After: "Compensated",
+	})
+}
+
+func TestAdmin_LiveTransactionsAreRefused(t *testing.T) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	for id, age := range map[string]time.Duration{"live": time.Second, "stale": time.Hour} {
+		assert.Error(t, store.Save(ctx, id, transaction.Snapshot{
+			ID: id, Method: "Order.Place", Status: transaction.StatusRunning, UpdatedAt: time.Now().Add(-age),
+		})).Nil()
+	}
+	reg := transaction.NewStepRegistry()
+	reg.Register("Order.Place", transaction.Step{Name: "charge",
+		Action: func(context.Context) (any, error) { return nil, nil }})
+	saga := admin.NewSagaSource(store, transaction.NewCoordinator(transaction.WithStore(store)), reg,
+		admin.WithStaleAfter(time.Minute))
+
+	// A replica may still be driving "live": neither compensating nor
+	// resolving it may race with that replica's next write.
+	_, err := saga.Compensate(ctx, "live")
+	assert.Error(t, err).Is(admin.ErrConflict)
+	_, err = saga.Resolve(ctx, "live")
+	assert.Error(t, err).Is(admin.ErrConflict)
+	_, err = store.Load(ctx, "live")
+	assert.Error(t, err).Nil()
+
+	tx, err := saga.Compensate(ctx, "stale")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("Compensated")
+
+	tccStore := &tcc.MemoryStore{}
+	assert.Error(t, tccStore.Save(ctx, "t1", tcc.Snapshot{
+		ID: "t1", Method: "Order.Place", Status: tcc.StatusTrying, UpdatedAt: time.Now(),
+	})).Nil()
+	src := admin.NewTCCSource(tccStore, tcc.NewCoordinator(tcc.WithStore(tccStore)), tcc.NewParticipantRegistry())
+	_, err = src.Retry(ctx, "t1")
+	assert.Error(t, err).Is(admin.ErrConflict)
+	_, err = src.Compensate(ctx, "t1")
+	assert.Error(t, err).Is(admin.ErrConflict)
+	_, err = src.Resolve(ctx, "t1")
+	assert.Error(t, err).Is(admin.ErrConflict)
+}
+
+func TestAdmin_ListFiltersByAge(t *testing.T) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	for id, age := range map[string]time.Duration{"old": time.Hour, "new": time.Second} {
+		assert.Error(t, store.Save(ctx, id, transaction.Snapshot{
+			ID: id, Status: transaction.StatusRunning, UpdatedAt: time.Now().Add(-age),
+		})).Nil()
+	}
+	a := admin.New(admin.Config{Sources: []admin.Source{
+		admin.NewSagaSource(store, transaction.NewCoordinator(transaction.WithStore(store)), transaction.NewStepRegistry()),
+	}})
+
+	txs, err := a.List(ctx, admin.Filter{})
+	assert.Error(t, err).Nil()
+	assert.That(t, len(txs)).Equal(2)
+	assert.That(t, txs[0].ID).Equal("old") // oldest first
+
+	txs, err = a.List(ctx, admin.Filter{OlderThan: time.Minute})
+	assert.Error(t, err).Nil()
+	assert.That(t, len(txs)).Equal(1)
+	assert.That(t, txs[0].ID).Equal("old")
+
+	txs, err = a.List(ctx, admin.Filter{Kind: admin.KindTCC})
+	assert.Error(t, err).Nil()
+	assert.That(t, len(txs)).Equal(0)
+}
+
+func TestAdmin_TCCConfirmFailed(t *testing.T) {
+	ctx := context.Background()
+	store := &tcc.MemoryStore{}
+	confirm := &flaky{}
+	participants := []tcc.Participant{{
+		Name:    "stock",
+		Try:     func(context.Context) (any, error) { return "r1", nil },
+		Confirm: func(context.Context, any) error { return confirm.call() },
+		Cancel:  func(context.Context, any) error { return nil },
+	}}
+	reg := tcc.NewParticipantRegistry()
+	reg.Register("Order.Place", participants...)
+	coord := tcc.NewCoordinator(tcc.WithStore(store))
+	res, _ := coord.Execute(ctx, tcc.Transaction{ID: "t1", Method: "Order.Place", Participants: participants})
+	assert.That(t, res.Status).Equal(tcc.StatusConfirmFailed)
+
+	a := admin.New(admin.Config{Sources: []admin.Source{admin.NewTCCSource(store, coord, reg)}})
+	tx, err := a.Get(ctx, admin.KindTCC, "t1")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("ConfirmFailed")
+	assert.That(t, tx.Steps).Equal([]admin.Step{{Name: "stock", State: "tried", Result: "r1"}})
+
+	// Commit was decided: cancelling now would break the transaction.
+	_, err = a.Do(ctx, admin.KindTCC, "t1", admin.ActionCompensate, "bob", "give up")
+	assert.That(t, errors.Is(err, admin.ErrConflict)).True()
+
+	confirm.ok = true
+	tx, err = a.Do(ctx, admin.KindTCC, "t1", admin.ActionRetry, "bob", "stock service fixed")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("Committed")
+
+	_, err = a.Get(ctx, admin.KindTCC, "t1")
+	assert.That(t, errors.Is(err, admin.ErrNotFound)).True()
+}
+
+// atBranch is an AT branch whose commit fails until ok is set.
+type atBranch struct {
+	flaky
+	commits int
+}
+
+func (b *atBranch) ID() string { return "db1" }
+
+func (b *atBranch) Commit(context.Context, string) error { b.commits++; return b.call() }
+
+func (b *atBranch) Rollback(context.Context, string) error { return nil }
+
+func TestAdmin_ATRetryCommit(t *testing.T) {
+	ctx := context.Background()
+	coord := at.NewCoordinator()
+	_, running := coord.Begin(ctx)
+	_, xid := coord.Begin(ctx)
+	b := &atBranch{}
+	assert.Error(t, coord.Register(ctx, xid, b)).Nil()
+	assert.Error(t, coord.Commit(ctx, xid)).NotNil()
+
+	a := admin.New(admin.Config{Sources: []admin.Source{admin.NewATSource(coord)}})
+	txs, err := a.List(ctx, admin.Filter{Kind: admin.KindAT, Status: "CommitFailed"})
+	assert.Error(t, err).Nil()
+	assert.That(t, len(txs)).Equal(1)
+	assert.That(t, txs[0].Steps).Equal([]admin.Step{{Name: "db1", State: "failed"}})
+
+	_, err = a.Do(ctx, admin.KindAT, xid, admin.ActionCompensate, "carol", "undo")
+	assert.That(t, errors.Is(err, admin.ErrConflict)).True()
+
+	b.ok = true
+	tx, err := a.Do(ctx, admin.KindAT, xid, admin.ActionRetry, "carol", "db1 back online")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("Committed")
+	assert.That(t, b.commits).Equal(2)
+
+	tx, err = a.Do(ctx, admin.KindAT, running, admin.ActionCompensate, "carol", "leaked transaction")
+	assert.Error(t, err).Nil()
+	assert.That(t, tx.Status).Equal("RolledBack")
+}
+
+func TestNew_PanicsOnDuplicateKind(t *testing.T) {
+	src := admin.NewATSource(at.NewCoordinator())
+	assert.Panic(t, func() {
+		admin.New(admin.Config{Sources: []admin.Source{src, src}})
+	}, "duplicate source")
+}
+
+func TestMemoryAuditLog_KeepsLatest(t *testing.T) {
+	ctx := context.Background()
+	l := admin.NewMemoryAuditLog(2)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.Error(t, l.Append(ctx, admin.AuditEntry{ID: id})).Nil()
+	}
+	entries, err := l.Entries(ctx, 0)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(entries)).Equal(2)
+	assert.That(t, entries[0].ID).Equal("c")
+	assert.That(t, entries[1].ID).Equal("b")
+
+	entries, err = l.Entries(ctx, 1)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(entries)).Equal(1)
+}
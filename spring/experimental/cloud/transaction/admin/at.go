@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-spring.org/spring/experimental/cloud/transaction/at"
+)
+
+// NewATSource adapts an AT coordinator for [Admin]. It panics if coord does
+// not implement [at.Inspector], as the bundled coordinator does. AT keeps its
+// global transactions in the coordinator's memory, so only the running ones
+// and those whose second phase failed are listed, and none survives a
+// restart — their undo logs do, in each branch's database.
+//
+// Retry runs the failed second phase again on the branches that failed.
+// Compensate rolls back a running or rollback-failed transaction; a
+// commit-failed one has already committed its other branches and is refused.
+// Resolve forgets the transaction and releases its global lock.
+func NewATSource(coord at.Coordinator) Source {
+	insp, ok := coord.(at.Inspector)
+	if !ok {
+		panic("admin: AT coordinator does not implement at.Inspector")
+	}
+	return &atSource{coord: coord, insp: insp}
+}
+
+type atSource struct {
+	coord at.Coordinator
+	insp  at.Inspector
+}
+
+func (s *atSource) Kind() string { return KindAT }
+
+func (s *atSource) List(context.Context) ([]Transaction, error) {
+	infos := s.insp.Unresolved()
+	out := make([]Transaction, len(infos))
+	for i, info := range infos {
+		out[i] = atView(info)
+	}
+	return out, nil
+}
+
+func (s *atSource) Get(_ context.Context, xid string) (Transaction, error) {
+	info, err := s.find(xid)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return atView(info), nil
+}
+
+func (s *atSource) Retry(ctx context.Context, xid string) (Transaction, error) {
+	info, err := s.find(xid)
+	if err != nil {
+		return Transaction{}, err
+	}
+	switch info.Status {
+	case at.StatusCommitFailed:
+		return s.after(xid, at.StatusCommitted, s.coord.Commit(ctx, xid))
+	case at.StatusRollbackFailed:
+		return s.after(xid, at.StatusRolledBack, s.coord.Rollback(ctx, xid))
+	default:
+		return atView(info), fmt.Errorf("%w: global transaction %s is %s", ErrConflict, xid, info.Status)
+	}
+}
+
+func (s *atSource) Compensate(ctx context.Context, xid string) (Transaction, error) {
+	info, err := s.find(xid)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if info.Status == at.StatusCommitFailed {
+		return atView(info), fmt.Errorf("%w: global transaction %s is %s", ErrConflict, xid, info.Status)
+	}
+	return s.after(xid, at.StatusRolledBack, s.coord.Rollback(ctx, xid))
+}
+
+func (s *atSource) Resolve(ctx context.Context, xid string) (Transaction, error) {
+	if err := s.insp.Forget(ctx, xid); err != nil {
+		if errors.Is(err, at.ErrUnknownTransaction) {
+			return Transaction{}, fmt.Errorf("%w: global transaction %s", ErrNotFound, xid)
+		}
+		return Transaction{}, err
+	}
+	return Transaction{Kind: KindAT, ID: xid, Status: "Resolved"}, nil
+}
+
+func (s *atSource) find(xid string) (at.GlobalInfo, error) {
+	for _, info := range s.insp.Unresolved() {
+		if info.XID == xid {
+			return info, nil
+		}
+	}
+	return at.GlobalInfo{}, fmt.Errorf("%w: global transaction %s", ErrNotFound, xid)
+}
+
+// after reports xid's state once a second phase ran: still tracked when it
+// failed again, otherwise resolved as ok.
+func (s *atSource) after(xid string, ok at.Status, err error) (Transaction, error) {
+	if info, ferr := s.find(xid); ferr == nil {
+		return atView(info), err
+	}
+	if err != nil {
+		return Transaction{}, err
+	}
+	return Transaction{Kind: KindAT, ID: xid, Status: ok.String()}, nil
+}
+
+func atView(info at.GlobalInfo) Transaction {
+	tx := Transaction{
+		Kind:      KindAT,
+		ID:        info.XID,
+		Status:    info.Status.String(),
+		Pending:   info.Status == at.StatusBegun,
+		UpdatedAt: info.UpdatedAt,
+		Errors:    info.Errors,
+	}
+	state := "registered"
+	if !tx.Pending {
+		state = "failed"
+	}
+	for _, b := range info.Branches {
+		tx.Steps = append(tx.Steps, Step{Name: b, State: state})
+	}
+	return tx
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AuditEntry records one operator action.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	ID       string    `json:"id"`
+	Action   Action    `json:"action"`
+	Operator string    `json:"operator"`
+	Reason   string    `json:"reason"`
+
+	// Before and After are the transaction's status around the action.
+	Before string `json:"before"`
+	After  string `json:"after,omitempty"`
+
+	// Error is set when the action failed.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog stores the audit trail of [Admin]. The bundled [MemoryAuditLog]
+// keeps the latest entries in process; an application that must keep them
+// registers a durable implementation. Implementations must be safe for
+// concurrent use.
+type AuditLog interface {
+	// Append records e.
+	Append(ctx context.Context, e AuditEntry) error
+
+	// Entries returns up to limit of the most recent entries, newest first;
+	// limit <= 0 returns all of them.
+	Entries(ctx context.Context, limit int) ([]AuditEntry, error)
+}
+
+// MemoryAuditLog is an in-process [AuditLog] keeping the most recent entries.
+type MemoryAuditLog struct {
+	mu      sync.Mutex
+	size    int
+	entries []AuditEntry // oldest first
+}
+
+// NewMemoryAuditLog returns a [MemoryAuditLog] keeping the last size entries
+// (at least 1).
+func NewMemoryAuditLog(size int) *MemoryAuditLog {
+	return &MemoryAuditLog{size: max(size, 1)}
+}
+
+// Append implements [AuditLog], dropping the oldest entry when full.
+func (l *MemoryAuditLog) Append(_ context.Context, e AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) == l.size {
+		l.entries = append(l.entries[:0], l.entries[1:]...)
+	}
+	l.entries = append(l.entries, e)
+	return nil
+}
+
+// Entries implements [AuditLog].
+func (l *MemoryAuditLog) Entries(_ context.Context, limit int) ([]AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.entries)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	out := make([]AuditEntry, n)
+	for i := range out {
+		out[i] = l.entries[len(l.entries)-1-i]
+	}
+	return out, nil
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-spring.org/spring/cloud/actuator/endpoint"
+)
+
+var _ endpoint.Endpoint = (*Admin)(nil)
+
+// transactionView is the JSON form of a transaction, with its age.
+type transactionView struct {
+	Transaction
+	Age string `json:"age"`
+}
+
+// actionRequest is the JSON body of an action.
+type actionRequest struct {
+	Operator string `json:"operator"`
+	Reason   string `json:"reason"`
+}
+
+// Path implements endpoint.Endpoint.
+func (a *Admin) Path() string { return "/transactions/" }
+
+// ServeHTTP implements endpoint.Endpoint:
+//
+//	GET  /transactions/                     list (?kind=&status=&olderThan=5m)
+//	GET  /transactions/{kind}/{id}          one transaction with its steps
+//	POST /transactions/{kind}/{id}/retry       re-drive it
+//	POST /transactions/{kind}/{id}/compensate  roll it back
+//	POST /transactions/{kind}/{id}/resolve     drop it as resolved by hand
+//	GET  /transactions/audit                the audit trail (?limit=50)
+//	GET  /transactions/metrics              Prometheus text metrics
+//
+// An action takes a JSON body {"operator": "...", "reason": "..."}; the
+// reason is required and the operator defaults to the caller's address. It
+// answers 404 for an unknown transaction and 409 for an action its state
+// forbids.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.muxOnce.Do(func() {
+		a.mux = http.NewServeMux()
+		a.mux.HandleFunc("GET /transactions/{$}", a.handleList)
+		a.mux.HandleFunc("GET /transactions/audit", a.handleAudit)
+		a.mux.HandleFunc("GET /transactions/metrics", a.handleMetrics)
+		a.mux.HandleFunc("GET /transactions/{kind}/{id}", a.handleGet)
+		a.mux.HandleFunc("POST /transactions/{kind}/{id}/{action}", a.handleAction)
+	})
+	a.mux.ServeHTTP(w, r)
+}
+
+// handleList lists the matching transactions, oldest first.
+func (a *Admin) handleList(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f := Filter{Kind: q.Get("kind"), Status: q.Get("status")}
+	if v := q.Get("olderThan"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			http.Error(w, "invalid olderThan", http.StatusBadRequest)
+			return
+		}
+		f.OlderThan = d
+	}
+	txs, err := a.List(r.Context(), f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	now := time.Now()
+	out := make([]transactionView, len(txs))
+	for i, tx := range txs {
+		out[i] = toView(tx, now)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleGet serves one transaction.
+func (a *Admin) handleGet(w http.ResponseWriter, r *http.Request) {
+	tx, err := a.Get(r.Context(), r.PathValue("kind"), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toView(tx, time.Now()))
+}
+
+// handleAction applies an action and answers the transaction's new state.
+func (a *Admin) handleAction(w http.ResponseWriter, r *http.Request) {
+	var req actionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		http.Error(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+	if req.Operator == "" {
+		req.Operator, _, _ = net.SplitHostPort(r.RemoteAddr)
+	}
+	action := Action(r.PathValue("action"))
+	switch action {
+	case ActionRetry, ActionCompensate, ActionResolve:
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	tx, err := a.Do(r.Context(), r.PathValue("kind"), r.PathValue("id"), action, req.Operator, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toView(tx, time.Now()))
+}
+
+// handleAudit serves the most recent audit entries.
+func (a *Admin) handleAudit(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	entries, err := a.Audit(r.Context(), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// handleMetrics renders the phase counters and the gauges computed from the
+// sources. Like the resilience metrics it needs no prometheus client.
+func (a *Admin) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	byKind := make(map[string][]Transaction, len(a.kinds))
+	for _, kind := range a.kinds {
+		txs, err := a.sources[kind].List(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		byKind[kind] = txs
+	}
+	var b strings.Builder
+	if a.metrics != nil {
+		a.metrics.writeCounters(&b)
+	}
+	writeGauges(&b, a.kinds, byKind, time.Now())
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func toView(tx Transaction, now time.Time) transactionView {
+	return transactionView{Transaction: tx, Age: now.Sub(tx.UpdatedAt).Truncate(time.Second).String()}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrUnsupported):
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestAdmin_Endpoint(t *testing.T) {
+	m := admin.NewMetrics()
+	src, _, refund := sagaFixture(t, m)
+	a := admin.New(admin.Config{Sources: []admin.Source{src}, Metrics: m})
+	assert.That(t, a.Path()).Equal("/transactions/")
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "/transactions/?kind=saga&olderThan=0s", "")
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	var list []map[string]any
+	assert.Error(t, json.Unmarshal(w.Body.Bytes(), &list)).Nil()
+	assert.That(t, len(list)).Equal(1)
+	assert.That(t, list[0]["status"]).Equal("CompensationFailed")
+
+	assert.That(t, do(http.MethodGet, "/transactions/?olderThan=soon", "").Code).Equal(http.StatusBadRequest)
+	assert.That(t, do(http.MethodGet, "/transactions/saga/s1", "").Code).Equal(http.StatusOK)
+	assert.That(t, do(http.MethodGet, "/transactions/saga/nope", "").Code).Equal(http.StatusNotFound)
+	assert.That(t, do(http.MethodGet, "/transactions/xa/s1", "").Code).Equal(http.StatusNotFound)
+
+	assert.That(t, do(http.MethodPost, "/transactions/saga/s1/retry", `{}`).Code).Equal(http.StatusBadRequest)
+	assert.That(t, do(http.MethodPost, "/transactions/saga/s1/explode", `{"reason":"x"}`).Code).Equal(http.StatusNotFound)
+
+	refund.ok = true
+	w = do(http.MethodPost, "/transactions/saga/s1/compensate", `{"operator":"dave","reason":"refund fixed"}`)
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	assert.String(t, w.Body.String()).Contains(`"status":"Compensated"`)
+	assert.That(t, do(http.MethodPost, "/transactions/saga/s1/compensate", `{"reason":"again"}`).Code).Equal(http.StatusConflict)
+
+	w = do(http.MethodGet, "/transactions/audit?limit=1", "")
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	var entries []admin.AuditEntry
+	assert.Error(t, json.Unmarshal(w.Body.Bytes(), &entries)).Nil()
+	assert.That(t, len(entries)).Equal(1)
+	assert.That(t, entries[0].Operator).Equal("192.0.2.1") // httptest's RemoteAddr
+
+	w = do(http.MethodGet, "/transactions/metrics", "")
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	body := w.Body.String()
+	assert.String(t, body).Contains(`transaction_phases_total{kind="saga",phase="compensate",outcome="error"} 1`)
+	assert.String(t, body).Contains(`transaction_phases_total{kind="saga",phase="compensate",outcome="success"} 1`)
+	assert.String(t, body).Contains(`transaction_in_flight{kind="saga"} 0`)
+	assert.String(t, body).Contains(`transaction_stored{kind="saga",status="Compensated"} 1`)
+}
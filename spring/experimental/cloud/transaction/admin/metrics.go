@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/experimental/cloud/transaction/at"
+	"go-spring.org/spring/experimental/cloud/transaction/tcc"
+)
+
+// Metrics counts the phases the coordinators run, by wrapping their Observers.
+// Together with the gauges [Admin] computes from its sources at scrape time it
+// renders:
+//
+//	transaction_phases_total{kind,phase,outcome}  counter; the compensation rate
+//	                                              is the rate of the compensate,
+//	                                              cancel and rollback phases
+//	transaction_in_flight{kind}                   transactions still pending
+//	transaction_recovery_lag_seconds{kind}        age of the oldest pending one
+//	transaction_stored{kind,status}               transactions held, by status
+//
+// The zero value is not usable; build it with [NewMetrics].
+type Metrics struct {
+	mu     sync.Mutex
+	phases map[phaseKey]int64
+}
+
+type phaseKey struct{ kind, phase, outcome string }
+
+// NewMetrics returns an empty [Metrics].
+func NewMetrics() *Metrics {
+	return &Metrics{phases: make(map[phaseKey]int64)}
+}
+
+// SagaObserver returns a saga Observer counting each phase before handing it
+// to next, which may be nil.
+func (m *Metrics) SagaObserver(next transaction.Observer) transaction.Observer {
+	return sagaObserver{m: m, next: next}
+}
+
+// TCCObserver returns a TCC Observer counting each phase before handing it to
+// next, which may be nil.
+func (m *Metrics) TCCObserver(next tcc.Observer) tcc.Observer {
+	return tccObserver{m: m, next: next}
+}
+
+// ATObserver returns an AT Observer counting each branch phase before handing
+// it to next, which may be nil.
+func (m *Metrics) ATObserver(next at.Observer) at.Observer {
+	return atObserver{m: m, next: next}
+}
+
+type sagaObserver struct {
+	m    *Metrics
+	next transaction.Observer
+}
+
+func (o sagaObserver) Begin(ctx context.Context, sagaID, step string, phase transaction.Phase) (context.Context, func(err error)) {
+	var end func(error)
+	if o.next != nil {
+		ctx, end = o.next.Begin(ctx, sagaID, step, phase)
+	}
+	return ctx, o.m.end(KindSaga, phase.String(), end)
+}
+
+type tccObserver struct {
+	m    *Metrics
+	next tcc.Observer
+}
+
+func (o tccObserver) Begin(ctx context.Context, txID, participant string, phase tcc.Phase) (context.Context, func(err error)) {
+	var end func(error)
+	if o.next != nil {
+		ctx, end = o.next.Begin(ctx, txID, participant, phase)
+	}
+	return ctx, o.m.end(KindTCC, phase.String(), end)
+}
+
+type atObserver struct {
+	m    *Metrics
+	next at.Observer
+}
+
+func (o atObserver) Begin(ctx context.Context, xid, branch string, phase at.Phase) (context.Context, func(err error)) {
+	var end func(error)
+	if o.next != nil {
+		ctx, end = o.next.Begin(ctx, xid, branch, phase)
+	}
+	return ctx, o.m.end(KindAT, phase.String(), end)
+}
+
+// end returns the end func of one phase: it counts the outcome, then calls
+// next's end func if any.
+func (m *Metrics) end(kind, phase string, next func(error)) func(error) {
+	return func(err error) {
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+		}
+		m.mu.Lock()
+		m.phases[phaseKey{kind, strings.ToLower(phase), outcome}]++
+		m.mu.Unlock()
+		if next != nil {
+			next(err)
+		}
+	}
+}
+
+// writeCounters renders the phase counters, in a stable order.
+func (m *Metrics) writeCounters(b *strings.Builder) {
+	m.mu.Lock()
+	keys := make([]phaseKey, 0, len(m.phases))
+	for k := range m.phases {
+		keys = append(keys, k)
+	}
+	counts := make([]int64, len(keys))
+	slices.SortFunc(keys, func(x, y phaseKey) int {
+		return strings.Compare(x.kind+"\x00"+x.phase+"\x00"+x.outcome, y.kind+"\x00"+y.phase+"\x00"+y.outcome)
+	})
+	for i, k := range keys {
+		counts[i] = m.phases[k]
+	}
+	m.mu.Unlock()
+
+	b.WriteString("# HELP transaction_phases_total Transaction phases run, by kind, phase and outcome.\n")
+	b.WriteString("# TYPE transaction_phases_total counter\n")
+	for i, k := range keys {
+		fmt.Fprintf(b, "transaction_phases_total{kind=%q,phase=%q,outcome=%q} %d\n", k.kind, k.phase, k.outcome, counts[i])
+	}
+}
+
+// writeGauges renders the gauges computed from the transactions each kind
+// holds at now.
+func writeGauges(b *strings.Builder, kinds []string, byKind map[string][]Transaction, now time.Time) {
+	b.WriteString("# HELP transaction_in_flight Transactions the coordinator or its recovery will still move on.\n")
+	b.WriteString("# TYPE transaction_in_flight gauge\n")
+	for _, kind := range kinds {
+		n := 0
+		for _, tx := range byKind[kind] {
+			if tx.Pending {
+				n++
+			}
+		}
+		fmt.Fprintf(b, "transaction_in_flight{kind=%q} %d\n", kind, n)
+	}
+
+	b.WriteString("# HELP transaction_recovery_lag_seconds Time since the oldest in-flight transaction last progressed.\n")
+	b.WriteString("# TYPE transaction_recovery_lag_seconds gauge\n")
+	for _, kind := range kinds {
+		lag := 0.0
+		for _, tx := range byKind[kind] {
+			if tx.Pending {
+				lag = max(lag, now.Sub(tx.UpdatedAt).Seconds())
+			}
+		}
+		fmt.Fprintf(b, "transaction_recovery_lag_seconds{kind=%q} %g\n", kind, lag)
+	}
+
+	b.WriteString("# HELP transaction_stored Transactions held, by kind and status.\n")
+	b.WriteString("# TYPE transaction_stored gauge\n")
+	for _, kind := range kinds {
+		counts := map[string]int{}
+		for _, tx := range byKind[kind] {
+			counts[tx.Status]++
+		}
+		statuses := make([]string, 0, len(counts))
+		for s := range counts {
+			statuses = append(statuses, s)
+		}
+		slices.Sort(statuses)
+		for _, s := range statuses {
+			fmt.Fprintf(b, "transaction_stored{kind=%q,status=%q} %d\n", kind, s, counts[s])
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/transaction"
+)
+
+// NewSagaSource adapts the saga log in store for [Admin]. coord must write to
+// the same store, and reg supplies the step definitions Retry and Compensate
+// replay. With a store that is not a [transaction.Lister] only the running
+// sagas are listed.
+//
+// A saga only ever recovers backward, so Retry returns [ErrUnsupported] and
+// Compensate is the way to re-drive it: a stale running saga now rather than
+// at the next recovery scan, a compensation-failed one once more over all its
+// completed steps (which is why compensations must be idempotent). A running
+// saga whose log was written within [WithStaleAfter] may still be driven by
+// a live replica, so Compensate and Resolve refuse it with [ErrConflict].
+func NewSagaSource(store transaction.Store, coord transaction.Coordinator, reg *transaction.StepRegistry, opts ...SourceOption) Source {
+	return &sagaSource{store: store, coord: coord, reg: reg, opts: applySourceOptions(opts)}
+}
+
+type sagaSource struct {
+	store transaction.Store
+	coord transaction.Coordinator
+	reg   *transaction.StepRegistry
+	opts  sourceOptions
+}
+
+func (s *sagaSource) Kind() string { return KindSaga }
+
+func (s *sagaSource) List(ctx context.Context) ([]Transaction, error) {
+	var snaps []transaction.Snapshot
+	var err error
+	if l, ok := s.store.(transaction.Lister); ok {
+		snaps, err = l.List(ctx)
+	} else {
+		snaps, err = s.store.Pending(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Transaction, len(snaps))
+	for i, snap := range snaps {
+		out[i] = sagaView(snap)
+	}
+	return out, nil
+}
+
+func (s *sagaSource) Get(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return sagaView(snap), nil
+}
+
+func (s *sagaSource) Retry(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return sagaView(snap), fmt.Errorf("%w: saga %s only recovers backward, compensate it instead", ErrUnsupported, id)
+}
+
+func (s *sagaSource) Compensate(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	switch snap.Status {
+	case transaction.StatusRunning:
+		if err = s.checkStale(snap); err != nil {
+			return sagaView(snap), err
+		}
+	case transaction.StatusCompensationFailed:
+		// Recover only acts on a running log: reopen it.
+		snap.Status = transaction.StatusRunning
+		if err = s.store.Save(ctx, id, snap); err != nil {
+			return Transaction{}, err
+		}
+	default:
+		return sagaView(snap), fmt.Errorf("%w: saga %s is %s", ErrConflict, id, snap.Status)
+	}
+	steps, ok := s.reg.Lookup(snap.Method)
+	if !ok {
+		return sagaView(snap), fmt.Errorf("admin: saga %s: no steps registered for method %q", id, snap.Method)
+	}
+	res, err := s.coord.Recover(ctx, transaction.Saga{ID: id, Method: snap.Method, Steps: steps})
+	if err != nil {
+		return sagaView(snap), err
+	}
+	return s.after(ctx, id, res.Status.String())
+}
+
+func (s *sagaSource) Resolve(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if snap.Status == transaction.StatusRunning {
+		if err = s.checkStale(snap); err != nil {
+			return sagaView(snap), err
+		}
+	}
+	if err = s.store.Delete(ctx, id); err != nil {
+		return sagaView(snap), err
+	}
+	return Transaction{Kind: KindSaga, ID: id, Method: snap.Method, Status: "Resolved"}, nil
+}
+
+// checkStale refuses a running saga whose log is recent enough that a live
+// replica may still be driving it; the replica would overwrite whatever the
+// action wrote.
+func (s *sagaSource) checkStale(snap transaction.Snapshot) error {
+	if s.opts.stillLive(snap.UpdatedAt) {
+		return fmt.Errorf("%w: saga %s is still running, updated %s ago", ErrConflict, snap.ID, time.Since(snap.UpdatedAt).Truncate(time.Second))
+	}
+	return nil
+}
+
+func (s *sagaSource) load(ctx context.Context, id string) (transaction.Snapshot, error) {
+	snap, err := s.store.Load(ctx, id)
+	if errors.Is(err, transaction.ErrSnapshotNotFound) {
+		return snap, fmt.Errorf("%w: saga %s", ErrNotFound, id)
+	}
+	return snap, err
+}
+
+// after reloads id after an action, falling back to status when the log is
+// gone.
+func (s *sagaSource) after(ctx context.Context, id, status string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return Transaction{Kind: KindSaga, ID: id, Status: status}, nil
+	}
+	if err != nil {
+		return Transaction{}, err
+	}
+	return sagaView(snap), nil
+}
+
+func sagaView(snap transaction.Snapshot) Transaction {
+	tx := Transaction{
+		Kind:      KindSaga,
+		ID:        snap.ID,
+		Method:    snap.Method,
+		Status:    snap.Status.String(),
+		Pending:   snap.Status == transaction.StatusRunning,
+		UpdatedAt: snap.UpdatedAt,
+		Errors:    snap.Errors,
+	}
+	for _, name := range snap.Completed {
+		tx.Steps = append(tx.Steps, Step{Name: name, State: "completed", Result: snap.StepResults[name]})
+	}
+	inFlight := snap.InFlight
+	if len(inFlight) == 0 && snap.InProgress != "" {
+		inFlight = []string{snap.InProgress}
+	}
+	for _, name := range inFlight {
+		if !slices.Contains(snap.Completed, name) {
+			tx.Steps = append(tx.Steps, Step{Name: name, State: "in-flight"})
+		}
+	}
+	return tx
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/transaction/tcc"
+)
+
+// NewTCCSource adapts the TCC log in store for [Admin]. coord must write to
+// the same store, and reg supplies the participant definitions Retry and
+// Compensate replay. With a store that is not a [tcc.Lister] only the pending
+// transactions are listed.
+//
+// Retry re-drives the recorded decision: a confirm-failed transaction is
+// confirmed again, a cancel-failed one cancelled again, a pending one
+// recovered now. Compensate cancels, and is refused once commit was decided —
+// cancelling after some participants confirmed would break the transaction.
+// A pending transaction whose log was written within [WithStaleAfter] may
+// still be driven by a live replica, so every action refuses it with
+// [ErrConflict].
+func NewTCCSource(store tcc.Store, coord tcc.Coordinator, reg *tcc.ParticipantRegistry, opts ...SourceOption) Source {
+	return &tccSource{store: store, coord: coord, reg: reg, opts: applySourceOptions(opts)}
+}
+
+type tccSource struct {
+	store tcc.Store
+	coord tcc.Coordinator
+	reg   *tcc.ParticipantRegistry
+	opts  sourceOptions
+}
+
+func (s *tccSource) Kind() string { return KindTCC }
+
+func (s *tccSource) List(ctx context.Context) ([]Transaction, error) {
+	var snaps []tcc.Snapshot
+	var err error
+	if l, ok := s.store.(tcc.Lister); ok {
+		snaps, err = l.List(ctx)
+	} else {
+		snaps, err = s.store.Pending(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Transaction, len(snaps))
+	for i, snap := range snaps {
+		out[i] = tccView(snap)
+	}
+	return out, nil
+}
+
+func (s *tccSource) Get(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return tccView(snap), nil
+}
+
+func (s *tccSource) Retry(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if err = s.checkStale(snap); err != nil {
+		return tccView(snap), err
+	}
+	switch snap.Status {
+	case tcc.StatusTrying, tcc.StatusConfirming, tcc.StatusCancelling:
+		return s.recover(ctx, snap, snap.Status)
+	case tcc.StatusConfirmFailed:
+		return s.recover(ctx, snap, tcc.StatusConfirming)
+	case tcc.StatusCancelFailed:
+		return s.recover(ctx, snap, tcc.StatusCancelling)
+	default:
+		return tccView(snap), fmt.Errorf("%w: tcc transaction %s is %s", ErrConflict, id, snap.Status)
+	}
+}
+
+func (s *tccSource) Compensate(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if err = s.checkStale(snap); err != nil {
+		return tccView(snap), err
+	}
+	switch snap.Status {
+	case tcc.StatusTrying, tcc.StatusCancelling, tcc.StatusCancelFailed:
+		return s.recover(ctx, snap, tcc.StatusCancelling)
+	default:
+		return tccView(snap), fmt.Errorf("%w: tcc transaction %s is %s", ErrConflict, id, snap.Status)
+	}
+}
+
+func (s *tccSource) Resolve(ctx context.Context, id string) (Transaction, error) {
+	snap, err := s.load(ctx, id)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if err = s.checkStale(snap); err != nil {
+		return tccView(snap), err
+	}
+	if err = s.store.Delete(ctx, id); err != nil {
+		return tccView(snap), err
+	}
+	return Transaction{Kind: KindTCC, ID: id, Method: snap.Method, Status: "Resolved"}, nil
+}
+
+// recover records status as the decision to drive snap towards, then recovers
+// it.
+func (s *tccSource) recover(ctx context.Context, snap tcc.Snapshot, status tcc.Status) (Transaction, error) {
+	participants, ok := s.reg.Lookup(snap.Method)
+	if !ok {
+		return tccView(snap), fmt.Errorf("admin: tcc transaction %s: no participants registered for method %q", snap.ID, snap.Method)
+	}
+	if snap.Status != status {
+		snap.Status = status
+		if err := s.store.Save(ctx, snap.ID, snap); err != nil {
+			return Transaction{}, err
+		}
+	}
+	res, err := s.coord.Recover(ctx, tcc.Transaction{ID: snap.ID, Method: snap.Method, Participants: participants})
+	if err != nil {
+		return tccView(snap), err
+	}
+	after, err := s.load(ctx, snap.ID)
+	if errors.Is(err, ErrNotFound) {
+		return Transaction{Kind: KindTCC, ID: snap.ID, Method: snap.Method, Status: res.Status.String()}, nil
+	}
+	if err != nil {
+		return Transaction{}, err
+	}
+	return tccView(after), nil
+}
+
+// checkStale refuses a pending transaction whose log is recent enough that a
+// live replica may still be driving it.
+func (s *tccSource) checkStale(snap tcc.Snapshot) error {
+	if pending(snap.Status) && s.opts.stillLive(snap.UpdatedAt) {
+		return fmt.Errorf("%w: tcc transaction %s is still %s, updated %s ago",
+			ErrConflict, snap.ID, snap.Status, time.Since(snap.UpdatedAt).Truncate(time.Second))
+	}
+	return nil
+}
+
+// pending reports whether status is one the coordinator is still moving on.
+func pending(status tcc.Status) bool {
+	switch status {
+	case tcc.StatusTrying, tcc.StatusConfirming, tcc.StatusCancelling:
+		return true
+	}
+	return false
+}
+
+func (s *tccSource) load(ctx context.Context, id string) (tcc.Snapshot, error) {
+	snap, err := s.store.Load(ctx, id)
+	if errors.Is(err, tcc.ErrSnapshotNotFound) {
+		return snap, fmt.Errorf("%w: tcc transaction %s", ErrNotFound, id)
+	}
+	return snap, err
+}
+
+func tccView(snap tcc.Snapshot) Transaction {
+	tx := Transaction{
+		Kind:      KindTCC,
+		ID:        snap.ID,
+		Method:    snap.Method,
+		Status:    snap.Status.String(),
+		UpdatedAt: snap.UpdatedAt,
+		Errors:    snap.Errors,
+	}
+	tx.Pending = pending(snap.Status)
+	for _, name := range snap.Tried {
+		tx.Steps = append(tx.Steps, Step{Name: name, State: "tried", Result: snap.TryResults[name]})
+	}
+	if snap.InProgress != "" {
+		tx.Steps = append(tx.Steps, Step{Name: snap.InProgress, State: "trying"})
+	}
+	return tx
+}
@@ -64,6 +64,7 @@ package at
 
 import (
 	"context"
+	"time"
 
 	"go-spring.org/spring/experimental/cloud/resilience"
 )
@@ -285,16 +286,54 @@ type Coordinator interface {
 
 	// Commit resolves xid successfully: every registered branch drops its undo
 	// logs and the global lock is released. A branch commit failure is collected
-	// and reported (StatusCommitFailed) but does not stop the others.
+	// and reported (StatusCommitFailed) but does not stop the others; the lock
+	// is then kept until a retry succeeds or the xid is forgotten.
 	Commit(ctx context.Context, xid string) error
 
 	// Rollback resolves xid by undoing it: every registered branch restores its
 	// rows from the before-images and the global lock is released. A branch
 	// rollback failure is collected and reported (StatusRollbackFailed) but does
-	// not stop the others.
+	// not stop the others; the lock is then kept until a retry succeeds or the
+	// xid is forgotten.
 	Rollback(ctx context.Context, xid string) error
 }
 
+// GlobalInfo describes a global transaction a coordinator still tracks: one
+// that is running, or whose second phase failed for some branches.
+type GlobalInfo struct {
+	// XID is the global transaction id.
+	XID string
+
+	// Status is StatusBegun while running, or StatusCommitFailed /
+	// StatusRollbackFailed after a failed second phase.
+	Status Status
+
+	// Branches are the registered branch ids; after a failed second phase,
+	// only the branches that failed.
+	Branches []string
+
+	// Errors are the branch failures of the last second phase.
+	Errors []string
+
+	// Begun is when the transaction began; UpdatedAt when it last changed.
+	Begun     time.Time
+	UpdatedAt time.Time
+}
+
+// Inspector is an optional [Coordinator] extension for operators, implemented
+// by the bundled coordinator. A failed second phase keeps its transaction
+// tracked: calling the same Commit or Rollback again retries the branches that
+// failed, while resolving it the other way is rejected with
+// [ErrAlreadyDecided].
+type Inspector interface {
+	// Unresolved returns every running or failed global transaction.
+	Unresolved() []GlobalInfo
+
+	// Forget stops tracking xid without touching its branches and releases its
+	// global lock — for a transaction an operator resolved by hand.
+	Forget(ctx context.Context, xid string) error
+}
+
 // Observer is the observability seam. The coordinator calls [Observer.Begin]
 // around every branch's second-phase operation; the returned end function is
 // invoked with the operation's error (nil on success). A starter implements this
@@ -95,6 +95,69 @@ func TestCoordinator_RollbackFailureIsSurfaced(t *testing.T) {
 	assert.Error(t, c.Rollback(ctx, xid)).Matches("restore failed")
 }
 
+func TestCoordinator_FailedSecondPhaseIsKeptForRetry(t *testing.T) {
+	c := NewCoordinator()
+	ctx, xid := c.Begin(context.Background())
+
+	ok := &fakeBranch{id: "db1"}
+	bad := &fakeBranch{id: "db2", commitErr: errors.New("disk full")}
+	assert.Error(t, c.Register(ctx, xid, ok)).Nil()
+	assert.Error(t, c.Register(ctx, xid, bad)).Nil()
+
+	assert.Error(t, c.Commit(ctx, xid)).Matches("disk full")
+	infos := c.(Inspector).Unresolved()
+	assert.That(t, len(infos)).Equal(1)
+	assert.That(t, infos[0].Status).Equal(StatusCommitFailed)
+	assert.Slice(t, infos[0].Branches).Equal([]string{"db2"})
+	assert.That(t, len(infos[0].Errors)).Equal(1)
+
+	// A committed-elsewhere transaction cannot be rolled back.
+	assert.That(t, errors.Is(c.Rollback(ctx, xid), ErrAlreadyDecided)).True()
+
+	// Committing again retries only the failed branch.
+	bad.commitErr = nil
+	assert.Error(t, c.Commit(ctx, xid)).Nil()
+	assert.That(t, ok.commits).Equal(1)
+	assert.That(t, bad.commits).Equal(2)
+	assert.That(t, len(c.(Inspector).Unresolved())).Equal(0)
+}
+
+func TestCoordinator_ForgetReleasesGlobalLock(t *testing.T) {
+	lk := &MemoryGlobalLock{}
+	c := NewCoordinator(WithGlobalLock(lk))
+	ctx, xid := c.Begin(context.Background())
+
+	keys := []LockKey{{Resource: "db1", Table: "account", PK: "1"}}
+	assert.Error(t, lk.Acquire(ctx, xid, keys)).Nil()
+	assert.Error(t, c.Register(ctx, xid, &fakeBranch{id: "db1", rollbackErr: errors.New("restore failed")})).Nil()
+	assert.Error(t, c.Rollback(ctx, xid)).NotNil()
+
+	// The rows are not restored yet, so the lock is still held.
+	assert.That(t, errors.Is(lk.Acquire(ctx, "other-xid", keys), ErrLockConflict)).True()
+
+	assert.Error(t, c.(Inspector).Forget(ctx, xid)).Nil()
+	assert.That(t, len(c.(Inspector).Unresolved())).Equal(0)
+	assert.Error(t, lk.Acquire(ctx, "other-xid", keys)).Nil()
+	assert.That(t, errors.Is(c.(Inspector).Forget(ctx, xid), ErrUnknownTransaction)).True()
+}
+
+func TestCoordinator_RetryReleasesGlobalLock(t *testing.T) {
+	lk := &MemoryGlobalLock{}
+	c := NewCoordinator(WithGlobalLock(lk))
+	ctx, xid := c.Begin(context.Background())
+
+	keys := []LockKey{{Resource: "db1", Table: "account", PK: "1"}}
+	assert.Error(t, lk.Acquire(ctx, xid, keys)).Nil()
+	b := &fakeBranch{id: "db1", commitErr: errors.New("disk full")}
+	assert.Error(t, c.Register(ctx, xid, b)).Nil()
+	assert.Error(t, c.Commit(ctx, xid)).NotNil()
+	assert.That(t, errors.Is(lk.Acquire(ctx, "other-xid", keys), ErrLockConflict)).True()
+
+	b.commitErr = nil
+	assert.Error(t, c.Commit(ctx, xid)).Nil()
+	assert.Error(t, lk.Acquire(ctx, "other-xid", keys)).Nil()
+}
+
 func TestCoordinator_RegisterUnknownTransaction(t *testing.T) {
 	c := NewCoordinator()
 	err := c.Register(context.Background(), "no-such-xid", &fakeBranch{id: "db1"})
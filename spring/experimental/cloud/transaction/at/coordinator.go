@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	"go-spring.org/spring/experimental/cloud/resilience"
 )
@@ -33,6 +34,11 @@ import (
 // resolved).
 var ErrUnknownTransaction = errors.New("at: unknown global transaction")
 
+// ErrAlreadyDecided is returned when xid's second phase failed one way and the
+// caller resolves it the other way: a failed commit can only be committed
+// again, a failed rollback only rolled back again.
+var ErrAlreadyDecided = errors.New("at: global transaction already decided")
+
 // Option configures the in-process [Coordinator] built by [NewCoordinator].
 type Option func(*coordinator)
 
@@ -54,7 +60,7 @@ func WithRetry(p RetryPolicy) Option { return func(c *coordinator) { c.retry = p
 // uses no global lock and no observer, which is a valid transparent setup for
 // tests and single-writer development.
 func NewCoordinator(opts ...Option) Coordinator {
-	c := &coordinator{active: make(map[string][]Branch)}
+	c := &coordinator{active: make(map[string]*global)}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -67,13 +73,24 @@ type coordinator struct {
 	retry    RetryPolicy
 
 	mu     sync.Mutex
-	active map[string][]Branch // xid -> registered branches, in registration order
+	active map[string]*global // xid -> running or failed global transaction
+}
+
+// global is the coordinator's record of one global transaction: running
+// (StatusBegun) or, after a failed second phase, the branches still to resolve.
+type global struct {
+	status   Status
+	branches []Branch // in registration order
+	errs     []string
+	begun    time.Time
+	updated  time.Time
 }
 
 func (c *coordinator) Begin(ctx context.Context) (context.Context, string) {
 	xid := newXID()
+	now := time.Now()
 	c.mu.Lock()
-	c.active[xid] = nil
+	c.active[xid] = &global{status: StatusBegun, begun: now, updated: now}
 	c.mu.Unlock()
 	return WithXID(ctx, xid), xid
 }
@@ -81,67 +98,134 @@ func (c *coordinator) Begin(ctx context.Context) (context.Context, string) {
 func (c *coordinator) Register(_ context.Context, xid string, b Branch) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	branches, ok := c.active[xid]
-	if !ok {
+	g, ok := c.active[xid]
+	if !ok || g.status != StatusBegun {
 		return fmt.Errorf("%w: %q", ErrUnknownTransaction, xid)
 	}
 	// Deduplicate by resource id: a database that writes several times in one
 	// global transaction is committed/rolled back exactly once.
-	if slices.ContainsFunc(branches, func(x Branch) bool { return x.ID() == b.ID() }) {
+	if slices.ContainsFunc(g.branches, func(x Branch) bool { return x.ID() == b.ID() }) {
 		return nil
 	}
-	c.active[xid] = append(branches, b)
+	g.branches = append(g.branches, b)
+	g.updated = time.Now()
 	return nil
 }
 
+// Commit implements [Coordinator]. Committing a transaction whose commit
+// failed retries the branches that failed.
 func (c *coordinator) Commit(ctx context.Context, xid string) error {
-	branches, err := c.take(xid)
+	g, err := c.take(xid, StatusCommitFailed)
 	if err != nil {
 		return err
 	}
-	defer c.release(ctx, xid)
-
 	var errs []error
-	for _, b := range branches {
+	var failed []Branch
+	for _, b := range g.branches {
 		if e := c.runPhase(ctx, xid, b, PhaseCommit, b.Commit); e != nil {
 			errs = append(errs, fmt.Errorf("branch %q commit: %w", b.ID(), e))
+			failed = append(failed, b)
 		}
 	}
+	c.finish(ctx, xid, g, StatusCommitFailed, failed, errs)
 	return errors.Join(errs...)
 }
 
+// Rollback implements [Coordinator]. Rolling back a transaction whose
+// rollback failed retries the branches that failed.
 func (c *coordinator) Rollback(ctx context.Context, xid string) error {
-	branches, err := c.take(xid)
+	g, err := c.take(xid, StatusRollbackFailed)
 	if err != nil {
 		return err
 	}
-	defer c.release(ctx, xid)
-
 	// Undo in reverse registration order, mirroring the reverse-order compensation
 	// of Saga/TCC: later branches are unwound before earlier ones.
 	var errs []error
-	for _, b := range slices.Backward(branches) {
+	var failed []Branch
+	for _, b := range slices.Backward(g.branches) {
 		if e := c.runPhase(ctx, xid, b, PhaseRollback, b.Rollback); e != nil {
 			errs = append(errs, fmt.Errorf("branch %q rollback: %w", b.ID(), e))
+			failed = slices.Insert(failed, 0, b) // keep registration order
 		}
 	}
+	c.finish(ctx, xid, g, StatusRollbackFailed, failed, errs)
 	return errors.Join(errs...)
 }
 
-// take removes and returns the branches for xid, erroring if it is unknown. It
-// makes resolution single-shot: a second Commit/Rollback of the same xid finds
-// nothing and reports ErrUnknownTransaction.
-func (c *coordinator) take(xid string) ([]Branch, error) {
+// Unresolved implements [Inspector], ordered by begin time.
+func (c *coordinator) Unresolved() []GlobalInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]GlobalInfo, 0, len(c.active))
+	for xid, g := range c.active {
+		info := GlobalInfo{
+			XID:       xid,
+			Status:    g.status,
+			Errors:    slices.Clone(g.errs),
+			Begun:     g.begun,
+			UpdatedAt: g.updated,
+		}
+		for _, b := range g.branches {
+			info.Branches = append(info.Branches, b.ID())
+		}
+		out = append(out, info)
+	}
+	slices.SortFunc(out, func(a, b GlobalInfo) int { return a.Begun.Compare(b.Begun) })
+	return out
+}
+
+// Forget implements [Inspector].
+func (c *coordinator) Forget(ctx context.Context, xid string) error {
+	c.mu.Lock()
+	_, ok := c.active[xid]
+	delete(c.active, xid)
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownTransaction, xid)
+	}
+	c.release(ctx, xid)
+	return nil
+}
+
+// take removes and returns the record for xid, erroring if it is unknown or
+// failed the other way than retry (the failed status the caller may retry).
+// It makes resolution single-shot: a second Commit/Rollback of the same xid
+// finds nothing and reports ErrUnknownTransaction.
+func (c *coordinator) take(xid string, retry Status) (*global, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	branches, ok := c.active[xid]
+	g, ok := c.active[xid]
 	if !ok {
 		return nil, fmt.Errorf("%w: %q", ErrUnknownTransaction, xid)
 	}
+	if g.status != StatusBegun && g.status != retry {
+		return nil, fmt.Errorf("%w: %q is %s", ErrAlreadyDecided, xid, g.status)
+	}
 	delete(c.active, xid)
-	return branches, nil
+	return g, nil
 }
 
+// finish releases the global lock for xid once every branch resolved.
+// Otherwise it puts xid back as failed with the branches still to resolve,
+// so an operator can see it and retry, and keeps the lock: releasing it would
+// let another transaction write rows whose undo is still pending.
+func (c *coordinator) finish(ctx context.Context, xid string, g *global, status Status, failed []Branch, errs []error) {
+	if len(failed) == 0 {
+		c.release(ctx, xid)
+		return
+	}
+	g.status, g.branches, g.updated = status, failed, time.Now()
+	g.errs = g.errs[:0]
+	for _, e := range errs {
+		g.errs = append(g.errs, e.Error())
+	}
+	c.mu.Lock()
+	c.active[xid] = g
+	c.mu.Unlock()
+}
+
+var _ Inspector = (*coordinator)(nil)
+
 // release frees the global lock for xid. A lock error is intentionally swallowed:
 // the transaction has already been resolved and failing the whole operation on a
 // lock-release error would be worse than a stranded lock, which a lock backend's
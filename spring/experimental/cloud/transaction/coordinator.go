@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
+	"go-spring.org/spring/experimental/cloud/messaging"
 	"go-spring.org/spring/experimental/cloud/resilience"
 )
 
@@ -38,10 +40,23 @@ func WithStore(s Store) Option { return func(c *coordinator) { c.store = s } }
 // starter that opens otel spans.
 func WithObserver(o Observer) Option { return func(c *coordinator) { c.observer = o } }
 
+// WithBinder sets the [messaging.Binder] async steps ([Step.Await]) receive
+// their replies through. A saga with an async step fails validation without
+// one.
+func WithBinder(b messaging.Binder) Option { return func(c *coordinator) { c.binder = b } }
+
+// WithHeartbeat makes the coordinator rewrite a running saga's log every d
+// while one of its steps is in flight, so a [RecoveryWorker] does not take a
+// long step or async wait for an abandoned saga. Keep d well below the
+// worker's StaleAfter, e.g. a third of it. Zero, the default, writes the log
+// only when a step starts or ends.
+func WithHeartbeat(d time.Duration) Option { return func(c *coordinator) { c.heartbeat = d } }
+
 // NewCoordinator returns the bundled in-process [Coordinator]: it runs a saga's
-// steps synchronously and, on the first failure, compensates the already-
-// succeeded steps in reverse order. With no options it uses no store and no
-// observer, which is a valid transparent setup for tests and development.
+// steps — parallel branches on their own goroutines — and, on the first
+// failure, compensates the already-succeeded steps in reverse order. With no
+// options it uses no store, no observer and no binder, which is a valid
+// transparent setup for tests and development.
 func NewCoordinator(opts ...Option) Coordinator {
 	c := &coordinator{}
 	for _, opt := range opts {
@@ -51,8 +66,10 @@ func NewCoordinator(opts ...Option) Coordinator {
 }
 
 type coordinator struct {
-	store    Store
-	observer Observer
+	store     Store
+	observer  Observer
+	binder    messaging.Binder
+	heartbeat time.Duration
 }
 
 // completedStep records a step that succeeded, so compensation can replay it in
@@ -62,47 +79,201 @@ type completedStep struct {
 	result any
 }
 
+// sagaRun is the state of one Execute. Parallel branches update it
+// concurrently, so every field below mu is guarded by it.
+type sagaRun struct {
+	c   *coordinator
+	s   Saga
+	ctx context.Context // the caller's ctx, for store writes
+
+	mu        sync.Mutex
+	completed []completedStep // in completion order
+	inFlight  []string        // steps whose Action started, in start order
+	results   map[string]any  // completed steps' results, read by StepOutput
+	res       Result          // the Result being built
+	failure   error           // the first failing action's error
+}
+
 func (c *coordinator) Execute(ctx context.Context, s Saga) (Result, error) {
-	res := Result{Status: StatusCommitted, StepResults: make(map[string]any, len(s.Steps))}
-	completed := make([]completedStep, 0, len(s.Steps))
+	if err := validateSteps(s.Steps, map[string]bool{}, c.binder); err != nil {
+		// Nothing ran, so there is nothing to undo.
+		return Result{Status: StatusCompensated, StepResults: map[string]any{}}, err
+	}
+	r := &sagaRun{
+		c:       c,
+		s:       s,
+		ctx:     ctx,
+		results: make(map[string]any),
+		res:     Result{Status: StatusCommitted, StepResults: make(map[string]any)},
+	}
+	runCtx := context.WithValue(WithSagaID(ctx, s.ID), runKey{}, r)
+	if !s.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithDeadline(runCtx, s.Deadline)
+		defer cancel()
+	}
+	stop := r.heartbeat()
+	r.runSteps(runCtx, s.Steps)
+	stop()
 
-	for _, step := range s.Steps {
-		// Record the intent before running: the Action may cause a side effect a
-		// crash would strand, so a recovering process must know this step was in
-		// flight and compensate it.
-		c.persistRunning(ctx, s, completed, step.Name)
+	// Every branch has returned, so the run state is no longer shared.
+	if r.failure != nil {
+		// Compensation runs under the caller's ctx: the saga deadline that may
+		// have caused the failure must not also prevent the undo.
+		compCtx := context.WithValue(WithSagaID(ctx, s.ID), runKey{}, r)
+		c.compensate(compCtx, s.ID, r.completed, &r.res)
+	}
+	c.finish(ctx, s, &r.res, r.completed)
+	return r.res, r.failure
+}
 
-		result, err := c.runPhase(ctx, s.ID, step, PhaseAction, func(ctx context.Context) (any, error) {
-			return step.Action(ctx)
-		})
+// runSteps runs steps in order until one fails anywhere in the saga.
+func (r *sagaRun) runSteps(ctx context.Context, steps []Step) {
+	for _, step := range steps {
+		if r.failed() {
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			// The saga deadline passed (or the caller gave up) between steps.
+			r.end(step, nil, false, err)
+			return
+		}
+		if step.When != nil && !step.When(ctx) {
+			continue
+		}
+		if step.Parallel == nil {
+			r.runStep(ctx, step)
+			continue
+		}
+		var wg sync.WaitGroup
+		for _, branch := range step.Parallel {
+			wg.Go(func() { r.runSteps(ctx, branch) })
+		}
+		wg.Wait()
+	}
+}
+
+// runStep runs one leaf step: its Action under the retry policy and timeout
+// and, for an async step, the wait for its reply.
+func (r *sagaRun) runStep(ctx context.Context, step Step) {
+	// Record the intent before running: the Action may cause a side effect a
+	// crash would strand, so a recovering process must know this step was in
+	// flight and compensate it.
+	r.begin(step.Name)
+
+	ctx = context.WithValue(ctx, stepKey{}, step.Name)
+	if step.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, step.Timeout)
+		defer cancel()
+	}
+	var wait func(context.Context) ([]byte, error)
+	if step.Await != nil {
+		// Subscribe before the Action publishes, so the reply cannot be missed.
+		w, closeFn, err := awaitReply(ctx, r.c.binder, r.s.ID, step)
 		if err != nil {
-			// The failing action's error leads Result.Errors so a compensated
-			// saga still explains why it rolled back.
-			res.Errors = append(res.Errors, StepError{Step: step.Name, Phase: PhaseAction, Err: err})
-			if result != nil {
-				res.StepResults[step.Name] = result
+			r.end(step, nil, false, err)
+			return
+		}
+		defer closeFn()
+		wait = w
+	}
+
+	result, err := r.c.runPhase(ctx, r.s.ID, step, PhaseAction, func(ctx context.Context) (any, error) {
+		return step.Action(ctx)
+	})
+	if err != nil || wait == nil {
+		r.end(step, result, err == nil, err)
+		return
+	}
+	// The request went out, so the step may have had an effect: it is
+	// compensated even when the reply reports a failure or never comes.
+	payload, err := wait(ctx)
+	r.end(step, Reply{Sent: result, Payload: payload}, true, err)
+}
+
+// begin marks name as in flight and persists the log.
+func (r *sagaRun) begin(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight = append(r.inFlight, name)
+	r.persistLocked()
+}
+
+// end settles a step: effected steps join the compensation set, a failure is
+// recorded, and the log is persisted with the step no longer in flight.
+func (r *sagaRun) end(step Step, result any, effected bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight = slices.DeleteFunc(r.inFlight, func(n string) bool { return n == step.Name })
+	if effected {
+		r.completed = append(r.completed, completedStep{step: step, result: result})
+		r.results[step.Name] = result
+	}
+	if err == nil || result != nil {
+		r.res.StepResults[step.Name] = result
+	}
+	if err != nil {
+		// The failing actions lead Result.Errors so a compensated saga still
+		// explains why it rolled back; the first one is what Execute returns.
+		r.res.Errors = append(r.res.Errors, StepError{Step: step.Name, Phase: PhaseAction, Err: err})
+		if r.failure == nil {
+			r.failure = err
+		}
+	}
+	r.persistLocked()
+}
+
+// heartbeat rewrites the running log every c.heartbeat while a step is in
+// flight, refreshing its UpdatedAt. The returned func stops it and waits, so
+// no heartbeat lands after the terminal log.
+func (r *sagaRun) heartbeat() (stop func()) {
+	if r.c.store == nil || r.c.heartbeat <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		t := time.NewTicker(r.c.heartbeat)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				r.mu.Lock()
+				if len(r.inFlight) > 0 {
+					r.persistLocked()
+				}
+				r.mu.Unlock()
 			}
-			c.compensate(ctx, s.ID, completed, &res)
-			c.finish(ctx, s, &res, completed)
-			return res, err
 		}
-		res.StepResults[step.Name] = result
-		completed = append(completed, completedStep{step: step, result: result})
-		// Confirm completion: fold this step into the log and clear the in-flight
-		// marker, still StatusRunning until the saga terminates.
-		c.persistRunning(ctx, s, completed, "")
+	})
+	return func() {
+		close(done)
+		wg.Wait()
 	}
+}
 
-	c.finish(ctx, s, &res, completed)
-	return res, nil
+func (r *sagaRun) failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failure != nil
+}
+
+// persistLocked writes the running log; r.mu keeps concurrent branches' writes
+// in order.
+func (r *sagaRun) persistLocked() {
+	r.c.persistRunning(r.ctx, r.s, r.completed, r.inFlight, r.res.Errors)
 }
 
 // Recover resumes an interrupted saga by compensating everything it might have
 // effected, in reverse order (backward recovery). It loads the saga log by
 // s.ID; a missing log or an already-terminal one is a no-op, so recovery is
-// idempotent. The in-flight step (if any) is compensated first with a nil
-// result — its Action return value was never recorded — followed by the
-// completed steps in reverse. s supplies the step definitions the log cannot.
+// idempotent. The in-flight steps (several when parallel branches were
+// running) are compensated first with a nil result — their Action return
+// values were never recorded — followed by the completed steps in reverse.
+// s supplies the step definitions the log cannot.
 func (c *coordinator) Recover(ctx context.Context, s Saga) (Result, error) {
 	if c.store == nil {
 		return Result{}, errors.New("transaction: Recover requires a Store")
@@ -122,15 +293,18 @@ func (c *coordinator) Recover(ctx context.Context, s Saga) (Result, error) {
 	}
 
 	byName := make(map[string]Step, len(s.Steps))
-	for _, step := range s.Steps {
-		byName[step.Name] = step
-	}
+	flattenSteps(s.Steps, byName)
 
-	// Build the set to compensate, most-recent first: the in-flight step (result
-	// unknown, so nil) then the completed steps in reverse execution order.
+	// Build the set to compensate, most-recent first: the in-flight steps
+	// (result unknown, so nil) then the completed steps in reverse order.
+	inFlight := snap.InFlight
+	if len(inFlight) == 0 && snap.InProgress != "" {
+		// A log written by a store that predates InFlight.
+		inFlight = []string{snap.InProgress}
+	}
 	var toCompensate []completedStep
-	if snap.InProgress != "" {
-		if step, ok := byName[snap.InProgress]; ok {
+	for _, name := range slices.Backward(inFlight) {
+		if step, ok := byName[name]; ok && !slices.Contains(snap.Completed, name) {
 			toCompensate = append(toCompensate, completedStep{step: step, result: nil})
 		}
 	}
@@ -217,16 +391,16 @@ func (c *coordinator) runPhase(ctx context.Context, sagaID string, step Step, ph
 
 // persistRunning writes the in-flight saga log while steps run, when a store is
 // configured: Status is StatusRunning, completed holds the steps whose Actions
-// succeeded and inProgress names the step currently running (empty between
+// succeeded and inFlight names the steps currently running (none between
 // steps). A persistence error is intentionally swallowed here: the saga has
 // already made progress and failing the whole operation on a log write would be
 // worse than a gap in the log. Durable-store implementations should surface such
 // problems through their own monitoring.
-func (c *coordinator) persistRunning(ctx context.Context, s Saga, completed []completedStep, inProgress string) {
+func (c *coordinator) persistRunning(ctx context.Context, s Saga, completed []completedStep, inFlight []string, errs []StepError) {
 	if c.store == nil {
 		return
 	}
-	_ = c.store.Save(ctx, s.ID, c.snapshot(s, StatusRunning, completed, inProgress))
+	_ = c.store.Save(ctx, s.ID, c.snapshot(s, StatusRunning, completed, inFlight, errs))
 }
 
 // finish writes the terminal saga log. A committed saga's log is deleted (the
@@ -240,25 +414,32 @@ func (c *coordinator) finish(ctx context.Context, s Saga, res *Result, completed
 		_ = c.store.Delete(ctx, s.ID)
 		return
 	}
-	_ = c.store.Save(ctx, s.ID, c.snapshot(s, res.Status, completed, ""))
+	_ = c.store.Save(ctx, s.ID, c.snapshot(s, res.Status, completed, nil, res.Errors))
 }
 
-func (c *coordinator) snapshot(s Saga, status Status, completed []completedStep, inProgress string) Snapshot {
+func (c *coordinator) snapshot(s Saga, status Status, completed []completedStep, inFlight []string, errs []StepError) Snapshot {
 	names := make([]string, len(completed))
 	results := make(map[string]any, len(completed))
 	for i, cs := range completed {
 		names[i] = cs.step.Name
 		results[cs.step.Name] = cs.result
 	}
-	return Snapshot{
+	snap := Snapshot{
 		ID:          s.ID,
 		Method:      s.Method,
 		Status:      status,
 		Completed:   names,
-		InProgress:  inProgress,
 		StepResults: results,
 		UpdatedAt:   time.Now(),
 	}
+	if len(inFlight) > 0 {
+		snap.InProgress = inFlight[0]
+		snap.InFlight = slices.Clone(inFlight)
+	}
+	for _, e := range errs {
+		snap.Errors = append(snap.Errors, e.Error())
+	}
+	return snap
 }
 
 // runWithPolicy runs fn once when the policy is zero, or under the bundled
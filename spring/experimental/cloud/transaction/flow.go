@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+)
+
+// Headers correlating an async step's messages with the step waiting for them.
+// [Correlate] sets the first two on an outgoing request; the reply must carry
+// them back unchanged.
+const (
+	// HeaderSagaID carries the saga's [Saga.ID].
+	HeaderSagaID = "saga-id"
+
+	// HeaderSagaStep carries the name of the step awaiting the reply.
+	HeaderSagaStep = "saga-step"
+
+	// HeaderSagaError, when set on a reply, fails the step with its value as
+	// the error message.
+	HeaderSagaError = "saga-error"
+)
+
+// Await configures an asynchronous [Step]. The step subscribes to Source
+// before its Action runs, so a reply cannot overtake the subscription, and
+// completes with the first message carrying its saga id and step name.
+type Await struct {
+	// Source is where the reply arrives, in the binder's own terms (subject,
+	// topic, ...). Every waiting saga subscribes without a consumer group and
+	// filters by the correlation headers, so any replica may wait on it.
+	Source string
+}
+
+// Reply is the result of an async step: the value its Action returned and the
+// payload of the message that completed it. Compensation receives it too; when
+// the Action succeeded but the reply failed or never came, Payload is nil.
+type Reply struct {
+	Sent    any
+	Payload []byte
+}
+
+// Parallel returns a fan-out/fan-in step named name: the branches run
+// concurrently, each in its own order, and the step completes when all have.
+// When a step in one branch fails, the other branches start no further step;
+// the steps already running finish and, with everything else that completed,
+// are compensated.
+//
+//	transaction.Parallel("reserve-and-charge",
+//	    transaction.Branch(reserveInventory),
+//	    transaction.Branch(chargePayment),
+//	)
+func Parallel(name string, branches ...[]Step) Step {
+	return Step{Name: name, Parallel: branches}
+}
+
+// Branch returns steps as one branch of a [Parallel] step.
+func Branch(steps ...Step) []Step { return steps }
+
+// runKey is the context key carrying the running saga's state to its steps.
+type runKey struct{}
+
+// stepKey is the context key carrying the name of the running step.
+type stepKey struct{}
+
+// StepOutput returns the result of the earlier step name of the saga running
+// in ctx, and whether that step has completed. It is how a step consumes what
+// a previous one produced (an order id, a reservation token); steps in a
+// parallel branch only see the other branches' steps once those completed.
+func StepOutput(ctx context.Context, name string) (any, bool) {
+	r, ok := ctx.Value(runKey{}).(*sagaRun)
+	if !ok {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.results[name]
+	return v, ok
+}
+
+// Correlate sets the saga id and step name of the step running in ctx on msg,
+// so the reply to it can complete an async step. Call it from the step's
+// Action on the request it publishes.
+func Correlate(ctx context.Context, msg *messaging.Message) {
+	if id, ok := SagaIDFromContext(ctx); ok {
+		msg.SetHeader(HeaderSagaID, id)
+	}
+	if step, ok := ctx.Value(stepKey{}).(string); ok {
+		msg.SetHeader(HeaderSagaStep, step)
+	}
+}
+
+// awaitReply subscribes to step's reply source and returns a function waiting
+// for the correlated message, plus a function releasing the subscription.
+func awaitReply(ctx context.Context, b messaging.Binder, sagaID string, step Step) (wait func(context.Context) ([]byte, error), closeFn func(), err error) {
+	sub, err := b.NewSubscriber(ctx, step.Await.Source, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	replies := make(chan *messaging.Message, 1)
+	err = sub.Subscribe(ctx, func(_ context.Context, msg *messaging.Message) error {
+		if msg.Header(HeaderSagaID) != sagaID || msg.Header(HeaderSagaStep) != step.Name {
+			return nil
+		}
+		select {
+		case replies <- msg:
+		default: // a duplicate reply: the first one wins
+		}
+		return nil
+	})
+	if err != nil {
+		_ = sub.Close()
+		return nil, nil, err
+	}
+	wait = func(ctx context.Context) ([]byte, error) {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction: awaiting reply on %q: %w", step.Await.Source, ctx.Err())
+		case msg := <-replies:
+			if e := msg.Header(HeaderSagaError); e != "" {
+				return nil, errors.New(e)
+			}
+			return msg.Payload, nil
+		}
+	}
+	return wait, func() { _ = sub.Close() }, nil
+}
+
+// validateSteps checks a saga definition before anything runs: names are
+// present and unique across all branches, a parallel step carries nothing but
+// its branches, every other step has an Action, and async steps have a binder.
+func validateSteps(steps []Step, seen map[string]bool, binder messaging.Binder) error {
+	for _, step := range steps {
+		if step.Name == "" {
+			return errors.New("transaction: step without a name")
+		}
+		if seen[step.Name] {
+			return fmt.Errorf("transaction: duplicate step name %q", step.Name)
+		}
+		seen[step.Name] = true
+		if step.Parallel != nil {
+			if step.Action != nil || step.Compensate != nil || step.Await != nil {
+				return fmt.Errorf("transaction: parallel step %q cannot have an Action, Compensate or Await", step.Name)
+			}
+			for _, branch := range step.Parallel {
+				if err := validateSteps(branch, seen, binder); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if step.Action == nil {
+			return fmt.Errorf("transaction: step %q has no Action", step.Name)
+		}
+		if step.Await != nil && binder == nil {
+			return fmt.Errorf("transaction: async step %q needs a Binder (WithBinder)", step.Name)
+		}
+	}
+	return nil
+}
+
+// flattenSteps indexes every leaf step of a definition by name, descending
+// into parallel branches.
+func flattenSteps(steps []Step, byName map[string]Step) {
+	for _, step := range steps {
+		if step.Parallel != nil {
+			for _, branch := range step.Parallel {
+				flattenSteps(branch, byName)
+			}
+			continue
+		}
+		byName[step.Name] = step
+	}
+}
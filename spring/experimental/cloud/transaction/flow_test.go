@@ -0,0 +1,340 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// journal records step events from concurrently running branches.
+type journal struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (j *journal) add(e string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.events = append(j.events, e)
+}
+
+func (j *journal) list() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.events)
+}
+
+// step returns a step recording its action and compensation in j.
+func (j *journal) step(name string, err error) transaction.Step {
+	return transaction.Step{
+		Name: name,
+		Action: func(context.Context) (any, error) {
+			j.add(name)
+			if err != nil {
+				return nil, err
+			}
+			return "r" + name, nil
+		},
+		Compensate: func(context.Context, any) error { j.add("!" + name); return nil },
+	}
+}
+
+func TestParallel_OrderSaga(t *testing.T) {
+	// Reserve inventory and charge payment in parallel, then ship.
+	j := &journal{}
+	coord := transaction.NewCoordinator()
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "o1", Steps: []transaction.Step{
+		transaction.Parallel("reserve-and-charge",
+			transaction.Branch(j.step("reserve", nil)),
+			transaction.Branch(j.step("charge", nil)),
+		),
+		j.step("ship", nil),
+	}})
+	assert.Error(t, err).Nil()
+	assert.That(t, res.Status).Equal(transaction.StatusCommitted)
+	assert.That(t, res.StepResults["reserve"]).Equal("rreserve")
+	assert.That(t, res.StepResults["charge"]).Equal("rcharge")
+	events := j.list()
+	assert.That(t, len(events)).Equal(3)
+	// ship only starts once both branches completed.
+	assert.That(t, events[2]).Equal("ship")
+}
+
+func TestParallel_FailureCompensatesCompletedSiblings(t *testing.T) {
+	j := &journal{}
+	boom := errors.New("card declined")
+	chargeStarted := make(chan struct{})
+	coord := transaction.NewCoordinator()
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "o2", Steps: []transaction.Step{
+		j.step("create", nil),
+		transaction.Parallel("reserve-and-charge",
+			transaction.Branch(
+				transaction.Step{Name: "reserve", Action: func(context.Context) (any, error) {
+					<-chargeStarted // complete while the sibling runs
+					j.add("reserve")
+					return "rreserve", nil
+				}, Compensate: func(context.Context, any) error { j.add("!reserve"); return nil }},
+				j.step("label", nil),
+			),
+			transaction.Branch(transaction.Step{Name: "charge", Action: func(context.Context) (any, error) {
+				close(chargeStarted)
+				time.Sleep(10 * time.Millisecond)
+				return nil, boom
+			}}),
+		),
+		j.step("ship", nil),
+	}})
+	assert.Error(t, err).Is(boom)
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	events := j.list()
+	// The failed branch stops its sibling from going further than the step
+	// it was running, and everything that completed is undone in reverse.
+	assert.That(t, slices.Contains(events, "ship")).False()
+	assert.That(t, events[0]).Equal("create")
+	assert.That(t, events[len(events)-1]).Equal("!create")
+	assert.That(t, slices.Contains(events, "!reserve")).True()
+	assert.That(t, slices.Index(events, "!reserve") < slices.Index(events, "!create")).True()
+}
+
+func TestExecute_RejectsInvalidDefinition(t *testing.T) {
+	coord := transaction.NewCoordinator()
+	cases := [][]transaction.Step{
+		{{Name: "a", Action: act(1)}, {Name: "a", Action: act(2)}},
+		{{Name: "a"}},
+		{transaction.Parallel("p", transaction.Branch(transaction.Step{Name: "p", Action: act(1)}))},
+		{{Name: "a", Action: act(1), Await: &transaction.Await{Source: "replies"}}}, // no binder
+	}
+	for _, steps := range cases {
+		ran := false
+		steps = append(steps, transaction.Step{Name: "probe", Action: func(context.Context) (any, error) { ran = true; return nil, nil }})
+		_, err := coord.Execute(context.Background(), transaction.Saga{ID: "bad", Steps: steps})
+		assert.Error(t, err).NotNil()
+		assert.That(t, ran).False()
+	}
+}
+
+func TestStep_WhenSkipsAndStepOutputFeedsLaterSteps(t *testing.T) {
+	var shipped any
+	coord := transaction.NewCoordinator()
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "w1", Steps: []transaction.Step{
+		{Name: "order", Action: act("order-42")},
+		{Name: "coupon", Action: act("used"), When: func(context.Context) bool { return false }},
+		{Name: "ship", Action: func(ctx context.Context) (any, error) {
+			shipped, _ = transaction.StepOutput(ctx, "order")
+			_, couponRan := transaction.StepOutput(ctx, "coupon")
+			assert.That(t, couponRan).False()
+			return nil, nil
+		}},
+	}})
+	assert.Error(t, err).Nil()
+	assert.That(t, res.Status).Equal(transaction.StatusCommitted)
+	assert.That(t, shipped).Equal("order-42")
+	_, ok := res.StepResults["coupon"]
+	assert.That(t, ok).False()
+	_, ok = transaction.StepOutput(context.Background(), "order")
+	assert.That(t, ok).False()
+}
+
+func TestStep_TimeoutFailsAndCompensates(t *testing.T) {
+	j := &journal{}
+	coord := transaction.NewCoordinator()
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "t1", Steps: []transaction.Step{
+		j.step("a", nil),
+		{Name: "slow", Timeout: 10 * time.Millisecond, Action: func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}},
+	}})
+	assert.Error(t, err).Is(context.DeadlineExceeded)
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	assert.Slice(t, j.list()).Equal([]string{"a", "!a"})
+}
+
+func TestSaga_DeadlineStopsFurtherSteps(t *testing.T) {
+	j := &journal{}
+	coord := transaction.NewCoordinator()
+	res, err := coord.Execute(context.Background(), transaction.Saga{
+		ID:       "d1",
+		Deadline: time.Now().Add(10 * time.Millisecond),
+		Steps: []transaction.Step{
+			{Name: "a", Action: func(context.Context) (any, error) {
+				j.add("a")
+				time.Sleep(20 * time.Millisecond)
+				return nil, nil
+			}, Compensate: func(context.Context, any) error { j.add("!a"); return nil }},
+			j.step("b", nil),
+		},
+	})
+	assert.Error(t, err).Is(context.DeadlineExceeded)
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	// b never started; compensation ran although the deadline had passed.
+	assert.Slice(t, j.list()).Equal([]string{"a", "!a"})
+}
+
+// paymentService answers charge requests on "payments.charge" with a reply
+// on "payments.replies", failing those whose payload is "declined".
+func paymentService(t *testing.T, b messaging.Binder) {
+	t.Helper()
+	ctx := context.Background()
+	sub, err := b.NewSubscriber(ctx, "payments.charge", "payments")
+	assert.Error(t, err).Nil()
+	pub, err := b.NewPublisher(ctx, "payments.replies")
+	assert.Error(t, err).Nil()
+	t.Cleanup(func() { _ = sub.Close(); _ = pub.Close() })
+	err = sub.Subscribe(ctx, func(ctx context.Context, req *messaging.Message) error {
+		reply := &messaging.Message{Payload: []byte("txn-" + string(req.Payload))}
+		reply.SetHeader(transaction.HeaderSagaID, req.Header(transaction.HeaderSagaID))
+		reply.SetHeader(transaction.HeaderSagaStep, req.Header(transaction.HeaderSagaStep))
+		if string(req.Payload) == "declined" {
+			reply.SetHeader(transaction.HeaderSagaError, "card declined")
+		}
+		return pub.Publish(ctx, reply)
+	})
+	assert.Error(t, err).Nil()
+}
+
+// chargeStep publishes payload as a correlated charge request and awaits the
+// payment service's reply.
+func chargeStep(b messaging.Binder, payload string, timeout time.Duration, compensated chan<- any) transaction.Step {
+	return transaction.Step{
+		Name:    "charge",
+		Timeout: timeout,
+		Await:   &transaction.Await{Source: "payments.replies"},
+		Action: func(ctx context.Context) (any, error) {
+			pub, err := b.NewPublisher(ctx, "payments.charge")
+			if err != nil {
+				return nil, err
+			}
+			defer pub.Close()
+			msg := &messaging.Message{Payload: []byte(payload)}
+			transaction.Correlate(ctx, msg)
+			return payload, pub.Publish(ctx, msg)
+		},
+		Compensate: func(_ context.Context, r any) error { compensated <- r; return nil },
+	}
+}
+
+func TestAwait_CompletesOnCorrelatedReply(t *testing.T) {
+	b := messaging.NewMemoryBinder(messaging.MemoryBinderConfig{})
+	paymentService(t, b)
+	coord := transaction.NewCoordinator(transaction.WithBinder(b))
+	compensated := make(chan any, 1)
+
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "a1", Steps: []transaction.Step{
+		chargeStep(b, "ok", time.Second, compensated),
+	}})
+	assert.Error(t, err).Nil()
+	assert.That(t, res.Status).Equal(transaction.StatusCommitted)
+	reply := res.StepResults["charge"].(transaction.Reply)
+	assert.That(t, reply.Sent).Equal("ok")
+	assert.String(t, string(reply.Payload)).Equal("txn-ok")
+}
+
+func TestAwait_ErrorReplyCompensatesTheStep(t *testing.T) {
+	b := messaging.NewMemoryBinder(messaging.MemoryBinderConfig{})
+	paymentService(t, b)
+	coord := transaction.NewCoordinator(transaction.WithBinder(b))
+	compensated := make(chan any, 1)
+
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "a2", Steps: []transaction.Step{
+		chargeStep(b, "declined", time.Second, compensated),
+	}})
+	assert.Error(t, err).String("card declined")
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	// The request went out, so the step is compensated with what it sent.
+	r := (<-compensated).(transaction.Reply)
+	assert.That(t, r.Sent).Equal("declined")
+	assert.That(t, r.Payload).Nil()
+}
+
+func TestAwait_TimeoutCompensatesTheStep(t *testing.T) {
+	b := messaging.NewMemoryBinder(messaging.MemoryBinderConfig{}) // nobody replies
+	coord := transaction.NewCoordinator(transaction.WithBinder(b))
+	compensated := make(chan any, 1)
+
+	res, err := coord.Execute(context.Background(), transaction.Saga{ID: "a3", Steps: []transaction.Step{
+		chargeStep(b, "lost", 20*time.Millisecond, compensated),
+	}})
+	assert.Error(t, err).Is(context.DeadlineExceeded)
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	assert.That(t, (<-compensated).(transaction.Reply).Sent).Equal("lost")
+}
+
+func TestRecover_CompensatesEveryInFlightBranch(t *testing.T) {
+	store := &transaction.MemoryStore{}
+	ctx := context.Background()
+	// Crashed while reserve and charge ran in parallel, after create.
+	assert.Error(t, store.Save(ctx, "p1", transaction.Snapshot{
+		ID:          "p1",
+		Status:      transaction.StatusRunning,
+		Completed:   []string{"create"},
+		InProgress:  "reserve",
+		InFlight:    []string{"reserve", "charge"},
+		StepResults: map[string]any{"create": "rcreate"},
+	})).Nil()
+
+	j := &journal{}
+	coord := transaction.NewCoordinator(transaction.WithStore(store))
+	res, err := coord.Recover(ctx, transaction.Saga{ID: "p1", Steps: []transaction.Step{
+		j.step("create", nil),
+		transaction.Parallel("reserve-and-charge",
+			transaction.Branch(j.step("reserve", nil)),
+			transaction.Branch(j.step("charge", nil)),
+		),
+	}})
+	assert.Error(t, err).Nil()
+	assert.That(t, res.Status).Equal(transaction.StatusCompensated)
+	assert.Slice(t, j.list()).Equal([]string{"!charge", "!reserve", "!create"})
+}
+
+func TestExecute_PersistsParallelInFlight(t *testing.T) {
+	store := &transaction.MemoryStore{}
+	bothRunning := make(chan struct{})
+	var once sync.Once
+	var running sync.WaitGroup
+	running.Add(2)
+	var seen transaction.Snapshot
+	probe := func(name string) transaction.Step {
+		return transaction.Step{Name: name, Action: func(ctx context.Context) (any, error) {
+			running.Done()
+			running.Wait()
+			once.Do(func() {
+				seen, _ = store.Load(ctx, "p2")
+				close(bothRunning)
+			})
+			<-bothRunning
+			return nil, nil
+		}}
+	}
+	coord := transaction.NewCoordinator(transaction.WithStore(store))
+	_, err := coord.Execute(context.Background(), transaction.Saga{ID: "p2", Steps: []transaction.Step{
+		transaction.Parallel("fan-out", transaction.Branch(probe("x")), transaction.Branch(probe("y"))),
+	}})
+	assert.Error(t, err).Nil()
+	names := slices.Sorted(slices.Values(seen.InFlight))
+	assert.Slice(t, names).Equal([]string{"x", "y"})
+	assert.That(t, seen.InProgress).Equal(seen.InFlight[0])
+}
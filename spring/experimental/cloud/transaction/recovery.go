@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+)
+
+// RecoveryConfig configures a [RecoveryWorker]. Store, Coordinator and
+// Registry are required.
+type RecoveryConfig struct {
+	// Store is the saga log scanned for interrupted sagas.
+	Store Store
+
+	// Coordinator recovers them; it must write to the same Store.
+	Coordinator Coordinator
+
+	// Registry supplies the step definitions the log cannot hold.
+	Registry *StepRegistry
+
+	// StaleAfter is how long a running saga's log must go unwritten before it
+	// is considered abandoned. The log is written when a step starts and
+	// ends, so StaleAfter must exceed the longest step, async waits included,
+	// or a saga still driven by a live replica gets compensated under it —
+	// unless the Coordinator refreshes the log with [WithHeartbeat].
+	// Default 5m.
+	StaleAfter time.Duration
+
+	// Interval is the pause between scans. Default 30s.
+	Interval time.Duration
+
+	// Locker, when set, elects a single recovering replica through
+	// [lock.Election] on LockKey; without it every replica scans, which is
+	// safe — recovery is idempotent — but compensates concurrently.
+	Locker  lock.Locker
+	LockKey string // Default "transaction:saga:recovery"
+
+	// OnRecovered is called for every stale saga the worker acted on, with
+	// its log, the recovery outcome and the error when it could not be
+	// recovered (e.g. its Method is no longer registered). Optional.
+	OnRecovered func(snap Snapshot, res Result, err error)
+}
+
+// RecoveryWorker periodically compensates sagas a crashed process left
+// running. Where a one-shot scan at startup only sees what the restarting
+// replica itself left behind, the worker also catches sagas of replicas that
+// never came back.
+type RecoveryWorker struct {
+	cfg RecoveryConfig
+}
+
+// NewRecoveryWorker builds a [RecoveryWorker], applying defaults. It panics
+// if Store, Coordinator or Registry is unset, since such a worker could never
+// recover anything.
+func NewRecoveryWorker(cfg RecoveryConfig) *RecoveryWorker {
+	if cfg.Store == nil {
+		panic("transaction: recovery worker requires a Store")
+	}
+	if cfg.Coordinator == nil {
+		panic("transaction: recovery worker requires a Coordinator")
+	}
+	if cfg.Registry == nil {
+		panic("transaction: recovery worker requires a Registry")
+	}
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = 5 * time.Minute
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	if cfg.LockKey == "" {
+		cfg.LockKey = "transaction:saga:recovery"
+	}
+	return &RecoveryWorker{cfg: cfg}
+}
+
+// Run scans until ctx is done and returns ctx.Err(). With a Locker it scans
+// only while this replica is the elected leader.
+func (w *RecoveryWorker) Run(ctx context.Context) error {
+	if w.cfg.Locker == nil {
+		w.loop(ctx)
+		return ctx.Err()
+	}
+	return lock.NewElection(lock.ElectionConfig{
+		Locker:    w.cfg.Locker,
+		Key:       w.cfg.LockKey,
+		OnElected: w.loop,
+	}).Run(ctx)
+}
+
+// loop scans the Store until ctx is done.
+func (w *RecoveryWorker) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		_, _ = w.RecoverOnce(ctx)
+		t := time.NewTimer(w.cfg.Interval)
+		select {
+		case <-ctx.Done():
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+// RecoverOnce recovers every running saga whose log is older than StaleAfter
+// and returns how many it acted on. A saga that cannot be recovered is
+// reported to OnRecovered and retried on the next scan.
+func (w *RecoveryWorker) RecoverOnce(ctx context.Context) (int, error) {
+	pending, err := w.cfg.Store.Pending(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("transaction: scan saga log: %w", err)
+	}
+	cutoff := time.Now().Add(-w.cfg.StaleAfter)
+	n := 0
+	for _, snap := range pending {
+		if ctx.Err() != nil {
+			return n, ctx.Err()
+		}
+		if snap.UpdatedAt.After(cutoff) {
+			continue // possibly still driven by a live replica
+		}
+		n++
+		var res Result
+		steps, ok := w.cfg.Registry.Lookup(snap.Method)
+		if ok {
+			res, err = w.cfg.Coordinator.Recover(ctx, Saga{ID: snap.ID, Method: snap.Method, Steps: steps})
+		} else {
+			err = fmt.Errorf("transaction: saga %s: no steps registered for method %q", snap.ID, snap.Method)
+		}
+		if w.cfg.OnRecovered != nil {
+			w.cfg.OnRecovered(snap, res, err)
+		}
+	}
+	return n, nil
+}
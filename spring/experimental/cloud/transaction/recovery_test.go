@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transaction_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestRecoveryWorker_RecoversOnlyStaleSagas(t *testing.T) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	stale := time.Now().Add(-time.Hour)
+	for _, snap := range []transaction.Snapshot{
+		{ID: "stale", Method: "Svc.Do", Status: transaction.StatusRunning,
+			Completed: []string{"a"}, StepResults: map[string]any{"a": "ra"}, UpdatedAt: stale},
+		{ID: "live", Method: "Svc.Do", Status: transaction.StatusRunning,
+			Completed: []string{"a"}, StepResults: map[string]any{"a": "ra"}, UpdatedAt: time.Now()},
+		{ID: "orphan", Method: "Gone.Do", Status: transaction.StatusRunning, UpdatedAt: stale},
+	} {
+		assert.Error(t, store.Save(ctx, snap.ID, snap)).Nil()
+	}
+
+	j := &journal{}
+	reg := transaction.NewStepRegistry()
+	reg.Register("Svc.Do", j.step("a", nil))
+
+	var mu sync.Mutex
+	outcomes := map[string]error{}
+	w := transaction.NewRecoveryWorker(transaction.RecoveryConfig{
+		Store:       store,
+		Coordinator: transaction.NewCoordinator(transaction.WithStore(store)),
+		Registry:    reg,
+		StaleAfter:  time.Minute,
+		OnRecovered: func(snap transaction.Snapshot, _ transaction.Result, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			outcomes[snap.ID] = err
+		},
+	})
+	n, err := w.RecoverOnce(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(2)
+	assert.Error(t, outcomes["stale"]).Nil()
+	// A saga whose steps are no longer registered is reported, not dropped.
+	assert.Error(t, outcomes["orphan"]).NotNil()
+	_, seen := outcomes["live"]
+	assert.That(t, seen).False()
+	assert.Slice(t, j.list()).Equal([]string{"!a"})
+
+	snap, err := store.Load(ctx, "stale")
+	assert.Error(t, err).Nil()
+	assert.That(t, snap.Status).Equal(transaction.StatusCompensated)
+	snap, err = store.Load(ctx, "live")
+	assert.Error(t, err).Nil()
+	assert.That(t, snap.Status).Equal(transaction.StatusRunning)
+}
+
+func TestRecoveryWorker_RunUnderElection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := &transaction.MemoryStore{}
+	assert.Error(t, store.Save(ctx, "s1", transaction.Snapshot{
+		ID: "s1", Method: "Svc.Do", Status: transaction.StatusRunning,
+		InProgress: "a", InFlight: []string{"a"}, UpdatedAt: time.Now().Add(-time.Hour),
+	})).Nil()
+
+	reg := transaction.NewStepRegistry()
+	recovered := make(chan string, 1)
+	reg.Register("Svc.Do", transaction.Step{Name: "a", Action: act("ra"),
+		Compensate: func(context.Context, any) error { return nil }})
+
+	w := transaction.NewRecoveryWorker(transaction.RecoveryConfig{
+		Store:       store,
+		Coordinator: transaction.NewCoordinator(transaction.WithStore(store)),
+		Registry:    reg,
+		Interval:    10 * time.Millisecond,
+		Locker:      lock.NewMemoryLocker(),
+		OnRecovered: func(snap transaction.Snapshot, res transaction.Result, err error) {
+			if err == nil && res.Status == transaction.StatusCompensated {
+				recovered <- snap.ID
+			}
+		},
+	})
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	select {
+	case id := <-recovered:
+		assert.That(t, id).Equal("s1")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the elected worker did not recover the saga")
+	}
+	cancel()
+	<-done
+}
+
+func TestRecoveryWorker_HeartbeatKeepsLongStepFresh(t *testing.T) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	coord := transaction.NewCoordinator(transaction.WithStore(store), transaction.WithHeartbeat(5*time.Millisecond))
+	reg := transaction.NewStepRegistry()
+	w := transaction.NewRecoveryWorker(transaction.RecoveryConfig{
+		Store:       store,
+		Coordinator: coord,
+		Registry:    reg,
+		StaleAfter:  30 * time.Millisecond,
+	})
+
+	// The step outlives StaleAfter; the heartbeat keeps its log fresh, so
+	// a scan in the middle of it leaves the saga alone.
+	var scanned int
+	step := transaction.Step{Name: "slow", Action: func(ctx context.Context) (any, error) {
+		time.Sleep(60 * time.Millisecond)
+		n, err := w.RecoverOnce(ctx)
+		scanned = n
+		return "done", err
+	}, Compensate: func(context.Context, any) error { return nil }}
+	reg.Register("Svc.Slow", step)
+
+	res, err := coord.Execute(ctx, transaction.Saga{ID: "s1", Method: "Svc.Slow", Steps: []transaction.Step{step}})
+	assert.Error(t, err).Nil()
+	assert.That(t, res.Status).Equal(transaction.StatusCommitted)
+	assert.That(t, scanned).Equal(0)
+}
+
+func TestNewRecoveryWorker_PanicsWithoutStore(t *testing.T) {
+	assert.Panic(t, func() {
+		transaction.NewRecoveryWorker(transaction.RecoveryConfig{Registry: transaction.NewStepRegistry()})
+	}, "requires a Store")
+}
@@ -55,13 +55,25 @@ type Snapshot struct {
 	// confirmed complete when the snapshot was written (empty when none is in
 	// flight). Because a crash may have interrupted it after a side effect,
 	// recovery compensates it too — before the completed steps — with a nil
-	// result, since its Action return value was never recorded.
+	// result, since its Action return value was never recorded. With parallel
+	// branches several steps can be in flight; this is the earliest started.
 	InProgress string
 
+	// InFlight lists every step in flight, in start order: InProgress alone
+	// for a linear saga, one per running branch for a parallel one. Recovery
+	// compensates all of them; when it is empty it falls back to InProgress,
+	// which is all a log from a Store that does not persist InFlight holds.
+	InFlight []string
+
 	// StepResults maps each completed step's name to its Action result, so
 	// compensation can target exactly what was created.
 	StepResults map[string]any
 
+	// Errors holds the rendered [StepError]s gathered so far, in the order they
+	// occurred — the failing actions and then any failed compensations — so an
+	// operator can see why a saga rolled back or is stuck.
+	Errors []string
+
 	// UpdatedAt is when this snapshot was written.
 	UpdatedAt time.Time
 }
@@ -89,6 +101,15 @@ type Store interface {
 	Pending(ctx context.Context) ([]Snapshot, error)
 }
 
+// Lister is an optional [Store] extension listing every stored snapshot,
+// including the compensated and failed ones Pending leaves out, so an
+// operator can find a saga that needs attention. A Store without it only
+// exposes its pending sagas.
+type Lister interface {
+	// List returns every stored snapshot. The order is unspecified.
+	List(ctx context.Context) ([]Snapshot, error)
+}
+
 // MemoryStore is a zero-dependency, concurrency-safe in-process [Store]. State is
 // lost when the process exits, so it does not provide crash recovery; back the
 // Store with a durable implementation in production. The zero value is ready to
@@ -98,6 +119,8 @@ type MemoryStore struct {
 	snaps map[string]Snapshot
 }
 
+var _ Lister = (*MemoryStore)(nil)
+
 // Save implements [Store]. It stores a copy so later mutations of snap by the
 // caller do not alias stored state.
 func (m *MemoryStore) Save(_ context.Context, id string, snap Snapshot) error {
@@ -143,12 +166,30 @@ func (m *MemoryStore) Pending(_ context.Context) ([]Snapshot, error) {
 	return out, nil
 }
 
+// List implements [Lister], returning copies of every snapshot, terminal ones
+// included.
+func (m *MemoryStore) List(_ context.Context) ([]Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Snapshot, 0, len(m.snaps))
+	for _, snap := range m.snaps {
+		out = append(out, cloneSnapshot(snap))
+	}
+	return out, nil
+}
+
 // cloneSnapshot deep-copies the slice/map fields so stored and returned
 // snapshots do not share backing arrays with the caller.
 func cloneSnapshot(snap Snapshot) Snapshot {
 	if snap.Completed != nil {
 		snap.Completed = append([]string(nil), snap.Completed...)
 	}
+	if snap.InFlight != nil {
+		snap.InFlight = append([]string(nil), snap.InFlight...)
+	}
+	if snap.Errors != nil {
+		snap.Errors = append([]string(nil), snap.Errors...)
+	}
 	if snap.StepResults != nil {
 		results := make(map[string]any, len(snap.StepResults))
 		maps.Copy(results, snap.StepResults)
@@ -237,7 +237,7 @@ func (c *coordinator) persist(ctx context.Context, t Transaction, status Status,
 	if c.store == nil {
 		return
 	}
-	_ = c.store.Save(ctx, t.ID, c.snapshot(t, status, tried, inProgress))
+	_ = c.store.Save(ctx, t.ID, c.snapshot(t, status, tried, inProgress, nil))
 }
 
 // finish writes the terminal transaction log. A committed transaction's log is
@@ -251,17 +251,17 @@ func (c *coordinator) finish(ctx context.Context, t Transaction, res *Result, tr
 		_ = c.store.Delete(ctx, t.ID)
 		return
 	}
-	_ = c.store.Save(ctx, t.ID, c.snapshot(t, res.Status, tried, ""))
+	_ = c.store.Save(ctx, t.ID, c.snapshot(t, res.Status, tried, "", res.Errors))
 }
 
-func (c *coordinator) snapshot(t Transaction, status Status, tried []triedParticipant, inProgress string) Snapshot {
+func (c *coordinator) snapshot(t Transaction, status Status, tried []triedParticipant, inProgress string, errs []ParticipantError) Snapshot {
 	names := make([]string, len(tried))
 	results := make(map[string]any, len(tried))
 	for i, tp := range tried {
 		names[i] = tp.participant.Name
 		results[tp.participant.Name] = tp.result
 	}
-	return Snapshot{
+	snap := Snapshot{
 		ID:         t.ID,
 		Method:     t.Method,
 		Status:     status,
@@ -270,6 +270,10 @@ func (c *coordinator) snapshot(t Transaction, status Status, tried []triedPartic
 		TryResults: results,
 		UpdatedAt:  time.Now(),
 	}
+	for _, e := range errs {
+		snap.Errors = append(snap.Errors, e.Error())
+	}
+	return snap
 }
 
 // validate rejects a transaction whose participants are not fully specified. A
@@ -65,6 +65,11 @@ type Snapshot struct {
 	// second phase can target exactly what was reserved.
 	TryResults map[string]any
 
+	// Errors holds the rendered [ParticipantError]s of the last run, in the
+	// order they occurred — the failing try and then any failed confirm or
+	// cancel — so an operator can see why a transaction is stuck.
+	Errors []string
+
 	// UpdatedAt is when this snapshot was written.
 	UpdatedAt time.Time
 }
@@ -92,6 +97,15 @@ type Store interface {
 	Pending(ctx context.Context) ([]Snapshot, error)
 }
 
+// Lister is an optional [Store] extension listing every stored snapshot,
+// including the cancelled and failed ones Pending leaves out, so an operator
+// can find a transaction that needs attention. A Store without it only
+// exposes its pending transactions.
+type Lister interface {
+	// List returns every stored snapshot. The order is unspecified.
+	List(ctx context.Context) ([]Snapshot, error)
+}
+
 // MemoryStore is a zero-dependency, concurrency-safe in-process [Store]. State is
 // lost when the process exits, so it does not provide crash recovery; back the
 // Store with a durable implementation in production. The zero value is ready to
@@ -101,6 +115,8 @@ type MemoryStore struct {
 	snaps map[string]Snapshot
 }
 
+var _ Lister = (*MemoryStore)(nil)
+
 // Save implements [Store]. It stores a copy so later mutations of snap by the
 // caller do not alias stored state.
 func (m *MemoryStore) Save(_ context.Context, id string, snap Snapshot) error {
@@ -146,12 +162,27 @@ func (m *MemoryStore) Pending(_ context.Context) ([]Snapshot, error) {
 	return out, nil
 }
 
+// List implements [Lister], returning copies of every snapshot, terminal ones
+// included.
+func (m *MemoryStore) List(_ context.Context) ([]Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Snapshot, 0, len(m.snaps))
+	for _, snap := range m.snaps {
+		out = append(out, cloneSnapshot(snap))
+	}
+	return out, nil
+}
+
 // cloneSnapshot deep-copies the slice/map fields so stored and returned
 // snapshots do not share backing arrays with the caller.
 func cloneSnapshot(snap Snapshot) Snapshot {
 	if snap.Tried != nil {
 		snap.Tried = append([]string(nil), snap.Tried...)
 	}
+	if snap.Errors != nil {
+		snap.Errors = append([]string(nil), snap.Errors...)
+	}
 	if snap.TryResults != nil {
 		results := make(map[string]any, len(snap.TryResults))
 		maps.Copy(results, snap.TryResults)
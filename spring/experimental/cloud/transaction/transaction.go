@@ -28,6 +28,14 @@
 // @Transactional cannot — MQ publishes, outbound HTTP calls, cache writes and
 // other non-SQL downstreams.
 //
+// # Flows
+//
+// Beyond a plain sequence, a step can fan out into [Parallel] branches, be
+// skipped by a condition, be bounded by a timeout, or wait for a reply message
+// through a messaging Binder; later steps read earlier results with
+// [StepOutput]. A [RecoveryWorker] compensates sagas a crashed process left
+// running.
+//
 // # What it is not
 //
 //   - It provides no isolation. Intermediate states of a Saga are visible to
@@ -51,6 +59,7 @@ package transaction
 
 import (
 	"context"
+	"time"
 
 	"go-spring.org/spring/experimental/cloud/resilience"
 )
@@ -63,7 +72,10 @@ import (
 type RetryPolicy = resilience.Policy
 
 // Step is one compensable unit of a [Saga]. Action is the forward operation;
-// Compensate undoes it if a later step fails.
+// Compensate undoes it if a later step fails. Beyond that plain form a step may
+// be conditional (When), bounded (Timeout), asynchronous (Await) or a
+// fan-out/fan-in node over parallel branches (Parallel, built with
+// [Parallel]).
 //
 // Compensate MUST be idempotent: it can be retried (per Retry) and, after crash
 // recovery, replayed against a resource that may already be in the compensated
@@ -91,6 +103,29 @@ type Step struct {
 	// transient downstream error does not immediately escalate to manual
 	// intervention.
 	Retry RetryPolicy
+
+	// Timeout bounds the step's Action, retries included and, for an async
+	// step, the wait for its completing message. A step that times out fails
+	// like any other. 0 means no bound beyond the saga's own deadline.
+	Timeout time.Duration
+
+	// When, if set, is evaluated just before the step would run; false skips
+	// it, so it is neither run nor compensated. It can read earlier steps'
+	// results through [StepOutput].
+	When func(ctx context.Context) bool
+
+	// Await, if set, makes the step asynchronous: its Action only starts the
+	// work (typically publishing a request with [Correlate]) and the step
+	// completes when a correlated message arrives on Await.Source. The
+	// coordinator needs a Binder ([WithBinder]) to run such a step.
+	Await *Await
+
+	// Parallel, if set, makes the step a fan-out/fan-in node: every branch
+	// runs its steps in order, all branches concurrently, and the step
+	// completes when every branch has. It has no Action or Compensate of its
+	// own — compensation reaches the branch steps themselves. Build it with
+	// [Parallel] and [Branch].
+	Parallel [][]Step
 }
 
 // Saga is an ordered set of [Step]s executed as a single logical operation. If
@@ -111,8 +146,14 @@ type Saga struct {
 	// [GlobalTransactional] fills it automatically from the joinpoint method.
 	Method string
 
-	// Steps are executed in slice order. Compensation runs in reverse.
+	// Steps are executed in slice order. Compensation runs in reverse order of
+	// completion, which for parallel branches interleaves them as they ran.
 	Steps []Step
+
+	// Deadline, if set, is when the saga must have committed. Once it passes
+	// no further step starts, the running ones see their context expire and
+	// the saga compensates. Compensation itself is not bound by it.
+	Deadline time.Time
 }
 
 // Status is the terminal outcome of executing a [Saga].
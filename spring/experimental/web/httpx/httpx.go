@@ -26,8 +26,9 @@
 //     [discovery.Resolver] keeps a fresh endpoint snapshot via Watch; in mesh
 //     mode a sidecar owns discovery+LB, so this layer is skipped;
 //   - loadbalance — a [loadbalance.Pool] picks one live endpoint per request
-//     (any of the registered strategies, plus optional outlier ejection) and the
-//     transport rewrites the request host to it;
+//     (any of the registered strategies, plus optional outlier ejection and
+//     routing rules) and the transport rewrites the request host to it; the
+//     lane on the request context is forwarded as a header;
 //   - resilience — an optional [resilience] executor wraps the whole chain so
 //     rate limiting, circuit breaking and retry protect every call; because it
 //     sits outside the balancer, a retry re-picks a fresh endpoint and the
@@ -75,7 +76,8 @@ type Config struct {
 	Discovery string
 
 	// Balancer names the registered load-balancing strategy (round_robin,
-	// least_conn, consistent_hash, weighted, zone_aware). Defaults to round_robin.
+	// least_conn, consistent_hash, weighted, zone_aware, p2c). Defaults to
+	// round_robin; p2c steers traffic away from slow endpoints.
 	Balancer string
 
 	// EjectThreshold is the consecutive-failure count that ejects an endpoint
@@ -83,9 +85,28 @@ type Config struct {
 	EjectThreshold int
 
 	// EjectFor is how long an ejected endpoint stays out before a half-open
-	// trial. Ignored when EjectThreshold is 0.
+	// trial; repeated ejections multiply it up to MaxEjectFor. Ignored when
+	// ejection is disabled.
 	EjectFor time.Duration
 
+	// MaxEjectFor caps the growing ejection time (default 300s).
+	MaxEjectFor time.Duration
+
+	// MaxEjectionPercent caps the share of endpoints ejected at once. 0 means
+	// no cap.
+	MaxEjectionPercent int
+
+	// SuccessRateStdevFactor ejects an endpoint whose success rate falls this
+	// many standard deviations below its peers' (Envoy uses 1.9). 0 disables
+	// success-rate ejection.
+	SuccessRateStdevFactor float64
+
+	// Router applies metadata routing rules (lanes, weighted canary splits)
+	// before the balancer picks. Request headers are its attributes. Nil
+	// routes nothing; the caller may update its rules while the transport is
+	// in use.
+	Router *loadbalance.Router
+
 	// ResilienceDriver names the registered resilience backend to protect calls
 	// with. Empty disables resilience (the chain is a transparent pass-through).
 	ResilienceDriver string
@@ -110,6 +131,9 @@ func NewTransport(cfg Config) (rt http.RoundTripper, close func() error, err err
 	if base == nil {
 		base = http.DefaultTransport
 	}
+	// The lane on the request context rides along to the downstream service,
+	// whichever addressing mode is in use.
+	base = &laneTransport{base: base}
 
 	var rsv *discovery.Resolver
 	closeFns := []func() error{}
@@ -140,13 +164,19 @@ func NewTransport(cfg Config) (rt http.RoundTripper, close func() error, err err
 		}
 
 		var opts []loadbalance.PoolOption
-		if cfg.EjectThreshold > 0 {
+		if cfg.EjectThreshold > 0 || cfg.SuccessRateStdevFactor > 0 {
 			t := loadbalance.NewTracker(loadbalance.TrackerConfig{
-				Threshold: cfg.EjectThreshold,
-				EjectFor:  cfg.EjectFor,
+				Threshold:              cfg.EjectThreshold,
+				EjectFor:               cfg.EjectFor,
+				MaxEjectFor:            cfg.MaxEjectFor,
+				MaxEjectionPercent:     cfg.MaxEjectionPercent,
+				SuccessRateStdevFactor: cfg.SuccessRateStdevFactor,
 			})
 			opts = append(opts, loadbalance.WithTracker(t))
 		}
+		if cfg.Router != nil {
+			opts = append(opts, loadbalance.WithRouter(cfg.Router))
+		}
 		// *discovery.Resolver satisfies loadbalance.EndpointSource via its
 		// Endpoints() method, so the Pool follows the naming service in real time.
 		pool := loadbalance.NewPool(rsv, bal, opts...)
@@ -188,7 +218,7 @@ type balancedTransport struct {
 }
 
 func (t *balancedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	res, err := t.pool.Pick(loadbalance.PickInfo{Ctx: req.Context()})
+	res, err := t.pool.Pick(loadbalance.PickInfo{Ctx: req.Context(), Attr: req.Header.Get})
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +251,36 @@ func (t *fixedHostTransport) RoundTrip(req *http.Request) (*http.Response, error
 	return t.base.RoundTrip(r)
 }
 
+// laneTransport sets the [loadbalance.LaneHeader] header from the lane carried
+// by the request context, unless the caller set the header itself.
+type laneTransport struct {
+	base http.RoundTripper
+}
+
+func (t *laneTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	lane := loadbalance.LaneFrom(req.Context())
+	if lane == "" || req.Header.Get(loadbalance.LaneHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+	r := req.Clone(req.Context())
+	r.Header.Set(loadbalance.LaneHeader, lane)
+	return t.base.RoundTrip(r)
+}
+
+// NewLaneHandler returns a handler that puts the lane of each inbound request,
+// taken from the [loadbalance.LaneHeader] header, on its context before
+// calling next. A server that calls downstream services through transports
+// built by [NewTransport] with the request context thereby keeps the whole
+// call chain on one lane.
+func NewLaneHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lane := r.Header.Get(loadbalance.LaneHeader); lane != "" {
+			r = r.WithContext(loadbalance.WithLane(r.Context(), lane))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func closeAll(fns []func() error) error {
 	var firstErr error
 	for i := len(fns) - 1; i >= 0; i-- {
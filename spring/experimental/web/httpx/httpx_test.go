@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"go-spring.org/spring/cloud/discovery"
+	"go-spring.org/spring/experimental/cloud/loadbalance"
 	"go-spring.org/spring/experimental/cloud/resilience"
 	"go-spring.org/stdlib/testing/assert"
 )
@@ -47,12 +48,14 @@ func (s stubDiscovery) Watch(ctx context.Context, _ string, _ ...discovery.Optio
 type recordRT struct {
 	mu     sync.Mutex
 	hosts  []string
+	lanes  []string
 	status int
 }
 
 func (r *recordRT) RoundTrip(req *http.Request) (*http.Response, error) {
 	r.mu.Lock()
 	r.hosts = append(r.hosts, req.URL.Host)
+	r.lanes = append(r.lanes, req.Header.Get(loadbalance.LaneHeader))
 	r.mu.Unlock()
 	status := r.status
 	if status == 0 {
@@ -114,6 +117,43 @@ func TestNewTransport_DiscoveryRewritesHost(t *testing.T) {
 	})
 }
 
+func TestNewTransport_LaneRouting(t *testing.T) {
+	discovery.RegisterDiscovery("stub-httpx-lane", stubDiscovery{eps: []discovery.Endpoint{
+		{Addr: "10.0.0.1:9000", Healthy: true},
+		{Addr: "10.0.0.2:9000", Healthy: true, Metadata: map[string]string{"lane": "gray"}},
+	}})
+	router, err := loadbalance.NewRouter(loadbalance.RoutingConfig{LaneKey: "lane"})
+	assert.That(t, err).Nil()
+
+	rec := &recordRT{}
+	rt, closeFn, err := NewTransport(Config{
+		ServiceName: "order-svc",
+		Discovery:   "stub-httpx-lane",
+		Router:      router,
+		Base:        rec,
+	})
+	assert.That(t, err).Nil()
+	defer func() { _ = closeFn() }()
+
+	// An inbound request on the gray lane calls downstream with its context.
+	h := NewLaneHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, "http://order-svc/ping", nil)
+		_, err := rt.RoundTrip(req)
+		assert.That(t, err).Nil()
+	}))
+	in, _ := http.NewRequest(http.MethodGet, "http://self/", nil)
+	in.Header.Set(loadbalance.LaneHeader, "gray")
+	h.ServeHTTP(nil, in)
+
+	// Untagged traffic stays off the lane instance.
+	req, _ := http.NewRequest(http.MethodGet, "http://order-svc/ping", nil)
+	_, err = rt.RoundTrip(req)
+	assert.That(t, err).Nil()
+
+	assert.That(t, rec.hosts).Equal([]string{"10.0.0.2:9000", "10.0.0.1:9000"})
+	assert.That(t, rec.lanes).Equal([]string{"gray", ""})
+}
+
 func TestNewTransport_FailFast(t *testing.T) {
 	// ServiceName set but discovery backend not registered -> fail fast.
 	_, _, err := NewTransport(Config{ServiceName: "x", Discovery: "no-such-backend"})
@@ -51,6 +51,11 @@
 //	GET  /configprops merged configuration as a nested tree, secrets masked.
 //	GET  /threaddump  goroutine stack dump (text/plain), the Go analogue of a
 //	                  JVM thread dump.
+//	GET  /logfile     recent events kept by log.RingBufferAppender (text, or
+//	                  JSON with ?format=json), filterable by tag/level/since.
+//	GET  /logs/tail   Server-Sent Events stream of new ring-buffer events.
+//	GET  /logging/metrics  async-logger queue/backpressure and per-appender
+//	                  write counters from log.Metrics.
 //
 // Health indicators are contributed by other beans: any bean exported as
 // health.Indicator (a redis client wrapper, a gorm pool wrapper, ...) is
@@ -68,8 +73,8 @@ import (
 	"time"
 
 	"go-spring.org/log"
-	"go-spring.org/spring/cloud/actuator/health"
 	"go-spring.org/spring/cloud/actuator/endpoint"
+	"go-spring.org/spring/cloud/actuator/health"
 	"go-spring.org/spring/gs"
 	"go-spring.org/stdlib/errutil"
 )
@@ -84,12 +89,28 @@ func init() {
 	gs.Provide(&Server{}).
 		Condition(gs.OnProperty("spring.actuator.addr")).
 		Export(gs.As[gs.Server]())
+
+	// Recent-log endpoints backed by log.RingBufferAppender. They are plain
+	// endpoint.Endpoint contributions so an app that serves its own admin mux
+	// can mount them without the actuator server.
+	gs.Provide(&LogFileEndpoint{}).
+		Condition(gs.OnProperty("spring.actuator.addr")).
+		Export(gs.As[endpoint.Endpoint]())
+	gs.Provide(&LogTailEndpoint{}).
+		Condition(gs.OnProperty("spring.actuator.addr")).
+		Export(gs.As[endpoint.Endpoint]())
+	gs.Provide(&LogMetricsEndpoint{}).
+		Condition(gs.OnProperty("spring.actuator.addr")).
+		Export(gs.As[endpoint.Endpoint]())
 }
 
 // checkTimeout bounds a single /readiness sweep across all indicators so one
 // slow dependency cannot stall the probe past a typical probe timeout.
 const checkTimeout = 3 * time.Second
 
+// stopTimeout bounds how long Stop waits for in-flight requests to finish.
+const stopTimeout = 5 * time.Second
+
 // Server serves the actuator endpoints on a dedicated management port.
 //
 // The exported fields are populated by the IoC container: Address from
@@ -169,6 +190,15 @@ func (s *Server) Run(ctx context.Context, sig gs.ReadySignal) error {
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
+	// Streaming endpoints (e.g. /logs/tail) only end when their client leaves;
+	// those that can be stopped are told as soon as shutdown begins, so Stop
+	// does not wait on a connected client.
+	for _, ep := range s.Endpoints {
+		if st, ok := ep.(interface{ Stop() }); ok {
+			s.svr.RegisterOnShutdown(st.Stop)
+		}
+	}
+
 	// Signal this server ready right away (so the app can proceed past its
 	// readiness barrier) and watch the shared channel: it closes once all
 	// servers are ready, at which point /readiness may return UP. We do NOT
@@ -188,13 +218,20 @@ func (s *Server) Run(ctx context.Context, sig gs.ReadySignal) error {
 	return errutil.Explain(err, "actuator: failed to serve on %s", s.Address)
 }
 
-// Stop gracefully shuts down the management server.
+// Stop gracefully shuts down the management server. Connections still open
+// after stopTimeout are closed forcibly.
 func (s *Server) Stop() error {
 	if s.svr == nil {
 		return nil
 	}
 	log.Debugf(context.Background(), actuatorTag, "stopping actuator server")
-	return s.svr.Shutdown(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	err := s.svr.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return s.svr.Close()
+	}
+	return err
 }
 
 // PreStop implements the framework's graceful-drain hook. It is called at the
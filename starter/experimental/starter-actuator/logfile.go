@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterActuator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-spring.org/log"
+	"go-spring.org/stdlib/errutil"
+)
+
+// logEntry is the JSON shape of a retained log event reported by /logfile.
+type logEntry struct {
+	Seq   uint64    `json:"seq"`
+	Time  time.Time `json:"time"`
+	Level string    `json:"level"`
+	Tag   string    `json:"tag"`
+	Text  string    `json:"text"`
+}
+
+// LogFileEndpoint serves GET /logfile: the recent events retained by the
+// log.RingBufferAppender instances configured in the logging setup, as text
+// (default) or JSON (?format=json). It is the Go analogue of Spring Boot's
+// /actuator/logfile, backed by memory instead of a file so it works in
+// containers with no shell access and no shared volume.
+//
+// Query parameters: appender (name, default every ring buffer), tag (exact or
+// "_*" suffix pattern), level (minimum level), since (RFC3339 time or a
+// duration such as 5m meaning "the last five minutes") and limit.
+type LogFileEndpoint struct{}
+
+func (e *LogFileEndpoint) Path() string { return "/logfile" }
+
+func (e *LogFileEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rings, f, err := parseLogQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var entries []log.RingEntry
+	for _, ring := range rings {
+		entries = append(entries, ring.Entries(f)...)
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		out := make([]logEntry, len(entries))
+		for i, x := range entries {
+			out[i] = logEntry{
+				Seq:   x.Seq,
+				Time:  x.Time,
+				Level: x.Level.UpperName(),
+				Tag:   x.Tag,
+				Text:  x.Text,
+			}
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	for _, x := range entries {
+		_, _ = fmt.Fprintln(w, x.Text)
+	}
+}
+
+// LogTailEndpoint serves GET /logs/tail: a Server-Sent Events stream of new
+// events as they are appended to the ring buffers. It accepts the same
+// appender, tag and level filters as /logfile. A client that falls behind
+// skips events instead of slowing the logger down. Streams end when the
+// client disconnects or [LogTailEndpoint.Stop] is called, which the actuator
+// server does when it begins shutting down.
+type LogTailEndpoint struct {
+	initOnce sync.Once
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// done returns the channel closed by Stop.
+func (e *LogTailEndpoint) done() chan struct{} {
+	e.initOnce.Do(func() { e.stop = make(chan struct{}) })
+	return e.stop
+}
+
+// Stop ends every open stream and makes later requests return at once, so a
+// connected client cannot hold up a graceful server shutdown.
+func (e *LogTailEndpoint) Stop() {
+	ch := e.done()
+	e.stopOnce.Do(func() { close(ch) })
+}
+
+func (e *LogTailEndpoint) Path() string { return "/logs/tail" }
+
+func (e *LogTailEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	rings, f, err := parseLogQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(rings) == 0 {
+		http.Error(w, "no RingBufferAppender configured", http.StatusNotFound)
+		return
+	}
+
+	// Fan the selected ring buffers into one channel. Each subscription is
+	// closed when the request ends or the appender stops (e.g. on refresh);
+	// the stream ends once every subscription is gone.
+	var wg sync.WaitGroup
+	merged := make(chan log.RingEntry, 256)
+	for _, ring := range rings {
+		ch, cancel := ring.Subscribe(256)
+		defer cancel()
+		wg.Go(func() {
+			for x := range ch {
+				select {
+				case merged <- x:
+				default:
+				}
+			}
+		})
+	}
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	done := e.done()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-done:
+			return
+		case <-stopped:
+			return
+		case x := <-merged:
+			if !f.Match(x) {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\n", x.Seq, x.Level.LowerName())
+			for line := range strings.SplitSeq(x.Text, "\n") {
+				_, _ = fmt.Fprintf(w, "data: %s\n", line)
+			}
+			_, _ = fmt.Fprint(w, "\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// parseLogQuery resolves the ring buffers and filter selected by the request's
+// query parameters.
+func parseLogQuery(r *http.Request) ([]*log.RingBufferAppender, log.RingFilter, error) {
+	q := r.URL.Query()
+
+	var f log.RingFilter
+	f.Tag = q.Get("tag")
+	if s := q.Get("level"); s != "" {
+		lr, err := log.ParseLevelRange(s)
+		if err != nil {
+			return nil, f, err
+		}
+		f.MinLevel = lr.MinLevel
+	}
+	if s := q.Get("since"); s != "" {
+		if d, err := time.ParseDuration(s); err == nil {
+			f.Since = time.Now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, s); err == nil {
+			f.Since = t
+		} else {
+			return nil, f, errutil.Explain(nil, "invalid since %q", s)
+		}
+	}
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, f, errutil.Explain(nil, "invalid limit %q", s)
+		}
+		f.Limit = n
+	}
+
+	rings := log.RingBuffers()
+	if name := q.Get("appender"); name != "" {
+		var selected []*log.RingBufferAppender
+		for _, ring := range rings {
+			if ring.GetName() == name {
+				selected = append(selected, ring)
+			}
+		}
+		rings = selected
+	}
+	return rings, f, nil
+}
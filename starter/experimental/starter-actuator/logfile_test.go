@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterActuator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-spring.org/log"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func startRing(t *testing.T) *log.RingBufferAppender {
+	ring := &log.RingBufferAppender{
+		AppenderBase: log.AppenderBase{Name: "recent", Layout: &log.TextLayout{}},
+		Capacity:     10,
+	}
+	assert.Error(t, ring.Start()).Nil()
+	t.Cleanup(ring.Stop)
+	now := time.Now()
+	ring.Append(&log.Event{Level: log.InfoLevel, Tag: "_app_def", Time: now, Fields: []log.Field{log.Msg("started")}})
+	ring.Append(&log.Event{Level: log.ErrorLevel, Tag: "_biz_order", Time: now, Fields: []log.Field{log.Msg("boom")}})
+	return ring
+}
+
+func TestLogFile_Text(t *testing.T) {
+	startRing(t)
+	rec := httptest.NewRecorder()
+	(&LogFileEndpoint{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logfile", nil))
+
+	assert.Number(t, rec.Code).Equal(http.StatusOK)
+	assert.String(t, rec.Body.String()).Contains("msg=started")
+	assert.String(t, rec.Body.String()).Contains("msg=boom")
+}
+
+func TestLogFile_Filter(t *testing.T) {
+	startRing(t)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/logfile?level=warn&since=1m&appender=recent", nil)
+	(&LogFileEndpoint{}).ServeHTTP(rec, req)
+
+	assert.Number(t, rec.Code).Equal(http.StatusOK)
+	assert.String(t, rec.Body.String()).Contains("msg=boom")
+	assert.That(t, strings.Contains(rec.Body.String(), "msg=started")).False()
+}
+
+func TestLogTail_Stop(t *testing.T) {
+	startRing(t)
+	ep := &LogTailEndpoint{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ep.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/logs/tail", nil))
+	}()
+
+	ep.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after Stop")
+	}
+	ep.Stop() // idempotent
+}
+
+func TestLogFile_BadQuery(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&LogFileEndpoint{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logfile?limit=x", nil))
+	assert.Number(t, rec.Code).Equal(http.StatusBadRequest)
+}
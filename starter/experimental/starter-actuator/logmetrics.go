@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterActuator
+
+import (
+	"net/http"
+
+	"go-spring.org/log"
+)
+
+// loggerMetricsEntry is the JSON shape of a logger's queue counters.
+// Durations are reported in milliseconds.
+type loggerMetricsEntry struct {
+	Enqueued            int64   `json:"enqueued"`
+	Discarded           int64   `json:"discarded"`
+	QueueDepth          int     `json:"queueDepth"`
+	QueueCapacity       int     `json:"queueCapacity"`
+	Blocked             int64   `json:"blocked"`
+	BlockedMillis       float64 `json:"blockedMillis"`
+	MaxEnqueueLatencyMs float64 `json:"maxEnqueueLatencyMillis"`
+}
+
+// appenderMetricsEntry is the JSON shape of an appender's write counters.
+// Durations are reported in milliseconds.
+type appenderMetricsEntry struct {
+	Writes         int64   `json:"writes"`
+	Errors         int64   `json:"errors"`
+	WriteMillis    float64 `json:"writeMillis"`
+	MaxWriteMillis float64 `json:"maxWriteMillis"`
+}
+
+// LogMetricsEndpoint serves GET /logging/metrics: the log.Metrics snapshot of
+// every configured logger (queue depth, discards, time blocked on a full
+// buffer) and appender (writes, errors, write latency), plus the total number
+// of errors the logging system reported. It makes silent log loss visible
+// while an incident is still going on rather than afterwards.
+type LogMetricsEndpoint struct{}
+
+func (e *LogMetricsEndpoint) Path() string { return "/logging/metrics" }
+
+func (e *LogMetricsEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	m := log.Metrics()
+	loggers := make(map[string]loggerMetricsEntry, len(m.Loggers))
+	for _, l := range m.Loggers {
+		loggers[l.Name] = loggerMetricsEntry{
+			Enqueued:            l.Enqueued,
+			Discarded:           l.Discarded,
+			QueueDepth:          l.QueueDepth,
+			QueueCapacity:       l.QueueCapacity,
+			Blocked:             l.Blocked,
+			BlockedMillis:       float64(l.BlockedTime.Microseconds()) / 1000,
+			MaxEnqueueLatencyMs: float64(l.MaxEnqueueLatency.Microseconds()) / 1000,
+		}
+	}
+	appenders := make(map[string]appenderMetricsEntry, len(m.Appenders))
+	for _, a := range m.Appenders {
+		appenders[a.Name] = appenderMetricsEntry{
+			Writes:         a.Writes,
+			Errors:         a.Errors,
+			WriteMillis:    float64(a.WriteTime.Microseconds()) / 1000,
+			MaxWriteMillis: float64(a.MaxWriteTime.Microseconds()) / 1000,
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"errors":    m.Errors,
+		"loggers":   loggers,
+		"appenders": appenders,
+	})
+}
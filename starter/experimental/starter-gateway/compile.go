@@ -140,8 +140,9 @@ func (t *RouteTable) Match(req *http.Request) *Route {
 }
 
 // recompile builds a fresh compiled route slice from raw and atomically swaps it
-// in. On any error it leaves the current table untouched and returns the error.
-// Routes are ordered by id for deterministic matching.
+// in. On any error it leaves the current table untouched, closes the executors
+// it built and returns the error. Routes are ordered by id for deterministic
+// matching.
 func (t *RouteTable) recompile(raw map[string]RouteRaw) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -161,17 +162,29 @@ func (t *RouteTable) recompile(raw map[string]RouteRaw) error {
 	for _, id := range ids {
 		rt, err := t.compileRoute(id, raw[id], execs)
 		if err != nil {
+			closeExecutors(execs)
 			return fmt.Errorf("route %q: %w", id, err)
 		}
 		routes = append(routes, rt)
 	}
 
 	t.compiled.Store(&routes)
+	// The replaced executors are closed so they leave the process-wide breaker
+	// reports; requests still running on the old routes may keep using them.
+	closeExecutors(t.execs)
 	t.execs = execs
 	atomic.StoreUintptr(&t.lastPtr, reflect.ValueOf(raw).Pointer())
 	return nil
 }
 
+// closeExecutors closes every executor in execs. Errors are ignored: closing
+// only releases bookkeeping such as the breaker report registration.
+func closeExecutors(execs map[string]resilience.Executor) {
+	for _, e := range execs {
+		_ = e.Close()
+	}
+}
+
 // buildExecutors turns each named resilience policy into an Executor via the
 // builtin driver. Routes reference these by name so they share breaker state.
 func (t *RouteTable) buildExecutors() (map[string]resilience.Executor, error) {
@@ -185,6 +198,7 @@ func (t *RouteTable) buildExecutors() (map[string]resilience.Executor, error) {
 	out := make(map[string]resilience.Executor, len(t.Cfg.Resilience))
 	for name, p := range t.Cfg.Resilience {
 		exec, err := driver.NewExecutor(resilience.Policy{
+			Name:           "gateway:" + name,
 			RateLimit:      p.RateLimit,
 			Burst:          p.Burst,
 			ErrorThreshold: p.ErrorThreshold,
@@ -194,6 +208,7 @@ func (t *RouteTable) buildExecutors() (map[string]resilience.Executor, error) {
 			Timeout:        p.Timeout,
 		})
 		if err != nil {
+			closeExecutors(out)
 			return nil, fmt.Errorf("resilience policy %q: %w", name, err)
 		}
 		out[name] = exec
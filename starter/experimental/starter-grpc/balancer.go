@@ -66,6 +66,7 @@ func init() {
 		loadbalance.ConsistentHash,
 		loadbalance.Weighted,
 		loadbalance.ZoneAware,
+		loadbalance.P2C,
 	} {
 		RegisterBalancer(BalancerName(s), s, defaultTracker)
 	}
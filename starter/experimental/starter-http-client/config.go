@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"time"
 
+	"go-spring.org/spring/experimental/cloud/loadbalance"
 	"go-spring.org/spring/experimental/cloud/resilience"
 	"go-spring.org/spring/experimental/web/httpx"
 )
@@ -47,7 +48,7 @@ type Config struct {
 	Discovery string `value:"${discovery:=}"`
 
 	// Balancer names the load-balancing strategy: round_robin (default),
-	// least_conn, consistent_hash, weighted, or zone_aware.
+	// least_conn, consistent_hash, weighted, zone_aware, or p2c.
 	Balancer string `value:"${balancer:=round_robin}"`
 
 	// EjectThreshold is the consecutive-failure count that ejects a failing
@@ -55,9 +56,20 @@ type Config struct {
 	EjectThreshold int `value:"${eject-threshold:=0}"`
 
 	// EjectFor is how long an ejected endpoint stays out before a trial request.
-	// Ignored when EjectThreshold is 0.
+	// Repeated ejections multiply it. Ignored when ejection is disabled.
 	EjectFor time.Duration `value:"${eject-for:=0}"`
 
+	// MaxEjectFor caps the growing ejection time (0 = 300s).
+	MaxEjectFor time.Duration `value:"${max-eject-for:=0}"`
+
+	// MaxEjectionPercent caps the share of endpoints ejected at once (0 = no
+	// cap).
+	MaxEjectionPercent int `value:"${max-ejection-percent:=0}"`
+
+	// SuccessRateStdevFactor ejects an endpoint whose success rate is this many
+	// standard deviations below its peers' (0 disables; Envoy uses 1.9).
+	SuccessRateStdevFactor float64 `value:"${success-rate-stdev-factor:=0}"`
+
 	// Timeout bounds each request made by the client. 0 means no timeout.
 	Timeout time.Duration `value:"${timeout:=0}"`
 
@@ -116,16 +128,21 @@ func (c Config) validate() error {
 }
 
 // toTransportConfig maps the bound Config onto the stdlib/httpx assembler input,
-// applying base as the underlying (trace-instrumented) transport.
-func (c Config) toTransportConfig(base http.RoundTripper) httpx.Config {
+// applying base as the underlying (trace-instrumented) transport and router as
+// the service's routing rules.
+func (c Config) toTransportConfig(base http.RoundTripper, router *loadbalance.Router) httpx.Config {
 	cfg := httpx.Config{
-		ServiceName:    c.ServiceName,
-		Addr:           c.Addr,
-		Discovery:      c.Discovery,
-		Balancer:       c.Balancer,
-		EjectThreshold: c.EjectThreshold,
-		EjectFor:       c.EjectFor,
-		Base:           base,
+		ServiceName:            c.ServiceName,
+		Addr:                   c.Addr,
+		Discovery:              c.Discovery,
+		Balancer:               c.Balancer,
+		EjectThreshold:         c.EjectThreshold,
+		EjectFor:               c.EjectFor,
+		MaxEjectFor:            c.MaxEjectFor,
+		MaxEjectionPercent:     c.MaxEjectionPercent,
+		SuccessRateStdevFactor: c.SuccessRateStdevFactor,
+		Router:                 router,
+		Base:                   base,
 	}
 	if c.Resilience.Enabled {
 		cfg.ResilienceDriver = c.Resilience.Driver
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterHTTPClient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/loadbalance"
+	"go-spring.org/spring/gs"
+)
+
+func init() {
+	gs.Provide(newRoutingWatcher).InitMethod("Init").Condition(gs.OnProperty("spring.http-client"))
+}
+
+// routers holds one loadbalance.Router per downstream service. Routing rules
+// belong to the service rather than to a client instance, so every client of
+// a service shares its router, and hot-refreshed rules reach them all.
+var routers = &routerRegistry{routers: map[string]*loadbalance.Router{}}
+
+type routerRegistry struct {
+	mu      sync.Mutex
+	routers map[string]*loadbalance.Router
+}
+
+// router returns the router of service. Clients may be created before or
+// after the watcher applies the rules, so a router without rules yet is
+// created on demand.
+func (r *routerRegistry) router(service string) *loadbalance.Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.getLocked(service)
+}
+
+func (r *routerRegistry) getLocked(service string) *loadbalance.Router {
+	rt, ok := r.routers[service]
+	if !ok {
+		rt, _ = loadbalance.NewRouter(loadbalance.RoutingConfig{}) // no rules, cannot fail
+		r.routers[service] = rt
+	}
+	return rt
+}
+
+// apply replaces the rules of every service; a service missing from cfgs
+// loses its rules. It returns the first invalid rule set; the other services
+// are still updated, and a router given invalid rules keeps its previous ones.
+func (r *routerRegistry) apply(cfgs map[string]loadbalance.RoutingConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for service := range cfgs {
+		r.getLocked(service)
+	}
+	var firstErr error
+	for service, rt := range r.routers {
+		if err := rt.Update(cfgs[service]); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("http-client: routing rules of %s: %w", service, err)
+		}
+	}
+	return firstErr
+}
+
+// routingWatcher binds the routing rules under
+// "${spring.http-client-routing}", keyed by service name, and pushes them into
+// the shared routers whenever the configuration is refreshed.
+type routingWatcher struct {
+	Rules gs.Dync[map[string]loadbalance.RoutingConfig] `value:"${spring.http-client-routing:=}"`
+}
+
+func newRoutingWatcher() *routingWatcher { return &routingWatcher{} }
+
+// Init applies the current rules, failing startup on an invalid rule, and
+// registers the refresh callback. A refresh with an invalid rule is logged and
+// leaves that service's previous rules in effect.
+func (w *routingWatcher) Init() error {
+	w.Rules.OnChanged(func(cfgs, _ map[string]loadbalance.RoutingConfig) {
+		if err := routers.apply(cfgs); err != nil {
+			log.Errorf(context.Background(), starterTag, "http-client: refresh routing rules failed: %v", err)
+		}
+	})
+	return routers.apply(w.Rules.Value()) // OnChanged does not fire on the init bind
+}
@@ -29,6 +29,7 @@ import (
 	"net/http"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/loadbalance"
 	"go-spring.org/spring/experimental/web/httpx"
 	"go-spring.org/spring/gs"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -59,7 +60,11 @@ func newClient(cp *gs.ContextProvider, c Config) (*http.Client, error) {
 		return nil, err
 	}
 	base := otelhttp.NewTransport(http.DefaultTransport)
-	rt, closeFn, err := httpx.NewTransport(c.toTransportConfig(base))
+	var router *loadbalance.Router
+	if c.ServiceName != "" {
+		router = routers.router(c.ServiceName)
+	}
+	rt, closeFn, err := httpx.NewTransport(c.toTransportConfig(base, router))
 	if err != nil {
 		log.Errorf(ctx, starterTag, "http-client: create transport failed: %v", err)
 		return nil, err
@@ -42,16 +42,26 @@ const (
 	// small values keep the "try once" latency low while giving the agent time
 	// to answer.
 	tryWaitTime = 500 * time.Millisecond
+
+	// rwSuffix places read/write holders beside, not on, the mutex key;
+	// semSuffix does the same for the semaphore's contender prefix.
+	rwSuffix  = "#rw/"
+	semSuffix = "#sem/"
+
+	// rwSlot namespaces write locks for re-entrancy bookkeeping.
+	rwSlot = "rw\x00"
 )
 
-// consulLocker is the [lock.Locker] backed by a shared *api.Client. It hands
-// out per-acquisition *api.Lock handles that each own their own consul session,
-// so cancelling one handle never affects another.
+// consulLocker is the [lock.Locker], [lock.RWLocker] and [lock.Semaphore]
+// backed by a shared *api.Client. It hands out per-acquisition handles that
+// each own their own consul session, so cancelling one handle never affects
+// another.
 type consulLocker struct {
-	client    *api.Client
-	keyPrefix string
-	ttl       time.Duration
-	closeOnce sync.Once
+	client     *api.Client
+	keyPrefix  string
+	ttl        time.Duration
+	closeOnce  sync.Once
+	reentrancy lock.Reentrancy
 }
 
 // newConsulLocker builds a locker plus its api.Client from a bound Config. It
@@ -123,6 +133,9 @@ func newConsulLocker(cp *gs.ContextProvider, c Config) (*consulLocker, error) {
 // non-retriable error. Contended acquisitions wait inside api.Lock's own
 // blocking-query loop; we cancel it by closing stopCh from a ctx watcher.
 func (l *consulLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, nil
+	}
 	o := lock.Apply(opts...)
 	al, err := l.buildLock(key, o, false)
 	if err != nil {
@@ -144,12 +157,15 @@ func (l *consulLocker) Acquire(ctx context.Context, key string, opts ...lock.Opt
 		}
 		return nil, errutil.Explain(nil, "lock-consul: acquire %s returned no leader channel", key)
 	}
-	return newConsulLock(al, l.keyPrefix+key, o.Token, leaderCh), nil
+	return l.reentrancy.Track(ctx, key, newConsulLock(al, l.keyPrefix+key, o.Token, leaderCh)), nil
 }
 
 // TryAcquire makes a single non-blocking attempt. ok=false with nil error is
 // ordinary contention; err is reserved for genuine backend failures.
 func (l *consulLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, true, nil
+	}
 	o := lock.Apply(opts...)
 	al, err := l.buildLock(key, o, true)
 	if err != nil {
@@ -176,7 +192,138 @@ func (l *consulLocker) TryAcquire(ctx context.Context, key string, opts ...lock.
 		}
 		return nil, false, nil
 	}
-	return newConsulLock(al, l.keyPrefix+key, o.Token, leaderCh), true, nil
+	return l.reentrancy.Track(ctx, key, newConsulLock(al, l.keyPrefix+key, o.Token, leaderCh)), true, nil
+}
+
+// TryAcquireRead queues a reader and keeps it when no writer queued before it.
+func (l *consulLocker) TryAcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	dir := l.keyPrefix + key + rwSuffix
+	return l.tryRanked(ctx, key, dir+"r/", dir+"w/", lock.Apply(opts...))
+}
+
+// AcquireRead polls TryAcquireRead until it succeeds or ctx ends.
+func (l *consulLocker) AcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireRead(ctx, key, opts...)
+	})
+}
+
+// TryAcquireWrite queues a writer and keeps it when nobody queued before it.
+func (l *consulLocker) TryAcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, rwSlot+key); ok {
+		return held, true, nil
+	}
+	dir := l.keyPrefix + key + rwSuffix
+	held, ok, err := l.tryRanked(ctx, key, dir+"w/", dir, lock.Apply(opts...))
+	if !ok {
+		return nil, false, err
+	}
+	return l.reentrancy.Track(ctx, rwSlot+key, held), true, nil
+}
+
+// AcquireWrite polls TryAcquireWrite until it succeeds or ctx ends.
+func (l *consulLocker) AcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireWrite(ctx, key, opts...)
+	})
+}
+
+// AcquirePermit blocks inside api.Semaphore's own wait loop until a permit is
+// free or ctx is done, mirroring Acquire over api.Lock.
+func (l *consulLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, error) {
+	held, _, err := l.acquirePermit(ctx, key, permits, lock.Apply(opts...), false)
+	return held, err
+}
+
+// TryAcquirePermit makes a single attempt at a permit.
+func (l *consulLocker) TryAcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, bool, error) {
+	return l.acquirePermit(ctx, key, permits, lock.Apply(opts...), true)
+}
+
+// acquirePermit builds a per-acquisition *api.Semaphore and takes one slot.
+// api.Semaphore records the limit in its lock file and fails with
+// api.ErrSemaphoreConflict when callers disagree on it.
+func (l *consulLocker) acquirePermit(ctx context.Context, key string, permits int, o lock.Options, tryOnce bool) (lock.Lock, bool, error) {
+	if permits <= 0 {
+		return nil, false, lock.ErrInvalidPermits
+	}
+	sem, err := l.client.SemaphoreOpts(&api.SemaphoreOptions{
+		Prefix:            l.keyPrefix + key + semSuffix,
+		Limit:             permits,
+		Value:             []byte(o.Token),
+		SessionName:       "go-spring/semaphore",
+		SessionTTL:        l.sessionTTL(o),
+		SemaphoreTryOnce:  tryOnce,
+		SemaphoreWaitTime: tryWaitTime,
+	})
+	if err != nil {
+		return nil, false, errutil.Explain(err, "lock-consul: SemaphoreOpts for %s failed", key)
+	}
+
+	stopCh, cancel := ctxStopCh(ctx)
+	defer cancel()
+
+	lostCh, err := sem.Acquire(stopCh)
+	if err != nil {
+		return nil, false, errutil.Explain(err, "lock-consul: acquire permit %s failed", key)
+	}
+	if lostCh == nil {
+		if ctx.Err() != nil {
+			return nil, false, ctx.Err()
+		}
+		return nil, false, nil // every permit is held
+	}
+	return &consulPermit{sem: sem, key: l.keyPrefix + key, token: o.Token, lost: lostCh}, true, nil
+}
+
+// tryRanked is the queue recipe behind read/write locks. It creates a session
+// (deleting its keys when invalidated), acquires a key under own with it, and
+// keeps the hold when no key under blockers was created before its own;
+// otherwise it destroys the session and reports contention. Holders only ever
+// leave the queue, so a granted hold stays first among its blockers.
+func (l *consulLocker) tryRanked(ctx context.Context, key, own, blockers string, o lock.Options) (lock.Lock, bool, error) {
+	ttl := l.sessionTTL(o)
+	q := (&api.WriteOptions{}).WithContext(ctx)
+	id, _, err := l.client.Session().Create(&api.SessionEntry{
+		Name:     "go-spring/rwlock",
+		TTL:      ttl,
+		Behavior: api.SessionBehaviorDelete,
+	}, q)
+	if err != nil {
+		return nil, false, errutil.Explain(err, "lock-consul: create session for %s failed", key)
+	}
+	h := &consulRWLock{
+		client: l.client,
+		key:    l.keyPrefix + key,
+		name:   own + id,
+		token:  o.Token,
+		id:     id,
+		done:   make(chan struct{}),
+		lost:   make(chan struct{}),
+	}
+	ok, err := h.rank(ctx, blockers)
+	if err != nil || !ok {
+		h.release(ctx)
+		if err != nil {
+			return nil, false, errutil.Explain(err, "lock-consul: queue %s failed", h.name)
+		}
+		return nil, false, nil
+	}
+	go h.renew(ttl)
+	return h, true, nil
+}
+
+// sessionTTL clamps the acquisition's TTL into consul's accepted window,
+// falling back to the configured TTL below the minimum.
+func (l *consulLocker) sessionTTL(o lock.Options) string {
+	ttl := o.TTL
+	if ttl < minSessionTTL {
+		ttl = l.ttl
+	}
+	if ttl > maxSessionTTL {
+		ttl = maxSessionTTL
+	}
+	return fmt.Sprintf("%ds", int(ttl.Round(time.Second).Seconds()))
 }
 
 // Close is idempotent. The api.Client has no Close method; held handles keep
@@ -190,19 +337,10 @@ func (l *consulLocker) Close() error {
 // buildLock constructs a per-acquisition *api.Lock with the shared client. The
 // tryOnce flag switches between blocking and single-shot semantics.
 func (l *consulLocker) buildLock(key string, o lock.Options, tryOnce bool) (*api.Lock, error) {
-	ttl := o.TTL
-	if ttl < minSessionTTL {
-		ttl = l.ttl
-	}
-	if ttl > maxSessionTTL {
-		ttl = maxSessionTTL
-	}
-	sessionTTL := fmt.Sprintf("%ds", int(ttl.Round(time.Second).Seconds()))
-
 	opts := &api.LockOptions{
 		Key:          l.keyPrefix + key,
 		Value:        []byte(o.Token),
-		SessionTTL:   sessionTTL,
+		SessionTTL:   l.sessionTTL(o),
 		SessionName:  "go-spring/lock",
 		LockTryOnce:  tryOnce,
 		LockWaitTime: tryWaitTime,
@@ -264,3 +402,104 @@ func (h *consulLock) Unlock(_ context.Context) error {
 	})
 	return out
 }
+
+// consulPermit is a held semaphore permit. Like consulLock it owns one
+// *api.Semaphore and its session, and uses the channel from Acquire as Lost().
+type consulPermit struct {
+	sem   *api.Semaphore
+	key   string
+	token string
+	lost  <-chan struct{}
+	once  sync.Once
+}
+
+func (h *consulPermit) Key() string           { return h.key }
+func (h *consulPermit) Token() string         { return h.token }
+func (h *consulPermit) Lost() <-chan struct{} { return h.lost }
+
+// Unlock releases the permit and best-effort destroys the semaphore's lock
+// file, which fails with api.ErrSemaphoreInUse while other permits are held.
+func (h *consulPermit) Unlock(_ context.Context) error {
+	var out error
+	h.once.Do(func() {
+		if err := h.sem.Release(); err != nil && !errors.Is(err, api.ErrSemaphoreNotHeld) {
+			out = errutil.Explain(err, "lock-consul: release permit %s failed", h.key)
+		}
+		_ = h.sem.Destroy()
+	})
+	return out
+}
+
+// consulRWLock is a held read or write lock: a key acquired by its own
+// session, which renew keeps alive. Destroying the session deletes the key.
+type consulRWLock struct {
+	client *api.Client
+	key    string
+	name   string
+	token  string
+	id     string
+
+	done     chan struct{} // closed by release to stop renew
+	doneOnce sync.Once
+	lost     chan struct{} // closed when renew returns
+	once     sync.Once
+}
+
+func (h *consulRWLock) Key() string           { return h.key }
+func (h *consulRWLock) Token() string         { return h.token }
+func (h *consulRWLock) Lost() <-chan struct{} { return h.lost }
+
+// rank acquires the handle's key and reports whether no key under blockers
+// was created before it.
+func (h *consulRWLock) rank(ctx context.Context, blockers string) (bool, error) {
+	kv := h.client.KV()
+	w := (&api.WriteOptions{}).WithContext(ctx)
+	q := (&api.QueryOptions{}).WithContext(ctx)
+	ok, _, err := kv.Acquire(&api.KVPair{Key: h.name, Value: []byte(h.token), Session: h.id}, w)
+	if err != nil || !ok {
+		return false, err
+	}
+	own, _, err := kv.Get(h.name, q)
+	if err != nil || own == nil {
+		return false, err
+	}
+	pairs, _, err := kv.List(blockers, q)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range pairs {
+		if p.Session != "" && p.CreateIndex < own.CreateIndex {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// renew keeps the session alive until release and closes lost when the
+// session ends for any reason — released, expired, or invalidated.
+func (h *consulRWLock) renew(ttl string) {
+	_ = h.client.Session().RenewPeriodic(ttl, h.id, nil, h.done)
+	h.once.Do(func() { close(h.lost) })
+}
+
+// release deletes the key and destroys the session synchronously, so a
+// waiting writer can proceed at once instead of after RenewPeriodic notices.
+func (h *consulRWLock) release(ctx context.Context) {
+	w := (&api.WriteOptions{}).WithContext(ctx)
+	_, _ = h.client.KV().Delete(h.name, w)
+	_, _ = h.client.Session().Destroy(h.id, w)
+	h.doneOnce.Do(func() { close(h.done) })
+}
+
+// Unlock releases the lock. It is idempotent; a key already gone with an
+// expired session is not an error.
+func (h *consulRWLock) Unlock(ctx context.Context) error {
+	h.release(ctx)
+	h.once.Do(func() { close(h.lost) })
+	return nil
+}
+
+var (
+	_ lock.RWLocker  = (*consulLocker)(nil)
+	_ lock.Semaphore = (*consulLocker)(nil)
+)
@@ -37,6 +37,9 @@ done
 # Give the agent a moment to finish electing itself before we start.
 sleep 2
 
+# Run the starter's read/write and semaphore tests against the live backend.
+( cd .. && STARTER_LOCK_CONSUL_ADDR=127.0.0.1:8500 go test -count=1 . )
+
 go run . &
 pid=$!
 ( sleep 45; kill -9 "${pid}" 2>/dev/null ) &
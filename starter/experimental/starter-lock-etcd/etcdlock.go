@@ -19,6 +19,7 @@ package StarterLockEtcd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"go-spring.org/log"
@@ -29,16 +30,27 @@ import (
 	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
-// etcdLocker implements [lock.Locker] on top of the etcd concurrency package.
-// It owns one *clientv3.Client shared across acquisitions; every acquired lock
-// runs on its own concurrency.Session so its lease and Lost() channel are
-// independent from other holds.
+// Suffixes place read/write and semaphore holders beside, not under, the
+// mutex prefix KeyPrefix+key+"/", so the three kinds never contend.
+const (
+	rwSuffix  = "#rw/"
+	semSuffix = "#sem/"
+
+	// rwSlot namespaces write locks for re-entrancy bookkeeping.
+	rwSlot = "rw\x00"
+)
+
+// etcdLocker implements [lock.Locker], [lock.RWLocker] and [lock.Semaphore] on
+// top of the etcd concurrency package. It owns one *clientv3.Client shared
+// across acquisitions; every acquired lock runs on its own concurrency.Session
+// so its lease and Lost() channel are independent from other holds.
 type etcdLocker struct {
 	client    *clientv3.Client
 	keyPrefix string
 	ttlSecs   int
 
-	closeOnce sync.Once
+	closeOnce  sync.Once
+	reentrancy lock.Reentrancy
 }
 
 // newEtcdLocker builds a *clientv3.Client from c and returns a Locker that
@@ -94,6 +106,9 @@ func newEtcdLocker(cp *gs.ContextProvider, c Config) (*etcdLocker, error) {
 // fresh session so this hold's lease and Lost() channel are isolated from any
 // other outstanding hold.
 func (l *etcdLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, nil
+	}
 	o := lock.Apply(opts...)
 	sess, mu, err := l.newMutex(key)
 	if err != nil {
@@ -103,13 +118,16 @@ func (l *etcdLocker) Acquire(ctx context.Context, key string, opts ...lock.Optio
 		_ = sess.Close()
 		return nil, err
 	}
-	return newEtcdLock(key, o.Token, sess, mu), nil
+	return l.reentrancy.Track(ctx, key, newEtcdLock(key, o.Token, sess, mu)), nil
 }
 
 // TryAcquire attempts to take the lock once without blocking. concurrency.Mutex
 // signals contention with a sentinel ErrLocked, which is translated to ok=false
 // so callers can distinguish it from a real backend error.
 func (l *etcdLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, true, nil
+	}
 	o := lock.Apply(opts...)
 	sess, mu, err := l.newMutex(key)
 	if err != nil {
@@ -122,7 +140,87 @@ func (l *etcdLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Op
 		}
 		return nil, false, err
 	}
-	return newEtcdLock(key, o.Token, sess, mu), true, nil
+	return l.reentrancy.Track(ctx, key, newEtcdLock(key, o.Token, sess, mu)), true, nil
+}
+
+// TryAcquireRead queues a reader and keeps it when no writer queued before it.
+func (l *etcdLocker) TryAcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	dir := l.keyPrefix + key + rwSuffix
+	return l.tryRanked(ctx, key, dir+"r/", dir+"w/", 1, lock.Apply(opts...))
+}
+
+// AcquireRead polls TryAcquireRead until it succeeds or ctx ends.
+func (l *etcdLocker) AcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireRead(ctx, key, opts...)
+	})
+}
+
+// TryAcquireWrite queues a writer and keeps it when nobody queued before it.
+func (l *etcdLocker) TryAcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, rwSlot+key); ok {
+		return held, true, nil
+	}
+	dir := l.keyPrefix + key + rwSuffix
+	held, ok, err := l.tryRanked(ctx, key, dir+"w/", dir, 1, lock.Apply(opts...))
+	if !ok {
+		return nil, false, err
+	}
+	return l.reentrancy.Track(ctx, rwSlot+key, held), true, nil
+}
+
+// AcquireWrite polls TryAcquireWrite until it succeeds or ctx ends.
+func (l *etcdLocker) AcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireWrite(ctx, key, opts...)
+	})
+}
+
+// TryAcquirePermit queues a holder and keeps it when fewer than permits
+// holders queued before it.
+func (l *etcdLocker) TryAcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, bool, error) {
+	if permits <= 0 {
+		return nil, false, lock.ErrInvalidPermits
+	}
+	dir := l.keyPrefix + key + semSuffix
+	return l.tryRanked(ctx, key, dir, dir, int64(permits), lock.Apply(opts...))
+}
+
+// AcquirePermit polls TryAcquirePermit until it succeeds or ctx ends.
+func (l *etcdLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquirePermit(ctx, key, permits, opts...)
+	})
+}
+
+// tryRanked is the queue recipe behind shared holds. It puts a key under own
+// bound to a fresh session's lease, then counts the keys under blockers
+// created before it: fewer than limit grants the hold, otherwise the session
+// is closed (revoking the lease deletes the key) and ok is false. Holders
+// only ever leave the queue, so a granted hold never falls out of the first
+// limit places.
+func (l *etcdLocker) tryRanked(ctx context.Context, key, own, blockers string, limit int64, o lock.Options) (lock.Lock, bool, error) {
+	sess, err := concurrency.NewSession(l.client, concurrency.WithTTL(l.ttlSecs))
+	if err != nil {
+		return nil, false, errutil.Explain(err, "lock-etcd: failed to create session")
+	}
+	name := fmt.Sprintf("%s%x", own, sess.Lease())
+	put, err := l.client.Put(ctx, name, o.Token, clientv3.WithLease(sess.Lease()))
+	if err != nil {
+		_ = sess.Close()
+		return nil, false, errutil.Explain(err, "lock-etcd: queue %s", name)
+	}
+	ahead, err := l.client.Get(ctx, blockers, clientv3.WithPrefix(),
+		clientv3.WithMaxCreateRev(put.Header.Revision-1), clientv3.WithCountOnly())
+	if err != nil {
+		_ = sess.Close()
+		return nil, false, errutil.Explain(err, "lock-etcd: rank %s", name)
+	}
+	if ahead.Count >= limit {
+		_ = sess.Close()
+		return nil, false, nil
+	}
+	return newEtcdLock(key, o.Token, sess, nil), true, nil
 }
 
 // Close releases the shared etcd client. Locks already handed out own their
@@ -149,6 +247,8 @@ func (l *etcdLocker) newMutex(key string) (*concurrency.Session, *concurrency.Mu
 
 // etcdLock is the [lock.Lock] handle for a single acquisition. It closes its
 // session on Unlock, which releases the underlying lease and fires Lost().
+// mutex is nil for read/write and semaphore holds, whose queue key goes with
+// the lease.
 type etcdLock struct {
 	key     string
 	token   string
@@ -206,7 +306,10 @@ func (h *etcdLock) Unlock(ctx context.Context) error {
 	h.once.Do(func() {
 		// Best-effort mutex.Unlock: if the lease is already gone the delete
 		// call may fail, but the session close below still frees resources.
-		unlockErr := h.mutex.Unlock(ctx)
+		var unlockErr error
+		if h.mutex != nil {
+			unlockErr = h.mutex.Unlock(ctx)
+		}
 		if closeErr := h.session.Close(); closeErr != nil && unlockErr == nil {
 			unlockErr = closeErr
 		}
@@ -224,3 +327,8 @@ func (h *etcdLock) Unlock(ctx context.Context) error {
 	})
 	return err
 }
+
+var (
+	_ lock.RWLocker  = (*etcdLocker)(nil)
+	_ lock.Semaphore = (*etcdLocker)(nil)
+)
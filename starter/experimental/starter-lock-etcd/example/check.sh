@@ -37,6 +37,9 @@ done
 # Give etcd a moment to finish booting after the port opens.
 sleep 2
 
+# Run the starter's read/write and semaphore tests against the live backend.
+( cd .. && STARTER_LOCK_ETCD_ENDPOINTS=127.0.0.1:2379 go test -count=1 . )
+
 go run . &
 pid=$!
 ( sleep 40; kill -9 "${pid}" 2>/dev/null ) &
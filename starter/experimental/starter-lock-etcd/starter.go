@@ -57,14 +57,14 @@ func init() {
 			}
 			b := r.Provide(newEtcdLocker, gs.ValueArg(c)).
 				Name(name).
-				Export(gs.As[lock.Locker]()).
+				Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]()).
 				Destroy(destroyEtcdLocker)
 			b.SetFileLine(file, line)
 
 			if c.Observer.Tracing.Enabled {
-				w := r.Provide(wrapLockerBean, gs.ValueArg(c), gs.TagArg(name)).
-					Name(name + "-observed").
-					Export(gs.As[lock.Locker]())
+				w := r.Provide(wrapLockerBean, gs.TagArg(name)).
+					Name(name+"-observed").
+					Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]())
 				w.SetFileLine(file, line)
 			}
 		}
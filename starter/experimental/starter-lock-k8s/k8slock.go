@@ -18,6 +18,10 @@ package StarterLockK8s
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,8 +36,20 @@ import (
 	"go-spring.org/stdlib/errutil"
 )
 
-// k8sLocker implements [lock.Locker] on top of coordination.k8s.io/Lease
-// objects. It owns one Kubernetes clientset shared across acquisitions; every
+// Lease-name suffixes for read/write locks and semaphore slots. A read/write
+// key uses Lease KeyPrefix+key+".rw" for its writer and one ".rw.r-<hash>"
+// Lease per reader; a semaphore uses one ".sem-<i>" Lease per permit.
+const (
+	rwSuffix     = ".rw"
+	readerSuffix = ".r-"
+	semSuffix    = ".sem-"
+
+	// rwSlot namespaces write locks for re-entrancy bookkeeping.
+	rwSlot = "rw\x00"
+)
+
+// k8sLocker implements [lock.Locker], [lock.RWLocker] and [lock.Semaphore] on
+// top of coordination.k8s.io/Lease objects. It owns one Kubernetes clientset shared across acquisitions; every
 // acquired lock maps to a single Lease (name = KeyPrefix+key) and runs its own
 // renewal goroutine so its lease and Lost() channel are independent of other
 // holds.
@@ -46,6 +62,8 @@ type k8sLocker struct {
 	client    kubernetes.Interface
 	namespace string
 	keyPrefix string
+
+	reentrancy lock.Reentrancy
 }
 
 // newK8sLocker builds a Locker from c, creating the shared clientset eagerly so
@@ -80,28 +98,107 @@ func newK8sLockerWithClient(client kubernetes.Interface, namespace, keyPrefix st
 // RetryInterval; a transient API error aborts, matching the abstraction's
 // contract that only genuine contention should be retried silently.
 func (l *k8sLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
-	o := lock.Apply(opts...)
-	for {
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
-		held, ok, err := l.tryOnce(ctx, key, o)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			return held, nil
-		}
-		if !sleep(ctx, o.RetryInterval) {
-			return nil, ctx.Err()
-		}
-	}
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquire(ctx, key, opts...)
+	})
 }
 
 // TryAcquire attempts to take the Lease once without blocking.
 func (l *k8sLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, true, nil
+	}
+	o := lock.Apply(opts...)
+	held, ok, err := l.tryOnce(ctx, key, l.keyPrefix+key, o)
+	if !ok {
+		return nil, false, err
+	}
+	return l.reentrancy.Track(ctx, key, held), true, nil
+}
+
+// TryAcquireRead takes a reader Lease of its own, then gives it back if a live
+// writer holds the key. Its writer counterpart takes the writer Lease before
+// listing readers, so with the API server's linearizable reads at least one of
+// two racing parties sees the other: both may back off, never both proceed.
+func (l *k8sLocker) TryAcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	o := lock.Apply(opts...)
+	writer := l.keyPrefix + key + rwSuffix
+	h := l.newLock(key, writer+readerSuffix+shortHash(o.Token), o)
+	h.remove = true
+	ok, err := h.tryAcquireOrRenew(ctx)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	live, err := l.leaseLive(ctx, writer)
+	if err != nil || live {
+		_ = h.Unlock(ctx)
+		return nil, false, err
+	}
+	h.start()
+	return h, true, nil
+}
+
+// AcquireRead polls TryAcquireRead until it succeeds or ctx ends.
+func (l *k8sLocker) AcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireRead(ctx, key, opts...)
+	})
+}
+
+// TryAcquireWrite takes the writer Lease, then gives it back if a live reader
+// Lease remains. Expired reader Leases left by crashed holders are deleted on
+// the way.
+func (l *k8sLocker) TryAcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, rwSlot+key); ok {
+		return held, true, nil
+	}
 	o := lock.Apply(opts...)
-	return l.tryOnce(ctx, key, o)
+	writer := l.keyPrefix + key + rwSuffix
+	h := l.newLock(key, writer, o)
+	ok, err := h.tryAcquireOrRenew(ctx)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	readers, err := l.liveReaders(ctx, writer+readerSuffix)
+	if err != nil || readers > 0 {
+		_ = h.Unlock(ctx)
+		return nil, false, err
+	}
+	h.start()
+	return l.reentrancy.Track(ctx, rwSlot+key, h), true, nil
+}
+
+// AcquireWrite polls TryAcquireWrite until it succeeds or ctx ends.
+func (l *k8sLocker) AcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquireWrite(ctx, key, opts...)
+	})
+}
+
+// TryAcquirePermit tries each of the permits slot Leases once, starting at a
+// random slot so concurrent callers spread out instead of all contending on
+// slot 0.
+func (l *k8sLocker) TryAcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, bool, error) {
+	if permits <= 0 {
+		return nil, false, lock.ErrInvalidPermits
+	}
+	o := lock.Apply(opts...)
+	first := rand.N(permits)
+	for i := range permits {
+		slot := fmt.Sprintf("%s%s%s%d", l.keyPrefix, key, semSuffix, (first+i)%permits)
+		held, ok, err := l.tryOnce(ctx, key, slot, o)
+		if err != nil || ok {
+			return held, ok, err
+		}
+	}
+	return nil, false, nil
+}
+
+// AcquirePermit polls TryAcquirePermit until it succeeds or ctx ends.
+func (l *k8sLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, error) {
+	return lock.Poll(ctx, lock.Apply(opts...), func() (lock.Lock, bool, error) {
+		return l.TryAcquirePermit(ctx, key, permits, opts...)
+	})
 }
 
 // Close releases backend resources. The Kubernetes clientset holds no
@@ -109,32 +206,93 @@ func (l *k8sLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Opt
 // completeness and idempotency.
 func (l *k8sLocker) Close() error { return nil }
 
-// tryOnce builds a Lease handle for key and attempts a single acquire-or-renew.
-// On success it starts the handle's renewal loop and returns a held Lock; on
-// contention it returns ok=false with no error.
-func (l *k8sLocker) tryOnce(ctx context.Context, key string, o lock.Options) (lock.Lock, bool, error) {
-	h := &k8sLock{
+// tryOnce builds a handle for the Lease name and attempts a single
+// acquire-or-renew. On success it starts the handle's renewal loop and returns
+// a held Lock; on contention it returns ok=false with no error.
+func (l *k8sLocker) tryOnce(ctx context.Context, key, name string, o lock.Options) (lock.Lock, bool, error) {
+	h := l.newLock(key, name, o)
+	ok, err := h.tryAcquireOrRenew(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	h.start()
+	return h, true, nil
+}
+
+// newLock builds an unstarted handle for the Lease name guarding key.
+func (l *k8sLocker) newLock(key, name string, o lock.Options) *k8sLock {
+	return &k8sLock{
 		key:           key,
 		token:         o.Token,
 		ttl:           o.TTL,
 		renewInterval: o.RenewInterval,
 		rl: &resourcelock.LeaseLock{
-			LeaseMeta:  metav1.ObjectMeta{Namespace: l.namespace, Name: l.keyPrefix + key},
+			LeaseMeta:  metav1.ObjectMeta{Namespace: l.namespace, Name: name},
 			Client:     l.client.CoordinationV1(),
 			LockConfig: resourcelock.ResourceLockConfig{Identity: o.Token},
 		},
 		stop: make(chan struct{}),
 		lost: make(chan struct{}),
 	}
-	ok, err := h.tryAcquireOrRenew(ctx)
+}
+
+// leaseLive reports whether the Lease name exists with a holder whose lease
+// has not run out.
+func (l *k8sLocker) leaseLive(ctx context.Context, name string) (bool, error) {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return nil, false, err
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errutil.Explain(err, "lock-k8s: get lease %s/%s", l.namespace, name)
 	}
-	if !ok {
-		return nil, false, nil
+	return leaseHeld(lease.Spec.HolderIdentity, lease.Spec.RenewTime, lease.Spec.LeaseDurationSeconds), nil
+}
+
+// liveReaders counts the live reader Leases whose names start with prefix,
+// deleting expired ones best-effort. Leases carry no labels of their own, so
+// it lists the namespace and filters by name.
+func (l *k8sLocker) liveReaders(ctx context.Context, prefix string) (int, error) {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	list, err := leases.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, errutil.Explain(err, "lock-k8s: list leases in %s", l.namespace)
 	}
-	h.start()
-	return h, true, nil
+	n := 0
+	for _, lease := range list.Items {
+		if !strings.HasPrefix(lease.Name, prefix) {
+			continue
+		}
+		if leaseHeld(lease.Spec.HolderIdentity, lease.Spec.RenewTime, lease.Spec.LeaseDurationSeconds) {
+			n++
+			continue
+		}
+		uid, rv := lease.UID, lease.ResourceVersion
+		_ = leases.Delete(ctx, lease.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &uid, ResourceVersion: &rv},
+		})
+	}
+	return n, nil
+}
+
+// leaseHeld reports whether a Lease spec names a holder whose lease has not
+// run out.
+func leaseHeld(holder *string, renew *metav1.MicroTime, seconds *int32) bool {
+	if holder == nil || *holder == "" || renew == nil || seconds == nil {
+		return false
+	}
+	return renew.Add(time.Duration(*seconds) * time.Second).After(time.Now())
+}
+
+// shortHash maps a fencing token, which may contain any characters, to a
+// string valid inside a Lease name.
+func shortHash(s string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return fmt.Sprintf("%016x", h.Sum64())
 }
 
 // k8sLock is the [lock.Lock] handle for a single held Lease. It renews the lease
@@ -146,6 +304,7 @@ type k8sLock struct {
 	ttl           time.Duration
 	renewInterval time.Duration
 	rl            *resourcelock.LeaseLock
+	remove        bool // delete the Lease on Unlock instead of clearing it
 
 	stop     chan struct{} // closed by Unlock to stop the renew loop
 	stopOnce sync.Once
@@ -166,7 +325,8 @@ func (h *k8sLock) Lost() <-chan struct{} { return h.lost }
 
 // Unlock stops renewal and best-effort releases the Lease by clearing its
 // holderIdentity so a waiter can take it immediately instead of waiting out the
-// lease duration. It is idempotent.
+// lease duration. A reader Lease, named after its holder and never reused, is
+// deleted instead. It is idempotent.
 func (h *k8sLock) Unlock(ctx context.Context) error {
 	h.stopOnce.Do(func() { close(h.stop) })
 	h.fireLost()
@@ -182,6 +342,10 @@ func (h *k8sLock) Unlock(ctx context.Context) error {
 	if record.HolderIdentity != h.token {
 		return nil // someone else already owns it; nothing to release
 	}
+	if h.remove {
+		_ = h.rl.Client.Leases(h.rl.LeaseMeta.Namespace).Delete(ctx, h.rl.LeaseMeta.Name, metav1.DeleteOptions{})
+		return nil
+	}
 	empty := *record
 	empty.HolderIdentity = ""
 	empty.LeaseDurationSeconds = 1
@@ -309,3 +473,8 @@ func sleep(ctx context.Context, d time.Duration) bool {
 		return true
 	}
 }
+
+var (
+	_ lock.RWLocker  = (*k8sLocker)(nil)
+	_ lock.Semaphore = (*k8sLocker)(nil)
+)
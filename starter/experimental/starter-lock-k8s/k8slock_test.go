@@ -170,3 +170,58 @@ func TestElectionElectsOneLeader(t *testing.T) {
 		t.Fatal("election did not stop")
 	}
 }
+
+// TestReadWriteLeases proves readers share a key while they exclude the writer,
+// and that a reader's Lease is deleted on Unlock so the writer can proceed.
+func TestReadWriteLeases(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocker()
+	defer func() { assert.Error(t, l.Close()).Nil() }()
+
+	r1, ok, err := l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	r2, ok, err := l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+
+	_, ok, err = l.TryAcquireWrite(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+
+	assert.Error(t, r1.Unlock(ctx)).Nil()
+	assert.Error(t, r2.Unlock(ctx)).Nil()
+
+	w, ok, err := l.TryAcquireWrite(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	_, ok, err = l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+	assert.Error(t, w.Unlock(ctx)).Nil()
+}
+
+// TestPermitSlots proves a semaphore hands out at most permits slot Leases.
+func TestPermitSlots(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocker()
+	defer func() { assert.Error(t, l.Close()).Nil() }()
+
+	var held []lock.Lock
+	for range 2 {
+		h, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+		assert.Error(t, err).Nil()
+		assert.That(t, ok).True()
+		held = append(held, h)
+	}
+	_, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+
+	assert.Error(t, held[0].Unlock(ctx)).Nil()
+	h, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	assert.Error(t, h.Unlock(ctx)).Nil()
+	assert.Error(t, held[1].Unlock(ctx)).Nil()
+}
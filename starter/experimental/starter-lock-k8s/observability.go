@@ -29,59 +29,39 @@ import (
 // tracerName identifies spans emitted by this starter.
 const tracerName = "go-spring.org/starter-lock-k8s"
 
-// wrapLockerBean is a bean constructor that creates the original k8s locker,
-// then wraps it with OTel tracing.
-func wrapLockerBean(c Config, inner lock.Locker) lock.Locker {
-	return WrapLocker(inner)
+// wrapLockerBean wraps the k8s locker bean named by its tag argument
+// with OTel tracing. It is registered as a separate "<name>-observed" bean
+// when observer.tracing.enabled=true.
+func wrapLockerBean(inner lock.Locker) *lock.ObservedLocker {
+	return lock.Observe(inner, spanObserver{})
 }
 
-// WrapLocker returns a lock.Locker that wraps Acquire and TryAcquire with OTel
-// client spans.
-func WrapLocker(inner lock.Locker) lock.Locker {
-	return &observedLocker{inner: inner}
-}
+// WrapLocker returns a lock.Locker that wraps every acquisition with OTel
+// client spans. Without starter-otel the global TracerProvider is a no-op, so
+// the wrapper adds negligible overhead.
+func WrapLocker(inner lock.Locker) lock.Locker { return lock.Observe(inner, spanObserver{}) }
 
-type observedLocker struct {
-	inner lock.Locker
-}
+// spanObserver opens an OTel client span around each lock operation; the
+// wrapping itself, shared-hold methods included, is lock.ObservedLocker.
+type spanObserver struct{}
 
-func (l *observedLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
-	ctx, span := otel.Tracer(tracerName).Start(ctx, "lock.acquire",
-		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(
-			attribute.String("lock.key", key),
-			attribute.String("lock.system", "k8s"),
-		),
-	)
-	held, err := l.inner.Acquire(ctx, key, opts...)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		span.RecordError(err)
+func (spanObserver) Begin(ctx context.Context, op, key string, permits int) (context.Context, func(bool, error)) {
+	attrs := []attribute.KeyValue{attribute.String("lock.key", key), attribute.String("lock.system", "k8s")}
+	if permits > 0 {
+		attrs = append(attrs, attribute.Int("lock.permits", permits))
 	}
-	span.End()
-	return held, err
-}
-
-func (l *observedLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
-	ctx, span := otel.Tracer(tracerName).Start(ctx, "lock.try_acquire",
+	ctx, span := otel.Tracer(tracerName).Start(ctx, op,
 		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(
-			attribute.String("lock.key", key),
-			attribute.String("lock.system", "k8s"),
-		),
+		trace.WithAttributes(attrs...),
 	)
-	held, ok, err := l.inner.TryAcquire(ctx, key, opts...)
-	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
-		span.RecordError(err)
-	}
-	if !ok {
-		span.SetAttributes(attribute.Bool("lock.acquired", false))
+	return ctx, func(acquired bool, err error) {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		if !acquired {
+			span.SetAttributes(attribute.Bool("lock.acquired", false))
+		}
+		span.End()
 	}
-	span.End()
-	return held, ok, err
-}
-
-func (l *observedLocker) Close() error {
-	return l.inner.Close()
 }
@@ -55,13 +55,13 @@ func init() {
 		for name, c := range m {
 			b := r.Provide(newK8sLocker, gs.ValueArg(c)).
 				Name(name).
-				Export(gs.As[lock.Locker]()).
+				Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]()).
 				Destroy(destroyK8sLocker)
 			b.SetFileLine(file, line)
 			if c.Observer.Tracing.Enabled {
-				w := r.Provide(wrapLockerBean, gs.ValueArg(c), gs.TagArg(name)).
-					Name(name + "-observed").
-					Export(gs.As[lock.Locker]())
+				w := r.Provide(wrapLockerBean, gs.TagArg(name)).
+					Name(name+"-observed").
+					Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]())
 				w.SetFileLine(file, line)
 			}
 		}
@@ -44,6 +44,9 @@ wait_port 6379 30 || {
     exit 1
 }
 
+# Run the starter's read/write and semaphore tests against the live backend.
+( cd .. && STARTER_LOCK_REDIS_ADDR=127.0.0.1:6379 go test -count=1 . )
+
 out=$(mktemp)
 go run . >"${out}" 2>&1 &
 pid=$!
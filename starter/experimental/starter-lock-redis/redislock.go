@@ -56,9 +56,83 @@ end
 return 0
 `)
 
-// redisLocker implements lock.Locker on top of a *redis.Client using the
-// SET NX PX / compare-and-DEL / compare-and-PEXPIRE Redlock-single-node
-// pattern. Multi-node Redlock is not implemented: the common case is a single
+// The shared-hold scripts keep readers and semaphore permits in a sorted set
+// whose members are tokens scored by their expiry in Redis server milliseconds,
+// so an expired holder is pruned by whoever looks next and no client clock is
+// trusted. Each script reads the server clock with TIME, which requires effect
+// replication (the default since Redis 5; forced here for older servers).
+const nowMillis = `
+redis.replicate_commands()
+local t = redis.call('time')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+`
+
+// readScript adds a reader to KEYS[2] unless the writer key KEYS[1] exists.
+// ARGV: token, ttl millis. Returns 1 when granted, 0 when a writer holds it.
+var readScript = redis.NewScript(nowMillis + `
+if redis.call('exists', KEYS[1]) == 1 then
+    return 0
+end
+redis.call('zremrangebyscore', KEYS[2], '-inf', now)
+redis.call('zadd', KEYS[2], now + tonumber(ARGV[2]), ARGV[1])
+if redis.call('pttl', KEYS[2]) < tonumber(ARGV[2]) then
+    redis.call('pexpire', KEYS[2], ARGV[2])
+end
+return 1
+`)
+
+// writeScript sets the writer key KEYS[1] unless it exists or the reader set
+// KEYS[2] still holds a live reader. ARGV: token, ttl millis. Returns 1 when
+// granted, 0 when contended.
+var writeScript = redis.NewScript(nowMillis + `
+if redis.call('exists', KEYS[1]) == 1 then
+    return 0
+end
+redis.call('zremrangebyscore', KEYS[2], '-inf', now)
+if redis.call('zcard', KEYS[2]) > 0 then
+    return 0
+end
+redis.call('set', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+// permitScript adds a permit holder to KEYS[1] while fewer than ARGV[3] live
+// holders remain. ARGV: token, ttl millis, permits. Returns 1 when granted, 0
+// when every permit is taken.
+var permitScript = redis.NewScript(nowMillis + `
+redis.call('zremrangebyscore', KEYS[1], '-inf', now)
+if redis.call('zcard', KEYS[1]) >= tonumber(ARGV[3]) then
+    return 0
+end
+redis.call('zadd', KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+if redis.call('pttl', KEYS[1]) < tonumber(ARGV[2]) then
+    redis.call('pexpire', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// renewSharedScript pushes the expiry of a live member ARGV[1] of KEYS[1] out
+// by ARGV[2] millis. Returns 1 on success, 0 when the member expired or was
+// removed — which the renew loop treats as "lock lost".
+var renewSharedScript = redis.NewScript(nowMillis + `
+local score = redis.call('zscore', KEYS[1], ARGV[1])
+if not score or tonumber(score) <= now then
+    return 0
+end
+redis.call('zadd', KEYS[1], now + tonumber(ARGV[2]), ARGV[1])
+if redis.call('pttl', KEYS[1]) < tonumber(ARGV[2]) then
+    redis.call('pexpire', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// rwSlot namespaces write locks for re-entrancy bookkeeping so they never
+// share an entry with a plain mutex on the same key.
+const rwSlot = "rw\x00"
+
+// redisLocker implements lock.Locker, lock.RWLocker and lock.Semaphore on top
+// of a *redis.Client using the SET NX PX / compare-and-DEL / compare-and-PEXPIRE
+// Redlock-single-node pattern, plus sorted sets for shared holds. Multi-node Redlock is not implemented: the common case is a single
 // Redis (single, sentinel-failover, or a single cluster), and callers who need
 // stronger guarantees than a single Redis provides should reach for etcd/consul
 // backends via a blank-import swap.
@@ -69,6 +143,8 @@ type redisLocker struct {
 	stop     chan struct{}
 	stopOnce sync.Once
 	wg       sync.WaitGroup
+
+	reentrancy lock.Reentrancy
 }
 
 // newRedisLocker builds a locker over an already-constructed *redis.Client.
@@ -88,6 +164,17 @@ func newRedisLocker(cp *gs.ContextProvider, c Config, client *redis.Client) (*re
 // key applies KeyPrefix so multiple apps can safely share a Redis instance.
 func (l *redisLocker) key(k string) string { return l.cfg.KeyPrefix + k }
 
+// rwKeys returns the writer and reader-set keys of a read/write lock. The
+// prefixed key is a hash tag so both land in one cluster slot, as a
+// multi-key script requires.
+func (l *redisLocker) rwKeys(k string) (writer, readers string) {
+	tag := "{" + l.key(k) + "}"
+	return tag + ":rw:w", tag + ":rw:r"
+}
+
+// semKey returns the permit-set key of a semaphore.
+func (l *redisLocker) semKey(k string) string { return l.key(k) + ":sem" }
+
 // applyDefaults folds the starter-level defaults into caller-supplied options
 // so both "user gave nothing" and "user gave WithTTL only" see consistent
 // values. lock.Apply then normalizes anything still zero to package defaults.
@@ -124,6 +211,9 @@ func (l *redisLocker) applyDefaults(opts []lock.Option) []lock.Option {
 // a handle and, when auto-renew is enabled, spawns a renewLoop goroutine to
 // extend the lease until Unlock or Locker.Close.
 func (l *redisLocker) TryAcquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, key); ok {
+		return held, true, nil
+	}
 	o := lock.Apply(l.applyDefaults(opts)...)
 	fullKey := l.key(key)
 
@@ -134,10 +224,102 @@ func (l *redisLocker) TryAcquire(ctx context.Context, key string, opts ...lock.O
 	if !ok {
 		return nil, false, nil
 	}
+	h := l.hold(fullKey, fullKey, false, o)
+	return l.reentrancy.Track(ctx, key, h), true, nil
+}
+
+// Acquire polls TryAcquire until it succeeds or ctx ends. It matches the
+// MemoryLocker contract: contention returns after a bounded sleep, backend
+// errors surface immediately.
+func (l *redisLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return l.poll(ctx, opts, func() (lock.Lock, bool, error) {
+		return l.TryAcquire(ctx, key, opts...)
+	})
+}
+
+// TryAcquireRead adds a reader to the lock's reader set unless a writer holds
+// it.
+func (l *redisLocker) TryAcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	o := lock.Apply(l.applyDefaults(opts)...)
+	writer, readers := l.rwKeys(key)
+	ok, err := l.runAcquire(ctx, readScript, []string{writer, readers}, o.Token, o.TTL.Milliseconds())
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return l.hold(l.key(key), readers, true, o), true, nil
+}
+
+// AcquireRead polls TryAcquireRead until it succeeds or ctx ends.
+func (l *redisLocker) AcquireRead(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return l.poll(ctx, opts, func() (lock.Lock, bool, error) {
+		return l.TryAcquireRead(ctx, key, opts...)
+	})
+}
+
+// TryAcquireWrite sets the lock's writer key unless a writer or a live reader
+// holds it. The writer key then behaves like a mutex key for renew and unlock.
+func (l *redisLocker) TryAcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, bool, error) {
+	if held, ok := l.reentrancy.Reenter(ctx, rwSlot+key); ok {
+		return held, true, nil
+	}
+	o := lock.Apply(l.applyDefaults(opts)...)
+	writer, readers := l.rwKeys(key)
+	ok, err := l.runAcquire(ctx, writeScript, []string{writer, readers}, o.Token, o.TTL.Milliseconds())
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	h := l.hold(l.key(key), writer, false, o)
+	return l.reentrancy.Track(ctx, rwSlot+key, h), true, nil
+}
+
+// AcquireWrite polls TryAcquireWrite until it succeeds or ctx ends.
+func (l *redisLocker) AcquireWrite(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
+	return l.poll(ctx, opts, func() (lock.Lock, bool, error) {
+		return l.TryAcquireWrite(ctx, key, opts...)
+	})
+}
+
+// TryAcquirePermit adds a holder to the semaphore's permit set while fewer
+// than permits live holders remain.
+func (l *redisLocker) TryAcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, bool, error) {
+	if permits <= 0 {
+		return nil, false, lock.ErrInvalidPermits
+	}
+	o := lock.Apply(l.applyDefaults(opts)...)
+	k := l.semKey(key)
+	ok, err := l.runAcquire(ctx, permitScript, []string{k}, o.Token, o.TTL.Milliseconds(), permits)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return l.hold(l.key(key), k, true, o), true, nil
+}
+
+// AcquirePermit polls TryAcquirePermit until it succeeds or ctx ends.
+func (l *redisLocker) AcquirePermit(ctx context.Context, key string, permits int, opts ...lock.Option) (lock.Lock, error) {
+	return l.poll(ctx, opts, func() (lock.Lock, bool, error) {
+		return l.TryAcquirePermit(ctx, key, permits, opts...)
+	})
+}
 
+// runAcquire runs one of the acquire scripts and reports whether it granted
+// the hold.
+func (l *redisLocker) runAcquire(ctx context.Context, script *redis.Script, keys []string, args ...any) (bool, error) {
+	res, err := script.Run(ctx, l.client, keys, args...).Int64()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// hold builds the handle for a granted acquisition and, when auto-renew is
+// enabled, spawns its renewLoop. rkey is the Redis key holding the lease: the
+// lock key itself, or the sorted set a shared hold is a member of.
+func (l *redisLocker) hold(key, rkey string, shared bool, o lock.Options) *redisLock {
 	h := &redisLock{
 		locker: l,
-		key:    fullKey,
+		key:    key,
+		rkey:   rkey,
+		shared: shared,
 		token:  o.Token,
 		lost:   make(chan struct{}),
 		done:   make(chan struct{}),
@@ -146,33 +328,22 @@ func (l *redisLocker) TryAcquire(ctx context.Context, key string, opts ...lock.O
 		l.wg.Add(1)
 		go l.renewLoop(h, o.TTL, o.RenewInterval)
 	}
-	return h, true, nil
+	return h
 }
 
-// Acquire polls TryAcquire until it succeeds or ctx ends. It matches the
-// MemoryLocker contract: contention returns after a bounded sleep, backend
-// errors surface immediately.
-func (l *redisLocker) Acquire(ctx context.Context, key string, opts ...lock.Option) (lock.Lock, error) {
-	o := lock.Apply(l.applyDefaults(opts)...)
-	for {
-		held, ok, err := l.TryAcquire(ctx, key, opts...)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			return held, nil
-		}
-		t := time.NewTimer(o.RetryInterval)
+// poll runs lock.Poll with the locker's default options; it also gives up
+// with context.Canceled once the locker closes.
+func (l *redisLocker) poll(ctx context.Context, opts []lock.Option, try func() (lock.Lock, bool, error)) (lock.Lock, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
 		select {
-		case <-ctx.Done():
-			t.Stop()
-			return nil, ctx.Err()
 		case <-l.stop:
-			t.Stop()
-			return nil, context.Canceled
-		case <-t.C:
+			cancel()
+		case <-ctx.Done():
 		}
-	}
+	}()
+	return lock.Poll(ctx, lock.Apply(l.applyDefaults(opts)...), try)
 }
 
 // Close signals every renew goroutine to exit and waits for them. It does not
@@ -206,8 +377,12 @@ func (l *redisLocker) renewLoop(h *redisLock, ttl, every time.Duration) {
 		case <-l.stop:
 			return
 		case <-ticker.C:
+			script := renewScript
+			if h.shared {
+				script = renewSharedScript
+			}
 			ctx, cancel := context.WithTimeout(context.Background(), every)
-			res, err := renewScript.Run(ctx, l.client, []string{h.key}, h.token, ttlMillis).Int64()
+			res, err := script.Run(ctx, l.client, []string{h.rkey}, h.token, ttlMillis).Int64()
 			cancel()
 			if err != nil {
 				// Transient failure: try again on the next tick. Redis is the
@@ -216,8 +391,8 @@ func (l *redisLocker) renewLoop(h *redisLock, ttl, every time.Duration) {
 				continue
 			}
 			if res == 0 {
-				// The key vanished or a new owner took over: we no longer hold
-				// the lock. Fire Lost() so the critical section can bail.
+				// The key or member vanished, or a new owner took over: we no
+				// longer hold the lock. Fire Lost() so the critical section can bail.
 				h.markLost()
 				return
 			}
@@ -225,12 +400,15 @@ func (l *redisLocker) renewLoop(h *redisLock, ttl, every time.Duration) {
 	}
 }
 
-// redisLock is a currently-held lock returned by redisLocker.TryAcquire.
-// It carries the (prefixed) key, the fencing token, and a `lost` channel
-// closed exactly once when the lease is lost or the handle is unlocked.
+// redisLock is a currently-held lock returned by redisLocker. It carries the
+// (prefixed) key, the fencing token, and a `lost` channel closed exactly once
+// when the lease is lost or the handle is unlocked. A shared hold (reader or
+// permit) is a member of the sorted set rkey rather than the owner of it.
 type redisLock struct {
 	locker *redisLocker
 	key    string
+	rkey   string
+	shared bool
 	token  string
 
 	lost     chan struct{}
@@ -261,16 +439,23 @@ func (h *redisLock) stopRenew() {
 	})
 }
 
-// Unlock runs the compare-and-DEL script exactly once. It is idempotent:
-// second and later calls are no-ops. It returns lock.ErrNotHeld only when
-// Redis proves another token owns the key (script returned -1).
+// Unlock runs the compare-and-DEL script (ZREM for a shared hold) exactly
+// once. It is idempotent: second and later calls are no-ops. It returns
+// lock.ErrNotHeld only when Redis proves another token owns the key (script
+// returned -1); a shared hold cannot be taken over, only expire.
 func (h *redisLock) Unlock(ctx context.Context) error {
 	h.unlockOnce.Do(func() {
 		// Signal the renew loop to stop before we touch Redis so it does not
 		// race a fresh PEXPIRE against our DEL.
 		h.stopRenew()
 
-		res, err := unlockScript.Run(ctx, h.locker.client, []string{h.key}, h.token).Int64()
+		var res int64
+		var err error
+		if h.shared {
+			err = h.locker.client.ZRem(ctx, h.rkey, h.token).Err()
+		} else {
+			res, err = unlockScript.Run(ctx, h.locker.client, []string{h.rkey}, h.token).Int64()
+		}
 		if err != nil {
 			h.unlockErr = err
 			// Even on backend error, mark lost so Lost() consumers unblock.
@@ -287,6 +472,7 @@ func (h *redisLock) Unlock(ctx context.Context) error {
 
 // Ensure interface compliance at compile time.
 var (
-	_ lock.Locker = (*redisLocker)(nil)
-	_ lock.Lock   = (*redisLock)(nil)
+	_ lock.RWLocker  = (*redisLocker)(nil)
+	_ lock.Semaphore = (*redisLocker)(nil)
+	_ lock.Lock      = (*redisLock)(nil)
 )
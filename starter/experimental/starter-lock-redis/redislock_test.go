@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterLockRedis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/spring/gs"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+// newTestLocker builds a locker against the Redis at STARTER_LOCK_REDIS_ADDR
+// (e.g. the one from example/docker-compose.yml) and skips when it is unset.
+// Every test gets its own key prefix so runs never see each other's keys.
+func newTestLocker(t *testing.T) *redisLocker {
+	addr := os.Getenv("STARTER_LOCK_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("STARTER_LOCK_REDIS_ADDR not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	l, err := newRedisLocker(&gs.ContextProvider{Context: context.Background()}, Config{
+		Client:        "test",
+		TTL:           10 * time.Second,
+		RetryInterval: 50 * time.Millisecond,
+		KeyPrefix:     fmt.Sprintf("starter-lock-redis:test:%d:", time.Now().UnixNano()),
+	}, client)
+	assert.Error(t, err).Nil()
+	t.Cleanup(func() { assert.Error(t, l.Close()).Nil() })
+	return l
+}
+
+// TestReadWriteLocks proves readers share a key while they exclude the writer,
+// and that the writer takes the key once every reader has unlocked.
+func TestReadWriteLocks(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocker(t)
+
+	r1, ok, err := l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	r2, ok, err := l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+
+	_, ok, err = l.TryAcquireWrite(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+
+	assert.Error(t, r1.Unlock(ctx)).Nil()
+	assert.Error(t, r2.Unlock(ctx)).Nil()
+
+	w, ok, err := l.TryAcquireWrite(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	_, ok, err = l.TryAcquireRead(ctx, "cfg")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+	assert.Error(t, w.Unlock(ctx)).Nil()
+}
+
+// TestAcquireWriteWaitsForReader proves the blocking form polls until the
+// last reader unlocks.
+func TestAcquireWriteWaitsForReader(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocker(t)
+
+	r, ok, err := l.TryAcquireRead(ctx, "doc")
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = r.Unlock(ctx)
+	}()
+
+	actx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	w, err := l.AcquireWrite(actx, "doc")
+	assert.Error(t, err).Nil()
+	assert.Error(t, w.Unlock(ctx)).Nil()
+}
+
+// TestPermitSlots proves a semaphore hands out at most permits holds.
+func TestPermitSlots(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocker(t)
+
+	var held []lock.Lock
+	for range 2 {
+		h, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+		assert.Error(t, err).Nil()
+		assert.That(t, ok).True()
+		held = append(held, h)
+	}
+	_, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).False()
+
+	assert.Error(t, held[0].Unlock(ctx)).Nil()
+	h, ok, err := l.TryAcquirePermit(ctx, "exports", 2)
+	assert.Error(t, err).Nil()
+	assert.That(t, ok).True()
+	assert.Error(t, h.Unlock(ctx)).Nil()
+	assert.Error(t, held[1].Unlock(ctx)).Nil()
+}
@@ -69,14 +69,14 @@ func init() {
 			// seam that ties the Locker to a specific redis instance.
 			b := r.Provide(newRedisLocker, gs.ValueArg(c), gs.TagArg(c.Client)).
 				Name(name).
-				Export(gs.As[lock.Locker]()).
+				Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]()).
 				Destroy(destroyLocker)
 			b.SetFileLine(file, line)
 
 			if c.Observer.Tracing.Enabled {
-				w := r.Provide(wrapLockerBean, gs.ValueArg(c), gs.TagArg(c.Client)).
-					Name(name + "-observed").
-					Export(gs.As[lock.Locker]())
+				w := r.Provide(wrapLockerBean, gs.TagArg(name)).
+					Name(name+"-observed").
+					Export(gs.As[lock.Locker](), gs.As[lock.RWLocker](), gs.As[lock.Semaphore]())
 				w.SetFileLine(file, line)
 			}
 		}
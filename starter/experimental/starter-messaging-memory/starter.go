@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package StarterMessagingMemory contributes the in-process
+// [messaging.MemoryBinder] as the application's [messaging.Binder], built
+// from spring.messaging.memory.* rather than the defaults of the binder
+// registered as "memory". It is enabled by a blank import plus one property:
+//
+//	import _ "go-spring.org/starter-messaging-memory"
+//	# spring.messaging.binder=memory
+//
+// The binder is closed when the application shuts down. It suits tests and
+// single-process deployments; messages do not survive a restart.
+package StarterMessagingMemory
+
+import (
+	"context"
+
+	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/spring/gs"
+)
+
+var (
+	// starterTag identifies logs emitted by the messaging memory starter.
+	starterTag = log.RegisterInfraTag("starter_messaging_memory", "")
+)
+
+func init() {
+	gs.Provide(newBinder, gs.TagArg("${spring.messaging.memory}")).
+		Condition(gs.OnProperty("spring.messaging.binder").HavingValue("memory")).
+		Export(gs.As[messaging.Binder]()).
+		Destroy((*messaging.MemoryBinder).Close)
+}
+
+// newBinder builds a dedicated binder from the bound configuration.
+func newBinder(c messaging.MemoryBinderConfig) *messaging.MemoryBinder {
+	log.Infof(context.Background(), starterTag, "memory binder created buffer=%d max-redeliveries=%d redelivery-delay=%s",
+		c.Buffer, c.MaxRedeliveries, c.RedeliveryDelay)
+	return messaging.NewMemoryBinder(c)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterMessagingMemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestNewBinder_AppliesConfig(t *testing.T) {
+	ctx := context.Background()
+	b := newBinder(messaging.MemoryBinderConfig{Buffer: 1, MaxRedeliveries: -1, RedeliveryDelay: time.Millisecond})
+
+	sub, err := b.NewSubscriber(ctx, "orders", "g")
+	assert.Error(t, err).Nil()
+	err = sub.Subscribe(ctx, func(context.Context, *messaging.Message) error {
+		return context.Canceled
+	})
+	assert.Error(t, err).Nil()
+	defer func() { _ = sub.Close() }()
+
+	pub, err := b.NewPublisher(ctx, "orders")
+	assert.Error(t, err).Nil()
+	assert.Error(t, pub.Publish(ctx, &messaging.Message{})).Nil()
+	assert.Error(t, b.WaitIdle(ctx)).Nil()
+	// Redelivery is disabled, so the failed message is dropped at once.
+	assert.That(t, len(b.Dropped("orders"))).Equal(1)
+
+	// Unlike the registered "memory" binder, this one really closes.
+	assert.Error(t, b.Close()).Nil()
+	assert.Error(t, pub.Publish(ctx, &messaging.Message{})).Is(messaging.ErrBinderClosed)
+}
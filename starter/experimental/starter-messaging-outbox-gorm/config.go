@@ -0,0 +1,43 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterMessagingOutboxGorm
+
+import "time"
+
+// outboxConfig binds ${spring.messaging.outbox}. It configures the gorm-backed
+// outbox store and the relay that drains it.
+type outboxConfig struct {
+	// Store selects the outbox store; it must be "gorm" for this starter to
+	// register anything.
+	Store string `value:"${store:=}"`
+
+	// Relay configures the relay server.
+	Relay relayConfig `value:"${relay}"`
+}
+
+// relayConfig binds ${spring.messaging.outbox.relay}. A negative Retention
+// disables cleanup; a negative MaxAttempts never parks a failing record.
+// ${spring.messaging.outbox.relay.enabled=false} turns the relay off on
+// replicas that should only write to the outbox.
+type relayConfig struct {
+	BatchSize       int           `value:"${batch-size:=100}"`
+	PollInterval    time.Duration `value:"${poll-interval:=1s}"`
+	Retention       time.Duration `value:"${retention:=168h}"`
+	CleanupInterval time.Duration `value:"${cleanup-interval:=1h}"`
+	MaxAttempts     int           `value:"${max-attempts:=10}"`
+	LockKey         string        `value:"${lock-key:=messaging:outbox:relay}"`
+}
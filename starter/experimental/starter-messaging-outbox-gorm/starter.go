@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package StarterMessagingOutboxGorm contributes a transactional outbox for the
+// messaging abstraction: a gorm-backed [messaging.OutboxStore] and a relay
+// server that delivers the stored messages through the application's
+// [messaging.Binder]. It is enabled by a blank import plus one property:
+//
+//	import _ "go-spring.org/starter-messaging-outbox-gorm"
+//	# spring.messaging.outbox.store=gorm
+//
+// Business code publishes through messaging.NewOutboxPublisher inside its own
+// gorm transaction, handing the transaction over with WithTx:
+//
+//	err := db.Transaction(func(tx *gorm.DB) error {
+//	    if err := tx.Create(&order).Error; err != nil {
+//	        return err
+//	    }
+//	    pub := messaging.NewOutboxPublisher(s.Outbox, "orders")
+//	    return pub.Publish(StarterMessagingOutboxGorm.WithTx(ctx, tx), msg)
+//	})
+//
+// The message row commits or rolls back with the order row; the relay
+// publishes it afterwards. When a lock.Locker bean is present the relay runs
+// under leader election, so only one replica relays at a time.
+package StarterMessagingOutboxGorm
+
+import (
+	"context"
+	"errors"
+
+	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/lock"
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/spring/gs"
+	"gorm.io/gorm"
+)
+
+var (
+	// starterTag identifies logs emitted by the messaging outbox-gorm starter.
+	starterTag = log.RegisterInfraTag("starter_outbox_gorm", "")
+)
+
+func init() {
+	selected := gs.OnProperty("spring.messaging.outbox.store").HavingValue("gorm")
+
+	// The store autowires the application's *gorm.DB (second arg) and is
+	// exported under the messaging.OutboxStore interface.
+	gs.Provide(newGormStore, gs.TagArg("${spring.messaging.outbox}"), gs.TagArg("")).
+		Condition(selected).
+		Export(gs.As[messaging.OutboxStore]())
+
+	// The relay plugs into the server lifecycle so it starts once the
+	// application is ready and stops with it.
+	gs.Provide(&RelayServer{}).
+		Name("outboxRelayServer").
+		Condition(selected, gs.OnProperty("spring.messaging.outbox.relay.enabled").HavingValue("true").MatchIfMissing()).
+		Export(gs.As[gs.Server]())
+}
+
+// newGormStore builds the store from an autowired *gorm.DB, creating the
+// messaging_outbox table if absent (fail-fast on error).
+func newGormStore(_ outboxConfig, db *gorm.DB) (messaging.OutboxStore, error) {
+	if err := db.AutoMigrate(&outboxRow{}); err != nil {
+		log.Errorf(context.Background(), starterTag, "auto-migrate messaging_outbox failed: %v", err)
+		return nil, err
+	}
+	log.Infof(context.Background(), starterTag, "gorm outbox store created")
+	return &gormStore{db: db}, nil
+}
+
+// RelayServer runs a messaging.OutboxRelay as part of the Go-Spring server
+// lifecycle. It opens no port. Its exported fields are populated by the
+// container; Locker is optional and enables leader election.
+type RelayServer struct {
+	Config outboxConfig          `value:"${spring.messaging.outbox}"`
+	Store  messaging.OutboxStore `autowire:""`
+	Binder messaging.Binder      `autowire:""`
+	Locker lock.Locker           `autowire:"?"`
+
+	cancel context.CancelFunc
+}
+
+// Run starts relaying once the application is ready and blocks until
+// shutdown.
+func (s *RelayServer) Run(ctx context.Context, sig gs.ReadySignal) error {
+	c := s.Config.Relay
+	relay := messaging.NewOutboxRelay(messaging.OutboxRelayConfig{
+		Store:           s.Store,
+		Binder:          s.Binder,
+		BatchSize:       c.BatchSize,
+		PollInterval:    c.PollInterval,
+		Retention:       c.Retention,
+		CleanupInterval: c.CleanupInterval,
+		MaxAttempts:     c.MaxAttempts,
+		Locker:          s.Locker,
+		LockKey:         c.LockKey,
+		OnError: func(rec messaging.OutboxRecord, err error) {
+			log.Warnf(ctx, starterTag, "relay outbox record %d to %s failed (attempt %d): %v",
+				rec.ID, rec.Destination, rec.Attempts+1, err)
+		},
+		OnPark: func(rec messaging.OutboxRecord) {
+			log.Errorf(ctx, starterTag, "outbox record %d to %s parked after %d attempts: %s",
+				rec.ID, rec.Destination, rec.Attempts, rec.LastError)
+		},
+		OnRelayError: func(err error) {
+			log.Errorf(ctx, starterTag, "outbox relay: %v", err)
+		},
+	})
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	<-sig.TriggerAndWait()
+
+	log.Infof(ctx, starterTag, "outbox relay started (leader election: %t)", s.Locker != nil)
+	if err := relay.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Stop ends relaying; records not yet published stay in the outbox and are
+// picked up by the next leader or the next start.
+func (s *RelayServer) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterMessagingOutboxGorm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// WithTx returns a copy of ctx carrying tx, so that an outbox publish made
+// with it is written inside that transaction and commits or rolls back with
+// the caller's other writes. Without it the store writes with its own
+// connection, outside any transaction.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// outboxRow is the persisted row for one outbox record, in table
+// messaging_outbox. Headers are stored JSON-encoded in a text column so the
+// schema stays backend-agnostic. SentAt and ParkedAt are NULL while the record
+// is pending and indexed so the relay's scan stays cheap. KeySeq numbers the
+// rows of one destination and key; it is NULL for unkeyed rows.
+type outboxRow struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement;column:id"`
+	Destination string     `gorm:"column:destination;size:255;uniqueIndex:idx_messaging_outbox_key_seq,priority:1"`
+	MsgKey      string     `gorm:"column:msg_key;size:255;uniqueIndex:idx_messaging_outbox_key_seq,priority:2"`
+	KeySeq      *int64     `gorm:"column:key_seq;uniqueIndex:idx_messaging_outbox_key_seq,priority:3"`
+	Payload     []byte     `gorm:"column:payload"`
+	Headers     string     `gorm:"column:headers;type:text"` // JSON-encoded map[string]string
+	Timestamp   time.Time  `gorm:"column:msg_timestamp"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	SentAt      *time.Time `gorm:"column:sent_at;index"`
+	ParkedAt    *time.Time `gorm:"column:parked_at;index"`
+	Attempts    int        `gorm:"column:attempts"`
+	LastError   string     `gorm:"column:last_error;type:text"`
+}
+
+// TableName pins the table name regardless of gorm's pluralization rules.
+func (outboxRow) TableName() string { return "messaging_outbox" }
+
+// gormStore is a durable [messaging.OutboxStore] backed by a *gorm.DB. IDs come
+// from the auto-increment primary key, which gives the relay its publish
+// order.
+//
+// An auto-increment ID is taken at insert, not at commit, so on its own it
+// would let a later transaction commit a lower-numbered row after a higher one
+// was already relayed. Keyed rows therefore also take the next KeySeq of their
+// destination and key, guarded by a unique index: two open transactions
+// writing the same key cannot both commit, so per key the committed rows
+// appear in ID order. The loser fails with the database's unique-violation
+// error and should be retried like any other write conflict. Unkeyed rows
+// promise no order and take no sequence.
+type gormStore struct {
+	db *gorm.DB
+}
+
+var _ messaging.OutboxStore = (*gormStore)(nil)
+
+// conn returns the transaction carried by ctx, or the store's own handle.
+func (s *gormStore) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok && tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return s.db.WithContext(ctx)
+}
+
+// Append inserts the records in the caller's transaction and assigns their IDs.
+func (s *gormStore) Append(ctx context.Context, records ...*messaging.OutboxRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	conn := s.conn(ctx)
+	rows := make([]outboxRow, len(records))
+	next := make(map[[2]string]int64)
+	for i, rec := range records {
+		row, err := toRow(rec)
+		if err != nil {
+			return err
+		}
+		if row.MsgKey != "" {
+			k := [2]string{row.Destination, row.MsgKey}
+			seq, ok := next[k]
+			if !ok {
+				if seq, err = s.lastSeq(conn, k[0], k[1]); err != nil {
+					return err
+				}
+			}
+			seq++
+			next[k] = seq
+			row.KeySeq = &seq
+		}
+		rows[i] = row
+	}
+	if err := conn.Create(&rows).Error; err != nil {
+		return err
+	}
+	for i, rec := range records {
+		rec.ID = rows[i].ID
+	}
+	return nil
+}
+
+// lastSeq returns the highest KeySeq stored for destination and key, or 0.
+func (s *gormStore) lastSeq(conn *gorm.DB, destination, key string) (int64, error) {
+	var last sql.NullInt64
+	err := conn.Model(&outboxRow{}).
+		Where("destination = ? AND msg_key = ?", destination, key).
+		Select("MAX(key_seq)").
+		Scan(&last).Error
+	return last.Int64, err
+}
+
+// Pending returns up to limit unsent, unparked records in ID order.
+func (s *gormStore) Pending(ctx context.Context, limit int) ([]messaging.OutboxRecord, error) {
+	var rows []outboxRow
+	err := s.db.WithContext(ctx).
+		Where("sent_at IS NULL AND parked_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]messaging.OutboxRecord, 0, len(rows))
+	for _, row := range rows {
+		rec, err := fromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+// MarkSent stamps sent_at on the records.
+func (s *gormStore) MarkSent(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.WithContext(ctx).
+		Model(&outboxRow{}).
+		Where("id IN ?", ids).
+		Update("sent_at", time.Now()).Error
+}
+
+// MarkFailed increments attempts and keeps the last error.
+func (s *gormStore) MarkFailed(ctx context.Context, id int64, cause string) error {
+	return s.db.WithContext(ctx).
+		Model(&outboxRow{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause,
+		}).Error
+}
+
+// Park stamps parked_at on the record. To retry it later, clear parked_at
+// (and reset attempts) with plain SQL.
+func (s *gormStore) Park(ctx context.Context, id int64) error {
+	return s.db.WithContext(ctx).
+		Model(&outboxRow{}).
+		Where("id = ?", id).
+		Update("parked_at", time.Now()).Error
+}
+
+// Purge deletes records sent before sentBefore.
+func (s *gormStore) Purge(ctx context.Context, sentBefore time.Time) (int, error) {
+	res := s.db.WithContext(ctx).
+		Where("sent_at IS NOT NULL AND sent_at < ?", sentBefore).
+		Delete(&outboxRow{})
+	return int(res.RowsAffected), res.Error
+}
+
+// toRow encodes a record into its persisted row form.
+func toRow(rec *messaging.OutboxRecord) (outboxRow, error) {
+	var headers string
+	if len(rec.Message.Headers) > 0 {
+		b, err := json.Marshal(rec.Message.Headers)
+		if err != nil {
+			return outboxRow{}, err
+		}
+		headers = string(b)
+	}
+	created := rec.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	return outboxRow{
+		Destination: rec.Destination,
+		MsgKey:      rec.Message.Key,
+		Payload:     rec.Message.Payload,
+		Headers:     headers,
+		Timestamp:   rec.Message.Timestamp,
+		CreatedAt:   created,
+		Attempts:    rec.Attempts,
+		LastError:   rec.LastError,
+	}, nil
+}
+
+// fromRow decodes a persisted row back into a record.
+func fromRow(row outboxRow) (messaging.OutboxRecord, error) {
+	var headers map[string]string
+	if row.Headers != "" {
+		if err := json.Unmarshal([]byte(row.Headers), &headers); err != nil {
+			return messaging.OutboxRecord{}, err
+		}
+	}
+	rec := messaging.OutboxRecord{
+		ID:          row.ID,
+		Destination: row.Destination,
+		Message: messaging.Message{
+			Key:       row.MsgKey,
+			Payload:   row.Payload,
+			Headers:   headers,
+			Timestamp: row.Timestamp,
+		},
+		CreatedAt: row.CreatedAt,
+		Attempts:  row.Attempts,
+		LastError: row.LastError,
+	}
+	if row.SentAt != nil {
+		rec.SentAt = *row.SentAt
+	}
+	if row.ParkedAt != nil {
+		rec.ParkedAt = *row.ParkedAt
+	}
+	return rec, nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterMessagingOutboxGorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/messaging"
+	"go-spring.org/stdlib/testing/assert"
+	sqlite "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newTestStore opens a fresh in-memory sqlite database and migrates the schema.
+func newTestStore(t *testing.T) *gormStore {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.Error(t, err).Nil()
+	assert.Error(t, db.AutoMigrate(&outboxRow{})).Nil()
+	return &gormStore{db: db}
+}
+
+func TestGormStore_TransactionalAppend(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	pub := messaging.NewOutboxPublisher(store, "orders")
+
+	// A rolled-back transaction leaves no outbox row behind.
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		assert.Error(t, pub.Publish(WithTx(ctx, tx), &messaging.Message{Key: "o-1", Payload: []byte("x")})).Nil()
+		return errors.New("rollback")
+	})
+	assert.Error(t, err).Matches("rollback")
+	pending, err := store.Pending(ctx, 10)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(pending)).Equal(0)
+
+	// A committed one does, with headers and key intact.
+	err = store.db.Transaction(func(tx *gorm.DB) error {
+		msg := &messaging.Message{Key: "o-2", Payload: []byte("y")}
+		msg.SetHeader("traceparent", "00-abc-def-01")
+		return pub.Publish(WithTx(ctx, tx), msg)
+	})
+	assert.Error(t, err).Nil()
+	pending, err = store.Pending(ctx, 10)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(pending)).Equal(1)
+	assert.String(t, pending[0].Destination).Equal("orders")
+	assert.String(t, pending[0].Message.Key).Equal("o-2")
+	assert.String(t, string(pending[0].Message.Payload)).Equal("y")
+	assert.String(t, pending[0].Message.Header("traceparent")).Equal("00-abc-def-01")
+}
+
+func TestGormStore_MarkAndPurge(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	a := &messaging.OutboxRecord{Destination: "d", Message: messaging.Message{Payload: []byte("a")}}
+	b := &messaging.OutboxRecord{Destination: "d", Message: messaging.Message{Payload: []byte("b")}}
+	assert.Error(t, store.Append(ctx, a, b)).Nil()
+	assert.That(t, b.ID > a.ID).True()
+
+	assert.Error(t, store.MarkFailed(ctx, a.ID, "broker down")).Nil()
+	assert.Error(t, store.MarkSent(ctx, b.ID)).Nil()
+
+	pending, err := store.Pending(ctx, 10)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(pending)).Equal(1)
+	assert.That(t, pending[0].ID).Equal(a.ID)
+	assert.That(t, pending[0].Attempts).Equal(1)
+	assert.String(t, pending[0].LastError).Equal("broker down")
+
+	n, err := store.Purge(ctx, time.Now().Add(time.Second))
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(1)
+	pending, _ = store.Pending(ctx, 10)
+	assert.That(t, len(pending)).Equal(1) // pending records are never purged
+}
+
+func TestGormStore_Park(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	a := &messaging.OutboxRecord{Destination: "d", Message: messaging.Message{Payload: []byte("a")}}
+	assert.Error(t, store.Append(ctx, a)).Nil()
+	assert.Error(t, store.Park(ctx, a.ID)).Nil()
+
+	pending, err := store.Pending(ctx, 10)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(pending)).Equal(0)
+
+	n, err := store.Purge(ctx, time.Now().Add(time.Second))
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(0) // parked records are kept for inspection
+}
+
+func TestGormStore_KeySequence(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	rec := func(key string) *messaging.OutboxRecord {
+		return &messaging.OutboxRecord{Destination: "d", Message: messaging.Message{Key: key}}
+	}
+	assert.Error(t, store.Append(ctx, rec("k"), rec("k"), rec(""), rec("j"))).Nil()
+	assert.Error(t, store.Append(ctx, rec("k"), rec(""))).Nil()
+
+	var rows []outboxRow
+	assert.Error(t, store.db.Order("id").Find(&rows).Error).Nil()
+	var seqs []int64
+	for _, row := range rows {
+		if row.KeySeq == nil {
+			seqs = append(seqs, 0)
+			continue
+		}
+		seqs = append(seqs, *row.KeySeq)
+	}
+	assert.That(t, seqs).Equal([]int64{1, 2, 0, 1, 3, 0})
+
+	// A second writer that read the same last sequence cannot commit.
+	dup := int64(3)
+	err := store.db.Create(&outboxRow{Destination: "d", MsgKey: "k", KeySeq: &dup}).Error
+	assert.Error(t, err).NotNil()
+}
@@ -34,6 +34,11 @@ import (
 // the message header via StartPublishSpan and consume extracts it via
 // StartConsumeSpan, so a trace links producer to consumer across services. All
 // tracing is a no-op without starter-otel.
+//
+// The binder implements messaging.NativeRequester, so messaging.Requester
+// uses NATS request/reply with inboxes instead of a reply subscription; a
+// request's inbox arrives at the responder as the messaging.HeaderReplyTo
+// header and is a valid publish subject.
 func NewBinder(conn *Conn) messaging.Binder {
 	return &binder{conn: conn}
 }
@@ -44,6 +49,19 @@ func (b *binder) NewPublisher(_ context.Context, destination string) (messaging.
 	return &publisher{conn: b.conn, subject: destination}, nil
 }
 
+// Request sends msg on destination and waits for the reply on a NATS inbox
+// until ctx is done.
+func (b *binder) Request(ctx context.Context, destination string, msg *messaging.Message) (*messaging.Message, error) {
+	nm := &nats.Msg{Subject: destination, Data: msg.Payload, Header: toNatsHeader(msg.Headers)}
+	ctx, span := StartPublishSpan(ctx, nm)
+	reply, err := b.conn.RequestMsgWithContext(ctx, nm)
+	EndSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return fromNatsMsg(reply), nil
+}
+
 func (b *binder) NewSubscriber(_ context.Context, source, group string) (messaging.Subscriber, error) {
 	return &subscriber{conn: b.conn, subject: source, group: group}, nil
 }
@@ -118,6 +136,8 @@ func toNatsHeader(h map[string]string) nats.Header {
 
 // fromNatsMsg builds a messaging.Message from a received nats.Msg, flattening
 // the multi-valued header into the single-valued envelope form (first value wins).
+// A native reply subject is exposed as messaging.HeaderReplyTo so generic
+// reply handlers can answer it.
 func fromNatsMsg(nm *nats.Msg) *messaging.Message {
 	var headers map[string]string
 	if len(nm.Header) > 0 {
@@ -126,5 +146,9 @@ func fromNatsMsg(nm *nats.Msg) *messaging.Message {
 			headers[k] = nm.Header.Get(k)
 		}
 	}
-	return &messaging.Message{Payload: nm.Data, Headers: headers}
+	msg := &messaging.Message{Payload: nm.Data, Headers: headers}
+	if nm.Reply != "" && msg.Header(messaging.HeaderReplyTo) == "" {
+		msg.SetHeader(messaging.HeaderReplyTo, nm.Reply)
+	}
+	return msg
 }
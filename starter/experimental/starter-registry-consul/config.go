@@ -46,29 +46,3 @@ type ConsulConfig struct {
 	// skipped Deregister). Zero disables auto-deregistration.
 	DeregisterCriticalAfter time.Duration `value:"${deregister-critical-after:=1m}"`
 }
-
-// RegistrationConfig binds the instance to advertise, under ${spring.registry}.
-// These fields describe the instance itself and are independent of which
-// registry backend this starter ships; swapping backends means swapping the
-// starter (and thus this struct's home), not a config migration.
-type RegistrationConfig struct {
-	// ServiceName is the logical name to publish — the same name discovery
-	// clients later resolve. Required.
-	ServiceName string `value:"${service-name:=}"`
-
-	// Addr is the connectable "host:port" advertised to clients. Required; the
-	// starter never guesses it, so a misconfiguration fails at startup.
-	Addr string `value:"${addr:=}"`
-
-	// ID overrides the instance id within the service; empty derives a stable one
-	// from ServiceName and Addr so restarts replace the same entry.
-	ID string `value:"${id:=}"`
-
-	// Weight is the load-balancing weight advertised to clients; 0 uses the
-	// backend default.
-	Weight int `value:"${weight:=0}"`
-
-	// Metadata is arbitrary key/value attributes stored with the instance
-	// (zone, unit, version, ...), bound from ${spring.registry.metadata.*}.
-	Metadata map[string]string `value:"${metadata:=}"`
-}
@@ -18,6 +18,7 @@ package StarterRegistryConsul
 
 import (
 	"context"
+	"maps"
 	"net"
 	"strconv"
 	"sync"
@@ -25,30 +26,30 @@ import (
 
 	"github.com/hashicorp/consul/api"
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/errutil"
 )
 
-// instance is the instance this process advertises to the Consul registry. It is
-// the local write-side value built from RegistrationConfig in Server.Run; the
-// crash-safety contract every registry starter follows lives in starter/DESIGN
-// §3 (Register must self-renew so correctness never depends on Deregister).
-type instance struct {
-	ServiceName string
-	ID          string
-	Addr        string
-	Weight      int
-	Metadata    map[string]string
-}
-
-// consulRegistrar publishes instances to a Consul agent and keeps each one live
-// by passing its TTL health check on a background heartbeat until Deregister.
+// consulRegistrar is the [discovery.Registrar] for a Consul agent. It keeps each
+// instance live by updating its TTL health check on a background heartbeat
+// until Deregister; the crash-safety contract lives in starter/DESIGN §3.
 type consulRegistrar struct {
 	client                  *api.Client
 	ttl                     time.Duration
 	deregisterCriticalAfter time.Duration
 
-	mu         sync.Mutex
-	heartbeats map[string]chan struct{} // service ID -> heartbeat stop channel
+	mu    sync.Mutex
+	holds map[string]*hold // service ID -> its TTL heartbeat
+}
+
+var _ discovery.Registrar = (*consulRegistrar)(nil)
+
+// hold tracks the background heartbeat of one registered instance and the
+// check status and metadata it last published.
+type hold struct {
+	stop   chan struct{}
+	status string
+	meta   map[string]string
 }
 
 // newConsulRegistrar builds a registrar backed by a Consul client for c.
@@ -68,34 +69,30 @@ func newConsulRegistrar(c ConsulConfig) (*consulRegistrar, error) {
 		client:                  client,
 		ttl:                     c.TTL,
 		deregisterCriticalAfter: c.DeregisterCriticalAfter,
-		heartbeats:              map[string]chan struct{}{},
+		holds:                   map[string]*hold{},
 	}, nil
 }
 
-// serviceID returns the Consul service instance id: the caller-supplied ID, or a
-// stable one derived from the service name and advertised address.
-func serviceID(reg instance) string {
-	if reg.ID != "" {
-		return reg.ID
+// checkStatus maps an instance status onto the Consul check status: anything
+// but UP is critical, which removes the instance from passing-only queries.
+func checkStatus(s discovery.Status) string {
+	if s == "" || s == discovery.StatusUp {
+		return api.HealthPassing
 	}
-	return reg.ServiceName + "-" + reg.Addr
+	return api.HealthCritical
 }
 
-// Register publishes reg with a TTL health check, passes the check immediately
-// so the instance is healthy without waiting a full TTL, then keeps it passing
-// on a background heartbeat until Deregister.
-func (r *consulRegistrar) Register(_ context.Context, reg instance) error {
+// registration builds the agent registration for reg.
+func (r *consulRegistrar) registration(reg discovery.Instance) (*api.AgentServiceRegistration, error) {
 	host, portStr, err := net.SplitHostPort(reg.Addr)
 	if err != nil {
-		return errutil.Explain(err, "registry-consul: addr %q must be host:port", reg.Addr)
+		return nil, errutil.Explain(err, "registry-consul: addr %q must be host:port", reg.Addr)
 	}
 	port, err := strconv.Atoi(portStr)
 	if err != nil {
-		return errutil.Explain(err, "registry-consul: addr %q has a non-numeric port", reg.Addr)
+		return nil, errutil.Explain(err, "registry-consul: addr %q has a non-numeric port", reg.Addr)
 	}
-
-	id := serviceID(reg)
-	checkID := "service:" + id
+	id := discovery.InstanceID(reg)
 	asr := &api.AgentServiceRegistration{
 		ID:      id,
 		Name:    reg.ServiceName,
@@ -103,7 +100,7 @@ func (r *consulRegistrar) Register(_ context.Context, reg instance) error {
 		Port:    port,
 		Meta:    reg.Metadata,
 		Check: &api.AgentServiceCheck{
-			CheckID:                        checkID,
+			CheckID:                        "service:" + id,
 			TTL:                            r.ttl.String(),
 			DeregisterCriticalServiceAfter: r.deregisterCriticalAfter.String(),
 		},
@@ -113,26 +110,41 @@ func (r *consulRegistrar) Register(_ context.Context, reg instance) error {
 	if reg.Weight > 0 {
 		asr.Weights = &api.AgentWeights{Passing: reg.Weight, Warning: 1}
 	}
+	if reg.Scheme != "" {
+		asr.Tags = []string{reg.Scheme}
+	}
+	return asr, nil
+}
+
+// Register publishes reg with a TTL health check, sets the check to reg's
+// status immediately so the instance is healthy without waiting a full TTL,
+// then keeps it updated on a background heartbeat until Deregister.
+func (r *consulRegistrar) Register(_ context.Context, reg discovery.Instance) error {
+	asr, err := r.registration(reg)
+	if err != nil {
+		return err
+	}
 	if err := r.client.Agent().ServiceRegister(asr); err != nil {
 		return errutil.Explain(err, "registry-consul: register %q", reg.ServiceName)
 	}
-	_ = r.client.Agent().UpdateTTL(checkID, "", api.HealthPassing)
+	h := &hold{stop: make(chan struct{}), status: checkStatus(reg.Status), meta: maps.Clone(reg.Metadata)}
+	_ = r.client.Agent().UpdateTTL(asr.Check.CheckID, "", h.status)
 
-	stop := make(chan struct{})
 	r.mu.Lock()
 	// Re-registering the same instance refreshes it: retire the old heartbeat.
-	if old, ok := r.heartbeats[id]; ok {
-		close(old)
+	if old, ok := r.holds[asr.ID]; ok {
+		close(old.stop)
 	}
-	r.heartbeats[id] = stop
+	r.holds[asr.ID] = h
 	r.mu.Unlock()
 
-	go r.heartbeat(checkID, stop)
+	go r.heartbeat(asr.ID, asr.Check.CheckID, h.stop)
 	return nil
 }
 
-// heartbeat re-passes the TTL check at half the TTL until stop is closed.
-func (r *consulRegistrar) heartbeat(checkID string, stop <-chan struct{}) {
+// heartbeat re-publishes the TTL check status at half the TTL until stop is
+// closed.
+func (r *consulRegistrar) heartbeat(id, checkID string, stop <-chan struct{}) {
 	interval := r.ttl / 2
 	if interval <= 0 {
 		interval = r.ttl
@@ -144,20 +156,58 @@ func (r *consulRegistrar) heartbeat(checkID string, stop <-chan struct{}) {
 		case <-stop:
 			return
 		case <-ticker.C:
-			_ = r.client.Agent().UpdateTTL(checkID, "", api.HealthPassing)
+			r.mu.Lock()
+			status := api.HealthPassing
+			if h, ok := r.holds[id]; ok {
+				status = h.status
+			}
+			r.mu.Unlock()
+			_ = r.client.Agent().UpdateTTL(checkID, "", status)
+		}
+	}
+}
+
+// Heartbeat publishes reg's status through its TTL check right away, and
+// re-registers the service when its metadata changed.
+func (r *consulRegistrar) Heartbeat(_ context.Context, reg discovery.Instance) error {
+	asr, err := r.registration(reg)
+	if err != nil {
+		return err
+	}
+	status := checkStatus(reg.Status)
+	r.mu.Lock()
+	h, ok := r.holds[asr.ID]
+	if !ok {
+		r.mu.Unlock()
+		return errutil.Explain(nil, "registry-consul: %q is not registered", asr.ID)
+	}
+	statusChanged := h.status != status
+	metaChanged := !maps.Equal(h.meta, reg.Metadata)
+	h.status, h.meta = status, maps.Clone(reg.Metadata)
+	r.mu.Unlock()
+
+	if metaChanged {
+		if err := r.client.Agent().ServiceRegister(asr); err != nil {
+			return errutil.Explain(err, "registry-consul: re-register %q", reg.ServiceName)
 		}
 	}
+	if statusChanged || metaChanged {
+		if err := r.client.Agent().UpdateTTL(asr.Check.CheckID, string(reg.Status), status); err != nil {
+			return errutil.Explain(err, "registry-consul: update check %q", asr.Check.CheckID)
+		}
+	}
+	return nil
 }
 
 // Deregister stops the heartbeat and removes the instance. It is idempotent:
 // deregistering an instance that is not registered is a no-op that still asks
 // Consul to drop the id (harmless if already gone).
-func (r *consulRegistrar) Deregister(_ context.Context, reg instance) error {
-	id := serviceID(reg)
+func (r *consulRegistrar) Deregister(_ context.Context, reg discovery.Instance) error {
+	id := discovery.InstanceID(reg)
 	r.mu.Lock()
-	if stop, ok := r.heartbeats[id]; ok {
-		close(stop)
-		delete(r.heartbeats, id)
+	if h, ok := r.holds[id]; ok {
+		close(h.stop)
+		delete(r.holds, id)
 	}
 	r.mu.Unlock()
 	if err := r.client.Agent().ServiceDeregister(id); err != nil {
@@ -22,14 +22,28 @@ import (
 	"time"
 
 	"github.com/hashicorp/consul/api"
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/testing/assert"
 )
 
-func TestServiceID(t *testing.T) {
-	// An explicit ID is used verbatim.
-	assert.That(t, serviceID(instance{ID: "fixed", ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("fixed")
-	// Otherwise it is derived from name and addr so restarts replace the entry.
-	assert.That(t, serviceID(instance{ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("orders-1.2.3.4:80")
+func TestCheckStatus(t *testing.T) {
+	// Only UP (or no status) passes; DOWN and OUT_OF_SERVICE fail the check.
+	assert.That(t, checkStatus("")).Equal(api.HealthPassing)
+	assert.That(t, checkStatus(discovery.StatusUp)).Equal(api.HealthPassing)
+	assert.That(t, checkStatus(discovery.StatusDown)).Equal(api.HealthCritical)
+	assert.That(t, checkStatus(discovery.StatusOutOfService)).Equal(api.HealthCritical)
+}
+
+func TestRegistration(t *testing.T) {
+	r := &consulRegistrar{ttl: time.Second}
+	asr, err := r.registration(discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80", Scheme: "tls"})
+	assert.Error(t, err).Nil()
+	// The id is derived from name and addr so restarts replace the entry.
+	assert.That(t, asr.ID).Equal("orders-1.2.3.4:80")
+	assert.That(t, asr.Check.CheckID).Equal("service:orders-1.2.3.4:80")
+	assert.That(t, asr.Tags).Equal([]string{"tls"})
+	// No weight set: Consul applies its default.
+	assert.That(t, asr.Weights).Nil()
 }
 
 func TestRegister_BadAddr(t *testing.T) {
@@ -37,11 +51,19 @@ func TestRegister_BadAddr(t *testing.T) {
 	// validated before any Consul call, so a malformed addr fails fast.
 	client, err := api.NewClient(&api.Config{Address: "127.0.0.1:8500"})
 	assert.Error(t, err).Nil()
-	r := &consulRegistrar{client: client, ttl: time.Second, heartbeats: map[string]chan struct{}{}}
+	r := &consulRegistrar{client: client, ttl: time.Second, holds: map[string]*hold{}}
 
-	err = r.Register(context.Background(), instance{ServiceName: "orders", Addr: "no-port"})
+	err = r.Register(context.Background(), discovery.Instance{ServiceName: "orders", Addr: "no-port"})
 	assert.Error(t, err).Matches("must be host:port")
 
-	err = r.Register(context.Background(), instance{ServiceName: "orders", Addr: "host:abc"})
+	err = r.Register(context.Background(), discovery.Instance{ServiceName: "orders", Addr: "host:abc"})
 	assert.Error(t, err).Matches("non-numeric port")
 }
+
+func TestHeartbeatRequiresRegistration(t *testing.T) {
+	// A heartbeat for an instance that was never registered is reported rather
+	// than registering it behind the lifecycle's back.
+	r := &consulRegistrar{ttl: time.Second, holds: map[string]*hold{}}
+	err := r.Heartbeat(context.Background(), discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
+	assert.Error(t, err).Matches("not registered")
+}
@@ -42,30 +42,3 @@ type NacosConfig struct {
 	// probe used to fail fast on an unreachable server.
 	TimeoutMs uint64 `value:"${timeout-ms:=5000}"`
 }
-
-// RegistrationConfig binds the instance to advertise, under ${spring.registry}.
-// These fields describe the instance itself and are independent of which
-// registry backend this starter ships; swapping backends means swapping the
-// starter (and thus this struct's home), not a config migration.
-type RegistrationConfig struct {
-	// ServiceName is the logical name to publish — the same name discovery
-	// clients later resolve. Required.
-	ServiceName string `value:"${service-name:=}"`
-
-	// Addr is the connectable "host:port" advertised to clients. Required; the
-	// starter never guesses it, so a misconfiguration fails at startup.
-	Addr string `value:"${addr:=}"`
-
-	// ID is accepted for parity with other registry backends but is unused by
-	// Nacos, which identifies an instance by its ip:port within a service and
-	// cluster. Restarting the same address therefore replaces the same entry.
-	ID string `value:"${id:=}"`
-
-	// Weight is the load-balancing weight advertised to clients; 0 falls back to
-	// Nacos's default weight of 1 (0 in Nacos means "receive no traffic").
-	Weight int `value:"${weight:=0}"`
-
-	// Metadata is arbitrary key/value attributes stored with the instance
-	// (zone, unit, version, ...), bound from ${spring.registry.metadata.*}.
-	Metadata map[string]string `value:"${metadata:=}"`
-}
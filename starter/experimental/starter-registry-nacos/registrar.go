@@ -18,37 +18,55 @@ package StarterRegistryNacos
 
 import (
 	"context"
+	"maps"
 	"net"
 	"strconv"
+	"sync"
 
 	"github.com/nacos-group/nacos-sdk-go/v2/clients"
 	"github.com/nacos-group/nacos-sdk-go/v2/clients/naming_client"
 	"github.com/nacos-group/nacos-sdk-go/v2/common/constant"
 	"github.com/nacos-group/nacos-sdk-go/v2/vo"
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/errutil"
 )
 
-// instance is the instance this process advertises to the Nacos registry. It is
-// the local write-side value built from RegistrationConfig in Server.Run; the
-// crash-safety contract every registry starter follows lives in starter/DESIGN
-// §3 (Register must self-renew so correctness never depends on Deregister).
-type instance struct {
-	ServiceName string
-	ID          string
-	Addr        string
-	Weight      int
-	Metadata    map[string]string
-}
-
-// nacosRegistrar publishes instances to a Nacos naming service. Instances are
-// registered as ephemeral, so the Nacos SDK keeps them alive with its own
-// background heartbeat and Nacos drops them automatically if the process dies
-// without Deregister — no heartbeat goroutine is needed here.
+// nacosRegistrar is the [discovery.Registrar] for a Nacos naming service.
+// Instances are registered as ephemeral, so the Nacos SDK keeps them alive with
+// its own background heartbeat and Nacos drops them automatically if the
+// process dies without Deregister — no heartbeat goroutine is needed here
+// (the crash-safety contract of starter/DESIGN §3).
 type nacosRegistrar struct {
 	client  naming_client.INamingClient
 	group   string
 	cluster string
+
+	mu        sync.Mutex
+	published map[string]published // instance id -> last published state
+}
+
+var _ discovery.Registrar = (*nacosRegistrar)(nil)
+
+// published is the mutable state of a registered instance as last sent to
+// Nacos, so a Heartbeat only updates a change.
+type published struct {
+	enable, healthy bool
+	meta            map[string]string
+}
+
+// publishedFor maps reg's status onto the Nacos flags: OUT_OF_SERVICE disables
+// the instance, anything but UP marks it unhealthy.
+func publishedFor(reg discovery.Instance) published {
+	return published{
+		enable:  reg.Status != discovery.StatusOutOfService,
+		healthy: reg.Status == "" || reg.Status == discovery.StatusUp,
+		meta:    maps.Clone(reg.Metadata),
+	}
+}
+
+func (p published) equal(o published) bool {
+	return p.enable == o.enable && p.healthy == o.healthy && maps.Equal(p.meta, o.meta)
 }
 
 // newNacosRegistrar builds a registrar backed by a Nacos naming client for c.
@@ -89,32 +107,32 @@ func newNacosRegistrar(c NacosConfig) (*nacosRegistrar, error) {
 		return nil, errutil.Explain(err, "registry-nacos: startup probe failed for %s", c.Server)
 	}
 
-	return &nacosRegistrar{client: client, group: c.Group, cluster: c.Cluster}, nil
+	return &nacosRegistrar{
+		client:    client,
+		group:     c.Group,
+		cluster:   c.Cluster,
+		published: map[string]published{},
+	}, nil
 }
 
 // Register publishes reg as an ephemeral Nacos instance. The SDK then keeps it
 // alive with its own heartbeat until Deregister. Registering the same ip:port
 // again refreshes the entry.
-func (r *nacosRegistrar) Register(_ context.Context, reg instance) error {
+func (r *nacosRegistrar) Register(_ context.Context, reg discovery.Instance) error {
 	host, port, err := splitAddr(reg.Addr)
 	if err != nil {
 		return err
 	}
-	// Nacos treats weight 0 as "receive no traffic"; default to 1 so an
-	// unweighted instance is actually reachable.
-	weight := float64(reg.Weight)
-	if weight <= 0 {
-		weight = 1
-	}
+	p := publishedFor(reg)
 	ok, err := r.client.RegisterInstance(vo.RegisterInstanceParam{
 		Ip:          host,
 		Port:        port,
 		ServiceName: reg.ServiceName,
 		GroupName:   r.group,
 		ClusterName: r.cluster,
-		Weight:      weight,
-		Enable:      true,
-		Healthy:     true,
+		Weight:      weightOf(reg),
+		Enable:      p.enable,
+		Healthy:     p.healthy,
 		Ephemeral:   true,
 		Metadata:    reg.Metadata,
 	})
@@ -124,16 +142,71 @@ func (r *nacosRegistrar) Register(_ context.Context, reg instance) error {
 	if !ok {
 		return errutil.Explain(nil, "registry-nacos: register %q was rejected by the server", reg.ServiceName)
 	}
+	r.mu.Lock()
+	r.published[discovery.InstanceID(reg)] = p
+	r.mu.Unlock()
 	return nil
 }
 
+// Heartbeat updates the instance when its status or metadata changed. The SDK
+// beat keeps an ephemeral instance alive, so an unchanged one costs no call.
+func (r *nacosRegistrar) Heartbeat(_ context.Context, reg discovery.Instance) error {
+	host, port, err := splitAddr(reg.Addr)
+	if err != nil {
+		return err
+	}
+	id := discovery.InstanceID(reg)
+	p := publishedFor(reg)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.published[id]
+	if !ok {
+		return errutil.Explain(nil, "registry-nacos: %q is not registered", id)
+	}
+	if old.equal(p) {
+		return nil
+	}
+	ok, err = r.client.UpdateInstance(vo.UpdateInstanceParam{
+		Ip:          host,
+		Port:        port,
+		ServiceName: reg.ServiceName,
+		GroupName:   r.group,
+		ClusterName: r.cluster,
+		Weight:      weightOf(reg),
+		Enable:      p.enable,
+		Healthy:     p.healthy,
+		Ephemeral:   true,
+		Metadata:    reg.Metadata,
+	})
+	if err != nil {
+		return errutil.Explain(err, "registry-nacos: update %q", reg.ServiceName)
+	}
+	if !ok {
+		return errutil.Explain(nil, "registry-nacos: update %q was rejected by the server", reg.ServiceName)
+	}
+	r.published[id] = p
+	return nil
+}
+
+// weightOf returns the Nacos weight of reg. Nacos treats weight 0 as "receive
+// no traffic"; default to 1 so an unweighted instance is actually reachable.
+func weightOf(reg discovery.Instance) float64 {
+	if reg.Weight <= 0 {
+		return 1
+	}
+	return float64(reg.Weight)
+}
+
 // Deregister removes the instance. It is idempotent: deregistering an instance
 // that is not registered is a no-op on the Nacos side.
-func (r *nacosRegistrar) Deregister(_ context.Context, reg instance) error {
+func (r *nacosRegistrar) Deregister(_ context.Context, reg discovery.Instance) error {
 	host, port, err := splitAddr(reg.Addr)
 	if err != nil {
 		return err
 	}
+	r.mu.Lock()
+	delete(r.published, discovery.InstanceID(reg))
+	r.mu.Unlock()
 	if _, err := r.client.DeregisterInstance(vo.DeregisterInstanceParam{
 		Ip:          host,
 		Port:        port,
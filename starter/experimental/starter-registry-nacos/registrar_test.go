@@ -17,8 +17,10 @@
 package StarterRegistryNacos
 
 import (
+	"context"
 	"testing"
 
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/testing/assert"
 )
 
@@ -37,3 +39,25 @@ func TestSplitAddr(t *testing.T) {
 	_, _, err = splitAddr("host:abc")
 	assert.Error(t, err).Matches("non-numeric port")
 }
+
+func TestPublishedFor(t *testing.T) {
+	// UP (or no status) is enabled and healthy.
+	p := publishedFor(discovery.Instance{})
+	assert.That(t, p.enable && p.healthy).True()
+	// DOWN stays enabled but unhealthy; OUT_OF_SERVICE is disabled.
+	p = publishedFor(discovery.Instance{Status: discovery.StatusDown})
+	assert.That(t, p.enable && !p.healthy).True()
+	p = publishedFor(discovery.Instance{Status: discovery.StatusOutOfService})
+	assert.That(t, !p.enable && !p.healthy).True()
+	// Weight 0 becomes 1 so the instance still receives traffic.
+	assert.That(t, weightOf(discovery.Instance{})).Equal(float64(1))
+	assert.That(t, weightOf(discovery.Instance{Weight: 5})).Equal(float64(5))
+}
+
+func TestHeartbeatRequiresRegistration(t *testing.T) {
+	// A heartbeat for an instance that was never registered is reported
+	// before any Nacos call.
+	r := &nacosRegistrar{published: map[string]published{}}
+	err := r.Heartbeat(context.Background(), discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
+	assert.Error(t, err).Matches("not registered")
+}
@@ -17,6 +17,7 @@
 package StarterRegistryZookeeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -24,40 +25,33 @@ import (
 
 	"github.com/go-zookeeper/zk"
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/errutil"
 )
 
-// instance is the instance this process advertises to the ZooKeeper registry.
-// It is the local write-side value built from RegistrationConfig in Server.Run;
-// the crash-safety contract every registry starter follows lives in
-// starter/DESIGN §3 (Register must self-renew so correctness never depends on
-// Deregister).
-type instance struct {
-	ServiceName string
-	ID          string
-	Addr        string
-	Weight      int
-	Metadata    map[string]string
-}
-
 // instanceValue is the JSON payload stored at an instance znode. A discovery
 // backend reading the same base path reconstructs an Endpoint from it.
 type instanceValue struct {
 	ServiceName string            `json:"service_name"`
 	Addr        string            `json:"addr"`
+	Scheme      string            `json:"scheme,omitempty"`
 	Weight      int               `json:"weight,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Status      discovery.Status  `json:"status,omitempty"`
 }
 
-// zkRegistrar publishes instances to a ZooKeeper ensemble as ephemeral znodes.
-// An ephemeral node lives only as long as the client session, so ZooKeeper
-// removes it automatically when the process dies without Deregister.
+// zkRegistrar is the [discovery.Registrar] for a ZooKeeper ensemble; instances
+// are ephemeral znodes. An ephemeral node lives only as long as the client
+// session, so ZooKeeper removes it automatically when the process dies without
+// Deregister (the crash-safety contract of starter/DESIGN §3).
 type zkRegistrar struct {
 	conn     *zk.Conn
 	basePath string
 	acl      []zk.ACL
 }
 
+var _ discovery.Registrar = (*zkRegistrar)(nil)
+
 // newZookeeperRegistrar connects to the ensemble and returns a registrar. The
 // connection is verified with a probe so an unreachable ensemble fails startup
 // rather than surfacing on the first Register.
@@ -89,35 +83,37 @@ func newZookeeperRegistrar(c ZookeeperConfig) (*zkRegistrar, error) {
 	}, nil
 }
 
-// instanceID returns the instance id within the service: the caller-supplied ID,
-// or a stable one derived from the service name and advertised address.
-func instanceID(reg instance) string {
-	if reg.ID != "" {
-		return reg.ID
-	}
-	return reg.ServiceName + "-" + reg.Addr
+// pathFor returns the znode an instance is written to: basePath/service/id.
+func (r *zkRegistrar) pathFor(reg discovery.Instance) string {
+	return r.basePath + "/" + reg.ServiceName + "/" + discovery.InstanceID(reg)
 }
 
-// pathFor returns the znode an instance is written to: basePath/service/id.
-func (r *zkRegistrar) pathFor(reg instance) string {
-	return r.basePath + "/" + reg.ServiceName + "/" + instanceID(reg)
+// valueFor returns the JSON payload stored for reg.
+func valueFor(reg discovery.Instance) ([]byte, error) {
+	val, err := json.Marshal(instanceValue{
+		ServiceName: reg.ServiceName,
+		Addr:        reg.Addr,
+		Scheme:      reg.Scheme,
+		Weight:      reg.Weight,
+		Metadata:    reg.Metadata,
+		Status:      reg.Status,
+	})
+	if err != nil {
+		return nil, errutil.Explain(err, "registry-zookeeper: marshal instance %q", reg.ServiceName)
+	}
+	return val, nil
 }
 
 // Register writes reg as an ephemeral znode, creating the persistent parent
 // directories on demand. Re-registering the same instance replaces the node so
 // the entry is refreshed rather than duplicated.
-func (r *zkRegistrar) Register(_ context.Context, reg instance) error {
+func (r *zkRegistrar) Register(_ context.Context, reg discovery.Instance) error {
 	if reg.Addr == "" {
 		return errutil.Explain(nil, "registry-zookeeper: addr is required")
 	}
-	val, err := json.Marshal(instanceValue{
-		ServiceName: reg.ServiceName,
-		Addr:        reg.Addr,
-		Weight:      reg.Weight,
-		Metadata:    reg.Metadata,
-	})
+	val, err := valueFor(reg)
 	if err != nil {
-		return errutil.Explain(err, "registry-zookeeper: marshal instance %q", reg.ServiceName)
+		return err
 	}
 
 	path := r.pathFor(reg)
@@ -142,7 +138,7 @@ func (r *zkRegistrar) Register(_ context.Context, reg instance) error {
 
 // Deregister removes the instance znode. It is idempotent: deregistering an
 // instance that is not registered (ErrNoNode) is a no-op.
-func (r *zkRegistrar) Deregister(_ context.Context, reg instance) error {
+func (r *zkRegistrar) Deregister(_ context.Context, reg discovery.Instance) error {
 	path := r.pathFor(reg)
 	if err := r.conn.Delete(path, -1); err != nil && !errors.Is(err, zk.ErrNoNode) {
 		return errutil.Explain(err, "registry-zookeeper: deregister %q", reg.ServiceName)
@@ -150,6 +146,32 @@ func (r *zkRegistrar) Deregister(_ context.Context, reg instance) error {
 	return nil
 }
 
+// Heartbeat rewrites the instance znode when its status or metadata changed.
+// The session keeps an ephemeral node alive, so an unchanged one is only read.
+func (r *zkRegistrar) Heartbeat(_ context.Context, reg discovery.Instance) error {
+	val, err := valueFor(reg)
+	if err != nil {
+		return err
+	}
+	path := r.pathFor(reg)
+	cur, stat, err := r.conn.Get(path)
+	if errors.Is(err, zk.ErrNoNode) {
+		return errutil.Explain(nil, "registry-zookeeper: %q is not registered", path)
+	}
+	if err != nil {
+		return errutil.Explain(err, "registry-zookeeper: get %q", path)
+	}
+	if bytes.Equal(cur, val) {
+		return nil
+	}
+	// Set against the version just read, so a concurrent re-registration is
+	// not overwritten with a stale value.
+	if _, err := r.conn.Set(path, val, stat.Version); err != nil {
+		return errutil.Explain(err, "registry-zookeeper: set %q", path)
+	}
+	return nil
+}
+
 // ensureParents creates every persistent ancestor of path that does not yet
 // exist (the leaf itself is created separately as ephemeral).
 func (r *zkRegistrar) ensureParents(path string) error {
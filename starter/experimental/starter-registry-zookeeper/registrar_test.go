@@ -19,20 +19,22 @@ package StarterRegistryZookeeper
 import (
 	"testing"
 
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/testing/assert"
 )
 
-func TestInstanceID(t *testing.T) {
-	// An explicit ID is used verbatim.
-	assert.That(t, instanceID(instance{ID: "fixed", ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("fixed")
-	// Otherwise it is derived from name and addr so restarts replace the entry.
-	assert.That(t, instanceID(instance{ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("orders-1.2.3.4:80")
+func TestValueFor(t *testing.T) {
+	// Scheme and Status are stored so a discovery backend can rebuild the
+	// Endpoint; empty fields are omitted.
+	got, err := valueFor(discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80", Scheme: "tls", Status: discovery.StatusDown})
+	assert.Error(t, err).Nil()
+	assert.That(t, string(got)).Equal(`{"service_name":"orders","addr":"1.2.3.4:80","scheme":"tls","status":"DOWN"}`)
 }
 
 func TestPathFor(t *testing.T) {
 	// The base path's trailing slash is normalised away at construction, so the
 	// znode path has exactly one separator per level.
 	r := &zkRegistrar{basePath: "/services"}
-	got := r.pathFor(instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
+	got := r.pathFor(discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
 	assert.That(t, got).Equal("/services/orders/orders-1.2.3.4:80")
 }
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterResilience
+
+import (
+	"sync"
+
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+
+	"go-spring.org/spring/experimental/cloud/resilience"
+)
+
+// sentinel keeps one breaker per rule and reports transitions to process-wide
+// listeners, so the neutral per-resource state is folded here: a resource is
+// open while any of its rules is open, half-open while any is probing, closed
+// otherwise.
+var breakers = struct {
+	sync.Mutex
+	rules    map[string]map[circuitbreaker.Strategy]circuitbreaker.State
+	listener map[string]resilience.StateListener
+}{
+	rules:    map[string]map[circuitbreaker.Strategy]circuitbreaker.State{},
+	listener: map[string]resilience.StateListener{},
+}
+
+func init() { circuitbreaker.RegisterStateChangeListeners(stateListener{}) }
+
+// watchBreaker starts tracking resource and routes its transitions to l, which
+// may be nil. Rules are global per resource in sentinel, so the executor that
+// loaded them last owns the listener.
+func watchBreaker(resource string, l resilience.StateListener) {
+	breakers.Lock()
+	defer breakers.Unlock()
+	breakers.rules[resource] = map[circuitbreaker.Strategy]circuitbreaker.State{}
+	breakers.listener[resource] = l
+}
+
+// breakerState returns the folded state of resource.
+func breakerState(resource string) resilience.CircuitState {
+	breakers.Lock()
+	defer breakers.Unlock()
+	return foldLocked(resource)
+}
+
+func foldLocked(resource string) resilience.CircuitState {
+	s := resilience.CircuitClosed
+	for _, st := range breakers.rules[resource] {
+		switch st {
+		case circuitbreaker.Open:
+			return resilience.CircuitOpen
+		case circuitbreaker.HalfOpen:
+			s = resilience.CircuitHalfOpen
+		}
+	}
+	return s
+}
+
+// transition records a rule's new state and notifies the resource's callback
+// when the folded state changes.
+func transition(rule circuitbreaker.Rule, to circuitbreaker.State) {
+	breakers.Lock()
+	rules, ok := breakers.rules[rule.Resource]
+	if !ok {
+		breakers.Unlock()
+		return // a rule loaded outside this driver
+	}
+	from := foldLocked(rule.Resource)
+	rules[rule.Strategy] = to
+	now := foldLocked(rule.Resource)
+	l := breakers.listener[rule.Resource]
+	breakers.Unlock()
+	if l != nil && from != now {
+		l.OnStateChange(rule.Resource, from, now)
+	}
+}
+
+type stateListener struct{}
+
+func (stateListener) OnTransformToClosed(_ circuitbreaker.State, rule circuitbreaker.Rule) {
+	transition(rule, circuitbreaker.Closed)
+}
+
+func (stateListener) OnTransformToOpen(_ circuitbreaker.State, rule circuitbreaker.Rule, _ any) {
+	transition(rule, circuitbreaker.Open)
+}
+
+func (stateListener) OnTransformToHalfOpen(_ circuitbreaker.State, rule circuitbreaker.Rule) {
+	transition(rule, circuitbreaker.HalfOpen)
+}
@@ -19,7 +19,9 @@ package StarterResilience
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	sentinel "github.com/alibaba/sentinel-golang/api"
 	"github.com/alibaba/sentinel-golang/core/base"
@@ -37,15 +39,48 @@ import (
 type sentinelExecutor struct {
 	policy resilience.Policy
 
-	mu     sync.Mutex
-	loaded map[string]bool
+	mu       sync.Mutex
+	loaded   map[string]bool
+	breakers []string // resources with circuit-breaker rules
+	limiters map[string]*resilience.AdaptiveLimiter
+	budgets  map[string]*resilience.RetryBudget
+
+	unreport func() // nil when the policy has no breaker
 }
 
 func newSentinelExecutor(p resilience.Policy) (resilience.Executor, error) {
 	if p.RateLimit < 0 {
 		return nil, fmt.Errorf("resilience: negative rate limit %v", p.RateLimit)
 	}
-	return &sentinelExecutor{policy: p, loaded: map[string]bool{}}, nil
+	if (p.FailureRateThreshold > 0 || p.SlowCallRateThreshold > 0) && p.SlidingWindowType != resilience.TimeBasedWindow {
+		// sentinel's breakers only keep time-based statistics; silently
+		// reading a call count as seconds would change the policy's meaning.
+		return nil, fmt.Errorf("resilience: sentinel supports only %q sliding windows", resilience.TimeBasedWindow)
+	}
+	if p.SlowCallRateThreshold > 0 && p.SlowCallDurationThreshold <= 0 {
+		return nil, fmt.Errorf("resilience: slow call rate threshold set without a slow call duration threshold")
+	}
+	if _, err := resilience.NewLimitAlgorithm(p.AdaptiveLimit); err != nil {
+		return nil, err
+	}
+	if p.Hedge != (resilience.HedgePolicy{}) {
+		// Hedging needs per-attempt cancellation and result arbitration that
+		// sentinel's entry model does not provide; use the default driver.
+		return nil, fmt.Errorf("resilience: sentinel does not support hedged requests")
+	}
+	if p.RetryJitter < 0 || p.RetryJitter > 1 {
+		return nil, fmt.Errorf("resilience: retry jitter %v out of [0, 1]", p.RetryJitter)
+	}
+	e := &sentinelExecutor{
+		policy:   p,
+		loaded:   map[string]bool{},
+		limiters: map[string]*resilience.AdaptiveLimiter{},
+		budgets:  map[string]*resilience.RetryBudget{},
+	}
+	if p.ErrorThreshold > 0 || p.FailureRateThreshold > 0 || p.SlowCallRateThreshold > 0 {
+		e.unreport = resilience.ReportBreakers(e)
+	}
+	return e, nil
 }
 
 // ensureRules loads flow and circuit-breaker rules for resource once, translating
@@ -69,21 +104,12 @@ func (e *sentinelExecutor) ensureRules(resource string) error {
 		}
 	}
 
-	if e.policy.ErrorThreshold > 0 {
-		openMs := uint32(e.policy.OpenDuration.Milliseconds())
-		if openMs == 0 {
-			openMs = 5000
-		}
-		if _, err := circuitbreaker.LoadRulesOfResource(resource, []*circuitbreaker.Rule{{
-			Resource:         resource,
-			Strategy:         circuitbreaker.ErrorCount,
-			RetryTimeoutMs:   openMs,
-			MinRequestAmount: 1,
-			StatIntervalMs:   1000,
-			Threshold:        float64(e.policy.ErrorThreshold),
-		}}); err != nil {
+	if rules := e.breakerRules(resource); len(rules) > 0 {
+		if _, err := circuitbreaker.LoadRulesOfResource(resource, rules); err != nil {
 			return fmt.Errorf("resilience: load breaker rule for %q: %w", resource, err)
 		}
+		e.breakers = append(e.breakers, resource)
+		watchBreaker(resource, e.policy.StateListener)
 	}
 
 	if e.policy.MaxConcurrent > 0 {
@@ -99,39 +125,145 @@ func (e *sentinelExecutor) ensureRules(resource string) error {
 		}
 	}
 
+	if alg, _ := resilience.NewLimitAlgorithm(e.policy.AdaptiveLimit); alg != nil {
+		// sentinel has no per-resource latency-driven limit, so the neutral
+		// adaptive limiter runs in front of its entry check.
+		e.limiters[resource] = resilience.NewAdaptiveLimiter(alg)
+	}
+
+	if b := resilience.NewRetryBudget(e.policy.RetryBudget); b != nil {
+		e.budgets[resource] = b
+	}
+
 	e.loaded[resource] = true
 	return nil
 }
 
+// breakerRules translates the neutral breaker knobs into one sentinel rule per
+// trigger; sentinel opens the resource when any of them trips.
+func (e *sentinelExecutor) breakerRules(resource string) []*circuitbreaker.Rule {
+	p := e.policy
+	openMs := uint32(p.OpenDuration.Milliseconds())
+	if openMs == 0 {
+		openMs = 5000
+	}
+	probes := uint64(max(p.PermittedCallsInHalfOpen, 1))
+	var rules []*circuitbreaker.Rule
+	if p.ErrorThreshold > 0 {
+		rules = append(rules, &circuitbreaker.Rule{
+			Resource:         resource,
+			Strategy:         circuitbreaker.ErrorCount,
+			RetryTimeoutMs:   openMs,
+			MinRequestAmount: 1,
+			StatIntervalMs:   1000,
+			Threshold:        float64(p.ErrorThreshold),
+			ProbeNum:         probes,
+		})
+	}
+	if p.FailureRateThreshold == 0 && p.SlowCallRateThreshold == 0 {
+		return rules
+	}
+	windowMs := uint32(p.SlidingWindowSize) * 1000
+	if windowMs == 0 {
+		windowMs = 60_000
+	}
+	minCalls := uint64(p.MinimumNumberOfCalls)
+	if minCalls == 0 {
+		minCalls = 100
+	}
+	if p.FailureRateThreshold > 0 {
+		rules = append(rules, &circuitbreaker.Rule{
+			Resource:         resource,
+			Strategy:         circuitbreaker.ErrorRatio,
+			RetryTimeoutMs:   openMs,
+			MinRequestAmount: minCalls,
+			StatIntervalMs:   windowMs,
+			Threshold:        p.FailureRateThreshold / 100,
+			ProbeNum:         probes,
+		})
+	}
+	if p.SlowCallRateThreshold > 0 {
+		rules = append(rules, &circuitbreaker.Rule{
+			Resource:         resource,
+			Strategy:         circuitbreaker.SlowRequestRatio,
+			RetryTimeoutMs:   openMs,
+			MinRequestAmount: minCalls,
+			StatIntervalMs:   windowMs,
+			MaxAllowedRtMs:   uint64(p.SlowCallDurationThreshold.Milliseconds()),
+			Threshold:        p.SlowCallRateThreshold / 100,
+			ProbeNum:         probes,
+		})
+	}
+	return rules
+}
+
 func (e *sentinelExecutor) Execute(ctx context.Context, resource string, fn func(context.Context) error) error {
 	if err := e.ensureRules(resource); err != nil {
 		return err
 	}
+	e.mu.Lock()
+	limiter, budget := e.limiters[resource], e.budgets[resource]
+	e.mu.Unlock()
+	if budget != nil {
+		budget.Deposit()
+	}
 
 	attempts := e.policy.MaxRetries + 1
 	var err error
-	for range attempts {
+	for i := range attempts {
+		if i > 0 && !e.retry(ctx, budget, err, i) {
+			break
+		}
+		release := func(resilience.LimitOutcome) {}
+		if limiter != nil {
+			var ok bool
+			if release, ok = limiter.Acquire(ctx); !ok {
+				return resilience.ErrConcurrencyLimited
+			}
+		}
 		entry, blockErr := sentinel.Entry(resource, sentinel.WithTrafficType(base.Outbound))
 		if blockErr != nil {
+			release(resilience.OutcomeIgnored)
 			return mapBlockError(blockErr)
 		}
 
 		err = e.runOnce(ctx, fn)
-		if err != nil {
+		if err != nil && (e.policy.FailureClassifier == nil || e.policy.FailureClassifier.IsFailure(err)) {
 			sentinel.TraceError(entry, err)
 		}
 		entry.Exit()
+		release(resilience.LimitOutcomeOf(err))
 
 		if err == nil {
 			return nil
 		}
-		if ctx.Err() != nil {
-			break
-		}
 	}
 	return err
 }
 
+// retry decides whether retry number n (1-based) may follow the failure err
+// and sleeps the backoff before returning true.
+func (e *sentinelExecutor) retry(ctx context.Context, budget *resilience.RetryBudget, err error, n int) bool {
+	if ctx.Err() != nil || !resilience.IsRetryable(e.policy, err) {
+		return false
+	}
+	if budget != nil && !budget.Withdraw() {
+		return false
+	}
+	d := resilience.RetryDelay(e.policy, n)
+	if d <= 0 {
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
 // runOnce applies the per-attempt timeout, if any, around fn.
 func (e *sentinelExecutor) runOnce(ctx context.Context, fn func(context.Context) error) error {
 	if e.policy.Timeout <= 0 {
@@ -142,7 +274,30 @@ func (e *sentinelExecutor) runOnce(ctx context.Context, fn func(context.Context)
 	return fn(attemptCtx)
 }
 
-func (e *sentinelExecutor) Close() error { return nil }
+// BreakerStates implements resilience.BreakerReporter. sentinel does not expose
+// its window statistics, so only the state is reported.
+func (e *sentinelExecutor) BreakerStates() []resilience.BreakerState {
+	e.mu.Lock()
+	resources := slices.Clone(e.breakers)
+	e.mu.Unlock()
+	out := make([]resilience.BreakerState, 0, len(resources))
+	for _, r := range resources {
+		out = append(out, resilience.BreakerState{
+			Executor: e.policy.Name,
+			Resource: r,
+			State:    breakerState(r),
+		})
+	}
+	return out
+}
+
+// Close withdraws the executor from resilience.Breakers.
+func (e *sentinelExecutor) Close() error {
+	if e.unreport != nil {
+		e.unreport()
+	}
+	return nil
+}
 
 // mapBlockError translates sentinel's block reason into the framework's neutral
 // sentinel errors so callers depend only on go-spring.org/spring/resilience.
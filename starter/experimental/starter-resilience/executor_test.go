@@ -18,6 +18,7 @@ package StarterResilience
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -123,3 +124,89 @@ func TestSentinelBulkhead(t *testing.T) {
 	// Slot freed: a subsequent call is admitted again.
 	assert.Error(t, e.Execute(context.Background(), "svc-bulkhead", func(context.Context) error { return nil })).Nil()
 }
+
+// TestSentinelBreakerReportsState drives an error-count rule open and checks
+// the transition reaches the StateListener and the neutral breaker report. A
+// count-based rate window is rejected, since sentinel only keeps time windows.
+func TestSentinelBreakerReportsState(t *testing.T) {
+	d, err := resilience.MustGetDriver("sentinel")
+	assert.Error(t, err).Nil()
+	_, err = d.NewExecutor(resilience.Policy{FailureRateThreshold: 50})
+	assert.Error(t, err).Matches("only \"time\" sliding windows")
+
+	var mu sync.Mutex
+	var seen []resilience.CircuitState
+	e := newExec(t, resilience.Policy{
+		Name:           "sentinel-test",
+		ErrorThreshold: 1,
+		OpenDuration:   time.Minute,
+		StateListener: resilience.NewStateListener(func(_ string, _, to resilience.CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen = append(seen, to)
+		}),
+	})
+	defer e.Close()
+
+	boom := errors.New("boom")
+	for range 3 {
+		_ = e.Execute(context.Background(), "svc-breaker-state", func(context.Context) error { return boom })
+	}
+	err = e.Execute(context.Background(), "svc-breaker-state", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(resilience.ErrCircuitOpen)
+
+	mu.Lock()
+	assert.That(t, seen).Equal([]resilience.CircuitState{resilience.CircuitOpen})
+	mu.Unlock()
+	states := e.(resilience.BreakerReporter).BreakerStates()
+	assert.That(t, len(states)).Equal(1)
+	assert.That(t, states[0].State).Equal(resilience.CircuitOpen)
+}
+
+// TestSentinelAdaptiveLimit proves the neutral adaptive limiter runs in front
+// of sentinel's entry: with a limit pinned at 1 a second in-flight call is
+// rejected, while critical traffic still passes.
+func TestSentinelAdaptiveLimit(t *testing.T) {
+	e := newExec(t, resilience.Policy{AdaptiveLimit: resilience.AdaptiveLimitPolicy{
+		Algorithm: resilience.AIMD, InitialLimit: 1, MaxLimit: 1,
+	}})
+
+	release := make(chan struct{})
+	entered := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_ = e.Execute(context.Background(), "svc-adaptive", func(context.Context) error {
+			close(entered)
+			<-release
+			return nil
+		})
+	})
+
+	<-entered
+	err := e.Execute(context.Background(), "svc-adaptive", func(context.Context) error { return nil })
+	assert.Error(t, err).Is(resilience.ErrConcurrencyLimited)
+	critical := resilience.WithPriority(context.Background(), resilience.PriorityCritical)
+	assert.Error(t, e.Execute(critical, "svc-adaptive", func(context.Context) error { return nil })).Nil()
+
+	close(release)
+	wg.Wait()
+}
+
+func TestSentinelRetryBudget(t *testing.T) {
+	e := newExec(t, resilience.Policy{MaxRetries: 3, RetryBudget: resilience.RetryBudgetPolicy{Percent: 50}})
+	boom := errors.New("boom")
+	var attempts int
+	fail := func(context.Context) error { attempts++; return boom }
+
+	// One request buys half a retry, two requests a whole one.
+	assert.Error(t, e.Execute(context.Background(), "svc-budget", fail)).Is(boom)
+	assert.That(t, attempts).Equal(1)
+	attempts = 0
+	assert.Error(t, e.Execute(context.Background(), "svc-budget", fail)).Is(boom)
+	assert.That(t, attempts).Equal(2)
+
+	// Hedging is left to the default driver.
+	d, _ := resilience.MustGetDriver("sentinel")
+	_, err := d.NewExecutor(resilience.Policy{Hedge: resilience.HedgePolicy{Delay: time.Millisecond}})
+	assert.Error(t, err).NotNil()
+}
@@ -36,7 +36,10 @@ import (
 	sentinel "github.com/alibaba/sentinel-golang/api"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/actuator/endpoint"
+	"go-spring.org/spring/cloud/actuator/health"
 	"go-spring.org/spring/experimental/cloud/resilience"
+	"go-spring.org/spring/gs"
 )
 
 var (
@@ -51,6 +54,11 @@ func init() {
 		panic("starter-resilience: sentinel init failed: " + err.Error())
 	}
 	resilience.RegisterDriver("sentinel", sentinelDriver{})
+
+	// Report every executor's circuit breakers (either driver) to the
+	// actuator: a health indicator and GET /resilience/metrics.
+	gs.Provide(newBreakerIndicator).Export(gs.As[health.Indicator]())
+	gs.Provide(resilience.NewBreakerMetrics).Export(gs.As[endpoint.Endpoint]())
 	log.Infof(context.Background(), starterTag, "registered sentinel resilience driver")
 }
 
@@ -59,3 +67,7 @@ type sentinelDriver struct{}
 func (sentinelDriver) NewExecutor(p resilience.Policy) (resilience.Executor, error) {
 	return newSentinelExecutor(p)
 }
+
+// newBreakerIndicator adapts the variadic resilience.NewBreakerIndicator to a
+// constructor the container can call.
+func newBreakerIndicator() health.Indicator { return resilience.NewBreakerIndicator() }
@@ -31,6 +31,19 @@ type Config struct {
 	// graceful shutdown before giving up. It is a safety net on top of the
 	// framework-level app.shutdown.timeout.
 	DrainTimeout time.Duration `value:"${drain-timeout:=30s}"`
+
+	// Store names a scheduling.JobStore bean (e.g. a scheduling.SQLJobStore)
+	// that persists next fire times and run history, so missed fires are
+	// handled by each job's misfire policy after a restart. Empty keeps the
+	// state in memory.
+	Store string `value:"${store:=}"`
+
+	// HistoryLimit bounds the executions kept per job by the in-memory store.
+	HistoryLimit int `value:"${history-limit:=100}"`
+
+	// FlushInterval is how often job state and run history are written to
+	// the store; writes in between are batched.
+	FlushInterval time.Duration `value:"${flush-interval:=1s}"`
 }
 
 // JobConfig declares one scheduled job's trigger and execution options. Exactly
@@ -38,9 +51,9 @@ type Config struct {
 // otherwise, because a job with no trigger (or two) is a configuration mistake
 // that would silently never fire the way the operator expects.
 type JobConfig struct {
-	// Cron is a standard 5-field cron expression (see
-	// go-spring.org/spring/scheduling.ParseCron). Mutually exclusive with
-	// FixedRate and FixedDelay.
+	// Cron is a 5-field cron expression, or 6 fields with a leading seconds
+	// field (see go-spring.org/spring/scheduling.ParseCron). Mutually exclusive
+	// with FixedRate and FixedDelay.
 	Cron string `value:"${cron:=}"`
 
 	// FixedRate fires every interval measured from each scheduled fire time; runs
@@ -72,4 +85,26 @@ type JobConfig struct {
 	// typical run so the lease is not lost mid-run; the lock package auto-renews
 	// it while the job holds it.
 	LockTTL time.Duration `value:"${lock-ttl:=30s}"`
+
+	// TimeZone is the IANA zone (e.g. "Asia/Shanghai") cron fire times are
+	// computed in. Empty means the process-local zone.
+	TimeZone string `value:"${time-zone:=}"`
+
+	// Jitter delays each fire by a random duration below it, spreading load
+	// when many replicas share a schedule.
+	Jitter time.Duration `value:"${jitter:=0}"`
+
+	// Misfire decides what happens to fires missed while the application was
+	// down, when a Store is configured: "fire-once" (default), "fire-all" or
+	// "skip".
+	Misfire string `value:"${misfire:=fire-once}"`
+
+	// Shards, when positive, splits each fire into that many shards and runs
+	// only the ones this replica owns; the job reads its shard with
+	// scheduling.ShardFrom. It requires Sharding.
+	Shards int `value:"${shards:=0}"`
+
+	// Sharding names a scheduling.ShardAssigner bean (e.g. a sharding.Assigner)
+	// that decides which shards this replica owns.
+	Sharding string `value:"${sharding:=}"`
 }
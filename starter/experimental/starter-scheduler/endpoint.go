@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterScheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-spring.org/spring/experimental/cloud/scheduling"
+)
+
+// taskView is the JSON form of a scheduled job.
+type taskView struct {
+	Name           string         `json:"name"`
+	Trigger        string         `json:"trigger"`
+	TimeZone       string         `json:"timeZone"`
+	Paused         bool           `json:"paused"`
+	Running        bool           `json:"running"`
+	NextFire       *time.Time     `json:"nextFire,omitempty"`
+	LastScheduled  *time.Time     `json:"lastScheduled,omitempty"`
+	LastCompletion *time.Time     `json:"lastCompletion,omitempty"`
+	Last           *executionView `json:"last,omitempty"`
+}
+
+// executionView is the JSON form of one run-history entry.
+type executionView struct {
+	Scheduled time.Time  `json:"scheduled"`
+	Start     *time.Time `json:"start,omitempty"`
+	Duration  string     `json:"duration,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Skipped   bool       `json:"skipped,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
+// Path implements endpoint.Endpoint.
+func (s *Server) Path() string { return "/scheduledtasks/" }
+
+// ServeHTTP implements endpoint.Endpoint, the Go analogue of Spring Boot's
+// /actuator/scheduledtasks with the management operations of Quartz:
+//
+//	GET  /scheduledtasks/                list the jobs
+//	GET  /scheduledtasks/{name}          one job with its history (?limit=20)
+//	POST /scheduledtasks/{name}/pause    skip its fires until resumed
+//	POST /scheduledtasks/{name}/resume   let it fire again
+//	POST /scheduledtasks/{name}/trigger  run it once now
+//
+// It answers 503 until the scheduler is built, 501 when the scheduler does not
+// implement scheduling.SchedulerAdmin and 404 for an unknown job.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.scheduler() == nil {
+		http.Error(w, "scheduler not running", http.StatusServiceUnavailable)
+		return
+	}
+	if s.admin() == nil {
+		http.Error(w, "scheduler does not support management", http.StatusNotImplemented)
+		return
+	}
+	s.muxOnce.Do(func() {
+		s.mux = http.NewServeMux()
+		s.mux.HandleFunc("GET /scheduledtasks/{$}", s.handleTasks)
+		s.mux.HandleFunc("GET /scheduledtasks/{name}", s.handleTask)
+		s.mux.HandleFunc("POST /scheduledtasks/{name}/{action}", s.handleAction)
+	})
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleTasks lists every job.
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	tasks := s.admin().Tasks()
+	out := make([]taskView, len(tasks))
+	for i, t := range tasks {
+		out[i] = toTaskView(t)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleAction pauses, resumes or triggers one job.
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request) {
+	sched, name := s.admin(), r.PathValue("name")
+	var err error
+	switch r.PathValue("action") {
+	case "pause":
+		err = sched.Pause(name)
+	case "resume":
+		err = sched.Resume(name)
+	case "trigger":
+		err = sched.RunNow(name)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleTask serves one job with its recent history.
+func (s *Server) handleTask(w http.ResponseWriter, r *http.Request) {
+	sched, name := s.admin(), r.PathValue("name")
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	history, err := sched.History(r.Context(), name, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var view *taskView
+	for _, t := range sched.Tasks() {
+		if t.Name == name {
+			v := toTaskView(t)
+			view = &v
+			break
+		}
+	}
+	if view == nil {
+		http.NotFound(w, r)
+		return
+	}
+	out := struct {
+		taskView
+		History []executionView `json:"history"`
+	}{taskView: *view, History: make([]executionView, len(history))}
+	for i, e := range history {
+		out.History[i] = toExecutionView(e)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func toTaskView(t scheduling.TaskInfo) taskView {
+	v := taskView{
+		Name:           t.Name,
+		Trigger:        t.Trigger,
+		TimeZone:       t.Location,
+		Paused:         t.Paused,
+		Running:        t.Running,
+		NextFire:       optTime(t.NextFire),
+		LastScheduled:  optTime(t.LastScheduled),
+		LastCompletion: optTime(t.LastCompletion),
+	}
+	if t.Last != nil {
+		e := toExecutionView(*t.Last)
+		v.Last = &e
+	}
+	return v
+}
+
+func toExecutionView(e scheduling.Execution) executionView {
+	v := executionView{
+		Scheduled: e.Scheduled,
+		Start:     optTime(e.Start),
+		Error:     e.Error,
+		Skipped:   e.Skipped,
+		Reason:    e.Reason,
+	}
+	if !e.Skipped {
+		v.Duration = e.Duration.String()
+	}
+	return v
+}
+
+func optTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, scheduling.ErrUnknownTask):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, scheduling.ErrNotStarted):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -136,6 +136,32 @@ func (c JobConfig) policy(name string) (scheduling.ConcurrencyPolicy, error) {
 	}
 }
 
+// misfire parses the misfire policy string.
+func (c JobConfig) misfire(name string) (scheduling.MisfirePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(c.Misfire)) {
+	case "", "fire-once":
+		return scheduling.MisfireFireOnce, nil
+	case "fire-all":
+		return scheduling.MisfireFireAll, nil
+	case "skip":
+		return scheduling.MisfireSkip, nil
+	default:
+		return 0, fmt.Errorf("scheduler: job %q has invalid misfire %q (want fire-once|fire-all|skip)", name, c.Misfire)
+	}
+}
+
+// location loads the job's time zone; nil means the process-local zone.
+func (c JobConfig) location(name string) (*time.Location, error) {
+	if c.TimeZone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: job %q has invalid time-zone %q: %w", name, c.TimeZone, err)
+	}
+	return loc, nil
+}
+
 // lockerAdapter adapts a lock.Locker to the minimal scheduling.Locker the
 // scheduler needs, baking in the lease options so the scheduler abstraction
 // stays free of the lock package. The lock is auto-renewed by the lock package
@@ -31,6 +31,10 @@
 // starter-lock-{redis,etcd,consul}; only the replica that wins the lock runs the
 // fire. See [go-spring.org/spring/lock].
 //
+// The server is also an endpoint.Endpoint, so starter-actuator mounts
+// /scheduledtasks/ on its management port: list the jobs, read their run
+// history, and pause, resume or trigger one (see [Server.ServeHTTP]).
+//
 // Register jobs from the application:
 //
 //	scheduler.Provide("cleanup", svc.Cleanup)
@@ -42,8 +46,12 @@ package StarterScheduler
 
 import (
 	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/actuator/endpoint"
 	"go-spring.org/spring/experimental/cloud/lock"
 	"go-spring.org/spring/experimental/cloud/scheduling"
 	"go-spring.org/spring/gs"
@@ -61,7 +69,7 @@ func init() {
 		Name("schedulerServer").
 		Condition(gs.OnProperty("spring.scheduler.enabled").HavingValue("true").MatchIfMissing()).
 		Condition(gs.OnBean[Job]()).
-		Export(gs.As[gs.Server]())
+		Export(gs.As[gs.Server](), gs.As[endpoint.Endpoint]())
 }
 
 // Server drives the scheduled jobs and plugs the scheduler into the Go-Spring
@@ -78,7 +86,17 @@ type Server struct {
 	// config key can reference one by name for multi-replica de-duplication.
 	Lockers map[string]lock.Locker `autowire:"?"`
 
-	sched scheduling.Scheduler
+	// Stores are all scheduling.JobStore beans, keyed by bean name, so the
+	// `store` config key can reference one by name.
+	Stores map[string]scheduling.JobStore `autowire:"?"`
+
+	// Assigners are all scheduling.ShardAssigner beans, keyed by bean name, so
+	// a job's `sharding` config key can reference one by name.
+	Assigners map[string]scheduling.ShardAssigner `autowire:"?"`
+
+	sched   atomic.Pointer[scheduling.Scheduler]
+	muxOnce sync.Once
+	mux     *http.ServeMux // serves the actuator endpoint
 }
 
 // Run wires the configured jobs, then blocks until the application shuts down.
@@ -89,15 +107,30 @@ type Server struct {
 func (s *Server) Run(ctx context.Context, sig gs.ReadySignal) error {
 	log.Debugf(context.Background(), starterTag, "scheduler starting with %d job(s)", len(s.Config.Jobs))
 
-	s.sched = scheduling.NewScheduler(scheduling.WithObserver(s.observe))
+	opts := []scheduling.SchedulerOption{
+		scheduling.WithObserver(s.observe),
+		scheduling.WithFlushInterval(s.Config.FlushInterval),
+	}
+	if s.Config.Store != "" {
+		store, ok := s.Stores[s.Config.Store]
+		if !ok {
+			return errutil.Explain(nil,
+				"scheduler: store %q is configured but no scheduling.JobStore bean of that name is registered", s.Config.Store)
+		}
+		opts = append(opts, scheduling.WithJobStore(store))
+	} else {
+		opts = append(opts, scheduling.WithJobStore(scheduling.NewMemoryJobStore(s.Config.HistoryLimit)))
+	}
+	sched := scheduling.NewScheduler(opts...)
 
-	if err := s.build(); err != nil {
+	if err := s.build(sched); err != nil {
 		return err
 	}
+	s.sched.Store(&sched)
 
 	<-sig.TriggerAndWait()
 
-	if err := s.sched.Start(ctx); err != nil {
+	if err := sched.Start(ctx); err != nil {
 		return err
 	}
 	log.Infof(ctx, log.TagAppDef, "scheduler started with %d job(s)", len(s.Config.Jobs))
@@ -109,7 +142,8 @@ func (s *Server) Run(ctx context.Context, sig gs.ReadySignal) error {
 // Stop halts scheduling and drains in-flight runs, bounded by the configured
 // drain timeout. It is called during graceful shutdown.
 func (s *Server) Stop() error {
-	if s.sched == nil {
+	sched := s.scheduler()
+	if sched == nil {
 		return nil
 	}
 	ctx := context.Background()
@@ -118,16 +152,31 @@ func (s *Server) Stop() error {
 		ctx, cancel = context.WithTimeout(ctx, s.Config.DrainTimeout)
 		defer cancel()
 	}
-	if err := s.sched.Stop(ctx); err != nil {
+	if err := sched.Stop(ctx); err != nil {
 		log.Warnf(context.Background(), log.TagAppDef, "scheduler drain timed out: %v", err)
 		return err
 	}
 	return nil
 }
 
+// scheduler returns the running scheduler, or nil before Run has built it.
+func (s *Server) scheduler() scheduling.Scheduler {
+	if p := s.sched.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// admin returns the running scheduler's management view, or nil before Run
+// has built it or when the scheduler does not implement one.
+func (s *Server) admin() scheduling.SchedulerAdmin {
+	a, _ := s.scheduler().(scheduling.SchedulerAdmin)
+	return a
+}
+
 // build translates the bound configuration and registered Job beans into
-// scheduled tasks. It fails fast on any misconfiguration.
-func (s *Server) build() error {
+// scheduled tasks on sched. It fails fast on any misconfiguration.
+func (s *Server) build(sched scheduling.Scheduler) error {
 	jobs := make(map[string]Job, len(s.Jobs))
 	for _, j := range s.Jobs {
 		if _, dup := jobs[j.JobName()]; dup {
@@ -161,7 +210,21 @@ func (s *Server) build() error {
 			return err
 		}
 
-		opts := []scheduling.Option{scheduling.WithConcurrencyPolicy(policy)}
+		misfire, err := jc.misfire(name)
+		if err != nil {
+			return err
+		}
+		loc, err := jc.location(name)
+		if err != nil {
+			return err
+		}
+
+		opts := []scheduling.Option{
+			scheduling.WithConcurrencyPolicy(policy),
+			scheduling.WithMisfirePolicy(misfire),
+			scheduling.WithLocation(loc),
+			scheduling.WithJitter(jc.Jitter),
+		}
 		if jc.Timeout > 0 {
 			opts = append(opts, scheduling.WithTimeout(jc.Timeout))
 		}
@@ -178,8 +241,17 @@ func (s *Server) build() error {
 			adapter := lockerAdapter{l: locker, opts: lockTTLOption(jc.LockTTL)}
 			opts = append(opts, scheduling.WithLock(adapter, key))
 		}
+		if jc.Shards > 0 || jc.Sharding != "" {
+			assigner, ok := s.Assigners[jc.Sharding]
+			if !ok || jc.Shards <= 0 {
+				return errutil.Explain(nil,
+					"scheduler: job %q needs positive shards and a scheduling.ShardAssigner bean named by sharding (got %d, %q)",
+					name, jc.Shards, jc.Sharding)
+			}
+			opts = append(opts, scheduling.WithShards(jc.Shards, assigner))
+		}
 
-		if _, err := s.sched.Schedule(name, trigger, job.Run, opts...); err != nil {
+		if _, err := sched.Schedule(name, trigger, job.Run, opts...); err != nil {
 			return errutil.Explain(err, "scheduler: failed to schedule job %q", name)
 		}
 	}
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterTransactionAdmin
+
+// Config binds ${spring.transaction.admin}.
+type Config struct {
+	// Enabled turns the starter's beans on. It defaults to true, so a blank import
+	// is enough; set it to false to import the module without contributing beans.
+	Enabled bool `value:"${enabled:=true}"`
+
+	// AuditSize is how many audit entries the in-memory default audit log
+	// keeps. It is ignored once the application registers its own
+	// admin.AuditLog bean.
+	AuditSize int `value:"${audit-size:=256}"`
+}
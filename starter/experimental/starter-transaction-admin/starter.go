@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package StarterTransactionAdmin serves the operator surface of
+// [go-spring.org/spring/experimental/cloud/transaction/admin] on the actuator's
+// management port. It is enabled by a blank import next to the transaction
+// starters it administers:
+//
+//	import _ "go-spring.org/starter-transaction-admin"
+//
+// Each of starter-transaction-saga, starter-transaction-tcc and
+// starter-transaction-at-gorm contributes an admin.Source bean; this starter
+// collects them into one admin.Admin, exported as an endpoint.Endpoint so
+// starter-actuator mounts /transactions/: list the transactions by status and
+// age, inspect their steps and errors, retry, compensate or resolve one — each
+// action recorded in the audit log — and scrape /transactions/metrics.
+//
+// It also contributes the *admin.Metrics bean the transaction starters wrap
+// their coordinator's Observer with, so phase counts flow in without further
+// wiring. The audit log is in memory by default; register an admin.AuditLog
+// bean to keep it elsewhere.
+//
+// The actions change distributed state, so expose the management port only
+// to operators.
+package StarterTransactionAdmin
+
+import (
+	"context"
+
+	"go-spring.org/log"
+	"go-spring.org/spring/cloud/actuator/endpoint"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
+	"go-spring.org/spring/gs"
+)
+
+var (
+	// starterTag identifies logs emitted by the transaction admin starter.
+	starterTag = log.RegisterInfraTag("starter_transaction_admin", "")
+)
+
+// enabled matches when the starter is not explicitly disabled.
+var enabled = gs.OnProperty("spring.transaction.admin.enabled").HavingValue("true").MatchIfMissing()
+
+func init() {
+	// The phase counters, picked up by the transaction starters' coordinators.
+	gs.Provide(admin.NewMetrics).
+		Condition(enabled)
+
+	// The default audit log: in memory. It steps aside (OnMissingBean) for an
+	// application-registered admin.AuditLog.
+	gs.Provide(newAuditLog, gs.TagArg("${spring.transaction.admin}")).
+		Condition(enabled, gs.OnMissingBean[admin.AuditLog]())
+
+	// The admin over every contributed source, mounted by starter-actuator.
+	gs.Provide(newAdmin, gs.TagArg("?"), gs.TagArg(""), gs.TagArg("")).
+		Condition(enabled).
+		Export(gs.As[endpoint.Endpoint]())
+}
+
+// newAuditLog builds the in-memory audit log sized from the configuration.
+func newAuditLog(c Config) admin.AuditLog {
+	return admin.NewMemoryAuditLog(c.AuditSize)
+}
+
+// newAdmin builds the admin over the collected sources.
+func newAdmin(sources []admin.Source, audit admin.AuditLog, metrics *admin.Metrics) *admin.Admin {
+	kinds := make([]string, len(sources))
+	for i, s := range sources {
+		kinds[i] = s.Kind()
+	}
+	log.Infof(context.Background(), starterTag, "transaction admin created sources=%v", kinds)
+	return admin.New(admin.Config{Sources: sources, Audit: audit, Metrics: metrics})
+}
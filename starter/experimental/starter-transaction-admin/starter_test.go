@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterTransactionAdmin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
+	"go-spring.org/stdlib/testing/assert"
+)
+
+func TestNewAdmin_ServesContributedSources(t *testing.T) {
+	ctx := context.Background()
+	store := &transaction.MemoryStore{}
+	assert.Error(t, store.Save(ctx, "s1", transaction.Snapshot{ID: "s1", Status: transaction.StatusCompensationFailed})).Nil()
+	src := admin.NewSagaSource(store, transaction.NewCoordinator(transaction.WithStore(store)), transaction.NewStepRegistry())
+
+	a := newAdmin([]admin.Source{src}, newAuditLog(Config{AuditSize: 8}), admin.NewMetrics())
+	assert.That(t, a.Path()).Equal("/transactions/")
+
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transactions/saga/s1", nil))
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	assert.String(t, w.Body.String()).Contains(`"status":"CompensationFailed"`)
+}
+
+func TestNewAdmin_NoSources(t *testing.T) {
+	a := newAdmin(nil, newAuditLog(Config{}), nil)
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/transactions/", nil))
+	assert.That(t, w.Code).Equal(http.StatusOK)
+	assert.That(t, w.Body.String()).Equal("[]\n")
+}
@@ -55,19 +55,23 @@
 //
 // Observability is on by default: when Config.Tracing is true the coordinator
 // emits an otel child span per branch phase on the globals starter-otel installs.
+// The starter also contributes an admin.Source over the coordinator's running
+// and failed global transactions; importing starter-transaction-admin serves
+// it on the actuator and counts the branch phases in its metrics.
 package StarterTransactionATGorm
 
 import (
 	"context"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
 	"go-spring.org/spring/experimental/cloud/transaction/at"
 	"go-spring.org/spring/gs"
 )
 
 var (
 	// starterTag identifies logs emitted by the transaction at-gorm starter.
-	starterTag = log.RegisterInfraTag("starter_transaction_at_gorm", "")
+	starterTag = log.RegisterInfraTag("starter_at_gorm", "")
 )
 
 // enabled matches when the starter is not explicitly disabled.
@@ -83,19 +87,33 @@ func init() {
 		Export(gs.As[at.GlobalLock]())
 
 	// The in-process coordinator, built from the bound configuration (for the
-	// tracing toggle) and the autowired GlobalLock. Exported as the at.Coordinator
-	// interface so business code depends on the abstraction, not this construction.
-	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.at}"), gs.TagArg("")).
+	// tracing toggle), the autowired GlobalLock and, when present, the admin
+	// metrics. Exported as the at.Coordinator interface so business code depends
+	// on the abstraction, not this construction.
+	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.at}"), gs.TagArg(""), gs.TagArg("?")).
 		Condition(enabled).
 		Export(gs.As[at.Coordinator]())
+
+	// The coordinator as seen by starter-transaction-admin. Inert without it.
+	gs.Provide(admin.NewATSource, gs.TagArg("")).
+		Condition(enabled).
+		Export(gs.As[admin.Source]())
 }
 
 // newCoordinator builds the bundled in-process coordinator over the autowired
-// global lock and, when tracing is enabled, the otel observer.
-func newCoordinator(c Config, lock at.GlobalLock) at.Coordinator {
+// global lock and an observer chaining the admin metrics (when present) with
+// the otel observer (when tracing is enabled).
+func newCoordinator(c Config, lock at.GlobalLock, metrics *admin.Metrics) at.Coordinator {
 	opts := []at.Option{at.WithGlobalLock(lock)}
+	var observer at.Observer
 	if c.Tracing {
-		opts = append(opts, at.WithObserver(otelObserver{}))
+		observer = otelObserver{}
+	}
+	if metrics != nil {
+		observer = metrics.ATObserver(observer)
+	}
+	if observer != nil {
+		opts = append(opts, at.WithObserver(observer))
 	}
 	log.Infof(context.Background(), starterTag, "at coordinator created tracing=%v", c.Tracing)
 	return at.NewCoordinator(opts...)
@@ -44,7 +44,7 @@ import (
 
 var (
 	// starterTag identifies logs emitted by the transaction saga-gorm starter.
-	starterTag = log.RegisterInfraTag("starter_transaction_saga_gorm", "")
+	starterTag = log.RegisterInfraTag("starter_saga_gorm", "")
 )
 
 func init() {
@@ -37,8 +37,10 @@ type sagaSnapshot struct {
 	Method      string    `gorm:"column:method"`
 	Status      int       `gorm:"column:status;index"`
 	InProgress  string    `gorm:"column:in_progress"`
+	InFlight    string    `gorm:"column:in_flight;type:text"`    // JSON-encoded []string
 	Completed   string    `gorm:"column:completed;type:text"`    // JSON-encoded []string
 	StepResults string    `gorm:"column:step_results;type:text"` // JSON-encoded map[string]any
+	Errors      string    `gorm:"column:errors;type:text"`       // JSON-encoded []string
 	UpdatedAt   time.Time `gorm:"column:updated_at"`
 }
 
@@ -53,13 +55,17 @@ func (sagaSnapshot) TableName() string { return "saga_snapshots" }
 // a value comes back in its JSON form — a number becomes float64, a struct
 // becomes map[string]any, and so on, not its original Go type. Sagas that must
 // survive a crash should keep Action results JSON-friendly (ids, tokens and
-// other scalars) and not rely on rich Go types in Compensate. The in-progress
-// step is always recovered with a nil result, so it sidesteps this entirely.
+// other scalars) and not rely on rich Go types in Compensate; an async step's
+// [transaction.Reply] comes back as a map, too. The in-flight steps are always
+// recovered with a nil result, so they sidestep this entirely.
 type gormStore struct {
 	db *gorm.DB
 }
 
-var _ transaction.Store = (*gormStore)(nil)
+var (
+	_ transaction.Store  = (*gormStore)(nil)
+	_ transaction.Lister = (*gormStore)(nil)
+)
 
 // Save upserts the snapshot for id, overwriting any previous row.
 func (s *gormStore) Save(ctx context.Context, id string, snap transaction.Snapshot) error {
@@ -92,11 +98,19 @@ func (s *gormStore) Delete(ctx context.Context, id string) error {
 
 // Pending returns every snapshot still in StatusRunning — the sagas to resume.
 func (s *gormStore) Pending(ctx context.Context) ([]transaction.Snapshot, error) {
+	return s.find(s.db.WithContext(ctx).Where("status = ?", int(transaction.StatusRunning)))
+}
+
+// List returns every snapshot, the compensated and failed ones included, for
+// the admin surface.
+func (s *gormStore) List(ctx context.Context) ([]transaction.Snapshot, error) {
+	return s.find(s.db.WithContext(ctx))
+}
+
+// find decodes the rows q selects.
+func (s *gormStore) find(q *gorm.DB) ([]transaction.Snapshot, error) {
 	var rows []sagaSnapshot
-	err := s.db.WithContext(ctx).
-		Where("status = ?", int(transaction.StatusRunning)).
-		Find(&rows).Error
-	if err != nil {
+	if err := q.Find(&rows).Error; err != nil {
 		return nil, err
 	}
 	out := make([]transaction.Snapshot, 0, len(rows))
@@ -116,10 +130,18 @@ func toRow(id string, snap transaction.Snapshot) (sagaSnapshot, error) {
 	if err != nil {
 		return sagaSnapshot{}, err
 	}
+	inFlight, err := encodeJSON(snap.InFlight)
+	if err != nil {
+		return sagaSnapshot{}, err
+	}
 	results, err := encodeJSON(snap.StepResults)
 	if err != nil {
 		return sagaSnapshot{}, err
 	}
+	errs, err := encodeJSON(snap.Errors)
+	if err != nil {
+		return sagaSnapshot{}, err
+	}
 	updated := snap.UpdatedAt
 	if updated.IsZero() {
 		updated = time.Now()
@@ -129,8 +151,10 @@ func toRow(id string, snap transaction.Snapshot) (sagaSnapshot, error) {
 		Method:      snap.Method,
 		Status:      int(snap.Status),
 		InProgress:  snap.InProgress,
+		InFlight:    inFlight,
 		Completed:   completed,
 		StepResults: results,
+		Errors:      errs,
 		UpdatedAt:   updated,
 	}, nil
 }
@@ -141,17 +165,27 @@ func fromRow(row sagaSnapshot) (transaction.Snapshot, error) {
 	if err := decodeJSON(row.Completed, &completed); err != nil {
 		return transaction.Snapshot{}, err
 	}
+	var inFlight []string
+	if err := decodeJSON(row.InFlight, &inFlight); err != nil {
+		return transaction.Snapshot{}, err
+	}
 	var results map[string]any
 	if err := decodeJSON(row.StepResults, &results); err != nil {
 		return transaction.Snapshot{}, err
 	}
+	var errs []string
+	if err := decodeJSON(row.Errors, &errs); err != nil {
+		return transaction.Snapshot{}, err
+	}
 	return transaction.Snapshot{
 		ID:          row.ID,
 		Method:      row.Method,
 		Status:      transaction.Status(row.Status),
 		Completed:   completed,
 		InProgress:  row.InProgress,
+		InFlight:    inFlight,
 		StepResults: results,
+		Errors:      errs,
 		UpdatedAt:   row.UpdatedAt,
 	}, nil
 }
@@ -47,6 +47,7 @@ func TestGormStore_SaveLoadDeletePending(t *testing.T) {
 		Status:      transaction.StatusRunning,
 		Completed:   []string{"a", "b"},
 		InProgress:  "c",
+		InFlight:    []string{"c", "d"},
 		StepResults: map[string]any{"a": "ra", "b": "rb"},
 	}
 	assert.Error(t, store.Save(ctx, "run", running)).Nil()
@@ -54,6 +55,7 @@ func TestGormStore_SaveLoadDeletePending(t *testing.T) {
 	// A terminal saga kept for inspection.
 	assert.Error(t, store.Save(ctx, "gone", transaction.Snapshot{
 		ID: "gone", Method: "Svc.Do", Status: transaction.StatusCompensated,
+		Errors: []string{"transaction: step b Action: boom"},
 	})).Nil()
 
 	// Load round-trips the fields (string results survive JSON cleanly).
@@ -63,6 +65,7 @@ func TestGormStore_SaveLoadDeletePending(t *testing.T) {
 	assert.That(t, got.Method).Equal("Svc.Do")
 	assert.That(t, got.Status).Equal(transaction.StatusRunning)
 	assert.That(t, got.InProgress).Equal("c")
+	assert.Slice(t, got.InFlight).Equal([]string{"c", "d"})
 	assert.Slice(t, got.Completed).Equal([]string{"a", "b"})
 	assert.That(t, got.StepResults["a"]).Equal("ra")
 	assert.That(t, got.StepResults["b"]).Equal("rb")
@@ -73,6 +76,14 @@ func TestGormStore_SaveLoadDeletePending(t *testing.T) {
 	assert.That(t, len(pending)).Equal(1)
 	assert.That(t, pending[0].ID).Equal("run")
 
+	// List returns the terminal saga too, with its errors.
+	all, err := store.List(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, len(all)).Equal(2)
+	gone, err := store.Load(ctx, "gone")
+	assert.Error(t, err).Nil()
+	assert.Slice(t, gone.Errors).Equal([]string{"transaction: step b Action: boom"})
+
 	// Delete removes it; Load then reports not found and Pending is empty.
 	assert.Error(t, store.Delete(ctx, "run")).Nil()
 	_, err = store.Load(ctx, "run")
@@ -16,6 +16,8 @@
 
 package StarterTransactionSaga
 
+import "time"
+
 // Config binds ${spring.transaction.saga}. It configures the bundled in-process
 // Saga coordinator contributed by this starter. The capability-level prefix
 // (spring.transaction, not spring.transaction.saga-memory) is intentional: a
@@ -31,10 +33,29 @@ type Config struct {
 	// starter-otel, since the global tracer is then a no-op.
 	Tracing bool `value:"${tracing:=true}"`
 
-	// RecoverOnStart makes the starter scan the durable Store at startup and
-	// compensate any saga left in flight by a crash (backward recovery). It
-	// defaults to true. With the in-memory default Store the scan is always empty
-	// (a restart loses the log), so this only does real work once a durable Store
-	// starter — e.g. starter-transaction-saga-gorm — is imported.
+	// RecoverOnStart runs the recovery worker, which from startup on scans the
+	// durable Store and compensates any saga left in flight by a crash
+	// (backward recovery). It defaults to true. With the in-memory default
+	// Store the scan is always empty (a restart loses the log), so this only
+	// does real work once a durable Store starter — e.g.
+	// starter-transaction-saga-gorm — is imported.
 	RecoverOnStart bool `value:"${recover-on-start:=true}"`
+
+	// Recovery tunes the recovery worker.
+	Recovery RecoveryConfig `value:"${recovery}"`
+}
+
+// RecoveryConfig binds ${spring.transaction.saga.recovery}.
+type RecoveryConfig struct {
+	// Interval is the pause between scans of the Store.
+	Interval time.Duration `value:"${interval:=30s}"`
+
+	// StaleAfter is how long a running saga's log must go unwritten before it
+	// is treated as abandoned by a crashed process. The coordinator rewrites
+	// the log of a saga with a step in flight every third of it, so a long
+	// step or async wait is not mistaken for an abandoned saga.
+	StaleAfter time.Duration `value:"${stale-after:=5m}"`
+
+	// LockKey is the leader-election key used when a lock.Locker bean exists.
+	LockKey string `value:"${lock-key:=transaction:saga:recovery}"`
 }
@@ -18,56 +18,77 @@ package StarterTransactionSaga
 
 import (
 	"context"
+	"errors"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/lock"
 	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/gs"
 )
 
-// recoveryRunner compensates sagas a crash left in flight. As a gs.Runner it
-// executes once, synchronously, during application startup — after wiring, so
-// the StepRegistry is already populated. It scans the durable Store for
-// StatusRunning snapshots and, for each, rebuilds the saga's steps from the
-// registry (keyed by the persisted method name) and hands them to the
-// coordinator for backward recovery.
+// RecoveryServer runs a transaction.RecoveryWorker as part of the Go-Spring
+// server lifecycle: once the application is ready — after wiring, so the
+// StepRegistry is populated — it periodically compensates sagas a crashed
+// process left in flight. It opens no port. Its exported fields are populated
+// by the container; Locker is optional and enables leader election, so only
+// one replica recovers at a time.
 //
 // It is a harmless no-op under the in-memory default Store, whose Pending is
 // always empty after a restart.
-type recoveryRunner struct {
+type RecoveryServer struct {
+	Config   Config                    `value:"${spring.transaction.saga}"`
 	Store    transaction.Store         `autowire:""`
 	Registry *transaction.StepRegistry `autowire:""`
 	Coord    transaction.Coordinator   `autowire:""`
+	Locker   lock.Locker               `autowire:"?"`
+
+	cancel context.CancelFunc
 }
 
-// newRecoveryRunner is the constructor registered with the container; the
-// dependencies are populated by the autowire tags.
-func newRecoveryRunner() *recoveryRunner { return &recoveryRunner{} }
+// Run starts recovering once the application is ready and blocks until
+// shutdown.
+func (s *RecoveryServer) Run(ctx context.Context, sig gs.ReadySignal) error {
+	w := s.newWorker(ctx)
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	<-sig.TriggerAndWait()
 
-// Run scans for interrupted sagas and recovers each. It never fails startup: a
-// saga whose steps are no longer registered is logged and skipped (its
-// definition must be re-declared for recovery to act), and an individual
-// recovery error is logged rather than aborting the remaining sagas.
-func (r *recoveryRunner) Run(ctx context.Context) error {
-	pending, err := r.Store.Pending(ctx)
-	if err != nil {
-		log.Errorf(ctx, log.TagAppDef, "saga recovery: scanning pending sagas failed: %v", err)
-		return nil
+	log.Infof(ctx, starterTag, "saga recovery started (leader election: %t)", s.Locker != nil)
+	if err := w.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
 	}
-	for _, snap := range pending {
-		steps, ok := r.Registry.Lookup(snap.Method)
-		if !ok {
-			// Recovery depends on the steps being registered at wiring time under
-			// the same method name GlobalTransactional recorded; without them the
-			// saga cannot be rebuilt.
-			log.Warnf(ctx, log.TagAppDef,
-				"saga recovery: no steps registered for method %q (saga %q); skipping", snap.Method, snap.ID)
-			continue
-		}
-		res, err := r.Coord.Recover(ctx, transaction.Saga{ID: snap.ID, Method: snap.Method, Steps: steps})
-		if err != nil {
-			log.Errorf(ctx, log.TagAppDef, "saga recovery: recovering saga %q failed: %v", snap.ID, err)
-			continue
-		}
-		log.Infof(ctx, log.TagAppDef, "saga recovery: saga %q recovered with status %s", snap.ID, res.Status)
+	return nil
+}
+
+// Stop ends recovering; sagas still pending are picked up by the next leader
+// or the next start.
+func (s *RecoveryServer) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
 	}
 	return nil
 }
+
+// newWorker builds the worker from the bound configuration. It never fails
+// the application: a saga whose steps are no longer registered is logged on
+// every scan (its definition must be re-declared for recovery to act), and an
+// individual recovery error is logged rather than aborting the other sagas.
+func (s *RecoveryServer) newWorker(ctx context.Context) *transaction.RecoveryWorker {
+	c := s.Config.Recovery
+	return transaction.NewRecoveryWorker(transaction.RecoveryConfig{
+		Store:       s.Store,
+		Coordinator: s.Coord,
+		Registry:    s.Registry,
+		StaleAfter:  c.StaleAfter,
+		Interval:    c.Interval,
+		Locker:      s.Locker,
+		LockKey:     c.LockKey,
+		OnRecovered: func(snap transaction.Snapshot, res transaction.Result, err error) {
+			if err != nil {
+				log.Errorf(ctx, log.TagAppDef, "saga recovery: recovering saga %q failed: %v", snap.ID, err)
+				return
+			}
+			log.Infof(ctx, log.TagAppDef, "saga recovery: saga %q recovered with status %s", snap.ID, res.Status)
+		},
+	})
+}
@@ -35,30 +35,39 @@
 //	chain := aspect.NewChain(transaction.GlobalTransactional(coord, reg))
 //
 // This is a Contributor-archetype starter (see starter/DESIGN.md §2.3): it opens
-// no port and starts no server. By default the saga log is kept in memory only —
-// enough for the common "one gateway process drives several downstreams" case
-// and for tests, but NOT crash-recoverable. Importing a durable-Store starter
-// (e.g. starter-transaction-saga-gorm with spring.transaction.saga.store=gorm)
-// supplies a transaction.Store bean; because the in-memory default is registered
-// with gs.OnMissingBean, that durable Store then takes over both the coordinator
-// and the startup recovery scan.
+// no port, and its only server is the background recovery worker. By default the
+// saga log is kept in memory only — enough for the common "one gateway process
+// drives several downstreams" case and for tests, but NOT crash-recoverable.
+// Importing a durable-Store starter (e.g. starter-transaction-saga-gorm with
+// spring.transaction.saga.store=gorm) supplies a transaction.Store bean; because
+// the in-memory default is registered with gs.OnMissingBean, that durable Store
+// then takes over both the coordinator and the recovery worker.
 //
-// When Config.RecoverOnStart is true (the default) a gs.Runner scans the Store at
-// startup and compensates any saga a crash left in flight. Recovery rebuilds each
-// saga's steps from the StepRegistry keyed by the persisted method name, so
-// applications MUST register their steps at wiring time (bean construction), not
-// from inside a custom Runner — otherwise the steps may be absent when the
-// recovery Runner runs.
+// When Config.RecoverOnStart is true (the default) a recovery server scans the
+// Store from startup on and compensates any saga a crash left in flight once its
+// log is older than spring.transaction.saga.recovery.stale-after. When a
+// lock.Locker bean is present it runs under leader election, so only one replica
+// recovers at a time. Recovery rebuilds each saga's steps from the StepRegistry
+// keyed by the persisted method name, so applications MUST register their steps
+// at wiring time (bean construction), not from inside a custom Runner.
+//
+// When a messaging.Binder bean is present the coordinator can also run async
+// steps (Step.Await) that complete on a reply message.
 //
 // Observability is on by default: when Config.Tracing is true the coordinator
 // emits an otel child span per step phase on the globals starter-otel installs.
+// The starter also contributes an admin.Source over the saga log; importing
+// starter-transaction-admin serves it on the actuator (list, retry, compensate,
+// resolve) and counts the coordinator's phases in its metrics.
 package StarterTransactionSaga
 
 import (
 	"context"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/messaging"
 	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
 	"go-spring.org/spring/gs"
 )
 
@@ -70,7 +79,7 @@ var (
 // enabled matches when the starter is not explicitly disabled.
 var enabled = gs.OnProperty("spring.transaction.saga.enabled").HavingValue("true").MatchIfMissing()
 
-// recoverOnStart matches when startup recovery is not explicitly disabled.
+// recoverOnStart matches when crash recovery is not explicitly disabled.
 var recoverOnStart = gs.OnProperty("spring.transaction.saga.recover-on-start").HavingValue("true").MatchIfMissing()
 
 func init() {
@@ -87,28 +96,60 @@ func init() {
 		Condition(enabled, gs.OnMissingBean[transaction.Store]())
 
 	// The in-process coordinator, built from the bound configuration (for the
-	// tracing toggle) and the autowired Store. Exported as the
-	// transaction.Coordinator interface so business code depends on the
+	// tracing toggle), the autowired Store and, when they exist, the
+	// messaging.Binder async steps wait on and the admin metrics. Exported as
+	// the transaction.Coordinator interface so business code depends on the
 	// abstraction, not this construction.
-	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.saga}"), gs.TagArg("")).
+	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.saga}"), gs.TagArg(""), gs.TagArg("?"), gs.TagArg("?")).
 		Condition(enabled).
 		Export(gs.As[transaction.Coordinator]())
 
-	// The startup recovery Runner. It is a no-op under the in-memory default Store
-	// (Pending is always empty after a restart) and does real work only with a
-	// durable Store.
-	gs.Provide(newRecoveryRunner).
+	// The saga log as seen by starter-transaction-admin. Inert without it.
+	gs.Provide(newSagaSource, gs.TagArg("${spring.transaction.saga}"), gs.TagArg(""), gs.TagArg(""), gs.TagArg("")).
+		Condition(enabled).
+		Export(gs.As[admin.Source]())
+
+	// The recovery worker, plugged into the server lifecycle so it starts once
+	// the application is ready and stops with it. It is a no-op under the
+	// in-memory default Store (Pending is always empty after a restart) and does
+	// real work only with a durable Store.
+	gs.Provide(&RecoveryServer{}).
+		Name("sagaRecoveryServer").
 		Condition(enabled, recoverOnStart).
-		Export(gs.As[gs.Runner]())
+		Export(gs.As[gs.Server]())
 }
 
 // newCoordinator builds the bundled in-process coordinator over the autowired
-// saga-log Store and, when tracing is enabled, the otel observer.
-func newCoordinator(c Config, store transaction.Store) transaction.Coordinator {
-	opts := []transaction.Option{transaction.WithStore(store)}
+// saga-log Store, the optional Binder and an observer chaining the admin
+// metrics (when present) with the otel observer (when tracing is enabled).
+// The coordinator refreshes a running saga's log every third of
+// recovery.stale-after, so no replica's recovery worker takes a long step
+// for an abandoned saga.
+func newCoordinator(c Config, store transaction.Store, binder messaging.Binder, metrics *admin.Metrics) transaction.Coordinator {
+	opts := []transaction.Option{
+		transaction.WithStore(store),
+		transaction.WithHeartbeat(c.Recovery.StaleAfter / 3),
+	}
+	if binder != nil {
+		opts = append(opts, transaction.WithBinder(binder))
+	}
+	var observer transaction.Observer
 	if c.Tracing {
-		opts = append(opts, transaction.WithObserver(otelObserver{}))
+		observer = otelObserver{}
 	}
-	log.Infof(context.Background(), starterTag, "saga coordinator created tracing=%v", c.Tracing)
+	if metrics != nil {
+		observer = metrics.SagaObserver(observer)
+	}
+	if observer != nil {
+		opts = append(opts, transaction.WithObserver(observer))
+	}
+	log.Infof(context.Background(), starterTag, "saga coordinator created tracing=%v async=%v", c.Tracing, binder != nil)
 	return transaction.NewCoordinator(opts...)
 }
+
+// newSagaSource builds the admin view of the saga log. It shares
+// recovery.stale-after with the recovery worker, so an operator cannot take
+// over a saga the worker would still leave to its live replica.
+func newSagaSource(c Config, store transaction.Store, coord transaction.Coordinator, reg *transaction.StepRegistry) admin.Source {
+	return admin.NewSagaSource(store, coord, reg, admin.WithStaleAfter(c.Recovery.StaleAfter))
+}
@@ -21,15 +21,18 @@ import (
 	"errors"
 	"testing"
 
+	"go-spring.org/spring/experimental/cloud/messaging"
 	"go-spring.org/spring/experimental/cloud/transaction"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
 	"go-spring.org/stdlib/testing/assert"
 )
 
 func TestNewCoordinator_TracingToggle(t *testing.T) {
 	// Both variants must produce a usable coordinator; the tracing flag only
-	// controls whether an observer is attached.
-	assert.That(t, newCoordinator(Config{Tracing: true}, &transaction.MemoryStore{})).NotNil()
-	assert.That(t, newCoordinator(Config{Tracing: false}, &transaction.MemoryStore{})).NotNil()
+	// controls whether an observer is attached, the binder whether async steps
+	// can run, the metrics whether phases are counted.
+	assert.That(t, newCoordinator(Config{Tracing: true}, &transaction.MemoryStore{}, nil, nil)).NotNil()
+	assert.That(t, newCoordinator(Config{Tracing: false}, &transaction.MemoryStore{}, messaging.NewMemoryBinder(messaging.MemoryBinderConfig{}), admin.NewMetrics())).NotNil()
 }
 
 func TestOtelObserver_DrivesSagaWithoutPanic(t *testing.T) {
@@ -57,7 +60,7 @@ func TestSpanName(t *testing.T) {
 	assert.That(t, spanName(transaction.PhaseCompensate, "DeductInventory")).Equal("saga.compensate DeductInventory")
 }
 
-func TestRecoveryRunner_CompensatesPendingSaga(t *testing.T) {
+func TestRecoveryServer_CompensatesPendingSaga(t *testing.T) {
 	ctx := context.Background()
 	store := &transaction.MemoryStore{}
 	// A saga interrupted while running step b, after completing a.
@@ -79,12 +82,15 @@ func TestRecoveryRunner_CompensatesPendingSaga(t *testing.T) {
 			Compensate: func(context.Context, any) error { order = append(order, "!b"); return nil }},
 	)
 
-	r := &recoveryRunner{
+	s := &RecoveryServer{
 		Store:    store,
 		Registry: reg,
 		Coord:    transaction.NewCoordinator(transaction.WithStore(store)),
 	}
-	assert.Error(t, r.Run(ctx)).Nil()
+	// The log was never stamped, so it is long past stale-after.
+	n, err := s.newWorker(ctx).RecoverOnce(ctx)
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(1)
 	// In-flight b compensated first, then completed a.
 	assert.Slice(t, order).Equal([]string{"!b", "!a"})
 	snap, err := store.Load(ctx, "order-1")
@@ -92,11 +98,13 @@ func TestRecoveryRunner_CompensatesPendingSaga(t *testing.T) {
 	assert.That(t, snap.Status).Equal(transaction.StatusCompensated)
 }
 
-func TestRecoveryRunner_NoOpWhenNothingPending(t *testing.T) {
-	r := &recoveryRunner{
+func TestRecoveryServer_NoOpWhenNothingPending(t *testing.T) {
+	s := &RecoveryServer{
 		Store:    &transaction.MemoryStore{},
 		Registry: transaction.NewStepRegistry(),
 		Coord:    transaction.NewCoordinator(transaction.WithStore(&transaction.MemoryStore{})),
 	}
-	assert.Error(t, r.Run(context.Background())).Nil()
+	n, err := s.newWorker(context.Background()).RecoverOnce(context.Background())
+	assert.Error(t, err).Nil()
+	assert.That(t, n).Equal(0)
 }
@@ -59,13 +59,17 @@
 //
 // Observability is on by default: when Config.Tracing is true the coordinator
 // emits an otel child span per participant phase on the globals starter-otel
-// installs.
+// installs. The starter also contributes an admin.Source over the TCC log;
+// importing starter-transaction-admin serves it on the actuator, so a stuck
+// transaction is confirmed, cancelled or resolved there instead of by hand in
+// the database, and counts the coordinator's phases in its metrics.
 package StarterTransactionTCC
 
 import (
 	"context"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
 	"go-spring.org/spring/experimental/cloud/transaction/tcc"
 	"go-spring.org/spring/gs"
 )
@@ -95,12 +99,18 @@ func init() {
 		Condition(enabled, gs.OnMissingBean[tcc.Store]())
 
 	// The in-process coordinator, built from the bound configuration (for the
-	// tracing toggle) and the autowired Store. Exported as the tcc.Coordinator
-	// interface so business code depends on the abstraction, not this construction.
-	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.tcc}"), gs.TagArg("")).
+	// tracing toggle), the autowired Store and, when present, the admin
+	// metrics. Exported as the tcc.Coordinator interface so business code
+	// depends on the abstraction, not this construction.
+	gs.Provide(newCoordinator, gs.TagArg("${spring.transaction.tcc}"), gs.TagArg(""), gs.TagArg("?")).
 		Condition(enabled).
 		Export(gs.As[tcc.Coordinator]())
 
+	// The TCC log as seen by starter-transaction-admin. Inert without it.
+	gs.Provide(admin.NewTCCSource, gs.TagArg(""), gs.TagArg(""), gs.TagArg("")).
+		Condition(enabled).
+		Export(gs.As[admin.Source]())
+
 	// The startup recovery Runner. It is a no-op under the in-memory default Store
 	// (Pending is always empty after a restart) and does real work only with a
 	// durable Store.
@@ -110,11 +120,19 @@ func init() {
 }
 
 // newCoordinator builds the bundled in-process coordinator over the autowired
-// TCC-log Store and, when tracing is enabled, the otel observer.
-func newCoordinator(c Config, store tcc.Store) tcc.Coordinator {
+// TCC-log Store and an observer chaining the admin metrics (when present) with
+// the otel observer (when tracing is enabled).
+func newCoordinator(c Config, store tcc.Store, metrics *admin.Metrics) tcc.Coordinator {
 	opts := []tcc.Option{tcc.WithStore(store)}
+	var observer tcc.Observer
 	if c.Tracing {
-		opts = append(opts, tcc.WithObserver(otelObserver{}))
+		observer = otelObserver{}
+	}
+	if metrics != nil {
+		observer = metrics.TCCObserver(observer)
+	}
+	if observer != nil {
+		opts = append(opts, tcc.WithObserver(observer))
 	}
 	log.Infof(context.Background(), starterTag, "tcc coordinator created tracing=%v", c.Tracing)
 	return tcc.NewCoordinator(opts...)
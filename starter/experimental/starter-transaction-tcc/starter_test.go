@@ -21,6 +21,7 @@ import (
 	"errors"
 	"testing"
 
+	"go-spring.org/spring/experimental/cloud/transaction/admin"
 	"go-spring.org/spring/experimental/cloud/transaction/tcc"
 	"go-spring.org/stdlib/testing/assert"
 )
@@ -37,8 +38,8 @@ func okParticipant(name string, confirmed, cancelled *[]string) tcc.Participant
 func TestNewCoordinator_TracingToggle(t *testing.T) {
 	// Both variants must produce a usable coordinator; the tracing flag only
 	// controls whether an observer is attached.
-	assert.That(t, newCoordinator(Config{Tracing: true}, &tcc.MemoryStore{})).NotNil()
-	assert.That(t, newCoordinator(Config{Tracing: false}, &tcc.MemoryStore{})).NotNil()
+	assert.That(t, newCoordinator(Config{Tracing: true}, &tcc.MemoryStore{}, nil)).NotNil()
+	assert.That(t, newCoordinator(Config{Tracing: false}, &tcc.MemoryStore{}, admin.NewMetrics())).NotNil()
 }
 
 func TestOtelObserver_DrivesTCCWithoutPanic(t *testing.T) {
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package StarterOTel
+
+import (
+	"context"
+
+	"go-spring.org/log"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// logMeterName identifies the logging-pipeline metrics emitted by this starter.
+const logMeterName = "go-spring.org/log"
+
+// registerLogMetrics publishes log.Metrics through mp as asynchronous
+// instruments, observed on every collection. Loggers are labeled with
+// "logger" and appenders with "appender". The callback is torn down together
+// with the provider by mp.Shutdown.
+func registerLogMetrics(mp otelmetric.MeterProvider) error {
+	meter := mp.Meter(logMeterName)
+
+	enqueued, err := meter.Int64ObservableCounter("log.events.enqueued",
+		otelmetric.WithDescription("Events accepted into an async logger buffer."))
+	if err != nil {
+		return err
+	}
+	discarded, err := meter.Int64ObservableCounter("log.events.discarded",
+		otelmetric.WithDescription("Events dropped by an async logger's buffer-full policy."))
+	if err != nil {
+		return err
+	}
+	depth, err := meter.Int64ObservableGauge("log.queue.depth",
+		otelmetric.WithDescription("Events currently buffered by an async logger."))
+	if err != nil {
+		return err
+	}
+	capacity, err := meter.Int64ObservableGauge("log.queue.capacity",
+		otelmetric.WithDescription("Buffer capacity of an async logger."))
+	if err != nil {
+		return err
+	}
+	blocked, err := meter.Int64ObservableCounter("log.enqueue.blocked",
+		otelmetric.WithDescription("Appends that found the buffer full and had to wait or evict."))
+	if err != nil {
+		return err
+	}
+	blockedTime, err := meter.Float64ObservableCounter("log.enqueue.blocked.duration",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Total time spent waiting for buffer space."))
+	if err != nil {
+		return err
+	}
+	maxEnqueue, err := meter.Float64ObservableGauge("log.enqueue.blocked.max",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Longest single append spent waiting for buffer space."))
+	if err != nil {
+		return err
+	}
+	writes, err := meter.Int64ObservableCounter("log.appender.writes",
+		otelmetric.WithDescription("Events written by an appender."))
+	if err != nil {
+		return err
+	}
+	writeErrors, err := meter.Int64ObservableCounter("log.appender.errors",
+		otelmetric.WithDescription("Write errors reported by an appender."))
+	if err != nil {
+		return err
+	}
+	writeTime, err := meter.Float64ObservableCounter("log.appender.write.duration",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Total time spent writing events."))
+	if err != nil {
+		return err
+	}
+	maxWriteTime, err := meter.Float64ObservableGauge("log.appender.write.max",
+		otelmetric.WithUnit("s"),
+		otelmetric.WithDescription("Longest single event write."))
+	if err != nil {
+		return err
+	}
+	logErrors, err := meter.Int64ObservableCounter("log.errors",
+		otelmetric.WithDescription("Errors reported by the logging system."))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o otelmetric.Observer) error {
+		m := log.Metrics()
+		for _, l := range m.Loggers {
+			attrs := otelmetric.WithAttributes(attribute.String("logger", l.Name))
+			o.ObserveInt64(enqueued, l.Enqueued, attrs)
+			o.ObserveInt64(discarded, l.Discarded, attrs)
+			o.ObserveInt64(depth, int64(l.QueueDepth), attrs)
+			o.ObserveInt64(capacity, int64(l.QueueCapacity), attrs)
+			o.ObserveInt64(blocked, l.Blocked, attrs)
+			o.ObserveFloat64(blockedTime, l.BlockedTime.Seconds(), attrs)
+			o.ObserveFloat64(maxEnqueue, l.MaxEnqueueLatency.Seconds(), attrs)
+		}
+		for _, a := range m.Appenders {
+			attrs := otelmetric.WithAttributes(attribute.String("appender", a.Name))
+			o.ObserveInt64(writes, a.Writes, attrs)
+			o.ObserveInt64(writeErrors, a.Errors, attrs)
+			o.ObserveFloat64(writeTime, a.WriteTime.Seconds(), attrs)
+			o.ObserveFloat64(maxWriteTime, a.MaxWriteTime.Seconds(), attrs)
+		}
+		o.ObserveInt64(logErrors, m.Errors)
+		return nil
+	}, enqueued, discarded, depth, capacity, blocked, blockedTime, maxEnqueue,
+		writes, writeErrors, writeTime, maxWriteTime, logErrors)
+	return err
+}
@@ -46,6 +46,16 @@ type MetricsConfig struct {
 	Path     string             `value:"${path:=/metrics}"`
 	Interval time.Duration      `value:"${interval:=10s}"` // push interval for otlp/stdout readers
 	Runtime  RuntimeMetricsConfig `value:"${runtime}"`
+	Log      LogMetricsConfig     `value:"${log}"`
+}
+
+// LogMetricsConfig controls the logging-pipeline metrics under
+// ${spring.observability.metrics.log}. When enabled the starter observes
+// go-spring.org/log's Metrics snapshot (async logger queue depth, discards,
+// time blocked on a full buffer, per-appender writes and errors) on every
+// collection.
+type LogMetricsConfig struct {
+	Enable bool `value:"${enable:=true}"`
 }
 
 // RuntimeMetricsConfig controls Go runtime instrumentation under
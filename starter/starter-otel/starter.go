@@ -158,6 +158,14 @@ func setupMetrics(r gs.BeanProvider, cfg metric.MetricsConfig, res *resource.Res
 			return err
 		}
 	}
+	// Publish the logging pipeline's own counters (async queue depth,
+	// discards, time blocked on a full buffer, appender errors) so silent
+	// log loss shows up on dashboards while it is happening.
+	if cfg.Log.Enable {
+		if err := registerLogMetrics(mp); err != nil {
+			return err
+		}
+	}
 	// Pull-based (prometheus) exporter: contribute the scrape handler as an
 	// endpoint.Endpoint so starter-actuator, if present, serves /metrics on
 	// the shared management port - no cross-starter import. The dedicated
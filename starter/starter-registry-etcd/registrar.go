@@ -22,34 +22,26 @@ import (
 	"sync"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/errutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
-// instance is the instance this process advertises to the etcd registry. It is
-// the local write-side value built from RegistrationConfig in Server.Run; the
-// crash-safety contract every registry starter follows lives in starter/DESIGN
-// §3 (Register must self-renew so correctness never depends on Deregister).
-type instance struct {
-	ServiceName string
-	ID          string
-	Addr        string
-	Weight      int
-	Metadata    map[string]string
-}
-
 // instanceValue is the JSON payload stored at an instance key. A discovery
 // backend reading the same prefix reconstructs an Endpoint from it.
 type instanceValue struct {
 	ServiceName string            `json:"service_name"`
 	Addr        string            `json:"addr"`
+	Scheme      string            `json:"scheme,omitempty"`
 	Weight      int               `json:"weight,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Status      discovery.Status  `json:"status,omitempty"`
 }
 
-// etcdRegistrar publishes instances to an etcd cluster. Each instance is written
-// under a key bound to its own lease and kept alive by a background keep-alive;
-// if the process dies the lease expires and etcd deletes the key automatically.
+// etcdRegistrar is the [discovery.Registrar] for an etcd cluster. Each instance
+// is written under a key bound to its own lease and kept alive by a background
+// keep-alive; if the process dies the lease expires and etcd deletes the key
+// automatically (the crash-safety contract of starter/DESIGN §3).
 type etcdRegistrar struct {
 	client    *clientv3.Client
 	keyPrefix string
@@ -59,10 +51,14 @@ type etcdRegistrar struct {
 	holds map[string]*hold // instance key -> its lease keep-alive
 }
 
-// hold tracks the lease and keep-alive goroutine backing one registered key.
+var _ discovery.Registrar = (*etcdRegistrar)(nil)
+
+// hold tracks the lease and keep-alive goroutine backing one registered key,
+// and the value last written so a Heartbeat only rewrites a change.
 type hold struct {
 	leaseID clientv3.LeaseID
 	cancel  context.CancelFunc
+	value   string
 }
 
 // newEtcdRegistrar builds a *clientv3.Client from c and returns a registrar. It
@@ -103,36 +99,38 @@ func newEtcdRegistrar(c EtcdConfig) (*etcdRegistrar, error) {
 	}, nil
 }
 
-// instanceID returns the instance id within the service: the caller-supplied ID,
-// or a stable one derived from the service name and advertised address.
-func instanceID(reg instance) string {
-	if reg.ID != "" {
-		return reg.ID
-	}
-	return reg.ServiceName + "-" + reg.Addr
-}
-
 // keyFor returns the etcd key an instance is written under: prefix + service +
 // "/" + instance id.
-func (r *etcdRegistrar) keyFor(reg instance) string {
-	return r.keyPrefix + reg.ServiceName + "/" + instanceID(reg)
+func (r *etcdRegistrar) keyFor(reg discovery.Instance) string {
+	return r.keyPrefix + reg.ServiceName + "/" + discovery.InstanceID(reg)
 }
 
-// Register grants a lease, writes the instance under it, and starts a keep-alive
-// so the entry stays live until Deregister or process death. Registering the
-// same instance again refreshes it: the previous lease is revoked first.
-func (r *etcdRegistrar) Register(ctx context.Context, reg instance) error {
-	if err := errutil.RequireField("registry-etcd", "addr", reg.Addr); err != nil {
-		return err
-	}
+// valueFor returns the JSON payload stored for reg.
+func valueFor(reg discovery.Instance) (string, error) {
 	val, err := json.Marshal(instanceValue{
 		ServiceName: reg.ServiceName,
 		Addr:        reg.Addr,
+		Scheme:      reg.Scheme,
 		Weight:      reg.Weight,
 		Metadata:    reg.Metadata,
+		Status:      reg.Status,
 	})
 	if err != nil {
-		return errutil.Explain(err, "registry-etcd: marshal instance %q", reg.ServiceName)
+		return "", errutil.Explain(err, "registry-etcd: marshal instance %q", reg.ServiceName)
+	}
+	return string(val), nil
+}
+
+// Register grants a lease, writes the instance under it, and starts a keep-alive
+// so the entry stays live until Deregister or process death. Registering the
+// same instance again refreshes it: the previous lease is revoked first.
+func (r *etcdRegistrar) Register(ctx context.Context, reg discovery.Instance) error {
+	if err := errutil.RequireField("registry-etcd", "addr", reg.Addr); err != nil {
+		return err
+	}
+	val, err := valueFor(reg)
+	if err != nil {
+		return err
 	}
 
 	grant, err := r.client.Grant(ctx, r.ttlSecs)
@@ -140,7 +138,7 @@ func (r *etcdRegistrar) Register(ctx context.Context, reg instance) error {
 		return errutil.Explain(err, "registry-etcd: grant lease for %q", reg.ServiceName)
 	}
 	key := r.keyFor(reg)
-	if _, err := r.client.Put(ctx, key, string(val), clientv3.WithLease(grant.ID)); err != nil {
+	if _, err := r.client.Put(ctx, key, val, clientv3.WithLease(grant.ID)); err != nil {
 		_, _ = r.client.Revoke(context.Background(), grant.ID)
 		return errutil.Explain(err, "registry-etcd: put %q", key)
 	}
@@ -166,14 +164,14 @@ func (r *etcdRegistrar) Register(ctx context.Context, reg instance) error {
 		old.cancel()
 		_, _ = r.client.Revoke(context.Background(), old.leaseID)
 	}
-	r.holds[key] = &hold{leaseID: grant.ID, cancel: cancel}
+	r.holds[key] = &hold{leaseID: grant.ID, cancel: cancel, value: val}
 	r.mu.Unlock()
 	return nil
 }
 
 // Deregister stops the keep-alive and revokes the lease, which deletes the key.
 // It is idempotent: deregistering an instance that is not registered is a no-op.
-func (r *etcdRegistrar) Deregister(ctx context.Context, reg instance) error {
+func (r *etcdRegistrar) Deregister(ctx context.Context, reg discovery.Instance) error {
 	key := r.keyFor(reg)
 	r.mu.Lock()
 	h, ok := r.holds[key]
@@ -190,3 +188,28 @@ func (r *etcdRegistrar) Deregister(ctx context.Context, reg instance) error {
 	}
 	return nil
 }
+
+// Heartbeat rewrites the instance under its existing lease when its Status or
+// Metadata changed. Liveness itself is the lease keep-alive, so an unchanged
+// instance costs no write.
+func (r *etcdRegistrar) Heartbeat(ctx context.Context, reg discovery.Instance) error {
+	val, err := valueFor(reg)
+	if err != nil {
+		return err
+	}
+	key := r.keyFor(reg)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.holds[key]
+	if !ok {
+		return errutil.Explain(nil, "registry-etcd: %q is not registered", key)
+	}
+	if h.value == val {
+		return nil
+	}
+	if _, err := r.client.Put(ctx, key, val, clientv3.WithLease(h.leaseID)); err != nil {
+		return errutil.Explain(err, "registry-etcd: put %q", key)
+	}
+	h.value = val
+	return nil
+}
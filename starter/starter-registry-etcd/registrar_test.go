@@ -21,19 +21,21 @@ import (
 	"testing"
 	"time"
 
+	"go-spring.org/spring/cloud/discovery"
 	"go-spring.org/stdlib/testing/assert"
 )
 
-func TestInstanceID(t *testing.T) {
-	// An explicit ID is used verbatim.
-	assert.That(t, instanceID(instance{ID: "fixed", ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("fixed")
-	// Otherwise it is derived from name and addr so restarts replace the entry.
-	assert.That(t, instanceID(instance{ServiceName: "orders", Addr: "1.2.3.4:80"})).Equal("orders-1.2.3.4:80")
+func TestValueFor(t *testing.T) {
+	// Scheme and Status are stored so a discovery backend can rebuild the
+	// Endpoint; empty fields are omitted.
+	got, err := valueFor(discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80", Scheme: "tls", Status: discovery.StatusDown})
+	assert.That(t, err).Nil()
+	assert.That(t, got).Equal(`{"service_name":"orders","addr":"1.2.3.4:80","scheme":"tls","status":"DOWN"}`)
 }
 
 func TestKeyFor(t *testing.T) {
 	r := &etcdRegistrar{keyPrefix: "/services/"}
-	got := r.keyFor(instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
+	got := r.keyFor(discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
 	assert.That(t, got).Equal("/services/orders/orders-1.2.3.4:80")
 }
 
@@ -51,8 +53,16 @@ func TestDeregisterIdempotent(t *testing.T) {
 	// not touch the (nil) client, so shutdown can call it unconditionally as an
 	// idempotent fallback after PreStop has already run.
 	r := &etcdRegistrar{keyPrefix: "/services/", holds: map[string]*hold{}}
-	reg := instance{ServiceName: "orders", Addr: "1.2.3.4:80"}
+	reg := discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"}
 	assert.That(t, r.Deregister(context.Background(), reg)).Nil()
 	// A second call is likewise a no-op.
 	assert.That(t, r.Deregister(context.Background(), reg)).Nil()
 }
+
+func TestHeartbeatRequiresRegistration(t *testing.T) {
+	// A heartbeat for an instance without a lease is reported, not silently
+	// turned into an unleased write.
+	r := &etcdRegistrar{keyPrefix: "/services/", holds: map[string]*hold{}}
+	err := r.Heartbeat(context.Background(), discovery.Instance{ServiceName: "orders", Addr: "1.2.3.4:80"})
+	assert.Error(t, err).Matches("not registered")
+}
@@ -29,10 +29,11 @@
 // expires and etcd deletes the key - self-healing without a reaper.
 //
 // This is a global / infrastructure-archetype starter (starter/DESIGN §2.4): it
-// opens no port. It exports a gs.Server so registration plugs into the server
-// lifecycle - the instance is published once the application is ready and
-// deregistered as shutdown begins (via PreStop), so discovery stops handing it
-// out before it actually stops serving. That ordering is what makes a rolling
+// opens no port. It only implements the etcd discovery.Registrar; the
+// lifecycle is the shared registration.AutoRegistration, exported as a
+// gs.Server - the instance is published once the application is ready,
+// deregistered as shutdown begins (via PreStop), and its health status is
+// re-published on every heartbeat. That ordering is what makes a rolling
 // restart lossless.
 //
 // Blank-import the package and configure it:
@@ -46,6 +47,7 @@ import (
 	"context"
 
 	"go-spring.org/log"
+	"go-spring.org/spring/experimental/cloud/registration"
 	"go-spring.org/spring/gs"
 	"go-spring.org/stdlib/errutil"
 )
@@ -59,10 +61,9 @@ func init() {
 	// Activated only when etcd endpoints are set. The constructor binds
 	// EtcdConfig from ${spring.registry.etcd} and builds the etcd registrar,
 	// probing the cluster so an unreachable one fails startup. The instance to
-	// advertise is bound separately into Server.Config from ${spring.registry}.
-	// The registrar is a local value held by the Server, not a globally
-	// registered backend: this starter owns the full register/deregister
-	// lifecycle.
+	// advertise is bound separately into the AutoRegistration's Config from
+	// ${spring.registry}. The registrar is a local value held by the
+	// AutoRegistration, not a globally registered backend.
 	gs.Provide(
 		NewServer,
 		gs.TagArg("${spring.registry.etcd}"),
@@ -71,78 +72,16 @@ func init() {
 		Condition(gs.OnProperty("spring.registry.etcd.endpoints"))
 }
 
-// NewServer builds the etcd registrar from c and returns the Server that
-// publishes this instance on ready and deregisters on shutdown. It probes the
-// etcd cluster (a Status call against the first endpoint) so a misconfigured or
-// unreachable cluster fails fast at startup rather than surfacing on the first
-// Register.
-func NewServer(c EtcdConfig) (*Server, error) {
+// NewServer builds the etcd registrar from c and returns the AutoRegistration
+// that publishes this instance on ready and deregisters on shutdown. It probes
+// the etcd cluster (a Status call against the first endpoint) so a
+// misconfigured or unreachable cluster fails fast at startup rather than
+// surfacing on the first Register.
+func NewServer(c EtcdConfig) (*registration.AutoRegistration, error) {
 	log.Debugf(context.Background(), starterTag, "creating etcd registrar endpoints=%v ttl=%s", c.Endpoints, c.TTL)
 	reg, err := newEtcdRegistrar(c)
 	if err != nil {
 		return nil, errutil.Explain(err, "registry-etcd: build registrar")
 	}
-	return &Server{registrar: reg}, nil
-}
-
-// Server publishes this instance to a service registry as part of the Go-Spring
-// server lifecycle. It opens no network port; its only job is Register-on-ready
-// and Deregister-on-shutdown. Its exported field is populated by the container.
-type Server struct {
-	// Config is bound from ${spring.registry}.
-	Config RegistrationConfig `value:"${spring.registry}"`
-
-	registrar *etcdRegistrar
-	reg       instance
-}
-
-// Run publishes this instance once the application is ready, then blocks until
-// shutdown. It validates required fields before signalling readiness so a
-// misconfiguration fails startup rather than surfacing later.
-func (s *Server) Run(ctx context.Context, sig gs.ReadySignal) error {
-	if s.Config.ServiceName == "" || s.Config.Addr == "" {
-		return errutil.Explain(nil, "registry: ${spring.registry.service-name} and ${spring.registry.addr} are required")
-	}
-	s.reg = instance{
-		ServiceName: s.Config.ServiceName,
-		ID:          s.Config.ID,
-		Addr:        s.Config.Addr,
-		Weight:      s.Config.Weight,
-		Metadata:    s.Config.Metadata,
-	}
-
-	<-sig.TriggerAndWait()
-
-	log.Debugf(ctx, starterTag, "registering service=%s id=%s addr=%s weight=%d", s.reg.ServiceName, s.reg.ID, s.reg.Addr, s.reg.Weight)
-	if err := s.registrar.Register(ctx, s.reg); err != nil {
-		log.Errorf(ctx, starterTag, "register service=%s failed: %v", s.reg.ServiceName, err)
-		return errutil.Explain(err, "registry: register %q", s.reg.ServiceName)
-	}
-	log.Infof(ctx, starterTag, "registered %q at %s", s.reg.ServiceName, s.reg.Addr)
-
-	<-ctx.Done()
-	return nil
-}
-
-// PreStop deregisters the instance as soon as shutdown begins - before the
-// pre-stop delay and before any server stops - so discovery removes it while
-// in-flight requests keep being served (the lossless-drain sequence).
-func (s *Server) PreStop(ctx context.Context) {
-	s.deregister(ctx)
-}
-
-// Stop deregisters as a fallback should PreStop not have run. Deregister is
-// idempotent, so a second call is a no-op.
-func (s *Server) Stop() error {
-	s.deregister(context.Background())
-	return nil
-}
-
-func (s *Server) deregister(ctx context.Context) {
-	if s.registrar == nil {
-		return
-	}
-	if err := s.registrar.Deregister(ctx, s.reg); err != nil {
-		log.Warnf(ctx, starterTag, "deregister %q: %v", s.reg.ServiceName, err)
-	}
+	return registration.New(reg), nil
 }